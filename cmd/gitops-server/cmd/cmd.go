@@ -16,7 +16,6 @@ import (
 	"time"
 
 	"github.com/NYTimes/gziphandler"
-	"github.com/alexedwards/scs/v2"
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -24,6 +23,7 @@ import (
 	httpmiddleware "github.com/slok/go-http-metrics/middleware"
 	httpmiddlewarestd "github.com/slok/go-http-metrics/middleware/std"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
@@ -36,11 +36,13 @@ import (
 	"github.com/weaveworks/weave-gitops/core/logger"
 	"github.com/weaveworks/weave-gitops/core/nsaccess"
 	core "github.com/weaveworks/weave-gitops/core/server"
+	"github.com/weaveworks/weave-gitops/pkg/audit"
 	"github.com/weaveworks/weave-gitops/pkg/featureflags"
 	"github.com/weaveworks/weave-gitops/pkg/health"
 	"github.com/weaveworks/weave-gitops/pkg/kube"
 	"github.com/weaveworks/weave-gitops/pkg/server"
 	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+	"github.com/weaveworks/weave-gitops/pkg/server/authz"
 	"github.com/weaveworks/weave-gitops/pkg/server/middleware"
 	"github.com/weaveworks/weave-gitops/pkg/telemetry"
 )
@@ -49,6 +51,7 @@ const (
 	// Allowed login requests per second
 	loginRequestRateLimit            = 20
 	InsecureNoAuthenticationUserFlag = "insecure-no-authentication-user"
+	sessionRedisPasswordEnvVar       = "WEAVE_GITOPS_SESSION_REDIS_PASSWORD"
 )
 
 // Options contains all the options for the gitops-server command.
@@ -57,6 +60,7 @@ type Options struct {
 	Host                          string
 	LogLevel                      string
 	NotificationControllerAddress string
+	SourceControllerAddress       string
 	Path                          string
 	RoutePrefix                   string
 	Port                          string
@@ -73,7 +77,26 @@ type Options struct {
 	OIDC       auth.OIDCConfig
 	OIDCSecret string
 	// Auth
-	NoAuthUser string
+	NoAuthUser               string
+	SessionStore             auth.SessionStoreOptions
+	SessionRedisPasswordFile string
+	TrackSessions            bool
+	// Token passthrough
+	TokenReview    bool
+	TokenReviewTTL time.Duration
+	// API tokens
+	APITokenCacheTTL time.Duration
+	// Audit
+	Audit          audit.Options
+	TrustedProxies []string
+	// Authorization
+	AuthorizationPolicyFile string
+	// Health checks
+	HealthChecksConfigMap  string
+	HealthHistory          bool
+	HealthHistoryOptions   health.HistoryOptions
+	HealthHistoryInterval  time.Duration
+	HealthHistoryConfigMap string
 	// Dev mode
 	DevMode bool
 	// Metrics
@@ -81,6 +104,11 @@ type Options struct {
 	MetricsAddress string
 
 	UseK8sCachedClients bool
+	// Additional clusters
+	KubeConfigDir            string
+	KubeConfigSecretSelector string
+	CAPIClusters             bool
+	CAPIClustersNamespace    string
 }
 
 var options Options
@@ -101,10 +129,16 @@ func NewCommand() *cobra.Command {
 	cmd.Flags().StringVar(&options.Host, "host", server.DefaultHost, "UI host")
 	cmd.Flags().StringVar(&options.LogLevel, "log-level", logger.DefaultLogLevel, "log level")
 	cmd.Flags().StringVar(&options.NotificationControllerAddress, "notification-controller-address", "", "the address of the notification-controller running in the cluster")
+	cmd.Flags().StringVar(&options.SourceControllerAddress, "source-controller-address", core.DefaultSourceControllerAddress, "The host, and port if any, source-controller serves the source artifacts at, the only one they're fetched from to show the drift of Kustomizations")
 	cmd.Flags().StringVar(&options.RoutePrefix, "route-prefix", "", "Mount the UI and API endpoint under a path prefix, e.g. /weave-gitops")
 	cmd.Flags().StringVar(&options.Port, "port", server.DefaultPort, "UI port")
 	cmd.Flags().StringSliceVar(&options.AuthMethods, "auth-methods", auth.DefaultAuthMethodStrings(), fmt.Sprintf("Which auth methods to use, valid values are %s", strings.Join(auth.AllUserAuthMethods(), ",")))
 	cmd.Flags().BoolVar(&options.UseK8sCachedClients, "use-k8s-cached-clients", false, "Enables the use of cached clients")
+	// Additional clusters
+	cmd.Flags().StringVar(&options.KubeConfigDir, "kubeconfig-dir", "", "Directory of kubeconfig files for additional clusters to show, each cluster is named after its file")
+	cmd.Flags().StringVar(&options.KubeConfigSecretSelector, "kubeconfig-secret-selector", "", fmt.Sprintf("Label selector for secrets in the server's namespace holding kubeconfigs for additional clusters under the %q key, each cluster is named after its secret", fetcher.KubeConfigSecretKey))
+	cmd.Flags().BoolVar(&options.CAPIClusters, "capi-clusters", false, "Show Cluster API clusters using their kubeconfig secrets, each cluster is named namespace/name")
+	cmd.Flags().StringVar(&options.CAPIClustersNamespace, "capi-clusters-namespace", "", "Only show Cluster API clusters from this namespace, defaults to all namespaces")
 	//  TLS
 	cmd.Flags().BoolVar(&options.Insecure, "insecure", false, "do not attempt to read TLS certificates")
 	cmd.Flags().BoolVar(&options.MTLS, "mtls", false, "disable enforce mTLS")
@@ -125,6 +159,33 @@ func NewCommand() *cobra.Command {
 	cmd.Flags().StringVar(&options.OIDC.GroupsPrefix, "oidc-group-prefix", "", "Prefix to add to the groups when impersonating")
 	// auth
 	cmd.Flags().StringVar(&options.NoAuthUser, InsecureNoAuthenticationUserFlag, "", "A kubernetes user to impersonate for all requests, no authentication will be performed")
+	cmd.Flags().BoolVar(&options.TokenReview, "token-passthrough-review", false, "Fill the user and groups of the token-passthrough auth method from the TokenReview of the tokens, for the audit log and the UI")
+	cmd.Flags().DurationVar(&options.TokenReviewTTL, "token-passthrough-review-ttl", auth.DefaultTokenReviewTTL, "How long to cache the TokenReviews of the token-passthrough auth method for")
+	cmd.Flags().DurationVar(&options.APITokenCacheTTL, "api-token-cache-ttl", auth.DefaultAPITokenCacheTTL, "How long to cache the API tokens for, a revoked token keeps working for up to this long")
+	// Sessions
+	cmd.Flags().StringVar(&options.SessionStore.Type, "session-store", auth.SessionStoreMemory, fmt.Sprintf("Where to store sessions, valid values are %s. Use a persistent store when running several replicas", strings.Join(auth.SessionStores(), ",")))
+	cmd.Flags().StringVar(&options.SessionStore.KeySecretName, "session-key-secret-name", auth.DefaultSessionKeySecretName, "Name of the secret holding the key persistent sessions are encrypted with, created if it doesn't exist")
+	cmd.Flags().StringVar(&options.SessionStore.Redis.Address, "session-redis-address", "", "The host:port of the redis server for the redis session store")
+	cmd.Flags().StringVar(&options.SessionStore.Redis.Username, "session-redis-username", "", "The username to authenticate to redis with")
+	cmd.Flags().StringVar(&options.SessionRedisPasswordFile, "session-redis-password-file", "", fmt.Sprintf("File holding the password to authenticate to redis with, such as a mounted secret. Otherwise the password is read from the %s environment variable", sessionRedisPasswordEnvVar))
+	cmd.Flags().BoolVar(&options.SessionStore.Redis.TLS, "session-redis-tls", false, "Connect to redis using TLS")
+	cmd.Flags().BoolVar(&options.TrackSessions, "track-sessions", false, fmt.Sprintf("Record each session of the signed in users in a secret labelled %s, so admins can list and revoke them", auth.SessionRecordLabel))
+	// Audit
+	cmd.Flags().StringVar(&options.Audit.File, "audit-log-file", "", "File to append the audit events of users changing objects and signing in to, as JSON lines")
+	cmd.Flags().BoolVar(&options.Audit.KubernetesEvents, "audit-kubernetes-events", false, "Record the audit events of users changing objects as Kubernetes Events on the objects")
+	cmd.Flags().StringVar(&options.Audit.WebhookURL, "audit-webhook-url", "", "URL to POST the audit events to as JSON")
+	cmd.Flags().StringSliceVar(&options.TrustedProxies, "trusted-proxies", nil, "IP addresses or CIDRs of the proxies in front of Weave GitOps trusted to set X-Forwarded-For, for the source IP of the audit events and sessions. Without them, the address of the connection is used")
+	// Authorization
+	cmd.Flags().StringVar(&options.AuthorizationPolicyFile, "authorization-policy-file", "", "File of rules restricting the API calls users can make by group, RPC, cluster, namespace and kind, on top of Kubernetes RBAC")
+
+	// Health checks
+	cmd.Flags().StringVar(&options.HealthChecksConfigMap, "health-checks-configmap", "", "Name of a ConfigMap in the server's namespace holding CEL health checks for custom resources, reloaded when it changes")
+	cmd.Flags().BoolVar(&options.HealthHistory, "health-history", false, "Record the health changes of the Deployments, StatefulSets and DaemonSets managed by Flux, to tell which ones are flapping")
+	cmd.Flags().DurationVar(&options.HealthHistoryInterval, "health-history-interval", core.DefaultHealthHistoryInterval, "How often the health of the objects is sampled for the health history")
+	cmd.Flags().IntVar(&options.HealthHistoryOptions.Size, "health-history-size", health.DefaultHistorySize, "How many health changes are kept per object")
+	cmd.Flags().DurationVar(&options.HealthHistoryOptions.FlappingWindow, "health-history-flapping-window", health.DefaultFlappingWindow, "How far back health changes are counted to tell if an object is flapping")
+	cmd.Flags().IntVar(&options.HealthHistoryOptions.FlappingThreshold, "health-history-flapping-threshold", health.DefaultFlappingThreshold, "How many health changes within the flapping window make an object flapping")
+	cmd.Flags().StringVar(&options.HealthHistoryConfigMap, "health-history-configmap", "", "Name of a ConfigMap in the server's namespace to persist the health history to, it's only kept in memory if empty")
 
 	// Metrics
 	cmd.Flags().BoolVar(&options.EnableMetrics, "enable-metrics", false, "Starts the metrics listener")
@@ -147,6 +208,17 @@ func runCmd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("%s flag set but no user specified", InsecureNoAuthenticationUserFlag)
 	}
 
+	var authorizationPolicy *authz.Policy
+
+	if options.AuthorizationPolicyFile != "" {
+		authorizationPolicy, err = authz.LoadPolicy(options.AuthorizationPolicyFile)
+		if err != nil {
+			return fmt.Errorf("could not load the authorization policy: %w", err)
+		}
+
+		log.Info("Loaded authorization policy", "file", options.AuthorizationPolicyFile, "rules", len(authorizationPolicy.Rules))
+	}
+
 	mux := http.NewServeMux()
 
 	mux.Handle("/health/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -184,9 +256,43 @@ func runCmd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("couldn't get current namespace")
 	}
 
-	sessionManager := scs.New()
+	options.SessionStore.Namespace = namespace
+
+	options.SessionStore.Redis.Password, err = sessionRedisPassword(options.SessionRedisPasswordFile)
+	if err != nil {
+		return err
+	}
+
+	sessionManager, err := auth.NewSessionManager(cmd.Context(), log, rawClient, options.SessionStore)
+	if err != nil {
+		return fmt.Errorf("could not create session manager: %w", err)
+	}
 	// TODO: Make this configurable
 	sessionManager.Lifetime = 24 * time.Hour
+
+	// The clusters manager is created after the auth server, it's only used
+	// for the Kubernetes Events of API calls, which are served later on.
+	var clustersManager clustersmngr.ClustersManager
+
+	trustedProxies, err := audit.ParseTrustedProxies(options.TrustedProxies)
+	if err != nil {
+		return err
+	}
+
+	auditSinks, err := audit.NewSinks(log, options.Audit, func(ctx context.Context, cluster string) (client.Client, error) {
+		c, err := clustersManager.GetServerClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return c.Scoped(cluster)
+	})
+	if err != nil {
+		return fmt.Errorf("could not create audit sinks: %w", err)
+	}
+
+	auditRecorder := audit.NewRecorder(log, auditSinks...)
+
 	authServer, err := auth.InitAuthServer(cmd.Context(), log, rawClient, auth.AuthParams{
 		OIDCConfig:        options.OIDC,
 		OIDCSecretName:    options.OIDCSecret,
@@ -194,6 +300,12 @@ func runCmd(cmd *cobra.Command, args []string) error {
 		NoAuthUser:        options.NoAuthUser,
 		Namespace:         namespace,
 		SessionManager:    sessionManager,
+		Audit:             auditRecorder,
+		TrustedProxies:    trustedProxies,
+		TrackSessions:     options.TrackSessions,
+		TokenReview:       options.TokenReview,
+		TokenReviewTTL:    options.TokenReviewTTL,
+		APITokenCacheTTL:  options.APITokenCacheTTL,
 	})
 	if err != nil {
 		return fmt.Errorf("could not initialise authentication server: %w", err)
@@ -242,22 +354,67 @@ func runCmd(cmd *cobra.Command, args []string) error {
 		cl = cluster.NewDelegatingCacheCluster(cl, rest, scheme)
 	}
 
-	fetcher := fetcher.NewSingleClusterFetcher(cl)
+	fetchers := []clustersmngr.ClusterFetcher{fetcher.NewSingleClusterFetcher(cl)}
 
-	clustersManager := clustersmngr.NewClustersManager([]clustersmngr.ClusterFetcher{fetcher}, nsaccess.NewChecker(nsaccess.DefautltWegoAppRules), log)
+	if options.KubeConfigDir != "" {
+		fetchers = append(fetchers, fetcher.NewKubeConfigDirFetcher(log, options.KubeConfigDir, scheme, oidcPrefixes, cluster.DefaultKubeConfigOptions...))
+	}
+
+	if options.KubeConfigSecretSelector != "" {
+		selector, err := labels.Parse(options.KubeConfigSecretSelector)
+		if err != nil {
+			return fmt.Errorf("invalid kubeconfig secret selector: %w", err)
+		}
+
+		fetchers = append(fetchers, fetcher.NewKubeConfigSecretFetcher(log, rawClient, namespace, selector, scheme, oidcPrefixes, cluster.DefaultKubeConfigOptions...))
+	}
+
+	if options.CAPIClusters {
+		fetchers = append(fetchers, fetcher.NewCAPIClusterFetcher(log, rawClient, options.CAPIClustersNamespace, scheme, oidcPrefixes, cluster.DefaultKubeConfigOptions...))
+	}
+
+	clustersManager = clustersmngr.NewClustersManager(fetchers, nsaccess.NewChecker(nsaccess.DefautltWegoAppRules), log)
 	clustersManager.Start(ctx)
 
 	healthChecker := health.NewHealthChecker()
 
+	if options.HealthChecksConfigMap != "" {
+		customHealthChecker := health.NewCustomHealthChecker()
+		go customHealthChecker.Watch(ctx, log, rawClient, client.ObjectKey{Namespace: namespace, Name: options.HealthChecksConfigMap})
+
+		healthChecker = customHealthChecker
+	}
+
 	coreConfig, err := core.NewCoreConfig(log, rest, clusterName, clustersManager, healthChecker)
 	if err != nil {
 		return fmt.Errorf("could not create core config: %w", err)
 	}
 
+	coreConfig.Audit = auditRecorder
+	coreConfig.TrustedProxies = trustedProxies
+	coreConfig.SourceControllerAddress = options.SourceControllerAddress
+
+	if options.HealthHistory {
+		history := health.NewHistory(options.HealthHistoryOptions)
+
+		recorder := core.NewHealthHistoryRecorder(log, core.HealthHistoryRecorderConfig{
+			ClustersManager: clustersManager,
+			HealthChecker:   healthChecker,
+			History:         history,
+			Interval:        options.HealthHistoryInterval,
+			Client:          rawClient,
+			ConfigMap:       client.ObjectKey{Namespace: namespace, Name: options.HealthHistoryConfigMap},
+		})
+		go recorder.Run(ctx)
+
+		coreConfig.HealthHistory = history
+	}
+
 	appAndProfilesHandlers, err := server.NewHandlers(ctx, log,
 		&server.Config{
-			CoreServerConfig: coreConfig,
-			AuthServer:       authServer,
+			CoreServerConfig:    coreConfig,
+			AuthServer:          authServer,
+			AuthorizationPolicy: authorizationPolicy,
 		},
 		sessionManager,
 	)
@@ -346,6 +503,10 @@ func runCmd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("server shutdown failed: %w", err)
 	}
 
+	if err := auditRecorder.Close(); err != nil {
+		log.Error(err, "Failed to close the audit sinks")
+	}
+
 	if options.EnableMetrics {
 		if err := metricsServer.Shutdown(ctx); err != nil {
 			return fmt.Errorf("metrics server shutdown failed: %w", err)
@@ -388,6 +549,22 @@ func listenAndServe(log logr.Logger, srv *http.Server, options Options) error {
 	return srv.ListenAndServeTLS(options.TLSCertFile, options.TLSKeyFile)
 }
 
+// sessionRedisPassword reads the redis password from the file if one is
+// given, and from the environment otherwise, so it never shows up in the
+// arguments of the process.
+func sessionRedisPassword(file string) (string, error) {
+	if file == "" {
+		return os.Getenv(sessionRedisPasswordEnvVar), nil
+	}
+
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("could not read the redis password: %w", err)
+	}
+
+	return strings.TrimSpace(string(b)), nil
+}
+
 func getAssets() fs.FS {
 	exec, err := os.Executable()
 	if err != nil {
@@ -30,6 +30,9 @@ func OIDCConfigCommand(opts *config.Options) *cobra.Command {
 		claimUsernameFlag string
 		claimGroupsFlag   string
 		issuerURLFlag     string
+		usernameExprFlag  string
+		groupsExprFlag    string
+		claimsFileFlag    string
 	)
 
 	cmd := &cobra.Command{
@@ -37,6 +40,8 @@ func OIDCConfigCommand(opts *config.Options) *cobra.Command {
 		Short: "Check an OIDC configuration for proper functionality.",
 		Long: `This command will send the user through an OIDC authorization code flow using the given OIDC configuration. This is helpful for verifying that a given configuration will work properly with Weave GitOps or for debugging issues. Without any provided flags it will read the configuration from a Secret on the cluster.
 
+The claim expressions computing the user and groups can be checked without logging in, by passing the ID token claims as a JSON file.
+
 NOTE: Make sure to configure your OIDC provider so that it accepts "http://localhost:9876" as redirect URI.`,
 		Example: `
 # Check the OIDC configuration stored in the flux-system/oidc-auth Secret
@@ -50,6 +55,9 @@ gitops check oidc-config --claim-username=sub
 
 # Check configuration without fetching a Secret from the cluster
 gitops check oidc-config --skip-secret --client-id=CID --client-secret=SEC --issuer-url=https://example.org
+
+# Check a groups expression against the claims of an ID token, without logging in
+gitops check oidc-config --claims-file=claims.json --groups-expression='claims.roles.filter(r, r.startsWith("k8s-"))'
 		`,
 		SilenceUsage:  true,
 		SilenceErrors: true,
@@ -63,7 +71,7 @@ gitops check oidc-config --skip-secret --client-id=CID --client-secret=SEC --iss
 				return err
 			}
 
-			if fromSecretFlag == "" {
+			if fromSecretFlag == "" && claimsFileFlag == "" {
 				if clientIDFlag == "" ||
 					clientSecretFlag == "" ||
 					issuerURLFlag == "" {
@@ -83,17 +91,31 @@ gitops check oidc-config --skip-secret --client-id=CID --client-secret=SEC --iss
 				return fmt.Errorf("failed getting namespace flag: %w", err)
 			}
 
+			var claimExpressions *auth.ClaimExpressions
+			if usernameExprFlag != "" || groupsExprFlag != "" {
+				claimExpressions = &auth.ClaimExpressions{
+					Username: usernameExprFlag,
+					Groups:   groupsExprFlag,
+				}
+
+				if err := claimExpressions.Compile(); err != nil {
+					return fmt.Errorf("invalid claim expression: %w", err)
+				}
+			}
+
 			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
 			defer cancel()
 			claims, err := check.GetPrincipal(ctx, check.Options{
-				ClientID:        clientIDFlag,
-				ClientSecret:    clientSecretFlag,
-				IssuerURL:       issuerURLFlag,
-				SecretName:      fromSecretFlag,
-				SecretNamespace: ns,
-				Scopes:          scopesFlag,
-				ClaimUsername:   claimUsernameFlag,
-				ClaimGroups:     claimGroupsFlag,
+				ClientID:         clientIDFlag,
+				ClientSecret:     clientSecretFlag,
+				IssuerURL:        issuerURLFlag,
+				SecretName:       fromSecretFlag,
+				SecretNamespace:  ns,
+				Scopes:           scopesFlag,
+				ClaimUsername:    claimUsernameFlag,
+				ClaimGroups:      claimGroupsFlag,
+				ClaimExpressions: claimExpressions,
+				ClaimsFile:       claimsFileFlag,
 			}, log, kubeClient)
 			if err != nil {
 				return fmt.Errorf("failed getting claims: %w", err)
@@ -122,6 +144,9 @@ gitops check oidc-config --skip-secret --client-id=CID --client-secret=SEC --iss
 		"Do not read OIDC configuration from a Kubernetes Secret but rely solely on the values from the given flags.")
 	cmd.Flags().StringVar(&claimUsernameFlag, "username-claim", "", "ID token claim to use for the user name.")
 	cmd.Flags().StringVar(&claimGroupsFlag, "groups-claim", "", "ID token claim to use for the groups.")
+	cmd.Flags().StringVar(&usernameExprFlag, "username-expression", "", "CEL expression computing the user name from the claims, instead of the expressions in the Secret.")
+	cmd.Flags().StringVar(&groupsExprFlag, "groups-expression", "", "CEL expression computing the groups from the claims, instead of the expressions in the Secret.")
+	cmd.Flags().StringVar(&claimsFileFlag, "claims-file", "", "JSON file of ID token claims to compute the user and groups from, instead of logging in.")
 	cmd.Flags().StringSliceVar(&scopesFlag, "scopes", nil, fmt.Sprintf("OIDC scopes to request (default [%s])", strings.Join(auth.DefaultScopes, ",")))
 
 	return cmd
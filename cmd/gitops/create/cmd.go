@@ -8,6 +8,8 @@ import (
 	"github.com/weaveworks/weave-gitops/cmd/gitops/config"
 	"github.com/weaveworks/weave-gitops/cmd/gitops/create/dashboard"
 	"github.com/weaveworks/weave-gitops/cmd/gitops/create/terraform"
+	"github.com/weaveworks/weave-gitops/cmd/gitops/create/token"
+	"github.com/weaveworks/weave-gitops/cmd/gitops/create/user"
 )
 
 type CreateCommandFlags struct {
@@ -34,6 +36,12 @@ gitops create terraform my-resource \
   --path ./terraform \
   --interval 1m \
   --export > ./clusters/my-cluster/infra/terraform-my-resource.yaml
+
+# Create an API token for a CI bot
+gitops create token ci-bot --user ci-bot --groups ci
+
+# Create a local user account in the developers group
+gitops create user alice --groups developers
 		`,
 	}
 
@@ -42,6 +50,8 @@ gitops create terraform my-resource \
 
 	cmd.AddCommand(dashboard.DashboardCommand(opts))
 	cmd.AddCommand(terraform.Command(opts))
+	cmd.AddCommand(token.Command(opts))
+	cmd.AddCommand(user.Command(opts))
 
 	return cmd
 }
@@ -0,0 +1,100 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/weaveworks/weave-gitops/cmd/gitops/cmderrors"
+	"github.com/weaveworks/weave-gitops/cmd/gitops/config"
+	"github.com/weaveworks/weave-gitops/pkg/logger"
+	"github.com/weaveworks/weave-gitops/pkg/run"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+// Command returns the cobra command for running `create token`.
+func Command(opts *config.Options) *cobra.Command {
+	var (
+		kubeConfigArgs *genericclioptions.ConfigFlags
+		userFlag       string
+		groupsFlag     []string
+		expiresInFlag  time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "Create an API token for automation clients",
+		Long: `Create a named API token for automation clients such as CI bots. Requests using the token impersonate the given user and groups.
+
+The token is only printed once, and the dashboard must be run with the api-token auth method enabled.`,
+		Example: `
+# Create a token for a CI bot, valid for 30 days
+gitops create token ci-bot --user ci-bot --groups ci,deployers --expires-in 720h
+
+# Use the token
+curl -H "Authorization: Bearer $TOKEN" https://gitops.example.com/v1/objects
+`,
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, err := cmd.Flags().GetString("namespace")
+			if err != nil {
+				return fmt.Errorf("failed getting namespace flag: %w", err)
+			}
+
+			cfg, err := kubeConfigArgs.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			// Only the token goes to stdout, so it can be captured.
+			log := logger.NewCLILogger(os.Stderr)
+
+			kubeClient, err := run.GetKubeClient(log, *kubeConfigArgs.Context, cfg, nil)
+			if err != nil {
+				return cmderrors.ErrGetKubeClient
+			}
+
+			entry := auth.APITokenEntry{
+				Name:   args[0],
+				User:   userFlag,
+				Groups: groupsFlag,
+			}
+
+			if expiresInFlag > 0 {
+				expiresAt := time.Now().Add(expiresInFlag).UTC().Truncate(time.Second)
+				entry.ExpiresAt = &expiresAt
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+
+			token, err := auth.CreateAPIToken(ctx, kubeClient, namespace, entry)
+			if err != nil {
+				return err
+			}
+
+			log.Successf("Created token %s for user %s, store it now as it won't be shown again", entry.Name, entry.User)
+			fmt.Println(token)
+
+			return nil
+		},
+		DisableAutoGenTag: true,
+	}
+
+	kubeConfigArgs = run.GetKubeConfigArgs()
+	kubeConfigArgs.AddFlags(cmd.Flags())
+	kubeConfigArgs.KubeConfig = &opts.Kubeconfig
+
+	cmd.Flags().StringVar(&userFlag, "user", "", "The user to impersonate for requests using the token")
+	cmd.Flags().StringSliceVar(&groupsFlag, "groups", nil, "The groups to impersonate for requests using the token")
+	cmd.Flags().DurationVar(&expiresInFlag, "expires-in", 90*24*time.Hour, "How long the token is valid for, 0 for never expiring")
+	cobra.CheckErr(cmd.MarkFlagRequired("user"))
+
+	return cmd
+}
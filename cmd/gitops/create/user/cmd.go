@@ -0,0 +1,129 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/term"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/weaveworks/weave-gitops/cmd/gitops/cmderrors"
+	"github.com/weaveworks/weave-gitops/cmd/gitops/config"
+	"github.com/weaveworks/weave-gitops/pkg/logger"
+	"github.com/weaveworks/weave-gitops/pkg/run"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+// Command returns the cobra command for running `create user`.
+func Command(opts *config.Options) *cobra.Command {
+	var (
+		kubeConfigArgs *genericclioptions.ConfigFlags
+		groupsFlag     []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "user",
+		Short: "Create or update a local user account",
+		Long: `Create a local user account that can log in to the dashboard with a password, impersonating the user and its groups.
+
+An existing user with the same name is replaced. The password is read from stdin, or prompted for.`,
+		Example: `
+# Create a user in the developers group, prompting for the password
+gitops create user alice --groups developers
+
+# Create a user with the password from stdin
+echo -n $PASSWORD | gitops create user bob --groups developers,admins
+`,
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, err := cmd.Flags().GetString("namespace")
+			if err != nil {
+				return fmt.Errorf("failed getting namespace flag: %w", err)
+			}
+
+			password, err := readPassword()
+			if err != nil {
+				return err
+			}
+
+			if password == "" {
+				return fmt.Errorf("a password is required")
+			}
+
+			hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+			if err != nil {
+				return err
+			}
+
+			cfg, err := kubeConfigArgs.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			log := logger.NewCLILogger(os.Stdout)
+
+			kubeClient, err := run.GetKubeClient(log, *kubeConfigArgs.Context, cfg, nil)
+			if err != nil {
+				return cmderrors.ErrGetKubeClient
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+
+			user := auth.LocalUser{
+				Username:     args[0],
+				PasswordHash: string(hash),
+				Groups:       groupsFlag,
+			}
+
+			if err := auth.SetLocalUser(ctx, kubeClient, namespace, user); err != nil {
+				return err
+			}
+
+			log.Successf("Saved user %s", user.Username)
+
+			return nil
+		},
+		DisableAutoGenTag: true,
+	}
+
+	kubeConfigArgs = run.GetKubeConfigArgs()
+	kubeConfigArgs.AddFlags(cmd.Flags())
+	kubeConfigArgs.KubeConfig = &opts.Kubeconfig
+
+	cmd.Flags().StringSliceVar(&groupsFlag, "groups", nil, "The groups to impersonate for the user")
+
+	return cmd
+}
+
+func readPassword() (string, error) {
+	stats, err := os.Stdin.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	if (stats.Mode() & os.ModeCharDevice) == 0 {
+		p, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", err
+		}
+
+		return strings.TrimRight(string(p), "\r\n"), nil
+	}
+
+	fmt.Print("Enter Password: ")
+
+	p, err := term.ReadPassword(int(os.Stdin.Fd()))
+
+	fmt.Println()
+
+	return string(p), err
+}
@@ -4,7 +4,10 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/weaveworks/weave-gitops/cmd/gitops/config"
+	"github.com/weaveworks/weave-gitops/cmd/gitops/delete/session"
 	"github.com/weaveworks/weave-gitops/cmd/gitops/delete/terraform"
+	"github.com/weaveworks/weave-gitops/cmd/gitops/delete/token"
+	"github.com/weaveworks/weave-gitops/cmd/gitops/delete/user"
 )
 
 func GetCommand(opts *config.Options) *cobra.Command {
@@ -13,7 +16,10 @@ func GetCommand(opts *config.Options) *cobra.Command {
 		Short: "Delete a resource",
 	}
 
+	cmd.AddCommand(session.Command(opts))
 	cmd.AddCommand(terraform.Command(opts))
+	cmd.AddCommand(token.Command(opts))
+	cmd.AddCommand(user.Command(opts))
 
 	return cmd
 }
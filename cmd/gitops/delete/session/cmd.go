@@ -0,0 +1,72 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/weaveworks/weave-gitops/cmd/gitops/cmderrors"
+	"github.com/weaveworks/weave-gitops/cmd/gitops/config"
+	"github.com/weaveworks/weave-gitops/pkg/logger"
+	"github.com/weaveworks/weave-gitops/pkg/run"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+// Command returns the cobra command for running `delete session`.
+func Command(opts *config.Options) *cobra.Command {
+	var kubeConfigArgs *genericclioptions.ConfigFlags
+
+	cmd := &cobra.Command{
+		Use:   "session",
+		Short: "Revoke the session of a dashboard user",
+		Long:  "Revoke the session of a dashboard user, who has to sign in again. It takes up to 10 seconds for every gitops-server replica to reject the session.",
+		Example: `
+# Revoke a session listed by gitops get sessions
+gitops delete session mzq4tgsqg7jqnbl2fsmrjxbhdy
+`,
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, err := cmd.Flags().GetString("namespace")
+			if err != nil {
+				return fmt.Errorf("failed getting namespace flag: %w", err)
+			}
+
+			cfg, err := kubeConfigArgs.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			log := logger.NewCLILogger(os.Stdout)
+
+			kubeClient, err := run.GetKubeClient(log, *kubeConfigArgs.Context, cfg, nil)
+			if err != nil {
+				return cmderrors.ErrGetKubeClient
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+
+			session, err := auth.RevokeSession(ctx, kubeClient, namespace, args[0])
+			if err != nil {
+				return err
+			}
+
+			log.Successf("Revoked session %s of user %s", session.ID, session.User)
+
+			return nil
+		},
+		DisableAutoGenTag: true,
+	}
+
+	kubeConfigArgs = run.GetKubeConfigArgs()
+	kubeConfigArgs.AddFlags(cmd.Flags())
+	kubeConfigArgs.KubeConfig = &opts.Kubeconfig
+
+	return cmd
+}
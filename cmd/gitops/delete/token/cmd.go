@@ -0,0 +1,71 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/weaveworks/weave-gitops/cmd/gitops/cmderrors"
+	"github.com/weaveworks/weave-gitops/cmd/gitops/config"
+	"github.com/weaveworks/weave-gitops/pkg/logger"
+	"github.com/weaveworks/weave-gitops/pkg/run"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+// Command returns the cobra command for running `delete token`.
+func Command(opts *config.Options) *cobra.Command {
+	var kubeConfigArgs *genericclioptions.ConfigFlags
+
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "Revoke an API token",
+		Long:  "Revoke an API token, which stops working once the servers' cache of the tokens expires, within 10 seconds by default. Revoked tokens are still listed by `gitops get tokens`.",
+		Example: `
+# Revoke the token of a CI bot
+gitops delete token ci-bot
+`,
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, err := cmd.Flags().GetString("namespace")
+			if err != nil {
+				return fmt.Errorf("failed getting namespace flag: %w", err)
+			}
+
+			cfg, err := kubeConfigArgs.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			log := logger.NewCLILogger(os.Stdout)
+
+			kubeClient, err := run.GetKubeClient(log, *kubeConfigArgs.Context, cfg, nil)
+			if err != nil {
+				return cmderrors.ErrGetKubeClient
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+
+			if err := auth.RevokeAPIToken(ctx, kubeClient, namespace, args[0]); err != nil {
+				return err
+			}
+
+			log.Successf("Revoked token %s", args[0])
+
+			return nil
+		},
+		DisableAutoGenTag: true,
+	}
+
+	kubeConfigArgs = run.GetKubeConfigArgs()
+	kubeConfigArgs.AddFlags(cmd.Flags())
+	kubeConfigArgs.KubeConfig = &opts.Kubeconfig
+
+	return cmd
+}
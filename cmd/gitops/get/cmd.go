@@ -6,6 +6,10 @@ import (
 	"github.com/weaveworks/weave-gitops/cmd/gitops/config"
 	"github.com/weaveworks/weave-gitops/cmd/gitops/get/bcrypt"
 	configCmd "github.com/weaveworks/weave-gitops/cmd/gitops/get/config"
+	"github.com/weaveworks/weave-gitops/cmd/gitops/get/images"
+	"github.com/weaveworks/weave-gitops/cmd/gitops/get/owner"
+	"github.com/weaveworks/weave-gitops/cmd/gitops/get/sessions"
+	"github.com/weaveworks/weave-gitops/cmd/gitops/get/tokens"
 )
 
 func GetCommand(opts *config.Options) *cobra.Command {
@@ -18,11 +22,27 @@ gitops get config
 
 # Generate a hashed secret
 PASSWORD="<your password>"
-echo -n $PASSWORD | gitops get bcrypt-hash`,
+echo -n $PASSWORD | gitops get bcrypt-hash
+
+# List the API tokens for automation clients
+gitops get tokens
+
+# List the sessions of the dashboard users
+gitops get sessions
+
+# Find the Flux automations managing an object
+gitops get owner deployment/podinfo --server https://gitops.example.com
+
+# List the images of the workloads on all the clusters
+gitops get images --server https://gitops.example.com`,
 	}
 
 	cmd.AddCommand(bcrypt.HashCommand(opts))
 	cmd.AddCommand(configCmd.ConfigCommand(opts))
+	cmd.AddCommand(tokens.Command(opts))
+	cmd.AddCommand(sessions.Command(opts))
+	cmd.AddCommand(owner.Command(opts))
+	cmd.AddCommand(images.Command(opts))
 
 	return cmd
 }
@@ -0,0 +1,142 @@
+package images
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/weaveworks/weave-gitops/cmd/gitops/config"
+	pb "github.com/weaveworks/weave-gitops/pkg/api/core"
+	"github.com/weaveworks/weave-gitops/pkg/apiclient"
+	"github.com/weaveworks/weave-gitops/pkg/logger"
+)
+
+// Command returns the cobra command for running `get images`.
+func Command(opts *config.Options) *cobra.Command {
+	var (
+		serverFlag     string
+		clusterFlag    string
+		registryFlag   string
+		repositoryFlag string
+		tagFlag        string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "images",
+		Aliases: []string{"image"},
+		Short:   "List the images of the workloads on all the clusters",
+		Long: `This command lists the images run by the Deployments, StatefulSets, DaemonSets, Jobs and CronJobs on all the clusters of a running Weave GitOps server, grouped by repository and tag, with the Kustomizations and HelmReleases the workloads are labelled as managed by. It lists the workloads in all the namespaces unless --namespace is set.
+
+Log in to the server with gitops login first. Only the workloads the user is allowed to see are listed.`,
+		Example: `
+# List the images of all the workloads
+gitops get images --server https://gitops.example.com
+
+# Find the clusters still running a vulnerable version of an image
+gitops get images --repository ghcr.io/stefanprodan/podinfo --tag 6.5.0 --server https://gitops.example.com
+
+# List the images pulled from Docker Hub on a cluster
+gitops get images --registry docker.io --cluster Default --server https://gitops.example.com`,
+		Args:              cobra.NoArgs,
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := &pb.ListImagesRequest{
+				ClusterName: clusterFlag,
+				Registry:    registryFlag,
+				Repository:  repositoryFlag,
+				Tag:         tagFlag,
+			}
+
+			// The namespace flag defaults to flux-system, which only restricts
+			// the listing when it's set explicitly.
+			if cmd.Flags().Changed("namespace") {
+				namespace, err := cmd.Flags().GetString("namespace")
+				if err != nil {
+					return fmt.Errorf("failed getting namespace flag: %w", err)
+				}
+
+				req.Namespace = namespace
+			}
+
+			client, err := apiclient.New(serverFlag, apiclient.NewHTTPClient(opts.InsecureSkipTLSVerify))
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), time.Minute)
+			defer cancel()
+
+			res, err := client.ListImages(ctx, req)
+			if err != nil {
+				return err
+			}
+
+			log := logger.NewCLILogger(os.Stdout)
+
+			for _, e := range res.Errors {
+				log.Warningf("Failed listing cluster %s namespace %s: %s", e.ClusterName, e.Namespace, e.Message)
+			}
+
+			if len(res.Images) == 0 {
+				log.Println("No images found")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+			fmt.Fprintln(w, "IMAGE\tCLUSTER\tWORKLOAD\tOWNERS")
+
+			for _, image := range res.Images {
+				for _, workload := range image.Workloads {
+					owners := make([]string, 0, len(workload.Owners))
+					for _, owner := range workload.Owners {
+						owners = append(owners, apiclient.ObjectName(owner))
+					}
+
+					if len(owners) == 0 {
+						owners = append(owners, "-")
+					}
+
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+						imageName(image),
+						workload.Workload.ClusterName,
+						apiclient.ObjectName(workload.Workload),
+						strings.Join(owners, ","),
+					)
+				}
+			}
+
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().StringVar(&serverFlag, "server", "", "The URL of the Weave GitOps server, including its route prefix")
+	cmd.Flags().StringVar(&clusterFlag, "cluster", "", "The cluster to list, all the clusters of the server by default")
+	cmd.Flags().StringVar(&registryFlag, "registry", "", "Only list the images of this registry, docker.io for the images without one")
+	cmd.Flags().StringVar(&repositoryFlag, "repository", "", "Only list the images of this repository, such as redis or docker.io/library/redis for the same image")
+	cmd.Flags().StringVar(&tagFlag, "tag", "", "Only list the images with this tag")
+
+	cobra.CheckErr(cmd.MarkFlagRequired("server"))
+
+	return cmd
+}
+
+// imageName returns the reference of image.
+func imageName(image *pb.Image) string {
+	name := image.Repository
+	if image.Tag != "" {
+		name += ":" + image.Tag
+	}
+
+	if image.Digest != "" {
+		name += "@" + image.Digest
+	}
+
+	return name
+}
@@ -0,0 +1,130 @@
+package owner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/weaveworks/weave-gitops/cmd/gitops/config"
+	pb "github.com/weaveworks/weave-gitops/pkg/api/core"
+	"github.com/weaveworks/weave-gitops/pkg/apiclient"
+	"github.com/weaveworks/weave-gitops/pkg/logger"
+)
+
+// Command returns the cobra command for running `get owner`.
+func Command(opts *config.Options) *cobra.Command {
+	var (
+		serverFlag  string
+		clusterFlag string
+		imageFlag   string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "owner [<kind>/<name>]",
+		Aliases: []string{"owners"},
+		Short:   "Find the Flux automations managing an object",
+		Long: `This command finds the Kustomizations and HelmReleases managing the objects of a kind and name, or the workloads running an image, on all the clusters of a running Weave GitOps server. It searches the objects in all the namespaces unless --namespace is set.
+
+Log in to the server with gitops login first. Only the objects the user is allowed to see are searched.`,
+		Example: `
+# Find the automations managing the podinfo deployments
+gitops get owner deployment/podinfo --server https://gitops.example.com
+
+# Find the automation managing a config map in a namespace of a cluster
+gitops get owner configmap/podinfo -n apps --cluster Default --server https://gitops.example.com
+
+# Find the workloads running an image, with any tag
+gitops get owner --image ghcr.io/stefanprodan/podinfo --server https://gitops.example.com`,
+		Args:              cobra.MaximumNArgs(1),
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := &pb.FindOwnersRequest{
+				ClusterName: clusterFlag,
+				Image:       imageFlag,
+			}
+
+			switch {
+			case len(args) == 1 && imageFlag != "":
+				return fmt.Errorf("either <kind>/<name> or --image can be set")
+			case len(args) == 1:
+				kind, name, ok := strings.Cut(args[0], "/")
+				if !ok || kind == "" || name == "" {
+					return fmt.Errorf("invalid object %q: must be <kind>/<name>", args[0])
+				}
+
+				req.Kind = kind
+				req.Name = name
+			case imageFlag == "":
+				return fmt.Errorf("either <kind>/<name> or --image is required")
+			}
+
+			// The namespace flag defaults to flux-system, which only restricts
+			// the search when it's set explicitly.
+			if cmd.Flags().Changed("namespace") {
+				namespace, err := cmd.Flags().GetString("namespace")
+				if err != nil {
+					return fmt.Errorf("failed getting namespace flag: %w", err)
+				}
+
+				req.Namespace = namespace
+			}
+
+			client, err := apiclient.New(serverFlag, apiclient.NewHTTPClient(opts.InsecureSkipTLSVerify))
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), time.Minute)
+			defer cancel()
+
+			res, err := client.FindOwners(ctx, req)
+			if err != nil {
+				return err
+			}
+
+			log := logger.NewCLILogger(os.Stdout)
+
+			for _, e := range res.Errors {
+				log.Warningf("Failed searching cluster %s namespace %s: %s", e.ClusterName, e.Namespace, e.Message)
+			}
+
+			if len(res.Objects) == 0 {
+				log.Println("No objects found")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+			fmt.Fprintln(w, "CLUSTER\tOBJECT\tOWNERS")
+
+			for _, o := range res.Objects {
+				owners := make([]string, 0, len(o.Owners))
+				for _, owner := range o.Owners {
+					owners = append(owners, apiclient.ObjectName(owner))
+				}
+
+				if len(owners) == 0 {
+					owners = append(owners, "-")
+				}
+
+				fmt.Fprintf(w, "%s\t%s\t%s\n", o.Object.ClusterName, apiclient.ObjectName(o.Object), strings.Join(owners, ","))
+			}
+
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().StringVar(&serverFlag, "server", "", "The URL of the Weave GitOps server, including its route prefix")
+	cmd.Flags().StringVar(&clusterFlag, "cluster", "", "The cluster to search, all the clusters of the server by default")
+	cmd.Flags().StringVar(&imageFlag, "image", "", "Find the workloads running this image, with any tag or digest unless it has one")
+
+	cobra.CheckErr(cmd.MarkFlagRequired("server"))
+
+	return cmd
+}
@@ -0,0 +1,96 @@
+package sessions
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/weaveworks/weave-gitops/cmd/gitops/cmderrors"
+	"github.com/weaveworks/weave-gitops/cmd/gitops/config"
+	"github.com/weaveworks/weave-gitops/pkg/logger"
+	"github.com/weaveworks/weave-gitops/pkg/run"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+// Command returns the cobra command for running `get sessions`.
+func Command(opts *config.Options) *cobra.Command {
+	var kubeConfigArgs *genericclioptions.ConfigFlags
+
+	cmd := &cobra.Command{
+		Use:     "sessions",
+		Aliases: []string{"session"},
+		Short:   "List the sessions of the users signed in to the dashboard",
+		Long:    "List the sessions of the users signed in to the dashboard. Sessions are only recorded if the gitops-server runs with --track-sessions.",
+		Example: `
+# List the sessions of the dashboard users
+gitops get sessions
+`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, err := cmd.Flags().GetString("namespace")
+			if err != nil {
+				return fmt.Errorf("failed getting namespace flag: %w", err)
+			}
+
+			cfg, err := kubeConfigArgs.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			log := logger.NewCLILogger(os.Stdout)
+
+			kubeClient, err := run.GetKubeClient(log, *kubeConfigArgs.Context, cfg, nil)
+			if err != nil {
+				return cmderrors.ErrGetKubeClient
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+
+			sessions, err := auth.ListSessions(ctx, kubeClient, namespace)
+			if err != nil {
+				return err
+			}
+
+			if len(sessions) == 0 {
+				log.Println("No sessions found in namespace %s", namespace)
+				return nil
+			}
+
+			now := time.Now()
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+			fmt.Fprintln(w, "ID\tUSER\tGROUPS\tAUTH METHOD\tIP\tSTATUS\tISSUED\tLAST SEEN")
+
+			for _, session := range sessions {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+					session.ID,
+					session.User,
+					strings.Join(session.Groups, ","),
+					session.AuthMethod,
+					session.IP,
+					session.Status(now),
+					session.IssuedAt.Format(time.RFC3339),
+					session.LastSeen.Format(time.RFC3339),
+				)
+			}
+
+			return w.Flush()
+		},
+		DisableAutoGenTag: true,
+	}
+
+	kubeConfigArgs = run.GetKubeConfigArgs()
+	kubeConfigArgs.AddFlags(cmd.Flags())
+	kubeConfigArgs.KubeConfig = &opts.Kubeconfig
+
+	return cmd
+}
@@ -0,0 +1,98 @@
+package tokens
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/weaveworks/weave-gitops/cmd/gitops/cmderrors"
+	"github.com/weaveworks/weave-gitops/cmd/gitops/config"
+	"github.com/weaveworks/weave-gitops/pkg/logger"
+	"github.com/weaveworks/weave-gitops/pkg/run"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+// Command returns the cobra command for running `get tokens`.
+func Command(opts *config.Options) *cobra.Command {
+	var kubeConfigArgs *genericclioptions.ConfigFlags
+
+	cmd := &cobra.Command{
+		Use:     "tokens",
+		Aliases: []string{"token"},
+		Short:   "List the API tokens for automation clients",
+		Example: `
+# List the API tokens
+gitops get tokens
+`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, err := cmd.Flags().GetString("namespace")
+			if err != nil {
+				return fmt.Errorf("failed getting namespace flag: %w", err)
+			}
+
+			cfg, err := kubeConfigArgs.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			log := logger.NewCLILogger(os.Stdout)
+
+			kubeClient, err := run.GetKubeClient(log, *kubeConfigArgs.Context, cfg, nil)
+			if err != nil {
+				return cmderrors.ErrGetKubeClient
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+
+			tokens, err := auth.ListAPITokens(ctx, kubeClient, namespace)
+			if err != nil {
+				return err
+			}
+
+			if len(tokens) == 0 {
+				log.Println("No API tokens found in namespace %s", namespace)
+				return nil
+			}
+
+			now := time.Now()
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+			fmt.Fprintln(w, "NAME\tUSER\tGROUPS\tSTATUS\tCREATED\tEXPIRES")
+
+			for _, token := range tokens {
+				expires := "never"
+				if token.ExpiresAt != nil {
+					expires = token.ExpiresAt.Format(time.RFC3339)
+				}
+
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+					token.Name,
+					token.User,
+					strings.Join(token.Groups, ","),
+					token.Status(now),
+					token.CreatedAt.Format(time.RFC3339),
+					expires,
+				)
+			}
+
+			return w.Flush()
+		},
+		DisableAutoGenTag: true,
+	}
+
+	kubeConfigArgs = run.GetKubeConfigArgs()
+	kubeConfigArgs.AddFlags(cmd.Flags())
+	kubeConfigArgs.KubeConfig = &opts.Kubeconfig
+
+	return cmd
+}
@@ -0,0 +1,100 @@
+package login
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	cfg "github.com/weaveworks/weave-gitops/cmd/gitops/config"
+	"github.com/weaveworks/weave-gitops/pkg/apiclient"
+	"github.com/weaveworks/weave-gitops/pkg/config"
+	"github.com/weaveworks/weave-gitops/pkg/logger"
+	"github.com/weaveworks/weave-gitops/pkg/oidc/login"
+)
+
+// Command returns the cobra command for running `login`.
+func Command(opts *cfg.Options) *cobra.Command {
+	var (
+		serverFlag     string
+		providerFlag   string
+		deviceCodeFlag bool
+		noBrowserFlag  bool
+		timeoutFlag    time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Log in to a Weave GitOps server",
+		Long: `This command logs in to a running Weave GitOps server with one of its OIDC providers, and stores the tokens the server issues in the CLI configuration, along with a refresh token when the provider grants one.
+
+By default the login happens in the browser, which hands the tokens back to the CLI on a local port. On machines without a browser, log in with the device code flow from another device instead, if the OIDC provider supports it.`,
+		Example: `
+# Log in to a Weave GitOps server through the browser
+gitops login --server https://gitops.example.com
+
+# Log in with a specific OIDC provider of the server
+gitops login --server https://gitops.example.com --provider dex
+
+# Log in from a machine without a browser
+gitops login --server https://gitops.example.com --device-code`,
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log := logger.NewCLILogger(os.Stdout)
+
+			client := apiclient.NewHTTPClient(opts.InsecureSkipTLSVerify)
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeoutFlag)
+			defer cancel()
+
+			serverLogin, err := login.Login(ctx, login.Options{
+				Server:     serverFlag,
+				Provider:   providerFlag,
+				DeviceCode: deviceCodeFlag,
+				NoBrowser:  noBrowserFlag,
+				HTTPClient: client,
+			}, log)
+			if err != nil {
+				return err
+			}
+
+			server, err := login.ServerKey(serverFlag)
+			if err != nil {
+				return err
+			}
+
+			gitopsConfig, err := config.GetConfig(true)
+			if err != nil {
+				return err
+			}
+
+			if gitopsConfig.Logins == nil {
+				gitopsConfig.Logins = map[string]config.ServerLogin{}
+			}
+
+			gitopsConfig.Logins[server] = *serverLogin
+
+			if err := config.SaveConfig(gitopsConfig); err != nil {
+				log.Failuref("Error saving GitOps CLI config")
+				return err
+			}
+
+			log.Successf("Logged in to %s", server)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&serverFlag, "server", "", "The URL of the Weave GitOps server, including its route prefix")
+	cmd.Flags().StringVar(&providerFlag, "provider", "", "The name of the OIDC provider to log in with, the first one of the server by default")
+	cmd.Flags().BoolVar(&deviceCodeFlag, "device-code", false, "Log in with the device code flow, from another device")
+	cmd.Flags().BoolVar(&noBrowserFlag, "no-browser", false, "Print the login URL instead of opening the browser")
+	cmd.Flags().DurationVar(&timeoutFlag, "timeout", 5*time.Minute, "How long to wait for the login to complete")
+
+	cobra.CheckErr(cmd.MarkFlagRequired("server"))
+
+	return cmd
+}
@@ -18,6 +18,7 @@ import (
 	deletepkg "github.com/weaveworks/weave-gitops/cmd/gitops/delete"
 	"github.com/weaveworks/weave-gitops/cmd/gitops/docs"
 	"github.com/weaveworks/weave-gitops/cmd/gitops/get"
+	"github.com/weaveworks/weave-gitops/cmd/gitops/login"
 	"github.com/weaveworks/weave-gitops/cmd/gitops/logs"
 	"github.com/weaveworks/weave-gitops/cmd/gitops/replan"
 	"github.com/weaveworks/weave-gitops/cmd/gitops/resume"
@@ -156,6 +157,7 @@ func RootCmd() *cobra.Command {
 	rootCmd.AddCommand(create.GetCommand(options))
 	rootCmd.AddCommand(deletepkg.GetCommand(options))
 	rootCmd.AddCommand(logs.GetCommand(options))
+	rootCmd.AddCommand(login.Command(options))
 	rootCmd.AddCommand(replan.Command(options))
 	rootCmd.AddCommand(resume.Command(options))
 	rootCmd.AddCommand(suspend.Command(options))
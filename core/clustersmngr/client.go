@@ -13,6 +13,9 @@ import (
 
 	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -39,8 +42,15 @@ type Client interface {
 	// This method supports pagination with a caveat, the client.Limit passed will be multiplied
 	// by the number of clusters and namespaces, we decided to do this to avoid the complex coordination
 	// that would be required to make sure the number of items returned match the limit passed.
+	// Pass CoordinatedPagination along with client.Limit to get exactly that many items per page instead.
 	ClusteredList(ctx context.Context, clist ClusteredObjectList, namespaced bool, opts ...client.ListOption) error
 
+	// ClusteredWatch loops through the list of clusters and namespaces the client has access and
+	// watches the objects of the list type returned by listFactory for each of them in parallel.
+	// Events from all the watches are merged in the returned channel, which is closed once ctx is done.
+	// Watches that are closed by the API server are restarted from the last seen resource version.
+	ClusteredWatch(ctx context.Context, listFactory func() client.ObjectList, namespaced bool, opts ...client.ListOption) <-chan WatchEvent
+
 	// ClientsPool returns the clients pool.
 	ClientsPool() ClientsPool
 
@@ -53,6 +63,8 @@ type Client interface {
 
 const (
 	clientTimeout = 30 * time.Second
+	// watchRetryInterval is how long to wait before re-establishing a watch that failed.
+	watchRetryInterval = 5 * time.Second
 )
 
 type clustersClient struct {
@@ -88,6 +100,16 @@ func (cle ClusteredListError) Error() string {
 	return strings.Join(errs, "; ")
 }
 
+// WatchEvent is an event received by ClusteredWatch from one of the watched clusters and namespaces.
+type WatchEvent struct {
+	Cluster   string
+	Namespace string
+	Type      watch.EventType
+	Object    client.Object
+	// Err is set when the watch could not be established or the API server reported an error.
+	Err error
+}
+
 func NewClient(clientsPool ClientsPool, namespaces map[string][]v1.Namespace, log logr.Logger) Client {
 	return &clustersClient{
 		pool:       clientsPool,
@@ -143,6 +165,10 @@ func (c *clustersClient) List(ctx context.Context, cluster string, list client.O
 }
 
 func (c *clustersClient) ClusteredList(ctx context.Context, clist ClusteredObjectList, namespaced bool, opts ...client.ListOption) error {
+	if limit, coordinated := extractCoordinatedPagination(opts...); coordinated && limit > 0 {
+		return c.coordinatedClusteredList(ctx, clist, namespaced, limit, opts...)
+	}
+
 	paginationInfo := &PaginationInfo{}
 
 	continueToken := extractContinueToken(opts...)
@@ -212,6 +238,137 @@ func (c *clustersClient) ClusteredList(ctx context.Context, clist ClusteredObjec
 	return nil
 }
 
+func (c *clustersClient) ClusteredWatch(ctx context.Context, listFactory func() client.ObjectList, namespaced bool, opts ...client.ListOption) <-chan WatchEvent {
+	events := make(chan WatchEvent)
+
+	wg := sync.WaitGroup{}
+
+	for clusterName, cc := range c.pool.Clients() {
+		namespaces := c.namespaces[clusterName]
+		if !namespaced {
+			namespaces = []v1.Namespace{{}}
+		}
+
+		for _, ns := range namespaces {
+			wg.Add(1)
+
+			go func(clusterName, nsName string, cc client.Client) {
+				defer wg.Done()
+
+				c.watchNamespace(ctx, events, clusterName, nsName, cc, listFactory, opts...)
+			}(clusterName, ns.Name, cc)
+		}
+	}
+
+	go func() {
+		wg.Wait()
+		close(events)
+	}()
+
+	return events
+}
+
+// watchNamespace keeps a watch open on a single cluster and namespace until ctx is done,
+// sending every event it receives to events.
+func (c *clustersClient) watchNamespace(ctx context.Context, events chan<- WatchEvent, clusterName, nsName string, cc client.Client, listFactory func() client.ObjectList, opts ...client.ListOption) {
+	send := func(ev WatchEvent) bool {
+		ev.Cluster = clusterName
+		ev.Namespace = nsName
+
+		select {
+		case events <- ev:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	wc, ok := cc.(client.WithWatch)
+	if !ok {
+		send(WatchEvent{Type: watch.Error, Err: fmt.Errorf("client for cluster %q does not support watching", clusterName)})
+		return
+	}
+
+	resourceVersion := ""
+
+	for ctx.Err() == nil {
+		watchOpts := append(append([]client.ListOption{}, opts...), client.InNamespace(nsName))
+		if resourceVersion != "" {
+			watchOpts = append(watchOpts, &client.ListOptions{Raw: &metav1.ListOptions{ResourceVersion: resourceVersion}})
+		}
+
+		w, err := wc.Watch(ctx, listFactory(), watchOpts...)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+
+			if !send(WatchEvent{Type: watch.Error, Err: err}) {
+				return
+			}
+
+			if apierrors.IsForbidden(err) || apierrors.IsNotFound(err) {
+				// retrying won't help, the user can't watch these objects.
+				return
+			}
+
+			resourceVersion = ""
+
+			select {
+			case <-time.After(watchRetryInterval):
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		resourceVersion = c.drainWatch(ctx, w, send, resourceVersion)
+	}
+}
+
+// drainWatch forwards the events of w until it is closed, returning the resource version
+// the watch should be restarted from.
+func (c *clustersClient) drainWatch(ctx context.Context, w watch.Interface, send func(WatchEvent) bool, resourceVersion string) string {
+	defer w.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return resourceVersion
+		case ev, ok := <-w.ResultChan():
+			if !ok {
+				return resourceVersion
+			}
+
+			if ev.Type == watch.Error {
+				err := apierrors.FromObject(ev.Object)
+				if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+					// the resource version is too old, restart from the current state.
+					return ""
+				}
+
+				if !send(WatchEvent{Type: watch.Error, Err: err}) {
+					return resourceVersion
+				}
+
+				continue
+			}
+
+			obj, ok := ev.Object.(client.Object)
+			if !ok {
+				c.log.Info("unexpected object in watch event", "type", fmt.Sprintf("%T", ev.Object))
+				continue
+			}
+
+			if ev.Type != watch.Bookmark && !send(WatchEvent{Type: ev.Type, Object: obj}) {
+				return resourceVersion
+			}
+
+			resourceVersion = obj.GetResourceVersion()
+		}
+	}
+}
+
 func extractContinueToken(opts ...client.ListOption) string {
 	for _, o := range opts {
 		switch v := o.(type) {
@@ -0,0 +1,266 @@
+package clustersmngr
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// coordinatedListConcurrency is how many cluster/namespace pairs are queried
+// at the same time while filling a page.
+const coordinatedListConcurrency = 10
+
+// CoordinatedPagination is a list option that makes ClusteredList return
+// exactly client.Limit items across all the clusters and namespaces, instead
+// of up to client.Limit items for each of them.
+// Clusters and namespaces are walked in name order, and the continue token
+// set on the list is an opaque cursor pointing at the next item to return.
+type CoordinatedPagination struct{}
+
+// ApplyToList is a no-op, so the option can be passed down to the clusters' clients.
+func (CoordinatedPagination) ApplyToList(*client.ListOptions) {}
+
+// CoordinatedPaginationInfo is the cursor encoded in the continue token of a
+// coordinated ClusteredList: the cluster and namespace to resume from, the
+// continue token to list them with, and how many of the items that listing
+// returns have already been handed out.
+type CoordinatedPaginationInfo struct {
+	Cluster   string `json:"cluster"`
+	Namespace string `json:"namespace"`
+	Continue  string `json:"continue,omitempty"`
+	Skip      int64  `json:"skip,omitempty"`
+}
+
+type listSource struct {
+	cluster    string
+	namespace  string
+	namespaces []v1.Namespace
+	client     client.Client
+}
+
+func (s listSource) before(cluster, namespace string) bool {
+	if s.cluster != cluster {
+		return s.cluster < cluster
+	}
+
+	return s.namespace < namespace
+}
+
+func extractCoordinatedPagination(opts ...client.ListOption) (int64, bool) {
+	var (
+		limit       int64
+		coordinated bool
+	)
+
+	for _, o := range opts {
+		switch v := o.(type) {
+		case client.Limit:
+			limit = int64(v)
+		case CoordinatedPagination, *CoordinatedPagination:
+			coordinated = true
+		}
+	}
+
+	return limit, coordinated
+}
+
+// listSources returns the cluster/namespace pairs the client can query, sorted by name.
+func (c *clustersClient) listSources(namespaced bool) []listSource {
+	sources := []listSource{}
+
+	for clusterName, cc := range c.pool.Clients() {
+		namespaces := c.namespaces[clusterName]
+		if !namespaced {
+			namespaces = []v1.Namespace{{}}
+		}
+
+		for _, ns := range namespaces {
+			sources = append(sources, listSource{
+				cluster:    clusterName,
+				namespace:  ns.Name,
+				namespaces: namespaces,
+				client:     cc,
+			})
+		}
+	}
+
+	sort.Slice(sources, func(i, j int) bool {
+		return sources[i].before(sources[j].cluster, sources[j].namespace)
+	})
+
+	return sources
+}
+
+type sourceListResult struct {
+	list client.ObjectList
+	err  error
+}
+
+// coordinatedClusteredList fills a page of exactly limit items, walking the
+// sources in order from the cursor in the continue token. Sources are listed
+// concurrently in batches, and items listed past the end of the page are
+// dropped and listed again by the next request.
+func (c *clustersClient) coordinatedClusteredList(ctx context.Context, clist ClusteredObjectList, namespaced bool, limit int64, opts ...client.ListOption) error {
+	cursor := CoordinatedPaginationInfo{}
+
+	continueToken := extractContinueToken(opts...)
+	if continueToken != "" {
+		if err := decodeFromBase64(&cursor, continueToken); err != nil {
+			return fmt.Errorf("failed decoding pagination info: %w", err)
+		}
+	}
+
+	sources := c.listSources(namespaced)
+
+	// The cursor's source might be gone if the user lost access to it,
+	// in which case carry on from the next one.
+	start := sort.Search(len(sources), func(i int) bool {
+		return !sources[i].before(cursor.Cluster, cursor.Namespace)
+	})
+
+	if start < len(sources) && (sources[start].cluster != cursor.Cluster || sources[start].namespace != cursor.Namespace) {
+		cursor.Continue = ""
+		cursor.Skip = 0
+	}
+
+	var (
+		errs      = ClusteredListError{}
+		remaining = limit
+		next      *CoordinatedPaginationInfo
+	)
+
+	for i := start; i < len(sources) && remaining > 0; {
+		batch := sources[i:min(i+coordinatedListConcurrency, len(sources))]
+		results := make([]sourceListResult, len(batch))
+
+		wg := sync.WaitGroup{}
+
+		for j, source := range batch {
+			sourceLimit, sourceContinue := remaining, ""
+			if j == 0 {
+				sourceLimit, sourceContinue = remaining+cursor.Skip, cursor.Continue
+			}
+
+			listOpts := append([]client.ListOption{}, opts...)
+			listOpts = append(listOpts, client.InNamespace(source.namespace), client.Limit(sourceLimit), client.Continue(sourceContinue))
+
+			wg.Add(1)
+
+			go func(j int, source listSource) {
+				defer wg.Done()
+
+				list := clist.NewList()
+
+				ctx, cancel := context.WithTimeout(ctx, clientTimeout)
+				defer cancel()
+
+				results[j] = sourceListResult{list: list, err: source.client.List(ctx, list, listOpts...)}
+			}(j, source)
+		}
+
+		wg.Wait()
+
+		consumed := 0
+
+		for j, source := range batch {
+			if remaining == 0 {
+				next = &CoordinatedPaginationInfo{Cluster: source.cluster, Namespace: source.namespace}
+				break
+			}
+
+			consumed++
+
+			result := results[j]
+			if result.err != nil {
+				errs.Add(ListError{Cluster: source.cluster, Namespace: source.namespace, Err: result.err})
+
+				cursor = CoordinatedPaginationInfo{}
+
+				continue
+			}
+
+			items, err := meta.ExtractList(result.list)
+			if err != nil {
+				return fmt.Errorf("failed extracting list items: %w", err)
+			}
+
+			listContinue := cursor.Continue
+			skip := min(cursor.Skip, int64(len(items)))
+			items = items[skip:]
+			cursor = CoordinatedPaginationInfo{}
+
+			if int64(len(items)) > remaining {
+				next = &CoordinatedPaginationInfo{
+					Cluster:   source.cluster,
+					Namespace: source.namespace,
+					Continue:  listContinue,
+					Skip:      skip + remaining,
+				}
+				items = items[:remaining]
+			}
+
+			if err := meta.SetList(result.list, items); err != nil {
+				return fmt.Errorf("failed setting list items: %w", err)
+			}
+
+			clist.AddObjectList(source.cluster, source.namespaces, result.list)
+
+			remaining -= int64(len(items))
+
+			if next != nil {
+				break
+			}
+
+			if result.list.GetContinue() != "" {
+				// There are more items in this namespace, resume from here.
+				cursor = CoordinatedPaginationInfo{
+					Cluster:   source.cluster,
+					Namespace: source.namespace,
+					Continue:  result.list.GetContinue(),
+				}
+
+				if remaining == 0 {
+					next = &cursor
+				}
+
+				consumed--
+
+				break
+			}
+		}
+
+		if next != nil {
+			break
+		}
+
+		i += consumed
+
+		if remaining == 0 && i < len(sources) {
+			next = &CoordinatedPaginationInfo{Cluster: sources[i].cluster, Namespace: sources[i].namespace}
+		}
+	}
+
+	continueToken = ""
+
+	if next != nil {
+		var err error
+
+		continueToken, err = encodeToBase64(next)
+		if err != nil {
+			return fmt.Errorf("failed encoding pagination info: %w", err)
+		}
+	}
+
+	clist.SetContinue(continueToken)
+
+	if len(errs.Errors) > 0 {
+		return errs
+	}
+
+	return nil
+}
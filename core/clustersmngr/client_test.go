@@ -18,6 +18,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -202,6 +203,77 @@ func TestClientClusteredListPagination(t *testing.T) {
 	g.Expect(cklist.Lists()[clusterName]).To(HaveLen(0))
 }
 
+func TestClientClusteredListCoordinatedPagination(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := t.Context()
+	ns1 := createNamespace(g)
+	ns2 := createNamespace(g)
+	namespaced := true
+
+	clusterName := "mycluster"
+
+	createKust := func(name, nsName string) {
+		kust := &kustomizev1.Kustomization{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: nsName,
+			},
+			Spec: kustomizev1.KustomizationSpec{
+				SourceRef: kustomizev1.CrossNamespaceSourceReference{
+					Kind: sourcev1.GitRepositoryKind,
+				},
+			},
+		}
+		g.Expect(k8sEnv.Client.Create(ctx, kust)).To(Succeed())
+	}
+
+	// Create 3 kustomizations in one namespace and 2 in the other
+	for i := 0; i < 3; i++ {
+		createKust("myapp-"+strconv.Itoa(i), ns1.Name)
+	}
+
+	for i := 0; i < 2; i++ {
+		createKust("myapp-"+strconv.Itoa(i), ns2.Name)
+	}
+
+	clientsPool := createClusterClientsPool(g, clusterName)
+
+	nsMap := map[string][]corev1.Namespace{
+		clusterName: {*ns1, *ns2},
+	}
+	clustersClient := clustersmngr.NewClient(clientsPool, nsMap, logr.Discard())
+
+	seen := map[string]bool{}
+	continueToken := ""
+
+	// Every page but the last is full, whichever namespace the items come from
+	for _, pageLen := range []int{2, 2, 1} {
+		cklist := clustersmngr.NewClusteredList(func() client.ObjectList {
+			return &kustomizev1.KustomizationList{}
+		})
+		g.Expect(clustersClient.ClusteredList(ctx, cklist, namespaced, client.Limit(2), client.Continue(continueToken), clustersmngr.CoordinatedPagination{})).To(Succeed())
+
+		items := 0
+
+		for _, l := range cklist.Lists()[clusterName] {
+			for _, k := range l.(*kustomizev1.KustomizationList).Items {
+				key := k.Namespace + "/" + k.Name
+				g.Expect(seen).NotTo(HaveKey(key))
+
+				seen[key] = true
+				items++
+			}
+		}
+
+		g.Expect(items).To(Equal(pageLen))
+
+		continueToken = cklist.GetContinue()
+	}
+
+	g.Expect(continueToken).To(BeEmpty())
+	g.Expect(seen).To(HaveLen(5))
+}
+
 func TestClientClusteredListClusterScoped(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -285,6 +357,60 @@ func TestClientCLusteredListErrors(t *testing.T) {
 	g.Expect(errors.As(cerr, &errs)).To(BeTrue())
 }
 
+func TestClientClusteredWatch(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ns := createNamespace(g)
+
+	clusterName := "mycluster"
+	appName := "myapp" + rand.String(5)
+
+	clientsPool := createClusterClientsPool(g, clusterName)
+
+	nsMap := map[string][]corev1.Namespace{
+		clusterName: {*ns},
+	}
+
+	clustersClient := clustersmngr.NewClient(clientsPool, nsMap, logr.Discard())
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	events := clustersClient.ClusteredWatch(ctx, func() client.ObjectList {
+		return &kustomizev1.KustomizationList{}
+	}, true)
+
+	kust := &kustomizev1.Kustomization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      appName,
+			Namespace: ns.Name,
+		},
+		Spec: kustomizev1.KustomizationSpec{
+			SourceRef: kustomizev1.CrossNamespaceSourceReference{
+				Kind: sourcev1.GitRepositoryKind,
+			},
+		},
+	}
+	g.Expect(k8sEnv.Client.Create(ctx, kust)).To(Succeed())
+
+	var ev clustersmngr.WatchEvent
+	g.Eventually(events).Should(Receive(&ev))
+	g.Expect(ev.Err).To(BeNil())
+	g.Expect(ev.Type).To(Equal(watch.Added))
+	g.Expect(ev.Cluster).To(Equal(clusterName))
+	g.Expect(ev.Namespace).To(Equal(ns.Name))
+	g.Expect(ev.Object.GetName()).To(Equal(appName))
+
+	g.Expect(k8sEnv.Client.Delete(ctx, kust)).To(Succeed())
+
+	g.Eventually(events).Should(Receive(&ev))
+	g.Expect(ev.Type).To(Equal(watch.Deleted))
+	g.Expect(ev.Object.GetName()).To(Equal(appName))
+
+	cancel()
+
+	g.Eventually(events).Should(BeClosed())
+}
+
 func TestClientList(t *testing.T) {
 	g := NewGomegaWithT(t)
 	ns := createNamespace(g)
@@ -513,7 +639,7 @@ func createClusterClientsPool(g *GomegaWithT, clusterName string) clustersmngr.C
 		// Put the user in the `system:masters` group to avoid auth errors
 		Groups: []string{"system:masters"},
 	}
-	client, err := client.New(&config, client.Options{
+	client, err := client.NewWithWatch(&config, client.Options{
 		Scheme: k8sEnv.Client.Scheme(),
 	})
 	g.Expect(err).To(BeNil())
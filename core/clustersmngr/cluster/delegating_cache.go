@@ -4,9 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 
 	v1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -64,7 +68,7 @@ func (c *delegatingCacheCluster) makeCachingClient(leafClient client.Client) (cl
 	// https://github.com/kubernetes-sigs/controller-runtime/pull/2150
 	delegatingCache := newDelegatingCache(leafClient, cache, c.scheme)
 
-	delegatingClient, err := client.New(c.restConfig, client.Options{
+	delegatingClient, err := client.NewWithWatch(c.restConfig, client.Options{
 		Cache: &client.CacheOptions{
 			Reader: delegatingCache,
 			// Non-exact field matches are not supported by the cache.
@@ -119,6 +123,10 @@ func (c *delegatingCacheCluster) GetServerConfig() (*rest.Config, error) {
 	return c.cluster.GetServerConfig()
 }
 
+// cacheContinuePrefix marks the continue tokens of the lists paginated from
+// the cache.
+const cacheContinuePrefix = "cache-offset:"
+
 type delegatingCache struct {
 	cache.Cache
 
@@ -168,19 +176,99 @@ func (dc delegatingCache) List(ctx context.Context, list client.ObjectList, opts
 		return err
 	}
 
+	listOpts := client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+
+	offset, err := decodeCacheContinue(listOpts.Continue)
+	if err != nil {
+		return err
+	}
+
+	limit := listOpts.Limit
+	listOpts.Limit = 0
+	listOpts.Continue = ""
+
 	bypass := dc.shouldBypassCheck(gvk)
 	if !bypass {
 		partial := &metav1.PartialObjectMetadataList{}
 		partial.SetGroupVersionKind(gvk)
 
-		if err := dc.Client.List(ctx, partial, opts...); err != nil {
+		checkOpts := listOpts
+		checkOpts.Limit = limit
+
+		if err := dc.Client.List(ctx, partial, &checkOpts); err != nil {
 			return err
 		}
 
 		dc.markGVKChecked(gvk)
 	}
 
-	return dc.Cache.List(ctx, list, opts...)
+	if err := dc.Cache.List(ctx, list, &listOpts); err != nil {
+		return err
+	}
+
+	if limit == 0 && offset == 0 {
+		list.SetContinue("")
+		return nil
+	}
+
+	return paginateCachedList(list, offset, limit)
+}
+
+// paginateCachedList cuts a page of limit items from offset out of the items
+// listed from the cache. The cache doesn't support continue tokens and lists
+// items in no particular order, so they're sorted and the offset of the next
+// page is used as continue token.
+func paginateCachedList(list client.ObjectList, offset, limit int64) error {
+	items, err := apimeta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		a, _ := apimeta.Accessor(items[i])
+		b, _ := apimeta.Accessor(items[j])
+
+		if a.GetNamespace() != b.GetNamespace() {
+			return a.GetNamespace() < b.GetNamespace()
+		}
+
+		return a.GetName() < b.GetName()
+	})
+
+	start := min(offset, int64(len(items)))
+	end := int64(len(items))
+
+	continueToken := ""
+	if limit > 0 && start+limit < end {
+		end = start + limit
+		continueToken = encodeCacheContinue(end)
+	}
+
+	if err := apimeta.SetList(list, items[start:end]); err != nil {
+		return err
+	}
+
+	list.SetContinue(continueToken)
+
+	return nil
+}
+
+func encodeCacheContinue(offset int64) string {
+	return cacheContinuePrefix + strconv.FormatInt(offset, 10)
+}
+
+func decodeCacheContinue(token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	offset, err := strconv.ParseInt(strings.TrimPrefix(token, cacheContinuePrefix), 10, 64)
+	if !strings.HasPrefix(token, cacheContinuePrefix) || err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid continue token %q for a cached list", token)
+	}
+
+	return offset, nil
 }
 
 func (dc *delegatingCache) shouldBypassCheck(gvk schema.GroupVersionKind) bool {
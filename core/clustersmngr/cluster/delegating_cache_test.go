@@ -86,6 +86,67 @@ func TestDelegatingCacheList(t *testing.T) {
 	g.Expect(fakeReader.Called).To(Equal(1))
 }
 
+func TestDelegatingCacheListPagination(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cache, err := cache.New(k8sEnv.Rest, cache.Options{})
+	g.Expect(err).To(BeNil())
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	go cache.Start(ctx)
+
+	if ok := cache.WaitForCacheSync(ctx); !ok {
+		g.Fail("failed syncing client cache")
+	}
+
+	prefix := "page" + rand.String(5)
+	labels := map[string]string{"test": prefix}
+
+	for _, suffix := range []string{"c", "a", "b"} {
+		ns := &corev1.Namespace{
+			ObjectMeta: v1.ObjectMeta{
+				Name:   prefix + "-" + suffix,
+				Labels: labels,
+			},
+		}
+
+		g.Expect(k8sEnv.Client.Create(ctx, ns)).To(Succeed())
+	}
+
+	delegatingCache := newDelegatingCache(&fakeReader{}, cache, scheme.Scheme)
+
+	names := func(list *corev1.NamespaceList) []string {
+		names := []string{}
+		for _, ns := range list.Items {
+			names = append(names, ns.Name)
+		}
+
+		return names
+	}
+
+	g.Eventually(func() []string {
+		nsList := &corev1.NamespaceList{}
+		_ = delegatingCache.List(ctx, nsList, client.MatchingLabels(labels))
+
+		return names(nsList)
+	}).Should(HaveLen(3))
+
+	// Pages are cut from the sorted items, as the cache doesn't support
+	// continue tokens.
+	nsList := &corev1.NamespaceList{}
+	g.Expect(delegatingCache.List(ctx, nsList, client.MatchingLabels(labels), client.Limit(2))).To(Succeed())
+	g.Expect(names(nsList)).To(Equal([]string{prefix + "-a", prefix + "-b"}))
+	g.Expect(nsList.Continue).NotTo(BeEmpty())
+
+	g.Expect(delegatingCache.List(ctx, nsList, client.MatchingLabels(labels), client.Limit(2), client.Continue(nsList.Continue))).To(Succeed())
+	g.Expect(names(nsList)).To(Equal([]string{prefix + "-c"}))
+	g.Expect(nsList.Continue).To(BeEmpty())
+
+	g.Expect(delegatingCache.List(ctx, nsList, client.Continue("not-an-offset"))).To(MatchError(ContainSubstring("invalid continue token")))
+}
+
 type fakeReader struct {
 	Called int
 }
@@ -0,0 +1,102 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	apiruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mngr "github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/cluster"
+	"github.com/weaveworks/weave-gitops/core/logger"
+	"github.com/weaveworks/weave-gitops/pkg/kube"
+)
+
+const (
+	// CAPIKubeConfigSecretSuffix is appended to a CAPI Cluster's name to get
+	// the name of the Secret holding its kubeconfig.
+	CAPIKubeConfigSecretSuffix = "-kubeconfig"
+	// CAPIKubeConfigSecretKey is the key in a CAPI kubeconfig Secret's data
+	// that holds the kubeconfig.
+	CAPIKubeConfigSecretKey = "value"
+)
+
+// CAPIClusterListGVK is the kind listed to discover CAPI clusters.
+var CAPIClusterListGVK = schema.GroupVersionKind{
+	Group:   "cluster.x-k8s.io",
+	Version: "v1beta1",
+	Kind:    "ClusterList",
+}
+
+type capiClusterFetcher struct {
+	log       logr.Logger
+	client    client.Client
+	namespace string
+	clusters  *kubeConfigClusters
+}
+
+// NewCAPIClusterFetcher creates a fetcher that returns a cluster for every
+// Cluster API Cluster in namespace, or in all namespaces if it's empty, using
+// the kubeconfig from its "<name>-kubeconfig" Secret. Clusters are named
+// "namespace/name", and clusters without a kubeconfig Secret yet are skipped.
+func NewCAPIClusterFetcher(log logr.Logger, cl client.Client, namespace string, scheme *apiruntime.Scheme, userPrefixes kube.UserPrefixes, kubeConfigOptions ...cluster.KubeConfigOption) mngr.ClusterFetcher {
+	log = log.WithValues("fetcher", "capi")
+
+	return &capiClusterFetcher{
+		log:       log,
+		client:    cl,
+		namespace: namespace,
+		clusters:  newKubeConfigClusters(log, scheme, userPrefixes, kubeConfigOptions),
+	}
+}
+
+func (f *capiClusterFetcher) Fetch(ctx context.Context) ([]cluster.Cluster, error) {
+	capiClusters := &unstructured.UnstructuredList{}
+	capiClusters.SetGroupVersionKind(CAPIClusterListGVK)
+
+	if err := f.client.List(ctx, capiClusters, client.InNamespace(f.namespace)); err != nil {
+		if meta.IsNoMatchError(err) || apierrors.IsNotFound(err) {
+			f.log.V(logger.LogLevelDebug).Info("CAPI is not installed, no clusters to fetch")
+			return []cluster.Cluster{}, nil
+		}
+
+		return f.clusters.stale(err, "failed to list CAPI clusters"), nil
+	}
+
+	kubeConfigs := map[string][]byte{}
+
+	for _, capiCluster := range capiClusters.Items {
+		key := types.NamespacedName{
+			Namespace: capiCluster.GetNamespace(),
+			Name:      capiCluster.GetName() + CAPIKubeConfigSecretSuffix,
+		}
+
+		secret := &corev1.Secret{}
+		if err := f.client.Get(ctx, key, secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				f.log.V(logger.LogLevelDebug).Info("kubeconfig secret not found, skipping cluster", "cluster", client.ObjectKeyFromObject(&capiCluster), "secret", key)
+				continue
+			}
+
+			return f.clusters.stale(err, fmt.Sprintf("failed to get kubeconfig secret %s", key)), nil
+		}
+
+		data, ok := secret.Data[CAPIKubeConfigSecretKey]
+		if !ok {
+			f.log.Info("secret has no kubeconfig, skipping cluster", "secret", key, "key", CAPIKubeConfigSecretKey)
+			continue
+		}
+
+		kubeConfigs[types.NamespacedName{Namespace: capiCluster.GetNamespace(), Name: capiCluster.GetName()}.String()] = data
+	}
+
+	return f.clusters.build(kubeConfigs), nil
+}
@@ -0,0 +1,75 @@
+package fetcher_test
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/fetcher"
+	"github.com/weaveworks/weave-gitops/pkg/kube"
+)
+
+func TestCAPIClusterFetcher(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	scheme, err := kube.CreateScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	makeCAPICluster := func(name, namespace string) client.Object {
+		c := &unstructured.Unstructured{}
+		c.SetGroupVersionKind(fetcher.CAPIClusterListGVK.GroupVersion().WithKind("Cluster"))
+		c.SetName(name)
+		c.SetNamespace(namespace)
+
+		return c
+	}
+
+	makeKubeConfigSecret := func(clusterName, namespace, host string) client.Object {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      clusterName + fetcher.CAPIKubeConfigSecretSuffix,
+				Namespace: namespace,
+			},
+			Data: map[string][]byte{
+				fetcher.CAPIKubeConfigSecretKey: makeKubeConfig(t, host),
+			},
+		}
+	}
+
+	k8s := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		makeCAPICluster("prod", "fleet"),
+		makeKubeConfigSecret("prod", "fleet", "https://prod:6443"),
+		makeCAPICluster("prod", "other-fleet"),
+		makeKubeConfigSecret("prod", "other-fleet", "https://other-prod:6443"),
+		makeCAPICluster("provisioning", "fleet"),
+	).Build()
+
+	f := fetcher.NewCAPIClusterFetcher(logr.Discard(), k8s, "", scheme, kube.UserPrefixes{})
+
+	clusters, err := f.Fetch(t.Context())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(clusterHosts(clusters)).To(Equal(map[string]string{
+		"fleet/prod":       "https://prod:6443",
+		"other-fleet/prod": "https://other-prod:6443",
+	}))
+
+	g.Expect(k8s.Create(t.Context(), makeKubeConfigSecret("provisioning", "fleet", "https://provisioning:6443"))).To(Succeed())
+
+	clusters, err = f.Fetch(t.Context())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(clusterHosts(clusters)).To(HaveKeyWithValue("fleet/provisioning", "https://provisioning:6443"))
+
+	namespaced := fetcher.NewCAPIClusterFetcher(logr.Discard(), k8s, "other-fleet", scheme, kube.UserPrefixes{})
+
+	clusters, err = namespaced.Fetch(t.Context())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(clusterHosts(clusters)).To(Equal(map[string]string{
+		"other-fleet/prod": "https://other-prod:6443",
+	}))
+}
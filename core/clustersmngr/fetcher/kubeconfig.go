@@ -0,0 +1,228 @@
+package fetcher
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	apiruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mngr "github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/cluster"
+	"github.com/weaveworks/weave-gitops/pkg/kube"
+)
+
+// KubeConfigSecretKey is the key in a Secret's data that holds the kubeconfig
+// read by the Secret fetcher.
+const KubeConfigSecretKey = "kubeconfig"
+
+// kubeConfigClusters builds clusters from raw kubeconfigs, and keeps them
+// around until the kubeconfig changes so they're not rebuilt on every fetch.
+type kubeConfigClusters struct {
+	log               logr.Logger
+	scheme            *apiruntime.Scheme
+	userPrefixes      kube.UserPrefixes
+	kubeConfigOptions []cluster.KubeConfigOption
+
+	mu       sync.Mutex
+	clusters map[string]kubeConfigCluster
+}
+
+type kubeConfigCluster struct {
+	checksum [sha256.Size]byte
+	cluster  cluster.Cluster
+}
+
+func newKubeConfigClusters(log logr.Logger, scheme *apiruntime.Scheme, userPrefixes kube.UserPrefixes, kubeConfigOptions []cluster.KubeConfigOption) *kubeConfigClusters {
+	return &kubeConfigClusters{
+		log:               log,
+		scheme:            scheme,
+		userPrefixes:      userPrefixes,
+		kubeConfigOptions: kubeConfigOptions,
+		clusters:          map[string]kubeConfigCluster{},
+	}
+}
+
+// build returns a cluster for every kubeconfig, keyed by cluster name.
+// Kubeconfigs that can't be turned into a cluster, or that would shadow the
+// management cluster, are logged and skipped, so a single broken kubeconfig
+// doesn't hide every other cluster.
+func (kc *kubeConfigClusters) build(kubeConfigs map[string][]byte) []cluster.Cluster {
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
+
+	names := make([]string, 0, len(kubeConfigs))
+	for name := range kubeConfigs {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	current := map[string]kubeConfigCluster{}
+	clusters := []cluster.Cluster{}
+
+	for _, name := range names {
+		if name == cluster.DefaultCluster {
+			kc.log.Error(nil, "kubeconfig cluster name collides with the management cluster, skipping", "cluster", name)
+			continue
+		}
+
+		data := kubeConfigs[name]
+		checksum := sha256.Sum256(data)
+
+		if existing, ok := kc.clusters[name]; ok && existing.checksum == checksum {
+			current[name] = existing
+			clusters = append(clusters, existing.cluster)
+
+			continue
+		}
+
+		cl, err := kc.newCluster(name, data)
+		if err != nil {
+			kc.log.Error(err, "failed to create cluster from kubeconfig", "cluster", name)
+			continue
+		}
+
+		current[name] = kubeConfigCluster{checksum: checksum, cluster: cl}
+		clusters = append(clusters, cl)
+	}
+
+	kc.clusters = current
+
+	return clusters
+}
+
+// stale logs why the kubeconfigs couldn't be read and returns the clusters
+// built from the last ones, so a fetcher failing doesn't remove every other
+// cluster, including the management one.
+func (kc *kubeConfigClusters) stale(err error, msg string) []cluster.Cluster {
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
+
+	kc.log.Error(err, msg+", keeping the clusters fetched last")
+
+	names := make([]string, 0, len(kc.clusters))
+	for name := range kc.clusters {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	clusters := make([]cluster.Cluster, 0, len(names))
+	for _, name := range names {
+		clusters = append(clusters, kc.clusters[name].cluster)
+	}
+
+	return clusters
+}
+
+func (kc *kubeConfigClusters) newCluster(name string, data []byte) (cluster.Cluster, error) {
+	config, err := clientcmd.RESTConfigFromKubeConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig: %w", err)
+	}
+
+	return cluster.NewSingleCluster(name, config, kc.scheme, kc.userPrefixes, kc.kubeConfigOptions...)
+}
+
+type kubeConfigDirFetcher struct {
+	dir      string
+	clusters *kubeConfigClusters
+}
+
+// NewKubeConfigDirFetcher creates a fetcher that returns a cluster for every
+// kubeconfig file in dir, named after the file without its extension.
+// Hidden files are ignored, so a directory mounted from a Secret or
+// ConfigMap can be used directly.
+func NewKubeConfigDirFetcher(log logr.Logger, dir string, scheme *apiruntime.Scheme, userPrefixes kube.UserPrefixes, kubeConfigOptions ...cluster.KubeConfigOption) mngr.ClusterFetcher {
+	return &kubeConfigDirFetcher{
+		dir:      dir,
+		clusters: newKubeConfigClusters(log.WithValues("dir", dir), scheme, userPrefixes, kubeConfigOptions),
+	}
+}
+
+func (f *kubeConfigDirFetcher) Fetch(ctx context.Context) ([]cluster.Cluster, error) {
+	entries, err := os.ReadDir(f.dir)
+	if err != nil {
+		return f.clusters.stale(err, "failed to read kubeconfig directory"), nil
+	}
+
+	kubeConfigs := map[string][]byte{}
+
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		path := filepath.Join(f.dir, entry.Name())
+
+		// os.Stat follows symlinks, which is how files mounted from a
+		// Secret or ConfigMap show up.
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() {
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			f.clusters.log.Error(err, "failed to read kubeconfig, skipping", "path", path)
+			continue
+		}
+
+		name := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		kubeConfigs[name] = data
+	}
+
+	return f.clusters.build(kubeConfigs), nil
+}
+
+type kubeConfigSecretFetcher struct {
+	client    client.Client
+	namespace string
+	selector  labels.Selector
+	clusters  *kubeConfigClusters
+}
+
+// NewKubeConfigSecretFetcher creates a fetcher that returns a cluster for
+// every Secret in namespace matching selector. The kubeconfig is read from
+// the KubeConfigSecretKey key, and the cluster is named after the Secret.
+func NewKubeConfigSecretFetcher(log logr.Logger, cl client.Client, namespace string, selector labels.Selector, scheme *apiruntime.Scheme, userPrefixes kube.UserPrefixes, kubeConfigOptions ...cluster.KubeConfigOption) mngr.ClusterFetcher {
+	return &kubeConfigSecretFetcher{
+		client:    cl,
+		namespace: namespace,
+		selector:  selector,
+		clusters:  newKubeConfigClusters(log.WithValues("namespace", namespace, "selector", selector.String()), scheme, userPrefixes, kubeConfigOptions),
+	}
+}
+
+func (f *kubeConfigSecretFetcher) Fetch(ctx context.Context) ([]cluster.Cluster, error) {
+	secrets := &corev1.SecretList{}
+
+	if err := f.client.List(ctx, secrets, client.InNamespace(f.namespace), client.MatchingLabelsSelector{Selector: f.selector}); err != nil {
+		return f.clusters.stale(err, "failed to list kubeconfig secrets"), nil
+	}
+
+	kubeConfigs := map[string][]byte{}
+
+	for _, secret := range secrets.Items {
+		data, ok := secret.Data[KubeConfigSecretKey]
+		if !ok {
+			f.clusters.log.Info("secret has no kubeconfig, skipping", "secret", secret.Name, "key", KubeConfigSecretKey)
+			continue
+		}
+
+		kubeConfigs[secret.Name] = data
+	}
+
+	return f.clusters.build(kubeConfigs), nil
+}
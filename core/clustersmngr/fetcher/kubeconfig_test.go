@@ -0,0 +1,155 @@
+package fetcher_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/cluster"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/fetcher"
+	"github.com/weaveworks/weave-gitops/pkg/kube"
+)
+
+func TestKubeConfigDirFetcher(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	dir := t.TempDir()
+
+	g.Expect(os.WriteFile(filepath.Join(dir, "prod.yaml"), makeKubeConfig(t, "https://prod:6443"), 0o600)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "staging"), makeKubeConfig(t, "https://staging:6443"), 0o600)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, ".hidden"), makeKubeConfig(t, "https://hidden:6443"), 0o600)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "broken.yaml"), []byte("not a kubeconfig: ["), 0o600)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "Default.yaml"), makeKubeConfig(t, "https://shadow:6443"), 0o600)).To(Succeed())
+	g.Expect(os.Mkdir(filepath.Join(dir, "subdir"), 0o700)).To(Succeed())
+
+	f := fetcher.NewKubeConfigDirFetcher(logr.Discard(), dir, nil, kube.UserPrefixes{})
+
+	clusters, err := f.Fetch(t.Context())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(clusterHosts(clusters)).To(Equal(map[string]string{
+		"prod":    "https://prod:6443",
+		"staging": "https://staging:6443",
+	}))
+
+	prod := clusters[0]
+
+	g.Expect(os.Remove(filepath.Join(dir, "staging"))).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "dev.yaml"), makeKubeConfig(t, "https://dev:6443"), 0o600)).To(Succeed())
+
+	clusters, err = f.Fetch(t.Context())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(clusterHosts(clusters)).To(Equal(map[string]string{
+		"dev":  "https://dev:6443",
+		"prod": "https://prod:6443",
+	}))
+	g.Expect(clusters[1]).To(BeIdenticalTo(prod), "unchanged kubeconfigs should reuse the existing cluster")
+
+	g.Expect(os.WriteFile(filepath.Join(dir, "prod.yaml"), makeKubeConfig(t, "https://prod-2:6443"), 0o600)).To(Succeed())
+
+	clusters, err = f.Fetch(t.Context())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(clusterHosts(clusters)).To(HaveKeyWithValue("prod", "https://prod-2:6443"))
+}
+
+func TestKubeConfigDirFetcherMissingDir(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	dir := filepath.Join(t.TempDir(), "kubeconfigs")
+	g.Expect(os.Mkdir(dir, 0o700)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "prod.yaml"), makeKubeConfig(t, "https://prod:6443"), 0o600)).To(Succeed())
+
+	f := fetcher.NewKubeConfigDirFetcher(logr.Discard(), dir, nil, kube.UserPrefixes{})
+
+	clusters, err := f.Fetch(t.Context())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(clusters).To(HaveLen(1))
+
+	// The clusters fetched last are kept while the directory can't be read,
+	// so the other fetchers' clusters aren't dropped along with them.
+	g.Expect(os.RemoveAll(dir)).To(Succeed())
+
+	clusters, err = f.Fetch(t.Context())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(clusterHosts(clusters)).To(Equal(map[string]string{
+		"prod": "https://prod:6443",
+	}))
+}
+
+func TestKubeConfigSecretFetcher(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	scheme, err := kube.CreateScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	clusterLabels := map[string]string{"weave.works/cluster": "true"}
+
+	makeSecret := func(name, namespace string, lbls map[string]string, data map[string][]byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels:    lbls,
+			},
+			Data: data,
+		}
+	}
+
+	k8s := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		makeSecret("prod", "flux-system", clusterLabels, map[string][]byte{fetcher.KubeConfigSecretKey: makeKubeConfig(t, "https://prod:6443")}),
+		makeSecret("unlabelled", "flux-system", nil, map[string][]byte{fetcher.KubeConfigSecretKey: makeKubeConfig(t, "https://unlabelled:6443")}),
+		makeSecret("other-ns", "default", clusterLabels, map[string][]byte{fetcher.KubeConfigSecretKey: makeKubeConfig(t, "https://other:6443")}),
+		makeSecret("no-kubeconfig", "flux-system", clusterLabels, map[string][]byte{"value": makeKubeConfig(t, "https://value:6443")}),
+	).Build()
+
+	f := fetcher.NewKubeConfigSecretFetcher(logr.Discard(), k8s, "flux-system", labels.SelectorFromSet(clusterLabels), scheme, kube.UserPrefixes{})
+
+	clusters, err := f.Fetch(t.Context())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(clusterHosts(clusters)).To(Equal(map[string]string{
+		"prod": "https://prod:6443",
+	}))
+
+	g.Expect(k8s.Create(t.Context(), makeSecret("staging", "flux-system", clusterLabels, map[string][]byte{fetcher.KubeConfigSecretKey: makeKubeConfig(t, "https://staging:6443")}))).To(Succeed())
+	g.Expect(k8s.Delete(t.Context(), makeSecret("prod", "flux-system", nil, nil))).To(Succeed())
+
+	clusters, err = f.Fetch(t.Context())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(clusterHosts(clusters)).To(Equal(map[string]string{
+		"staging": "https://staging:6443",
+	}))
+}
+
+func makeKubeConfig(t *testing.T, host string) []byte {
+	t.Helper()
+
+	config := clientcmdapi.NewConfig()
+	config.Clusters["cluster"] = &clientcmdapi.Cluster{Server: host}
+	config.AuthInfos["user"] = &clientcmdapi.AuthInfo{Token: "token"}
+	config.Contexts["context"] = &clientcmdapi.Context{Cluster: "cluster", AuthInfo: "user"}
+	config.CurrentContext = "context"
+
+	data, err := clientcmd.Write(*config)
+	if err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
+	}
+
+	return data
+}
+
+func clusterHosts(clusters []cluster.Cluster) map[string]string {
+	hosts := map[string]string{}
+	for _, c := range clusters {
+		hosts[c.GetName()] = c.GetHost()
+	}
+
+	return hosts
+}
@@ -40,6 +40,7 @@ type SourceRef interface {
 type Automation interface {
 	Reconcilable
 	SourceRef() SourceRef
+	GetDependsOn() []meta.NamespacedObjectReference
 }
 
 type GitRepositoryAdapter struct {
@@ -179,7 +180,22 @@ func (obj HelmReleaseAdapter) AsClientObject() client.Object {
 	return obj.HelmRelease
 }
 
+// SourceRef returns the source of the HelmRelease's chart: the HelmChart
+// or OCIRepository in its chartRef, or the source of its chart template.
 func (obj HelmReleaseAdapter) SourceRef() SourceRef {
+	if obj.Spec.ChartRef != nil {
+		return sRef{
+			apiVersion: obj.Spec.ChartRef.APIVersion,
+			name:       obj.Spec.ChartRef.Name,
+			namespace:  obj.Spec.ChartRef.Namespace,
+			kind:       obj.Spec.ChartRef.Kind,
+		}
+	}
+
+	if obj.Spec.Chart == nil {
+		return sRef{}
+	}
+
 	sr := obj.Spec.Chart.Spec.SourceRef
 
 	return sRef{
@@ -46,10 +46,16 @@ func RequestReconciliation(ctx context.Context, k client.Client, name client.Obj
 
 // WaitForSync polls the k8s API until the resources is sync'd, and times out eventually.
 func WaitForSync(ctx context.Context, c client.Client, key client.ObjectKey, obj Reconcilable) error {
+	return WaitForSyncWithTimeout(ctx, c, key, obj, k8sTimeout)
+}
+
+// WaitForSyncWithTimeout is WaitForSync for resources that can take longer
+// than usual to sync, timing out after timeout.
+func WaitForSyncWithTimeout(ctx context.Context, c client.Client, key client.ObjectKey, obj Reconcilable, timeout time.Duration) error {
 	if err := wait.PollUntilContextTimeout(
 		ctx,
 		k8sPollInterval,
-		k8sTimeout,
+		timeout,
 		true,
 		checkResourceSync(c, key, obj, obj.GetLastHandledReconcileRequest()),
 	); err != nil {
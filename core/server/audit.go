@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+
+	pb "github.com/weaveworks/weave-gitops/pkg/api/core"
+	"github.com/weaveworks/weave-gitops/pkg/audit"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+// auditObjectResult records the result of calling rpc on an object.
+func (cs *coreServer) auditObjectResult(ctx context.Context, rpc string, result *pb.ObjectResult, comment string, details map[string]string) {
+	event := audit.Event{
+		Type:     audit.EventTypeRPC,
+		Action:   rpc,
+		SourceIP: cs.trustedProxies.SourceIPFromContext(ctx),
+		Outcome:  audit.OutcomeSuccess,
+		Error:    result.Error,
+		Comment:  comment,
+		Details:  details,
+	}
+
+	if principal := auth.Principal(ctx); principal != nil {
+		event.Principal = principal.ID
+		event.Groups = principal.Groups
+	}
+
+	switch result.Status {
+	case objectResultFailed:
+		event.Outcome = audit.OutcomeFailure
+	case objectResultSkipped:
+		event.Outcome = audit.OutcomeSkipped
+	}
+
+	if ref := result.Object; ref != nil {
+		event.Cluster = ref.ClusterName
+		event.Object = &audit.ObjectReference{
+			Kind:      ref.Kind,
+			Name:      ref.Name,
+			Namespace: ref.Namespace,
+		}
+
+		if gvk, err := cs.primaryKinds.Lookup(ref.Kind); err == nil {
+			event.Object.APIVersion = gvk.GroupVersion().String()
+		}
+	}
+
+	cs.audit.Record(ctx, event)
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/metadata"
+
+	pb "github.com/weaveworks/weave-gitops/pkg/api/core"
+	"github.com/weaveworks/weave-gitops/pkg/audit"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+type auditSinkFunc func(context.Context, audit.Event) error
+
+func (f auditSinkFunc) Write(ctx context.Context, event audit.Event) error {
+	return f(ctx, event)
+}
+
+func TestAuditObjectResult(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	var events []audit.Event
+
+	primaryKinds, err := DefaultPrimaryKinds()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	cs := &coreServer{
+		primaryKinds: primaryKinds,
+		audit: audit.NewRecorder(logr.Discard(), auditSinkFunc(func(_ context.Context, event audit.Event) error {
+			event.Time = time.Time{}
+			events = append(events, event)
+
+			return nil
+		})),
+	}
+
+	ctx := auth.WithPrincipal(context.Background(), &auth.UserPrincipal{ID: "anne", Groups: []string{"team-a"}})
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", "10.0.0.1"))
+
+	ref := &pb.ObjectRef{ClusterName: "Default", Kind: "Kustomization", Name: "apps", Namespace: "flux-system"}
+
+	cs.auditObjectResult(ctx, "ToggleSuspendResource", objectResult(ref, objectResultSucceeded, nil), "maintenance", map[string]string{"suspend": "true"})
+	cs.auditObjectResult(ctx, "SyncFluxObject", objectResult(ref, objectResultFailed, errors.New("timed out")), "", nil)
+	cs.auditObjectResult(ctx, "SyncFluxObject", objectResult(ref, objectResultSkipped, errors.New("dependency failed")), "", nil)
+
+	object := &audit.ObjectReference{APIVersion: "kustomize.toolkit.fluxcd.io/v1", Kind: "Kustomization", Name: "apps", Namespace: "flux-system"}
+
+	g.Expect(events).To(Equal([]audit.Event{
+		{
+			Type:      audit.EventTypeRPC,
+			Action:    "ToggleSuspendResource",
+			Principal: "anne",
+			Groups:    []string{"team-a"},
+			SourceIP:  "10.0.0.1",
+			Cluster:   "Default",
+			Object:    object,
+			Outcome:   audit.OutcomeSuccess,
+			Comment:   "maintenance",
+			Details:   map[string]string{"suspend": "true"},
+		},
+		{
+			Type:      audit.EventTypeRPC,
+			Action:    "SyncFluxObject",
+			Principal: "anne",
+			Groups:    []string{"team-a"},
+			SourceIP:  "10.0.0.1",
+			Cluster:   "Default",
+			Object:    object,
+			Outcome:   audit.OutcomeFailure,
+			Error:     "timed out",
+		},
+		{
+			Type:      audit.EventTypeRPC,
+			Action:    "SyncFluxObject",
+			Principal: "anne",
+			Groups:    []string{"team-a"},
+			SourceIP:  "10.0.0.1",
+			Cluster:   "Default",
+			Object:    object,
+			Outcome:   audit.OutcomeSkipped,
+			Error:     "dependency failed",
+		},
+	}))
+}
@@ -0,0 +1,402 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/core/fluxsync"
+	pb "github.com/weaveworks/weave-gitops/pkg/api/core"
+)
+
+const (
+	dependencyEdgeSource    = "source"
+	dependencyEdgeDependsOn = "dependsOn"
+	dependencyEdgeInventory = "inventory"
+)
+
+// dependencyGraphKinds are the kinds listed to build the dependency graph,
+// automations first.
+var dependencyGraphKinds = []string{
+	kustomizev1.KustomizationKind,
+	helmv2.HelmReleaseKind,
+	sourcev1.GitRepositoryKind,
+	sourcev1.OCIRepositoryKind,
+	sourcev1.BucketKind,
+	sourcev1.HelmRepositoryKind,
+	sourcev1.HelmChartKind,
+}
+
+// graphObject is an object listed while building the dependency graph.
+type graphObject struct {
+	node       *pb.DependencyGraphNode
+	automation fluxsync.Automation
+}
+
+// dependencyGraph links the listed objects together, adding nodes for the
+// references that point at objects that weren't found.
+type dependencyGraph struct {
+	objects map[string]graphObject
+	nodes   map[string]*pb.DependencyGraphNode
+	edges   map[dependencyGraphEdgeKey]*pb.DependencyGraphEdge
+	// listedKinds are the kinds that could be listed, references to
+	// other kinds can't be told missing.
+	listedKinds map[string]bool
+}
+
+// dependencyGraphNodeID returns the ID of a node. The cluster name is
+// escaped, as CAPI clusters are named "namespace/name", while kinds and
+// object names can't hold a "/".
+func dependencyGraphNodeID(clusterName, kind, namespace, name string) string {
+	return strings.Join([]string{url.PathEscape(clusterName), kind, namespace, name}, "/")
+}
+
+// dependencyGraphEdgeKey identifies an edge in the graph.
+type dependencyGraphEdgeKey struct {
+	source, target, edgeType string
+}
+
+func (cs *coreServer) GetDependencyGraph(ctx context.Context, msg *pb.GetDependencyGraphRequest) (*pb.GetDependencyGraphResponse, error) {
+	clustersClient, respErrors := cs.impersonatedClient(ctx, msg.ClusterName)
+
+	graph := &dependencyGraph{
+		objects:     map[string]graphObject{},
+		nodes:       map[string]*pb.DependencyGraphNode{},
+		edges:       map[dependencyGraphEdgeKey]*pb.DependencyGraphEdge{},
+		listedKinds: map[string]bool{},
+	}
+
+	// Everything is listed, whatever the namespace asked for, as automations
+	// can depend on objects in other namespaces.
+	for _, kind := range dependencyGraphKinds {
+		gvk, err := cs.primaryKinds.Lookup(kind)
+		if err != nil {
+			return nil, err
+		}
+
+		clist := clustersmngr.NewClusteredList(func() client.ObjectList {
+			list := unstructured.UnstructuredList{}
+			list.SetGroupVersionKind(*gvk)
+			return &list
+		})
+
+		if err := clustersClient.ClusteredList(ctx, clist, true); err != nil {
+			var errs clustersmngr.ClusteredListError
+			if !errors.As(err, &errs) {
+				return nil, err
+			}
+
+			for _, e := range errs.Errors {
+				respErrors = append(respErrors, &pb.ListError{ClusterName: e.Cluster, Namespace: e.Namespace, Message: e.Err.Error()})
+			}
+		}
+
+		graph.listedKinds[kind] = true
+
+		for clusterName, lists := range clist.Lists() {
+			for _, l := range lists {
+				list, ok := l.(*unstructured.UnstructuredList)
+				if !ok {
+					continue
+				}
+
+				for _, obj := range list.Items {
+					if err := graph.addObject(clusterName, *gvk, obj, objectStatus(obj, cs.healthChecker)); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+	}
+
+	roots := []string{}
+
+	for id, obj := range graph.objects {
+		if obj.automation != nil && (msg.Namespace == "" || obj.node.Namespace == msg.Namespace) {
+			roots = append(roots, id)
+		}
+	}
+
+	graph.walk(roots)
+
+	return &pb.GetDependencyGraphResponse{
+		Nodes:  graph.sortedNodes(),
+		Edges:  graph.sortedEdges(),
+		Cycles: graph.cycles(),
+		Errors: respErrors,
+	}, nil
+}
+
+func (g *dependencyGraph) addObject(clusterName string, gvk schema.GroupVersionKind, obj unstructured.Unstructured, status string) error {
+	node := &pb.DependencyGraphNode{
+		Id:          dependencyGraphNodeID(clusterName, gvk.Kind, obj.GetNamespace(), obj.GetName()),
+		Kind:        gvk.Kind,
+		Name:        obj.GetName(),
+		Namespace:   obj.GetNamespace(),
+		ClusterName: clusterName,
+		Status:      status,
+	}
+
+	gobj := graphObject{node: node}
+
+	reconcilable := fluxsync.ToReconcileable(gvk)
+	if automation, ok := reconcilable.(fluxsync.Automation); ok {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, automation.AsClientObject()); err != nil {
+			return fmt.Errorf("converting %s %s/%s: %w", gvk.Kind, obj.GetNamespace(), obj.GetName(), err)
+		}
+
+		gobj.automation = automation
+	}
+
+	g.objects[node.Id] = gobj
+
+	return nil
+}
+
+// walk adds the nodes reachable from roots, and the edges between them.
+func (g *dependencyGraph) walk(roots []string) {
+	queue := append([]string{}, roots...)
+
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+
+		if _, ok := g.nodes[id]; ok {
+			continue
+		}
+
+		obj, ok := g.objects[id]
+		if !ok {
+			continue
+		}
+
+		g.nodes[id] = obj.node
+
+		if obj.automation == nil {
+			continue
+		}
+
+		for _, target := range graphReferences(obj) {
+			if _, ok := g.objects[target.id]; !ok {
+				g.addMissing(target)
+			}
+
+			source, dest := id, target.id
+			if target.edgeType == dependencyEdgeSource {
+				source, dest = target.id, id
+			}
+
+			g.edges[dependencyGraphEdgeKey{source, dest, target.edgeType}] = &pb.DependencyGraphEdge{
+				Source: source,
+				Target: dest,
+				Type:   target.edgeType,
+			}
+
+			queue = append(queue, target.id)
+		}
+	}
+}
+
+type graphReference struct {
+	id          string
+	clusterName string
+	kind        string
+	name        string
+	namespace   string
+	edgeType    string
+}
+
+// graphReferences returns the objects an automation refers to: its source, the
+// automations it depends on and, for Kustomizations, the automations and
+// sources in its inventory.
+func graphReferences(obj graphObject) []graphReference {
+	var (
+		refs        = []graphReference{}
+		clusterName = obj.node.ClusterName
+		namespace   = obj.node.Namespace
+	)
+
+	addRef := func(kind, name, refNamespace, edgeType string) {
+		// The namespace of a reference defaults to the namespace of the object holding it.
+		if refNamespace == "" {
+			refNamespace = namespace
+		}
+
+		refs = append(refs, graphReference{
+			id:          dependencyGraphNodeID(clusterName, kind, refNamespace, name),
+			clusterName: clusterName,
+			kind:        kind,
+			name:        name,
+			namespace:   refNamespace,
+			edgeType:    edgeType,
+		})
+	}
+
+	if sourceRef := obj.automation.SourceRef(); sourceRef.Name() != "" {
+		addRef(sourceRef.Kind(), sourceRef.Name(), sourceRef.Namespace(), dependencyEdgeSource)
+	}
+
+	for _, dep := range obj.automation.GetDependsOn() {
+		addRef(obj.node.Kind, dep.Name, dep.Namespace, dependencyEdgeDependsOn)
+	}
+
+	if ks, ok := obj.automation.(fluxsync.KustomizationAdapter); ok && ks.Status.Inventory != nil {
+		for _, entry := range ks.Status.Inventory.Entries {
+			objMetadata, err := object.ParseObjMetadata(entry.ID)
+			if err != nil || !slices.Contains(dependencyGraphKinds, objMetadata.GroupKind.Kind) {
+				continue
+			}
+
+			addRef(objMetadata.GroupKind.Kind, objMetadata.Name, objMetadata.Namespace, dependencyEdgeInventory)
+		}
+	}
+
+	return refs
+}
+
+// addMissing adds a node for a reference that wasn't listed. It's flagged
+// as missing if its kind was listed, as it would have been found otherwise.
+func (g *dependencyGraph) addMissing(ref graphReference) {
+	g.objects[ref.id] = graphObject{
+		node: &pb.DependencyGraphNode{
+			Id:          ref.id,
+			Kind:        ref.kind,
+			Name:        ref.name,
+			Namespace:   ref.namespace,
+			ClusterName: ref.clusterName,
+			Missing:     g.listedKinds[ref.kind],
+		},
+	}
+}
+
+func (g *dependencyGraph) sortedNodes() []*pb.DependencyGraphNode {
+	nodes := make([]*pb.DependencyGraphNode, 0, len(g.nodes))
+	for _, node := range g.nodes {
+		nodes = append(nodes, node)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Id < nodes[j].Id
+	})
+
+	return nodes
+}
+
+func (g *dependencyGraph) sortedEdges() []*pb.DependencyGraphEdge {
+	edges := make([]*pb.DependencyGraphEdge, 0, len(g.edges))
+	for _, edge := range g.edges {
+		edges = append(edges, edge)
+	}
+
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].Source != edges[j].Source {
+			return edges[i].Source < edges[j].Source
+		}
+
+		if edges[i].Target != edges[j].Target {
+			return edges[i].Target < edges[j].Target
+		}
+
+		return edges[i].Type < edges[j].Type
+	})
+
+	return edges
+}
+
+// cycles returns the groups of automations that depend on each other, which
+// Flux will never reconcile. These are the strongly connected components of
+// the dependsOn edges, found with Tarjan's algorithm.
+func (g *dependencyGraph) cycles() []*pb.DependencyGraphCycle {
+	dependsOn := map[string][]string{}
+
+	for _, edge := range g.sortedEdges() {
+		if edge.Type == dependencyEdgeDependsOn {
+			dependsOn[edge.Source] = append(dependsOn[edge.Source], edge.Target)
+		}
+	}
+
+	var (
+		index   = 0
+		indices = map[string]int{}
+		lowlink = map[string]int{}
+		onStack = map[string]bool{}
+		stack   = []string{}
+		cycles  = []*pb.DependencyGraphCycle{}
+		visit   func(id string)
+	)
+
+	visit = func(id string) {
+		indices[id] = index
+		lowlink[id] = index
+		index++
+
+		stack = append(stack, id)
+		onStack[id] = true
+
+		for _, dep := range dependsOn[id] {
+			if _, visited := indices[dep]; !visited {
+				visit(dep)
+				lowlink[id] = min(lowlink[id], lowlink[dep])
+			} else if onStack[dep] {
+				lowlink[id] = min(lowlink[id], indices[dep])
+			}
+		}
+
+		if lowlink[id] != indices[id] {
+			return
+		}
+
+		component := []string{}
+
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+
+			component = append(component, top)
+
+			if top == id {
+				break
+			}
+		}
+
+		selfDependent := false
+
+		for _, dep := range dependsOn[id] {
+			if dep == id {
+				selfDependent = true
+			}
+		}
+
+		if len(component) > 1 || selfDependent {
+			sort.Strings(component)
+			cycles = append(cycles, &pb.DependencyGraphCycle{Nodes: component})
+		}
+	}
+
+	for _, node := range g.sortedNodes() {
+		if _, visited := indices[node.Id]; !visited {
+			visit(node.Id)
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool {
+		return cycles[i].Nodes[0] < cycles[j].Nodes[0]
+	})
+
+	return cycles
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"testing"
+
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	pb "github.com/weaveworks/weave-gitops/pkg/api/core"
+)
+
+func TestDependencyGraphCAPIClusters(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	graph := &dependencyGraph{
+		objects:     map[string]graphObject{},
+		nodes:       map[string]*pb.DependencyGraphNode{},
+		edges:       map[dependencyGraphEdgeKey]*pb.DependencyGraphEdge{},
+		listedKinds: map[string]bool{kustomizev1.KustomizationKind: true, sourcev1.GitRepositoryKind: true},
+	}
+
+	ks := &kustomizev1.Kustomization{
+		ObjectMeta: metav1.ObjectMeta{Name: "apps", Namespace: "flux-system"},
+		Spec: kustomizev1.KustomizationSpec{
+			SourceRef: kustomizev1.CrossNamespaceSourceReference{Kind: sourcev1.GitRepositoryKind, Name: "flux-system"},
+		},
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(ks)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	gvk := kustomizev1.GroupVersion.WithKind(kustomizev1.KustomizationKind)
+	roots := []string{}
+
+	for _, clusterName := range []string{"default/prod", "default"} {
+		g.Expect(graph.addObject(clusterName, gvk, unstructured.Unstructured{Object: content}, "Ready")).To(Succeed())
+		roots = append(roots, dependencyGraphNodeID(clusterName, gvk.Kind, ks.Namespace, ks.Name))
+	}
+
+	graph.walk(roots)
+
+	clusters := map[string]string{}
+	for _, node := range graph.sortedNodes() {
+		clusters[node.Id] = node.ClusterName
+	}
+
+	g.Expect(clusters).To(Equal(map[string]string{
+		"default/Kustomization/flux-system/apps":               "default",
+		"default/GitRepository/flux-system/flux-system":        "default",
+		"default%2Fprod/Kustomization/flux-system/apps":        "default/prod",
+		"default%2Fprod/GitRepository/flux-system/flux-system": "default/prod",
+	}), "the missing nodes should be in the cluster of the objects referring to them")
+}
@@ -0,0 +1,133 @@
+package server_test
+
+import (
+	"testing"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pb "github.com/weaveworks/weave-gitops/pkg/api/core"
+	"github.com/weaveworks/weave-gitops/pkg/kube"
+)
+
+func TestGetDependencyGraph(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ctx := t.Context()
+
+	scheme, err := kube.CreateScheme()
+	g.Expect(err).To(BeNil())
+
+	fluxNs := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "flux-system"}}
+	appsNs := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "apps"}}
+
+	repo := &sourcev1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "flux-system", Namespace: fluxNs.Name},
+		Status: sourcev1.GitRepositoryStatus{
+			Conditions: []metav1.Condition{{Type: meta.ReadyCondition, Status: metav1.ConditionTrue}},
+		},
+	}
+
+	makeKustomization := func(name, namespace string, dependsOn ...kustomizev1.DependencyReference) *kustomizev1.Kustomization {
+		return &kustomizev1.Kustomization{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: kustomizev1.KustomizationSpec{
+				SourceRef: kustomizev1.CrossNamespaceSourceReference{
+					Kind:      sourcev1.GitRepositoryKind,
+					Name:      repo.Name,
+					Namespace: repo.Namespace,
+				},
+				DependsOn: dependsOn,
+			},
+		}
+	}
+
+	infra := makeKustomization("infra", fluxNs.Name)
+	infra.Status.Conditions = []metav1.Condition{{Type: meta.ReadyCondition, Status: metav1.ConditionFalse}}
+	infra.Status.Inventory = &kustomizev1.ResourceInventory{
+		Entries: []kustomizev1.ResourceRef{
+			{ID: "apps_apps_kustomize.toolkit.fluxcd.io_Kustomization", Version: "v1"},
+			{ID: "apps_podinfo_apps_Deployment", Version: "v1"},
+		},
+	}
+
+	apps := makeKustomization("apps", appsNs.Name, kustomizev1.DependencyReference{Name: "infra", Namespace: fluxNs.Name})
+	cycleA := makeKustomization("cycle-a", appsNs.Name, kustomizev1.DependencyReference{Name: "cycle-b"})
+	cycleB := makeKustomization("cycle-b", appsNs.Name, kustomizev1.DependencyReference{Name: "cycle-a"})
+
+	release := &helmv2.HelmRelease{
+		ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: appsNs.Name},
+		Spec: helmv2.HelmReleaseSpec{
+			ChartRef: &helmv2.CrossNamespaceSourceReference{
+				Kind: sourcev1.OCIRepositoryKind,
+				Name: "podinfo",
+			},
+			DependsOn: []helmv2.DependencyReference{{Name: "redis"}},
+		},
+	}
+
+	client := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(fluxNs, appsNs, repo, infra, apps, cycleA, cycleB, release).Build()
+	cfg := makeServerConfig(t, client, "")
+	c := makeServer(ctx, t, cfg)
+
+	res, err := c.GetDependencyGraph(ctx, &pb.GetDependencyGraphRequest{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res.Errors).To(BeEmpty())
+
+	nodes := map[string]*pb.DependencyGraphNode{}
+	for _, node := range res.Nodes {
+		nodes[node.Id] = node
+	}
+
+	g.Expect(nodes).To(HaveLen(8))
+	g.Expect(nodes["Default/GitRepository/flux-system/flux-system"].Status).To(Equal("Ready"))
+	g.Expect(nodes["Default/Kustomization/flux-system/infra"].Status).To(Equal("NotReady"))
+	g.Expect(nodes["Default/OCIRepository/apps/podinfo"].Missing).To(BeTrue())
+	g.Expect(nodes["Default/HelmRelease/apps/redis"].Missing).To(BeTrue())
+	g.Expect(nodes["Default/HelmRelease/apps/podinfo"].Missing).To(BeFalse())
+
+	edges := []string{}
+	for _, edge := range res.Edges {
+		edges = append(edges, edge.Type+": "+edge.Source+" -> "+edge.Target)
+	}
+
+	g.Expect(edges).To(ConsistOf(
+		"source: Default/GitRepository/flux-system/flux-system -> Default/Kustomization/flux-system/infra",
+		"source: Default/GitRepository/flux-system/flux-system -> Default/Kustomization/apps/apps",
+		"source: Default/GitRepository/flux-system/flux-system -> Default/Kustomization/apps/cycle-a",
+		"source: Default/GitRepository/flux-system/flux-system -> Default/Kustomization/apps/cycle-b",
+		"source: Default/OCIRepository/apps/podinfo -> Default/HelmRelease/apps/podinfo",
+		"dependsOn: Default/Kustomization/apps/apps -> Default/Kustomization/flux-system/infra",
+		"dependsOn: Default/Kustomization/apps/cycle-a -> Default/Kustomization/apps/cycle-b",
+		"dependsOn: Default/Kustomization/apps/cycle-b -> Default/Kustomization/apps/cycle-a",
+		"dependsOn: Default/HelmRelease/apps/podinfo -> Default/HelmRelease/apps/redis",
+		"inventory: Default/Kustomization/flux-system/infra -> Default/Kustomization/apps/apps",
+	))
+
+	g.Expect(res.Cycles).To(HaveLen(1))
+	g.Expect(res.Cycles[0].Nodes).To(Equal([]string{
+		"Default/Kustomization/apps/cycle-a",
+		"Default/Kustomization/apps/cycle-b",
+	}))
+
+	res, err = c.GetDependencyGraph(ctx, &pb.GetDependencyGraphRequest{Namespace: fluxNs.Name})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	ids := []string{}
+	for _, node := range res.Nodes {
+		ids = append(ids, node.Id)
+	}
+
+	g.Expect(ids).To(Equal([]string{
+		"Default/GitRepository/flux-system/flux-system",
+		"Default/Kustomization/apps/apps",
+		"Default/Kustomization/flux-system/infra",
+	}), "objects in other namespaces should only be included when referenced")
+	g.Expect(res.Cycles).To(BeEmpty())
+}
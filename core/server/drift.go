@@ -0,0 +1,584 @@
+package server
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	"github.com/fluxcd/pkg/ssa"
+	"github.com/fluxcd/pkg/ssa/normalize"
+	"github.com/fluxcd/pkg/ssa/utils"
+	"github.com/pmezard/go-difflib/difflib"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	kustypes "sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/yaml"
+
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/cluster"
+	pb "github.com/weaveworks/weave-gitops/pkg/api/core"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+const (
+	// artifactRoot is where the source artifact is extracted in the
+	// in-memory filesystem the manifests are built from.
+	artifactRoot = "/artifact"
+	// maxArtifactSize is the largest source artifact that will be built,
+	// once decompressed.
+	maxArtifactSize = 100 << 20
+	// artifactFetchTimeout is how long fetching the source artifact can take.
+	artifactFetchTimeout = time.Minute
+	// lastAppliedConfigAnnotation holds the whole object as last applied by
+	// kubectl, including the data of Secrets.
+	lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+// DefaultSourceControllerAddress is where source-controller serves the
+// artifacts when Flux is installed in flux-system.
+const DefaultSourceControllerAddress = "source-controller.flux-system.svc.cluster.local."
+
+// artifactClient fetches the source artifacts from source-controller. It
+// doesn't follow redirects, that could lead anywhere.
+var artifactClient = &http.Client{
+	Timeout: artifactFetchTimeout,
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// kustomizeOwner is the field manager and label group kustomize-controller
+// applies with, so the dry-run sees the same ownership as a reconciliation.
+var kustomizeOwner = ssa.Owner{
+	Field: "kustomize-controller",
+	Group: kustomizev1.GroupVersion.Group,
+}
+
+// GetDrift builds the manifests of a Kustomization the way kustomize-controller
+// would and dry-runs them as the user. Post-build variable substitution isn't
+// supported, so objects using it will show the unsubstituted variables as drift.
+// Only Kustomizations of the management cluster are supported, as the
+// artifacts of the other clusters are served at addresses internal to them.
+func (cs *coreServer) GetDrift(ctx context.Context, msg *pb.GetDriftRequest) (*pb.GetDriftResponse, error) {
+	if msg.ClusterName != cluster.DefaultCluster {
+		return nil, status.Errorf(codes.FailedPrecondition, "drift is only available for the %s cluster, the source artifacts of cluster %s can't be fetched", cluster.DefaultCluster, msg.ClusterName)
+	}
+
+	clustersClient, err := cs.clustersManager.GetImpersonatedClient(ctx, auth.Principal(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("error getting impersonating client: %w", err)
+	}
+
+	c, err := clustersClient.Scoped(msg.ClusterName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scoped client for cluster=%s: %w", msg.ClusterName, err)
+	}
+
+	ks := &kustomizev1.Kustomization{}
+	if err := c.Get(ctx, client.ObjectKey{Name: msg.Name, Namespace: msg.Namespace}, ks); err != nil {
+		return nil, fmt.Errorf("getting kustomization: %w", err)
+	}
+
+	artifact, err := cs.getSourceArtifact(ctx, c, ks)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := fetchArtifact(ctx, artifact, cs.sourceControllerAddress)
+	if err != nil {
+		return nil, fmt.Errorf("fetching source artifact: %w", err)
+	}
+
+	objects, err := buildKustomization(data, ks)
+	if err != nil {
+		return nil, fmt.Errorf("building kustomization: %w", err)
+	}
+
+	entries, err := driftEntries(ctx, c, ks, objects)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetDriftResponse{
+		Revision: artifact.revision,
+		Entries:  entries,
+	}, nil
+}
+
+// sourceArtifact is where a source's latest artifact can be downloaded from.
+type sourceArtifact struct {
+	url      string
+	digest   string
+	revision string
+}
+
+func (cs *coreServer) getSourceArtifact(ctx context.Context, c client.Client, ks *kustomizev1.Kustomization) (sourceArtifact, error) {
+	gvk, err := cs.primaryKinds.Lookup(ks.Spec.SourceRef.Kind)
+	if err != nil {
+		return sourceArtifact{}, err
+	}
+
+	namespace := ks.Spec.SourceRef.Namespace
+	if namespace == "" {
+		namespace = ks.Namespace
+	}
+
+	source := &unstructured.Unstructured{}
+	source.SetGroupVersionKind(*gvk)
+
+	if err := c.Get(ctx, client.ObjectKey{Name: ks.Spec.SourceRef.Name, Namespace: namespace}, source); err != nil {
+		return sourceArtifact{}, fmt.Errorf("getting source: %w", err)
+	}
+
+	field := func(name string) string {
+		value, _, _ := unstructured.NestedString(source.Object, "status", "artifact", name)
+		return value
+	}
+
+	artifact := sourceArtifact{
+		url:      field("url"),
+		digest:   field("digest"),
+		revision: field("revision"),
+	}
+
+	if artifact.url == "" {
+		return sourceArtifact{}, status.Errorf(codes.FailedPrecondition, "%s %s/%s has no artifact", gvk.Kind, namespace, source.GetName())
+	}
+
+	return artifact, nil
+}
+
+// fetchArtifact downloads the artifact from source-controller, checking it
+// against its digest. The URL is taken from the status of the source, that
+// users may be able to write, so it's only fetched from the address of
+// source-controller.
+func fetchArtifact(ctx context.Context, artifact sourceArtifact, address string) ([]byte, error) {
+	u, err := url.Parse(artifact.url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid artifact URL: %w", err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host != address {
+		return nil, status.Errorf(codes.FailedPrecondition, "artifact URL %s isn't served by source-controller at %s", artifact.url, address)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, artifactFetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := artifactClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s from %s", res.Status, artifact.url)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(res.Body, maxArtifactSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > maxArtifactSize {
+		return nil, fmt.Errorf("artifact is larger than %d bytes", maxArtifactSize)
+	}
+
+	if err := verifyDigest(data, artifact.digest); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// verifyDigest checks data against an OCI style digest, like sha256:<hex>.
+// Artifacts without a digest aren't checked.
+func verifyDigest(data []byte, digest string) error {
+	if digest == "" {
+		return nil
+	}
+
+	algorithm, expected, _ := strings.Cut(digest, ":")
+
+	var h hash.Hash
+
+	switch algorithm {
+	case "sha256":
+		h = sha256.New()
+	case "sha384":
+		h = sha512.New384()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return fmt.Errorf("unsupported digest algorithm %q", algorithm)
+	}
+
+	h.Write(data)
+
+	if actual := hex.EncodeToString(h.Sum(nil)); actual != expected {
+		return fmt.Errorf("artifact digest %s:%s does not match %s", algorithm, actual, digest)
+	}
+
+	return nil
+}
+
+// buildKustomization extracts the artifact and builds the Kustomization's
+// path, with its target namespace, name prefix and suffix, patches, images
+// and components.
+func buildKustomization(artifact []byte, ks *kustomizev1.Kustomization) ([]*unstructured.Unstructured, error) {
+	fs := filesys.MakeFsInMemory()
+
+	if err := extractArtifact(fs, artifact); err != nil {
+		return nil, fmt.Errorf("extracting artifact: %w", err)
+	}
+
+	dir := path.Join(artifactRoot, path.Clean("/"+ks.Spec.Path))
+	if !fs.IsDir(dir) {
+		return nil, fmt.Errorf("path %q not found in artifact", ks.Spec.Path)
+	}
+
+	if err := writeKustomization(fs, dir, ks); err != nil {
+		return nil, err
+	}
+
+	opts := krusty.MakeDefaultOptions()
+	opts.LoadRestrictions = kustypes.LoadRestrictionsNone
+
+	resources, err := krusty.MakeKustomizer(opts).Run(fs, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	manifests, err := resources.AsYaml()
+	if err != nil {
+		return nil, err
+	}
+
+	objects, err := utils.ReadObjects(bytes.NewReader(manifests))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := normalize.UnstructuredList(objects); err != nil {
+		return nil, err
+	}
+
+	return objects, nil
+}
+
+// extractArtifact writes the files of a tar.gz artifact under artifactRoot.
+// Paths are cleaned against the root so entries can't escape it.
+func extractArtifact(fs filesys.FileSystem, artifact []byte) error {
+	gz, err := gzip.NewReader(bytes.NewReader(artifact))
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	if err := fs.MkdirAll(artifactRoot); err != nil {
+		return err
+	}
+
+	tr := tar.NewReader(io.LimitReader(gz, maxArtifactSize))
+
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		name := path.Join(artifactRoot, path.Clean("/"+header.Name))
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := fs.MkdirAll(name); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := fs.MkdirAll(path.Dir(name)); err != nil {
+				return err
+			}
+
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return err
+			}
+
+			if err := fs.WriteFile(name, data); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// writeKustomization adds the Kustomization's overrides to the
+// kustomization.yaml in dir, generating one if there's none.
+func writeKustomization(fs filesys.FileSystem, dir string, ks *kustomizev1.Kustomization) error {
+	kfile := ""
+
+	for _, name := range konfig.RecognizedKustomizationFileNames() {
+		if fs.Exists(path.Join(dir, name)) {
+			kfile = path.Join(dir, name)
+			break
+		}
+	}
+
+	kus := kustypes.Kustomization{}
+
+	if kfile == "" {
+		kfile = path.Join(dir, konfig.DefaultKustomizationFileName())
+
+		resources, err := generateResources(fs, dir)
+		if err != nil {
+			return fmt.Errorf("generating kustomization: %w", err)
+		}
+
+		kus.Resources = resources
+	} else {
+		data, err := fs.ReadFile(kfile)
+		if err != nil {
+			return err
+		}
+
+		if err := yaml.Unmarshal(data, &kus); err != nil {
+			return fmt.Errorf("reading %s: %w", path.Base(kfile), err)
+		}
+	}
+
+	kus.APIVersion = kustypes.KustomizationVersion
+	kus.Kind = kustypes.KustomizationKind
+
+	if ks.Spec.TargetNamespace != "" {
+		kus.Namespace = ks.Spec.TargetNamespace
+	}
+
+	if ks.Spec.NamePrefix != "" {
+		kus.NamePrefix = ks.Spec.NamePrefix
+	}
+
+	if ks.Spec.NameSuffix != "" {
+		kus.NameSuffix = ks.Spec.NameSuffix
+	}
+
+	kus.Components = append(kus.Components, ks.Spec.Components...)
+
+	// The Flux patch and image types serialize the same as kustomize's.
+	var (
+		patches []kustypes.Patch
+		images  []kustypes.Image
+	)
+
+	if err := convertJSON(ks.Spec.Patches, &patches); err != nil {
+		return fmt.Errorf("converting patches: %w", err)
+	}
+
+	if err := convertJSON(ks.Spec.Images, &images); err != nil {
+		return fmt.Errorf("converting images: %w", err)
+	}
+
+	kus.Patches = append(kus.Patches, patches...)
+	kus.Images = append(kus.Images, images...)
+
+	data, err := yaml.Marshal(kus)
+	if err != nil {
+		return err
+	}
+
+	return fs.WriteFile(kfile, data)
+}
+
+// generateResources lists the manifests under dir for a generated
+// kustomization, like kustomize-controller does. Directories with their own
+// kustomization are added as a whole.
+func generateResources(fs filesys.FileSystem, dir string) ([]string, error) {
+	resources := []string{}
+
+	err := fs.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if p == dir {
+				return nil
+			}
+
+			for _, name := range konfig.RecognizedKustomizationFileNames() {
+				if fs.Exists(path.Join(p, name)) {
+					resources = append(resources, strings.TrimPrefix(p, dir+"/"))
+					return filepath.SkipDir
+				}
+			}
+
+			return nil
+		}
+
+		if ext := path.Ext(p); ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+
+		data, err := fs.ReadFile(p)
+		if err != nil {
+			return err
+		}
+
+		// Only files holding Kubernetes objects are kept, as repositories
+		// often hold other YAML, like CI configuration.
+		if objects, err := utils.ReadObjects(bytes.NewReader(data)); err != nil || len(objects) == 0 {
+			return nil
+		}
+
+		resources = append(resources, strings.TrimPrefix(p, dir+"/"))
+
+		return nil
+	})
+
+	return resources, err
+}
+
+func convertJSON(from, to any) error {
+	data, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, to)
+}
+
+// driftEntries dry-runs the objects with Flux's owner labels, diffing them
+// against the live ones. Objects that can't be dry-run get an error rather
+// than failing the lot.
+func driftEntries(ctx context.Context, c client.Client, ks *kustomizev1.Kustomization, objects []*unstructured.Unstructured) ([]*pb.DriftEntry, error) {
+	manager := ssa.NewResourceManager(c, nil, kustomizeOwner)
+	manager.SetOwnerLabels(objects, ks.Name, ks.Namespace)
+
+	opts := ssa.DefaultDiffOptions()
+	opts.Exclusions = map[string]string{
+		fmt.Sprintf("%s/reconcile", kustomizeOwner.Group): kustomizev1.DisabledValue,
+	}
+	opts.IfNotPresentSelector = map[string]string{
+		fmt.Sprintf("%s/ssa", kustomizeOwner.Group): kustomizev1.IfNotPresentValue,
+	}
+	opts.Force = ks.Spec.Force
+
+	entries := []*pb.DriftEntry{}
+
+	for _, obj := range objects {
+		entry := &pb.DriftEntry{
+			Kind:      obj.GetKind(),
+			Name:      obj.GetName(),
+			Namespace: obj.GetNamespace(),
+		}
+		entries = append(entries, entry)
+
+		change, existing, dryRun, err := manager.Diff(ctx, obj, opts)
+		if err != nil {
+			entry.Error = err.Error()
+			continue
+		}
+
+		entry.Action = change.Action.String()
+
+		switch change.Action {
+		case ssa.CreatedAction:
+			existing, dryRun = nil, obj
+		case ssa.ConfiguredAction:
+		default:
+			continue
+		}
+
+		diff, err := driftDiff(existing, dryRun)
+		if err != nil {
+			return nil, fmt.Errorf("diffing %s %s/%s: %w", entry.Kind, entry.Namespace, entry.Name, err)
+		}
+
+		entry.Diff = diff
+	}
+
+	return entries, nil
+}
+
+// driftDiff returns a unified diff between the YAML of the live object and
+// the object that would be applied. Either can be nil, and Secrets are redacted.
+func driftDiff(live, applied *unstructured.Unstructured) (string, error) {
+	liveYAML, err := driftYAML(live)
+	if err != nil {
+		return "", err
+	}
+
+	appliedYAML, err := driftYAML(applied)
+	if err != nil {
+		return "", err
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(liveYAML),
+		B:        difflib.SplitLines(appliedYAML),
+		FromFile: "live",
+		ToFile:   "applied",
+		Context:  3,
+	})
+}
+
+func driftYAML(obj *unstructured.Unstructured) (string, error) {
+	if obj == nil {
+		return "", nil
+	}
+
+	if utils.IsSecret(obj) {
+		sanitized, err := sanitizeUnstructuredSecret(*obj)
+		if err != nil {
+			return "", err
+		}
+
+		// stringData is write-only, but may be in the applied object, and
+		// the last applied configuration and managed fields describe the
+		// data too.
+		unstructured.RemoveNestedField(sanitized.Object, "stringData")
+		unstructured.RemoveNestedField(sanitized.Object, "metadata", "annotations", lastAppliedConfigAnnotation)
+		unstructured.RemoveNestedField(sanitized.Object, "metadata", "managedFields")
+
+		if len(sanitized.GetAnnotations()) == 0 {
+			unstructured.RemoveNestedField(sanitized.Object, "metadata", "annotations")
+		}
+
+		obj = &sanitized
+	}
+
+	data, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,41 @@
+package server
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestDriftDiff(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	makeObject := func(kind, field, value string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       kind,
+			"metadata":   map[string]any{"name": "config", "namespace": "apps"},
+			field:        map[string]any{"color": value},
+		}}
+	}
+
+	diff, err := driftDiff(makeObject("ConfigMap", "data", "red"), makeObject("ConfigMap", "data", "blue"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(diff).To(HavePrefix("--- live\n+++ applied\n"))
+	g.Expect(diff).To(ContainSubstring("-  color: red\n+  color: blue\n"))
+
+	diff, err = driftDiff(makeObject("Secret", "data", "cmVk"), makeObject("Secret", "stringData", "blue"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(diff).To(BeEmpty(), "secret data should be redacted on both sides")
+
+	live := makeObject("Secret", "data", "cmVk")
+	live.SetAnnotations(map[string]string{
+		lastAppliedConfigAnnotation: `{"apiVersion":"v1","kind":"Secret","stringData":{"color":"red"}}`,
+	})
+	live.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationApply}})
+
+	diff, err = driftDiff(live, makeObject("Secret", "stringData", "blue"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(diff).To(BeEmpty(), "the last applied configuration and managed fields of secrets should be left out")
+}
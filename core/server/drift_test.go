@@ -0,0 +1,174 @@
+package server_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	"github.com/fluxcd/pkg/apis/kustomize"
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pb "github.com/weaveworks/weave-gitops/pkg/api/core"
+	"github.com/weaveworks/weave-gitops/pkg/kube"
+)
+
+func TestGetDrift(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ctx := t.Context()
+
+	scheme, err := kube.CreateScheme()
+	g.Expect(err).To(BeNil())
+
+	artifact := makeArtifact(t, map[string]string{
+		"apps/config.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+data:
+  color: blue
+`,
+		"apps/new.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: new
+data:
+  size: small
+`,
+		"apps/secret.yaml": `apiVersion: v1
+kind: Secret
+metadata:
+  name: credentials
+stringData:
+  password: hunter2
+`,
+		"apps/.github/ci.yaml": "on: push\n",
+	})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(artifact)
+	}))
+	defer srv.Close()
+
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "apps"}}
+
+	repo := &sourcev1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "flux-system", Namespace: "flux-system"},
+		Status: sourcev1.GitRepositoryStatus{
+			Artifact: &meta.Artifact{
+				URL:      srv.URL + "/artifact.tar.gz",
+				Revision: "main@sha1:abc",
+				Digest:   fmt.Sprintf("sha256:%x", sha256.Sum256(artifact)),
+			},
+		},
+	}
+
+	ks := &kustomizev1.Kustomization{
+		ObjectMeta: metav1.ObjectMeta{Name: "apps", Namespace: "flux-system"},
+		Spec: kustomizev1.KustomizationSpec{
+			Path:            "./apps",
+			TargetNamespace: ns.Name,
+			SourceRef: kustomizev1.CrossNamespaceSourceReference{
+				Kind: sourcev1.GitRepositoryKind,
+				Name: repo.Name,
+			},
+			Patches: []kustomize.Patch{{
+				Patch:  `[{"op": "add", "path": "/data/patched", "value": "yes"}]`,
+				Target: &kustomize.Selector{Kind: "ConfigMap", Name: "new"},
+			}},
+		},
+	}
+
+	client := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(ns, repo, ks).Build()
+	cfg := makeServerConfig(t, client, "")
+	cfg.SourceControllerAddress = srv.Listener.Addr().String()
+	c := makeServer(ctx, t, cfg)
+
+	res, err := c.GetDrift(ctx, &pb.GetDriftRequest{Name: ks.Name, Namespace: ks.Namespace, ClusterName: "Default"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res.Revision).To(Equal("main@sha1:abc"))
+
+	entries := map[string]*pb.DriftEntry{}
+	for _, entry := range res.Entries {
+		g.Expect(entry.Namespace).To(Equal(ns.Name))
+		g.Expect(entry.Error).To(BeEmpty())
+		entries[entry.Kind+"/"+entry.Name] = entry
+	}
+
+	g.Expect(entries).To(HaveLen(3), "files that aren't Kubernetes objects should be left out")
+
+	g.Expect(entries["ConfigMap/config"].Action).To(Equal("created"))
+	g.Expect(entries["ConfigMap/config"].Diff).To(ContainSubstring("+  color: blue\n"))
+	g.Expect(entries["ConfigMap/config"].Diff).To(ContainSubstring("+    kustomize.toolkit.fluxcd.io/name: apps\n"))
+
+	g.Expect(entries["ConfigMap/new"].Action).To(Equal("created"))
+	g.Expect(entries["ConfigMap/new"].Diff).To(ContainSubstring("+  patched: \"yes\"\n"))
+
+	g.Expect(entries["Secret/credentials"].Action).To(Equal("created"))
+	g.Expect(entries["Secret/credentials"].Diff).To(ContainSubstring("redacted"))
+	g.Expect(entries["Secret/credentials"].Diff).NotTo(ContainSubstring("hunter2"))
+
+	g.Expect(client.Get(ctx, ctrlclient.ObjectKeyFromObject(repo), repo)).To(Succeed())
+	repo.Status.Artifact.Digest = "sha256:0000"
+	g.Expect(client.Update(ctx, repo)).To(Succeed())
+
+	_, err = c.GetDrift(ctx, &pb.GetDriftRequest{Name: ks.Name, Namespace: ks.Namespace, ClusterName: "Default"})
+	g.Expect(err).To(MatchError(ContainSubstring("does not match")))
+
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the artifact was fetched from another server than source-controller")
+	}))
+	defer other.Close()
+
+	g.Expect(client.Get(ctx, ctrlclient.ObjectKeyFromObject(repo), repo)).To(Succeed())
+	repo.Status.Artifact.URL = other.URL + "/artifact.tar.gz"
+	g.Expect(client.Update(ctx, repo)).To(Succeed())
+
+	_, err = c.GetDrift(ctx, &pb.GetDriftRequest{Name: ks.Name, Namespace: ks.Namespace, ClusterName: "Default"})
+	g.Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+
+	_, err = c.GetDrift(ctx, &pb.GetDriftRequest{Name: ks.Name, Namespace: ks.Namespace, ClusterName: "leaf"})
+	g.Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+}
+
+func makeArtifact(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gz)
+
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content))}); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
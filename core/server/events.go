@@ -66,15 +66,7 @@ func (cs *coreServer) ListEvents(ctx context.Context, msg *pb.ListEventsRequest)
 			}
 
 			for _, e := range list.Items {
-				events = append(events, &pb.Event{
-					Type:      e.Type,
-					Component: e.Source.Component,
-					Name:      e.Name,
-					Reason:    e.Reason,
-					Message:   e.Message,
-					Timestamp: e.LastTimestamp.Format(time.RFC3339),
-					Host:      e.Source.Host,
-				})
+				events = append(events, eventToProto(e))
 			}
 		}
 	}
@@ -82,6 +74,18 @@ func (cs *coreServer) ListEvents(ctx context.Context, msg *pb.ListEventsRequest)
 	return &pb.ListEventsResponse{Events: events}, nil
 }
 
+func eventToProto(e corev1.Event) *pb.Event {
+	return &pb.Event{
+		Type:      e.Type,
+		Component: e.Source.Component,
+		Name:      e.Name,
+		Reason:    e.Reason,
+		Message:   e.Message,
+		Timestamp: e.LastTimestamp.Format(time.RFC3339),
+		Host:      e.Source.Host,
+	}
+}
+
 func list(ctx context.Context, k8s clustersmngr.Client, appName, namespace string, list clustersmngr.ClusteredObjectList, extraOpts ...client.ListOption) error {
 	opts := []client.ListOption{
 		getMatchingLabels(appName),
@@ -0,0 +1,211 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	pb "github.com/weaveworks/weave-gitops/pkg/api/core"
+	"github.com/weaveworks/weave-gitops/pkg/health"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+// DefaultHealthHistoryInterval is how often the health of the objects is
+// sampled.
+const DefaultHealthHistoryInterval = 30 * time.Second
+
+// healthHistoryKinds are the kinds of the Flux-managed objects whose health
+// is recorded.
+var healthHistoryKinds = []schema.GroupVersionKind{
+	{Group: "apps", Version: "v1", Kind: "Deployment"},
+	{Group: "apps", Version: "v1", Kind: "StatefulSet"},
+	{Group: "apps", Version: "v1", Kind: "DaemonSet"},
+}
+
+// fluxManagedLabels are the labels Flux sets on the objects it applies.
+var fluxManagedLabels = []string{
+	kustomizev1.GroupVersion.Group + "/name",
+	helmv2.GroupVersion.Group + "/name",
+}
+
+// HealthHistoryRecorderConfig configures the sampling of the health of the
+// Flux-managed objects on all the clusters.
+type HealthHistoryRecorderConfig struct {
+	ClustersManager clustersmngr.ClustersManager
+	HealthChecker   health.HealthChecker
+	History         *health.History
+	// Interval is how often the objects are sampled.
+	Interval time.Duration
+	// Client and ConfigMap persist the history across restarts, it's only
+	// kept in memory when the ConfigMap name is empty.
+	Client    client.Client
+	ConfigMap client.ObjectKey
+}
+
+// HealthHistoryRecorder records the health of the Flux-managed objects.
+type HealthHistoryRecorder struct {
+	cfg HealthHistoryRecorderConfig
+	log logr.Logger
+}
+
+func NewHealthHistoryRecorder(log logr.Logger, cfg HealthHistoryRecorderConfig) *HealthHistoryRecorder {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultHealthHistoryInterval
+	}
+
+	return &HealthHistoryRecorder{
+		cfg: cfg,
+		log: log.WithName("health-history"),
+	}
+}
+
+// Run loads the persisted history and samples the objects until ctx is done.
+func (r *HealthHistoryRecorder) Run(ctx context.Context) {
+	persisted := r.cfg.ConfigMap.Name != ""
+
+	if persisted {
+		if err := r.cfg.History.Load(ctx, r.cfg.Client, r.cfg.ConfigMap); err != nil {
+			r.log.Error(err, "failed to load health history", "configmap", r.cfg.ConfigMap)
+		}
+	}
+
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		r.Sample(ctx)
+
+		if persisted {
+			if err := r.cfg.History.Save(ctx, r.cfg.Client, r.cfg.ConfigMap); err != nil {
+				r.log.Error(err, "failed to save health history", "configmap", r.cfg.ConfigMap)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sample records the health of the Flux-managed objects. The objects that
+// couldn't be listed are kept, the others that weren't seen for a flapping
+// window are forgotten.
+func (r *HealthHistoryRecorder) Sample(ctx context.Context) {
+	started := time.Now()
+
+	clustersClient, err := r.cfg.ClustersManager.GetServerClient(ctx)
+	if err != nil {
+		r.log.Error(err, "failed to get server client")
+		return
+	}
+
+	listed := true
+
+	for _, gvk := range healthHistoryKinds {
+		clist := clustersmngr.NewClusteredList(func() client.ObjectList {
+			list := &unstructured.UnstructuredList{}
+			list.SetGroupVersionKind(gvk)
+
+			return list
+		})
+
+		if err := clustersClient.ClusteredList(ctx, clist, false); err != nil {
+			r.log.Error(err, "failed to list objects", "kind", gvk.Kind)
+
+			listed = false
+		}
+
+		for clusterName, lists := range clist.Lists() {
+			for _, l := range lists {
+				list, ok := l.(*unstructured.UnstructuredList)
+				if !ok {
+					continue
+				}
+
+				for _, obj := range list.Items {
+					if !fluxManaged(obj) {
+						continue
+					}
+
+					// Failed checks are recorded as Unknown with the error as message.
+					objHealth, _ := r.cfg.HealthChecker.Check(obj)
+
+					r.cfg.History.Record(health.HistoryKey(clusterName, gvk.GroupKind(), obj.GetNamespace(), obj.GetName()), objHealth)
+				}
+			}
+		}
+	}
+
+	if listed {
+		r.cfg.History.Prune(started.Add(-r.cfg.History.FlappingWindow()))
+	}
+}
+
+func fluxManaged(obj unstructured.Unstructured) bool {
+	labels := obj.GetLabels()
+
+	for _, label := range fluxManagedLabels {
+		if _, ok := labels[label]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetHealthHistory returns the recorded health of an object the user can
+// get.
+func (cs *coreServer) GetHealthHistory(ctx context.Context, msg *pb.GetHealthHistoryRequest) (*pb.GetHealthHistoryResponse, error) {
+	if cs.healthHistory == nil {
+		return nil, status.Error(codes.FailedPrecondition, "health history is not enabled on the server")
+	}
+
+	gvk, err := cs.primaryKinds.Lookup(msg.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	clustersClient, err := cs.clustersManager.GetImpersonatedClient(ctx, auth.Principal(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("error getting impersonating client: %w", err)
+	}
+
+	obj := unstructured.Unstructured{}
+	obj.SetGroupVersionKind(*gvk)
+
+	if err := clustersClient.Get(ctx, msg.ClusterName, client.ObjectKey{Name: msg.Name, Namespace: msg.Namespace}, &obj); err != nil {
+		return nil, err
+	}
+
+	history, ok := cs.healthHistory.Get(health.HistoryKey(msg.ClusterName, gvk.GroupKind(), msg.Namespace, msg.Name))
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no health history recorded for %s %s/%s", gvk.Kind, msg.Namespace, msg.Name)
+	}
+
+	entries := make([]*pb.HealthHistoryEntry, 0, len(history.Entries))
+	for _, e := range history.Entries {
+		entries = append(entries, &pb.HealthHistoryEntry{
+			Status:    string(e.Status),
+			Message:   e.Message,
+			Timestamp: e.Time.Format(time.RFC3339),
+		})
+	}
+
+	return &pb.GetHealthHistoryResponse{
+		Entries:  entries,
+		Changes:  int32(history.Changes),
+		Flapping: history.Flapping,
+	}, nil
+}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/cluster/clusterfakes"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/clustersmngrfakes"
+	pb "github.com/weaveworks/weave-gitops/pkg/api/core"
+	"github.com/weaveworks/weave-gitops/pkg/health"
+	"github.com/weaveworks/weave-gitops/pkg/kube"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+func TestHealthHistory(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := auth.WithPrincipal(t.Context(), &auth.UserPrincipal{ID: "anne"})
+
+	podinfo := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "podinfo",
+			Namespace:  "apps",
+			Generation: 1,
+			Labels:     map[string]string{"kustomize.toolkit.fluxcd.io/name": "apps"},
+		},
+		Spec:   appsv1.DeploymentSpec{Replicas: ptr.To[int32](1)},
+		Status: appsv1.DeploymentStatus{ObservedGeneration: 1, UpdatedReplicas: 1},
+	}
+	unmanaged := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: "apps"},
+	}
+
+	scheme, err := kube.CreateScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(podinfo, unmanaged).Build()
+
+	defaultCluster := &clusterfakes.FakeCluster{}
+	defaultCluster.GetNameReturns("Default")
+
+	pool := clustersmngr.NewClustersClientsPool()
+	g.Expect(pool.Add(k8sClient, defaultCluster)).To(Succeed())
+
+	clustersClient := clustersmngr.NewClient(pool, nil, logr.Discard())
+
+	clustersManager := &clustersmngrfakes.FakeClustersManager{}
+	clustersManager.GetServerClientReturns(clustersClient, nil)
+	clustersManager.GetImpersonatedClientReturns(clustersClient, nil)
+
+	history := health.NewHistory(health.HistoryOptions{FlappingThreshold: 2})
+
+	recorder := NewHealthHistoryRecorder(logr.Discard(), HealthHistoryRecorderConfig{
+		ClustersManager: clustersManager,
+		HealthChecker:   health.NewHealthChecker(),
+		History:         history,
+	})
+
+	kinds, err := DefaultPrimaryKinds()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	cs := &coreServer{
+		clustersManager: clustersManager,
+		primaryKinds:    kinds,
+	}
+
+	getHistory := func(name string) (*pb.GetHealthHistoryResponse, error) {
+		return cs.GetHealthHistory(ctx, &pb.GetHealthHistoryRequest{Name: name, Namespace: "apps", Kind: "Deployment", ClusterName: "Default"})
+	}
+
+	_, err = getHistory("podinfo")
+	g.Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+
+	cs.healthHistory = history
+
+	// The deployment rolls out a new version and back to healthy.
+	setGeneration := func(generation int64) {
+		g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(podinfo), podinfo)).To(Succeed())
+		podinfo.Generation = generation
+		g.Expect(k8sClient.Update(ctx, podinfo)).To(Succeed())
+	}
+
+	recorder.Sample(context.Background())
+	setGeneration(2)
+	recorder.Sample(context.Background())
+	recorder.Sample(context.Background())
+	setGeneration(1)
+	recorder.Sample(context.Background())
+
+	res, err := getHistory("podinfo")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res.Entries).To(HaveLen(3))
+	g.Expect(res.Entries[0].Status).To(Equal(string(health.HealthStatusHealthy)))
+	g.Expect(res.Entries[1].Status).To(Equal(string(health.HealthStatusProgressing)))
+	g.Expect(res.Entries[1].Message).To(Equal("waiting spec to be observed"))
+	g.Expect(res.Entries[2].Status).To(Equal(string(health.HealthStatusHealthy)))
+	g.Expect(res.Changes).To(Equal(int32(2)))
+	g.Expect(res.Flapping).To(BeTrue())
+
+	// Objects that aren't managed by Flux aren't recorded.
+	_, err = getHistory("debug")
+	g.Expect(status.Code(err)).To(Equal(codes.NotFound))
+}
@@ -0,0 +1,212 @@
+package server
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	pb "github.com/weaveworks/weave-gitops/pkg/api/core"
+	"github.com/weaveworks/weave-gitops/pkg/health"
+)
+
+// maxConcurrentHealthRollups bounds the health rollups computed in parallel
+// for a page of objects, each of them fetching the objects of an inventory.
+const maxConcurrentHealthRollups = 8
+
+// objectInventory is the inventory of a Kustomization or HelmRelease, walked
+// by its health rollup.
+type objectInventory struct {
+	refs      []*unstructured.Unstructured
+	defaultNS string
+	err       error
+}
+
+// getObjectInventory returns the inventory of a Kustomization, from its
+// status, or of a HelmRelease, from its Helm storage Secret.
+func getObjectInventory(ctx context.Context, k8sClient client.Client, obj unstructured.Unstructured) objectInventory {
+	inventory := objectInventory{defaultNS: obj.GetNamespace()}
+
+	switch obj.GetKind() {
+	case kustomizev1.KustomizationKind:
+		if _, found, _ := unstructured.NestedMap(obj.Object, "status", "inventory"); found {
+			inventory.refs, inventory.err = parseInventoryFromUnstructured(&obj)
+		}
+	case helmv2.HelmReleaseKind:
+		var release helmv2.HelmRelease
+
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), &release); err != nil {
+			inventory.err = fmt.Errorf("converting unstructured to helmrelease: %w", err)
+			break
+		}
+
+		inventory.refs, inventory.err = getHelmReleaseObjects(ctx, k8sClient, &release)
+		inventory.defaultNS = defaultNSFromHelmRelease(&release)
+	}
+
+	return inventory
+}
+
+// objectHealthRollup returns the health rollup of obj on clusterName, or nil
+// for the kinds that have no inventory to roll up. The inventory is fetched
+// unless it's given.
+func (cs *coreServer) objectHealthRollup(ctx context.Context, clustersClient clustersmngr.Client, clusterName string, obj unstructured.Unstructured, inventory *objectInventory) (*pb.HealthStatus, error) {
+	switch obj.GetKind() {
+	case kustomizev1.KustomizationKind, helmv2.HelmReleaseKind:
+	default:
+		return nil, nil
+	}
+
+	k8sClient, err := clustersClient.Scoped(clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scoped client for cluster=%s: %w", clusterName, err)
+	}
+
+	if inventory == nil {
+		fetched := getObjectInventory(ctx, k8sClient, obj)
+		inventory = &fetched
+	}
+
+	rollup := healthRollup(ctx, k8sClient, obj, *inventory, cs.healthChecker, cs.logger)
+
+	return &pb.HealthStatus{
+		Status:  string(rollup.Status),
+		Message: rollup.Message,
+	}, nil
+}
+
+// setHealthRollups sets the health rollup of the queried objects, computing
+// at most maxConcurrentHealthRollups of them at a time. It returns the errors
+// of the objects whose rollup failed.
+func (cs *coreServer) setHealthRollups(ctx context.Context, clustersClient clustersmngr.Client, queried []queriedObject) []*pb.ListError {
+	var (
+		wg   sync.WaitGroup
+		sem  = make(chan struct{}, maxConcurrentHealthRollups)
+		errs = make([]error, len(queried))
+	)
+
+	for i, q := range queried {
+		wg.Add(1)
+
+		sem <- struct{}{}
+
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			q.object.HealthRollup, errs[i] = cs.objectHealthRollup(ctx, clustersClient, q.object.ClusterName, q.source, q.inventory)
+		}()
+	}
+
+	wg.Wait()
+
+	var respErrors []*pb.ListError
+
+	for i, err := range errs {
+		if err != nil {
+			respErrors = append(respErrors, &pb.ListError{ClusterName: queried[i].object.ClusterName, Message: err.Error()})
+		}
+	}
+
+	return respErrors
+}
+
+// healthRollup combines the health of a Kustomization or HelmRelease, from
+// its Ready condition, with the worst health of the objects in its inventory
+// and their children. The message says which object the verdict comes from.
+func healthRollup(ctx context.Context, k8sClient client.Client, obj unstructured.Unstructured, inventory objectInventory, healthChecker health.HealthChecker, logger logr.Logger) health.HealthStatus {
+	rollup := fluxReadyHealth(obj)
+
+	if inventory.err != nil {
+		inventoryHealth := health.HealthStatus{Status: health.HealthStatusUnknown, Message: fmt.Sprintf("failed getting inventory: %s", inventory.err)}
+		if inventoryHealth.Status.Worse(rollup.Status) {
+			rollup = inventoryHealth
+		}
+
+		return rollup
+	}
+
+	var walk func(objs []*ObjectWithChildren)
+	walk = func(objs []*ObjectWithChildren) {
+		// The objects are fetched in parallel, sort them so the same object
+		// is reported when several are as unhealthy.
+		slices.SortFunc(objs, func(a, b *ObjectWithChildren) int {
+			return cmp.Or(
+				cmp.Compare(a.Object.GetKind(), b.Object.GetKind()),
+				cmp.Compare(a.Object.GetNamespace(), b.Object.GetNamespace()),
+				cmp.Compare(a.Object.GetName(), b.Object.GetName()),
+			)
+		})
+
+		for _, o := range objs {
+			// Failed checks are reported as Unknown with the error as message.
+			objHealth, _ := healthChecker.Check(*o.Object)
+			if objHealth.Status.Worse(rollup.Status) {
+				rollup = health.HealthStatus{
+					Status:  objHealth.Status,
+					Message: objectHealthMessage(*o.Object, objHealth),
+				}
+			}
+
+			walk(o.Children)
+		}
+	}
+
+	walk(getObjectsWithChildren(ctx, inventory.defaultNS, inventory.refs, k8sClient, true, logger))
+
+	return rollup
+}
+
+// fluxReadyHealth derives the health of a Flux object from its Ready
+// condition. Objects that weren't reconciled since they last changed are
+// Progressing, unless they're suspended.
+func fluxReadyHealth(obj unstructured.Unstructured) health.HealthStatus {
+	suspended, _, _ := unstructured.NestedBool(obj.Object, "spec", "suspend")
+	observedGeneration, _, _ := unstructured.NestedInt64(obj.Object, "status", "observedGeneration")
+
+	if !suspended && observedGeneration != obj.GetGeneration() {
+		return health.HealthStatus{Status: health.HealthStatusProgressing, Message: "waiting for the latest changes to be reconciled"}
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != objectStatusReady {
+			continue
+		}
+
+		message, _ := condition["message"].(string)
+
+		switch condition["status"] {
+		case string(metav1.ConditionTrue):
+			return health.HealthStatus{Status: health.HealthStatusHealthy, Message: message}
+		case string(metav1.ConditionFalse):
+			return health.HealthStatus{Status: health.HealthStatusUnhealthy, Message: message}
+		default:
+			return health.HealthStatus{Status: health.HealthStatusProgressing, Message: message}
+		}
+	}
+
+	return health.HealthStatus{Status: health.HealthStatusProgressing, Message: "waiting to be reconciled"}
+}
+
+func objectHealthMessage(obj unstructured.Unstructured, objHealth health.HealthStatus) string {
+	message := fmt.Sprintf("%s %s is %s", obj.GetKind(), client.ObjectKeyFromObject(&obj), objHealth.Status)
+	if objHealth.Message != "" {
+		message += ": " + objHealth.Message
+	}
+
+	return message
+}
@@ -0,0 +1,132 @@
+package server
+
+import (
+	"testing"
+
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	"github.com/fluxcd/pkg/apis/meta"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/weaveworks/weave-gitops/pkg/health"
+	"github.com/weaveworks/weave-gitops/pkg/kube"
+)
+
+func TestHealthRollup(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: "apps", UID: "deployment-uid"},
+		Spec:       appsv1.DeploymentSpec{Replicas: ptr.To[int32](1)},
+		Status:     appsv1.DeploymentStatus{UpdatedReplicas: 1},
+	}
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "podinfo-7d9f",
+			Namespace:       "apps",
+			UID:             "replicaset-uid",
+			OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: "podinfo", UID: "deployment-uid"}},
+		},
+		Spec:   appsv1.ReplicaSetSpec{Replicas: ptr.To[int32](1)},
+		Status: appsv1.ReplicaSetStatus{AvailableReplicas: 1},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "podinfo-7d9f-x2kq",
+			Namespace:       "apps",
+			OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "podinfo-7d9f", UID: "replicaset-uid"}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodFailed, Message: "out of memory"},
+	}
+
+	scheme, err := kube.CreateScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	makeKustomization := func(ready metav1.ConditionStatus, message string) unstructured.Unstructured {
+		ks := &kustomizev1.Kustomization{
+			TypeMeta:   metav1.TypeMeta{APIVersion: kustomizev1.GroupVersion.String(), Kind: kustomizev1.KustomizationKind},
+			ObjectMeta: metav1.ObjectMeta{Name: "apps", Namespace: "flux-system"},
+			Status: kustomizev1.KustomizationStatus{
+				Conditions: []metav1.Condition{{Type: meta.ReadyCondition, Status: ready, Message: message}},
+				Inventory: &kustomizev1.ResourceInventory{
+					Entries: []kustomizev1.ResourceRef{{ID: "apps_podinfo_apps_Deployment", Version: "v1"}},
+				},
+			},
+		}
+
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(ks)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		return unstructured.Unstructured{Object: content}
+	}
+
+	tests := []struct {
+		name     string
+		objects  []runtime.Object
+		ks       unstructured.Unstructured
+		expected health.HealthStatus
+	}{
+		{
+			name:     "healthy inventory",
+			objects:  []runtime.Object{deployment, replicaSet},
+			ks:       makeKustomization(metav1.ConditionTrue, "Applied revision: main@sha1:4f2e"),
+			expected: health.HealthStatus{Status: health.HealthStatusHealthy, Message: "Applied revision: main@sha1:4f2e"},
+		},
+		{
+			name:     "unhealthy pod of a deployment",
+			objects:  []runtime.Object{deployment, replicaSet, pod},
+			ks:       makeKustomization(metav1.ConditionTrue, "Applied revision: main@sha1:4f2e"),
+			expected: health.HealthStatus{Status: health.HealthStatusUnhealthy, Message: "Pod apps/podinfo-7d9f-x2kq is Unhealthy: out of memory"},
+		},
+		{
+			name:     "reconciling",
+			objects:  []runtime.Object{deployment, replicaSet},
+			ks:       makeKustomization(metav1.ConditionUnknown, "Reconciliation in progress"),
+			expected: health.HealthStatus{Status: health.HealthStatusProgressing, Message: "Reconciliation in progress"},
+		},
+		{
+			name:     "failed reconciliation",
+			objects:  []runtime.Object{deployment, replicaSet, pod},
+			ks:       makeKustomization(metav1.ConditionFalse, "kustomize build failed"),
+			expected: health.HealthStatus{Status: health.HealthStatusUnhealthy, Message: "kustomize build failed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(tt.objects...).Build()
+
+			rollup := healthRollup(t.Context(), k8sClient, tt.ks, getObjectInventory(t.Context(), k8sClient, tt.ks), health.NewHealthChecker(), logr.Discard())
+			g.Expect(rollup).To(Equal(tt.expected))
+		})
+	}
+}
+
+func TestFluxReadyHealth(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	obj := unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"name": "apps", "generation": int64(2)},
+		"status": map[string]any{
+			"observedGeneration": int64(1),
+			"conditions":         []any{map[string]any{"type": "Ready", "status": "True"}},
+		},
+	}}
+
+	g.Expect(fluxReadyHealth(obj).Status).To(Equal(health.HealthStatusProgressing))
+
+	g.Expect(unstructured.SetNestedField(obj.Object, true, "spec", "suspend")).To(Succeed())
+	g.Expect(fluxReadyHealth(obj).Status).To(Equal(health.HealthStatusHealthy))
+
+	unstructured.RemoveNestedField(obj.Object, "status", "conditions")
+	g.Expect(fluxReadyHealth(obj).Status).To(Equal(health.HealthStatusProgressing))
+}
@@ -1,27 +1,15 @@
 package server
 
 import (
-	"context"
-	"fmt"
 	"strings"
 
-	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
-	"github.com/weaveworks/weave-gitops/core/clustersmngr"
 	pb "github.com/weaveworks/weave-gitops/pkg/api/core"
 )
 
-func getHelmReleaseInventory(ctx context.Context, helmRelease helmv2.HelmRelease, c clustersmngr.Client, cluster string) ([]*pb.GroupVersionKind, error) {
-	k8sClient, err := c.Scoped(cluster)
-	if err != nil {
-		return nil, fmt.Errorf("error getting scoped client for cluster=%s: %w", cluster, err)
-	}
-
-	objects, err := getHelmReleaseObjects(ctx, k8sClient, &helmRelease)
-	if err != nil {
-		return nil, err
-	}
-
+// inventoryKinds returns the kinds of the objects of an inventory.
+func inventoryKinds(objects []*unstructured.Unstructured) []*pb.GroupVersionKind {
 	var gvk []*pb.GroupVersionKind
 
 	found := map[string]bool{}
@@ -40,5 +28,5 @@ func getHelmReleaseInventory(ctx context.Context, helmRelease helmv2.HelmRelease
 		}
 	}
 
-	return gvk, nil
+	return gvk
 }
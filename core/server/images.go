@@ -0,0 +1,172 @@
+package server
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/distribution/reference"
+
+	pb "github.com/weaveworks/weave-gitops/pkg/api/core"
+)
+
+// imageRef is an image reference split in its parts.
+type imageRef struct {
+	registry   string
+	repository string
+	tag        string
+	digest     string
+}
+
+// ListImages lists the images of the workloads in the namespaces the user can
+// access, and the Kustomizations and HelmReleases the workloads are labelled
+// as managed by.
+func (cs *coreServer) ListImages(ctx context.Context, msg *pb.ListImagesRequest) (*pb.ListImagesResponse, error) {
+	clustersClient, respErrors := cs.impersonatedClient(ctx, msg.ClusterName)
+
+	filter := imageFilter{
+		registry:   normalizeRegistry(msg.Registry),
+		repository: normalizeRepository(msg.Repository),
+		tag:        msg.Tag,
+	}
+
+	found := map[imageRef]*pb.Image{}
+
+	for _, gvk := range workloadKinds {
+		items, listErrors := cs.clusteredItems(ctx, clustersClient, gvk)
+		respErrors = append(respErrors, listErrors...)
+
+		for clusterName, objs := range items {
+			for _, obj := range objs {
+				if msg.Namespace != "" && obj.GetNamespace() != msg.Namespace {
+					continue
+				}
+
+				workload := &pb.ObjectRef{
+					ClusterName: clusterName,
+					Kind:        gvk.Kind,
+					Name:        obj.GetName(),
+					Namespace:   obj.GetNamespace(),
+				}
+				owners := labelOwners(clusterName, obj)
+
+				for _, image := range workloadImages(obj) {
+					ref := parseImageRef(image)
+
+					if !filter.matches(ref) {
+						continue
+					}
+
+					img, ok := found[ref]
+					if !ok {
+						img = &pb.Image{
+							Registry:   ref.registry,
+							Repository: ref.repository,
+							Tag:        ref.tag,
+							Digest:     ref.digest,
+						}
+						found[ref] = img
+					}
+
+					// Workloads running an image in several containers are
+					// only listed once.
+					if !slices.ContainsFunc(img.Workloads, func(w *pb.ImageWorkload) bool { return compareObjectRefs(w.Workload, workload) == 0 }) {
+						img.Workloads = append(img.Workloads, &pb.ImageWorkload{Workload: workload, Owners: owners})
+					}
+				}
+			}
+		}
+	}
+
+	images := make([]*pb.Image, 0, len(found))
+	for _, img := range found {
+		slices.SortFunc(img.Workloads, func(a, b *pb.ImageWorkload) int {
+			return compareObjectRefs(a.Workload, b.Workload)
+		})
+
+		images = append(images, img)
+	}
+
+	slices.SortFunc(images, func(a, b *pb.Image) int {
+		return cmp.Or(
+			cmp.Compare(a.Repository, b.Repository),
+			cmp.Compare(a.Tag, b.Tag),
+			cmp.Compare(a.Digest, b.Digest),
+		)
+	})
+
+	return &pb.ListImagesResponse{
+		Images: images,
+		Errors: respErrors,
+	}, nil
+}
+
+// imageFilter is the normalized registry, repository and tag the images are
+// filtered on, the empty ones match any image.
+type imageFilter imageRef
+
+func (f imageFilter) matches(ref imageRef) bool {
+	return (f.registry == "" || ref.registry == f.registry) &&
+		(f.repository == "" || ref.repository == f.repository) &&
+		(f.tag == "" || ref.tag == f.tag)
+}
+
+// parseImageRef splits an image reference as written in a pod spec, once
+// normalized, so the different ways of writing the same image are grouped:
+// the registry defaults to docker.io, the official Docker Hub images are in
+// library/, and the tag defaults to latest unless the image is pinned to a
+// digest. The repository includes the registry, e.g.
+// docker.io/library/redis. Invalid references are kept as the repository.
+func parseImageRef(image string) imageRef {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return imageRef{repository: image}
+	}
+
+	named = reference.TagNameOnly(named)
+
+	ref := imageRef{
+		registry:   reference.Domain(named),
+		repository: named.Name(),
+	}
+
+	if tagged, ok := named.(reference.Tagged); ok {
+		ref.tag = tagged.Tag()
+	}
+
+	if digested, ok := named.(reference.Digested); ok {
+		ref.digest = digested.Digest().String()
+	}
+
+	return ref
+}
+
+// normalizeRegistry normalizes a registry filter, e.g. index.docker.io is
+// docker.io.
+func normalizeRegistry(registry string) string {
+	if registry == "" {
+		return ""
+	}
+
+	named, err := reference.ParseNormalizedNamed(registry + "/image")
+	if err != nil {
+		return registry
+	}
+
+	return reference.Domain(named)
+}
+
+// normalizeRepository normalizes a repository filter, e.g. redis is
+// docker.io/library/redis.
+func normalizeRepository(repository string) string {
+	if repository == "" {
+		return ""
+	}
+
+	named, err := reference.ParseNormalizedNamed(repository)
+	if err != nil {
+		return repository
+	}
+
+	return named.Name()
+}
@@ -0,0 +1,177 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/cluster/clusterfakes"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/clustersmngrfakes"
+	pb "github.com/weaveworks/weave-gitops/pkg/api/core"
+	"github.com/weaveworks/weave-gitops/pkg/kube"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+func TestListImages(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := auth.WithPrincipal(t.Context(), &auth.UserPrincipal{ID: "anne"})
+
+	podinfo := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "podinfo",
+			Namespace: "apps",
+			Labels: map[string]string{
+				KustomizeNameKey:      "apps",
+				KustomizeNamespaceKey: "flux-system",
+			},
+		},
+		Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{
+					{Name: "init", Image: "busybox"},
+					// The same image, written out in full.
+					{Name: "setup", Image: "docker.io/library/busybox:latest"},
+				},
+				Containers: []corev1.Container{
+					{Name: "app", Image: "ghcr.io/stefanprodan/podinfo:6.5.0"},
+					{Name: "sidecar", Image: "ghcr.io/stefanprodan/podinfo:6.5.0"},
+				},
+			},
+		}},
+	}
+	backup := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "backup",
+			Namespace: "ops",
+			Labels: map[string]string{
+				HelmNameKey:      "backup",
+				HelmNamespaceKey: "ops",
+			},
+		},
+		Spec: batchv1.CronJobSpec{JobTemplate: batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "backup", Image: "ghcr.io/stefanprodan/podinfo:6.4.0"}}},
+		}}}},
+	}
+
+	scheme, err := kube.CreateScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(podinfo, backup).Build()
+
+	defaultCluster := &clusterfakes.FakeCluster{}
+	defaultCluster.GetNameReturns("Default")
+
+	pool := clustersmngr.NewClustersClientsPool()
+	g.Expect(pool.Add(k8sClient, defaultCluster)).To(Succeed())
+
+	namespaces := map[string][]corev1.Namespace{
+		"Default": {
+			{ObjectMeta: metav1.ObjectMeta{Name: "apps"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "ops"}},
+		},
+	}
+
+	clustersManager := &clustersmngrfakes.FakeClustersManager{}
+	clustersManager.GetImpersonatedClientReturns(clustersmngr.NewClient(pool, namespaces, logr.Discard()), nil)
+
+	cs := &coreServer{
+		logger:          logr.Discard(),
+		clustersManager: clustersManager,
+	}
+
+	podinfoRef := &pb.ObjectRef{ClusterName: "Default", Kind: "Deployment", Name: "podinfo", Namespace: "apps"}
+
+	t.Run("all images", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		res, err := cs.ListImages(ctx, &pb.ListImagesRequest{})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(res.Errors).To(BeEmpty())
+		g.Expect(res.Images).To(HaveLen(3))
+
+		g.Expect(res.Images[0].Registry).To(Equal("docker.io"))
+		g.Expect(res.Images[0].Repository).To(Equal("docker.io/library/busybox"))
+		g.Expect(res.Images[0].Tag).To(Equal("latest"))
+		g.Expect(res.Images[0].Workloads).To(HaveLen(1))
+
+		g.Expect(res.Images[1].Tag).To(Equal("6.4.0"))
+		g.Expect(res.Images[1].Workloads).To(HaveLen(1))
+		g.Expect(res.Images[1].Workloads[0].Workload.Kind).To(Equal("CronJob"))
+		g.Expect(res.Images[1].Workloads[0].Owners).To(Equal([]*pb.ObjectRef{{ClusterName: "Default", Kind: "HelmRelease", Name: "backup", Namespace: "ops"}}))
+
+		g.Expect(res.Images[2].Tag).To(Equal("6.5.0"))
+		g.Expect(res.Images[2].Workloads).To(HaveLen(1))
+		g.Expect(res.Images[2].Workloads[0].Workload).To(Equal(podinfoRef))
+		g.Expect(res.Images[2].Workloads[0].Owners).To(Equal([]*pb.ObjectRef{{ClusterName: "Default", Kind: "Kustomization", Name: "apps", Namespace: "flux-system"}}))
+	})
+
+	t.Run("filtered", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		res, err := cs.ListImages(ctx, &pb.ListImagesRequest{Registry: "ghcr.io"})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(res.Images).To(HaveLen(2))
+
+		res, err = cs.ListImages(ctx, &pb.ListImagesRequest{Repository: "ghcr.io/stefanprodan/podinfo", Tag: "6.5.0"})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(res.Images).To(HaveLen(1))
+		g.Expect(res.Images[0].Workloads[0].Workload).To(Equal(podinfoRef))
+
+		// The filters are normalized like the images.
+		res, err = cs.ListImages(ctx, &pb.ListImagesRequest{Registry: "index.docker.io", Repository: "busybox"})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(res.Images).To(HaveLen(1))
+		g.Expect(res.Images[0].Repository).To(Equal("docker.io/library/busybox"))
+
+		res, err = cs.ListImages(ctx, &pb.ListImagesRequest{Namespace: "ops", Tag: "6.5.0"})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(res.Images).To(BeEmpty())
+	})
+}
+
+func TestParseImageRef(t *testing.T) {
+	const digest = "sha256:8f2f1f2a0b1e1c4f1c5c0e5d4a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d"
+
+	tests := []struct {
+		image string
+		want  imageRef
+	}{
+		{"redis", imageRef{registry: "docker.io", repository: "docker.io/library/redis", tag: "latest"}},
+		{"library/redis:latest", imageRef{registry: "docker.io", repository: "docker.io/library/redis", tag: "latest"}},
+		{"docker.io/library/redis", imageRef{registry: "docker.io", repository: "docker.io/library/redis", tag: "latest"}},
+		{"index.docker.io/library/redis", imageRef{registry: "docker.io", repository: "docker.io/library/redis", tag: "latest"}},
+		{"bitnami/redis:7.2", imageRef{registry: "docker.io", repository: "docker.io/bitnami/redis", tag: "7.2"}},
+		{"ghcr.io/stefanprodan/podinfo:6.5.0", imageRef{registry: "ghcr.io", repository: "ghcr.io/stefanprodan/podinfo", tag: "6.5.0"}},
+		{"localhost:5000/podinfo", imageRef{registry: "localhost:5000", repository: "localhost:5000/podinfo", tag: "latest"}},
+		{"localhost/podinfo:dev", imageRef{registry: "localhost", repository: "localhost/podinfo", tag: "dev"}},
+		{"redis@" + digest, imageRef{registry: "docker.io", repository: "docker.io/library/redis", digest: digest}},
+		{"redis:7@" + digest, imageRef{registry: "docker.io", repository: "docker.io/library/redis", tag: "7", digest: digest}},
+		{"Invalid:Image", imageRef{repository: "Invalid:Image"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			g.Expect(parseImageRef(tt.image)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestNormalizeImageFilters(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(normalizeRegistry("")).To(BeEmpty())
+	g.Expect(normalizeRegistry("index.docker.io")).To(Equal("docker.io"))
+	g.Expect(normalizeRegistry("ghcr.io")).To(Equal("ghcr.io"))
+
+	g.Expect(normalizeRepository("")).To(BeEmpty())
+	g.Expect(normalizeRepository("busybox")).To(Equal("docker.io/library/busybox"))
+	g.Expect(normalizeRepository("ghcr.io/stefanprodan/podinfo")).To(Equal("ghcr.io/stefanprodan/podinfo"))
+}
@@ -376,15 +376,14 @@ func getChildren(ctx context.Context, k8sClient client.Client, parentObj unstruc
 	children := []*ObjectWithChildren{}
 
 	for _, c := range unstructuredChildren {
-		var err error
-		children, err = getChildren(ctx, k8sClient, c)
+		grandchildren, err := getChildren(ctx, k8sClient, c)
 		if err != nil {
 			return nil, err
 		}
 
 		entry := &ObjectWithChildren{
 			Object:   &c,
-			Children: children,
+			Children: grandchildren,
 		}
 		children = append(children, entry)
 	}
@@ -16,6 +16,8 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	apitypes "k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/weaveworks/weave-gitops/core/clustersmngr/cluster"
@@ -141,6 +143,104 @@ func TestGetInventoryKustomization(t *testing.T) {
 	g.Expect(res.Entries[0].Tenant).To(Equal("tenant"))
 }
 
+func TestGetInventoryKustomizationGrandchildren(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ctx := t.Context()
+
+	ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-namespace"}}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-deployment",
+			Namespace: ns.Name,
+			UID:       "deployment-uid",
+		},
+	}
+
+	ownedBy := func(kind, name string, uid apitypes.UID) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{UID: uid, APIVersion: "apps/v1", Kind: kind, Name: name}}
+	}
+
+	objects := []runtime.Object{&ns, deployment}
+
+	// Each ReplicaSet of the Deployment has its own Pod.
+	for _, hash := range []string{"123abcd", "456efgh"} {
+		rs := &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "my-deployment-" + hash,
+				Namespace:       ns.Name,
+				UID:             apitypes.UID("rs-uid-" + hash),
+				OwnerReferences: ownedBy("Deployment", deployment.Name, deployment.UID),
+			},
+		}
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            rs.Name + "-pod",
+				Namespace:       ns.Name,
+				OwnerReferences: ownedBy("ReplicaSet", rs.Name, rs.UID),
+			},
+		}
+
+		objects = append(objects, rs, pod)
+	}
+
+	kust := &kustomizev1.Kustomization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-automation",
+			Namespace: ns.Name,
+		},
+		Status: kustomizev1.KustomizationStatus{
+			Inventory: &kustomizev1.ResourceInventory{
+				Entries: []kustomizev1.ResourceRef{
+					{
+						ID:      fmt.Sprintf("%s_%s_apps_Deployment", ns.Name, deployment.Name),
+						Version: "v1",
+					},
+				},
+			},
+		},
+	}
+
+	objects = append(objects, kust)
+
+	scheme, err := kube.CreateScheme()
+	g.Expect(err).To(BeNil())
+
+	client := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objects...).Build()
+	cfg := makeServerConfig(t, client, "")
+	c := makeServer(ctx, t, cfg)
+
+	res, err := c.GetInventory(ctx, &pb.GetInventoryRequest{
+		Namespace:    ns.Name,
+		ClusterName:  cluster.DefaultCluster,
+		Kind:         "Kustomization",
+		Name:         kust.Name,
+		WithChildren: true,
+	})
+
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res.Entries).To(HaveLen(1))
+
+	// The Pods are the children of their ReplicaSet, not its siblings.
+	replicaSets := res.Entries[0].Children
+	g.Expect(replicaSets).To(HaveLen(2))
+
+	for _, rs := range replicaSets {
+		var rsObj unstructured.Unstructured
+		g.Expect(json.Unmarshal([]byte(rs.Payload), &rsObj.Object)).To(Succeed())
+		g.Expect(rsObj.GetKind()).To(Equal("ReplicaSet"))
+
+		g.Expect(rs.Children).To(HaveLen(1))
+
+		var podObj unstructured.Unstructured
+		g.Expect(json.Unmarshal([]byte(rs.Children[0].Payload), &podObj.Object)).To(Succeed())
+		g.Expect(podObj.GetKind()).To(Equal("Pod"))
+		g.Expect(podObj.GetName()).To(Equal(rsObj.GetName() + "-pod"))
+	}
+}
+
 func TestGetBlankInventoryKustomization(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 
 	helmv2 "github.com/fluxcd/helm-controller/api/v2"
 	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
 	"github.com/hashicorp/go-multierror"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	v1 "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -25,34 +28,54 @@ const (
 	sessionObjectsInfo = "session objects created"
 )
 
-func getUnstructuredHelmReleaseInventory(ctx context.Context, obj unstructured.Unstructured, c clustersmngr.Client, cluster string) ([]*pb.GroupVersionKind, error) {
-	var release helmv2.HelmRelease
-
-	err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), &release)
+// getUnstructuredHelmReleaseInventory returns the kinds in the inventory of
+// a HelmRelease, along with the inventory for its health rollup.
+func getUnstructuredHelmReleaseInventory(ctx context.Context, obj unstructured.Unstructured, c clustersmngr.Client, cluster string) ([]*pb.GroupVersionKind, *objectInventory, error) {
+	k8sClient, err := c.Scoped(cluster)
 	if err != nil {
-		return nil, fmt.Errorf("converting unstructured to helmrelease: %w", err)
+		return nil, nil, fmt.Errorf("error getting scoped client for cluster=%s: %w", cluster, err)
 	}
 
-	inventory, err := getHelmReleaseInventory(ctx, release, c, cluster)
-	if err != nil {
-		return nil, fmt.Errorf("get helmrelease inventory: %w", err)
+	inventory := getObjectInventory(ctx, k8sClient, obj)
+	if inventory.err != nil {
+		return nil, &inventory, fmt.Errorf("get helmrelease inventory: %w", inventory.err)
 	}
 
-	return inventory, nil
+	return inventoryKinds(inventory.refs), &inventory, nil
 }
 
-func (cs *coreServer) ListObjects(ctx context.Context, msg *pb.ListObjectsRequest) (*pb.ListObjectsResponse, error) {
-	respErrors := []*pb.ListError{}
+// setHelmReleaseInventories fetches the inventories of the queried
+// HelmReleases, returning the errors of the ones that couldn't be fetched.
+func (cs *coreServer) setHelmReleaseInventories(ctx context.Context, clustersClient clustersmngr.Client, queried []queriedObject) []*pb.ListError {
+	var respErrors []*pb.ListError
 
-	gvk, err := cs.primaryKinds.Lookup(msg.Kind)
-	if err != nil {
-		return nil, err
+	for i, q := range queried {
+		inventory, helmInventory, err := getUnstructuredHelmReleaseInventory(ctx, q.source, clustersClient, q.object.ClusterName)
+		if err != nil {
+			respErrors = append(respErrors, &pb.ListError{ClusterName: q.object.ClusterName, Message: err.Error()})
+			inventory = nil // We can still display most things without inventory
+
+			cs.logger.V(logger.LogLevelDebug).Info("Couldn't grab inventory for helm release", "error", err)
+		}
+
+		q.object.Inventory = inventory
+		queried[i].inventory = helmInventory
 	}
 
-	var clustersClient clustersmngr.Client
+	return respErrors
+}
 
-	if msg.ClusterName != "" {
-		clustersClient, err = cs.clustersManager.GetImpersonatedClientForCluster(ctx, auth.Principal(ctx), msg.ClusterName)
+// impersonatedClient returns a client for clusterName, or for all the
+// clusters if it's empty, along with the clusters that failed to connect.
+func (cs *coreServer) impersonatedClient(ctx context.Context, clusterName string) (clustersmngr.Client, []*pb.ListError) {
+	var (
+		clustersClient clustersmngr.Client
+		err            error
+		respErrors     []*pb.ListError
+	)
+
+	if clusterName != "" {
+		clustersClient, err = cs.clustersManager.GetImpersonatedClientForCluster(ctx, auth.Principal(ctx), clusterName)
 	} else {
 		clustersClient, err = cs.clustersManager.GetImpersonatedClient(ctx, auth.Principal(ctx))
 	}
@@ -69,6 +92,30 @@ func (cs *coreServer) ListObjects(ctx context.Context, msg *pb.ListObjectsReques
 		}
 	}
 
+	return clustersClient, respErrors
+}
+
+func (cs *coreServer) ListObjects(ctx context.Context, msg *pb.ListObjectsRequest) (*pb.ListObjectsResponse, error) {
+	respErrors := []*pb.ListError{}
+
+	gvk, err := cs.primaryKinds.Lookup(msg.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := newObjectQuery(msg, cs.healthChecker)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "bad request: %s", err.Error())
+	}
+
+	// Page tokens only make sense for the page size they were issued for.
+	if msg.Pagination != nil && msg.Pagination.PageToken != "" && msg.Pagination.PageSize <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "bad request: a page size is required with a page token")
+	}
+
+	clustersClient, clientErrors := cs.impersonatedClient(ctx, msg.ClusterName)
+	respErrors = append(respErrors, clientErrors...)
+
 	clist := clustersmngr.NewClusteredList(func() client.ObjectList {
 		list := unstructured.UnstructuredList{}
 		list.SetGroupVersionKind(*gvk)
@@ -82,6 +129,15 @@ func (cs *coreServer) ListObjects(ctx context.Context, msg *pb.ListObjectsReques
 		listOptions = append(listOptions, client.MatchingLabels(msg.Labels))
 	}
 
+	// Filtered or sorted results are paginated once they've all been listed.
+	if msg.Pagination != nil && query.empty() {
+		listOptions = append(listOptions,
+			client.Limit(msg.Pagination.PageSize),
+			client.Continue(msg.Pagination.PageToken),
+			clustersmngr.CoordinatedPagination{},
+		)
+	}
+
 	if err := clustersClient.ClusteredList(ctx, clist, true, listOptions...); err != nil {
 		var errs clustersmngr.ClusteredListError
 		if !errors.As(err, &errs) {
@@ -93,23 +149,37 @@ func (cs *coreServer) ListObjects(ctx context.Context, msg *pb.ListObjectsReques
 		}
 	}
 
-	var results []*pb.Object
+	var queried []queriedObject
 
 	queriedNamespaces := clist.Namespaces()
 
-	for clusterName, lists := range clist.Lists() {
-		for _, l := range lists {
+	clusteredLists := clist.Lists()
+
+	// Keep the clusters in a stable order, so pages come back in the order they were listed.
+	clusterNames := make([]string, 0, len(clusteredLists))
+	for clusterName := range clusteredLists {
+		clusterNames = append(clusterNames, clusterName)
+	}
+
+	sort.Strings(clusterNames)
+
+	for _, clusterName := range clusterNames {
+		for _, l := range clusteredLists[clusterName] {
 			list, ok := l.(*unstructured.UnstructuredList)
 			if !ok {
 				continue
 			}
 
 			for _, unstructuredObj := range list.Items {
+				objStatus, ok := query.matches(unstructuredObj)
+				if !ok {
+					continue
+				}
+
 				tenant := GetTenant(unstructuredObj.GetNamespace(), clusterName, queriedNamespaces)
 
 				var obj client.Object = &unstructuredObj
 
-				var inventory []*pb.GroupVersionKind = nil
 				var info string
 
 				switch gvk.Kind {
@@ -119,14 +189,6 @@ func (cs *coreServer) ListObjects(ctx context.Context, msg *pb.ListObjectsReques
 						respErrors = append(respErrors, &pb.ListError{ClusterName: clusterName, Message: fmt.Sprintf("error sanitizing secrets: %v", err)})
 						continue
 					}
-				case helmv2.HelmReleaseKind:
-					inventory, err = getUnstructuredHelmReleaseInventory(ctx, unstructuredObj, clustersClient, clusterName)
-					if err != nil {
-						respErrors = append(respErrors, &pb.ListError{ClusterName: clusterName, Message: err.Error()})
-						inventory = nil // We can still display most things without inventory
-
-						cs.logger.V(logger.LogLevelDebug).Info("Couldn't grab inventory for helm release", "error", err)
-					}
 				case "StatefulSet":
 					clusterName, kind, err := parseSessionInfo(unstructuredObj)
 					if err != nil {
@@ -140,21 +202,52 @@ func (cs *coreServer) ListObjects(ctx context.Context, msg *pb.ListObjectsReques
 					}
 				}
 
-				o, err := types.K8sObjectToProto(obj, clusterName, tenant, inventory, info)
+				o, err := types.K8sObjectToProto(obj, clusterName, tenant, nil, info)
 				if err != nil {
 					respErrors = append(respErrors, &pb.ListError{ClusterName: clusterName, Message: "converting items: " + err.Error()})
 					continue
 				}
 
-				results = append(results, o)
+				queried = append(queried, queriedObject{object: o, source: unstructuredObj, status: objStatus})
 			}
 		}
 	}
 
+	var nextPageToken string
+
+	if query.empty() {
+		if msg.Pagination != nil {
+			nextPageToken = clist.GetContinue()
+		}
+	} else {
+		query.sort(queried)
+
+		queried, nextPageToken, err = paginateObjects(queried, msg.Pagination)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "bad request: %s", err.Error())
+		}
+	}
+
+	// The inventories and rollups are fetched for the page that's returned
+	// only, the rollups reuse the HelmRelease inventories.
+	if gvk.Kind == helmv2.HelmReleaseKind {
+		respErrors = append(respErrors, cs.setHelmReleaseInventories(ctx, clustersClient, queried)...)
+	}
+
+	if msg.WithHealthRollup {
+		respErrors = append(respErrors, cs.setHealthRollups(ctx, clustersClient, queried)...)
+	}
+
+	results := make([]*pb.Object, 0, len(queried))
+	for _, q := range queried {
+		results = append(results, q.object)
+	}
+
 	return &pb.ListObjectsResponse{
 		Objects:            results,
 		Errors:             respErrors,
 		SearchedNamespaces: GetClusterUserNamespacesNames(queriedNamespaces),
+		NextPageToken:      nextPageToken,
 	}, nil
 }
 
@@ -246,6 +339,8 @@ func (cs *coreServer) GetObject(ctx context.Context, msg *pb.GetObjectRequest) (
 
 	var inventory []*pb.GroupVersionKind = nil
 
+	var helmInventory *objectInventory
+
 	var obj client.Object = &unstructuredObj
 
 	switch gvk.Kind {
@@ -255,7 +350,7 @@ func (cs *coreServer) GetObject(ctx context.Context, msg *pb.GetObjectRequest) (
 			return nil, fmt.Errorf("error sanitizing secrets: %w", err)
 		}
 	case helmv2.HelmReleaseKind:
-		inventory, err = getUnstructuredHelmReleaseInventory(ctx, unstructuredObj, clustersClient, msg.ClusterName)
+		inventory, helmInventory, err = getUnstructuredHelmReleaseInventory(ctx, unstructuredObj, clustersClient, msg.ClusterName)
 		if err != nil {
 			inventory = nil // We can still display most things without inventory
 
@@ -272,5 +367,12 @@ func (cs *coreServer) GetObject(ctx context.Context, msg *pb.GetObjectRequest) (
 		return nil, fmt.Errorf("converting object to proto: %w", err)
 	}
 
+	if msg.WithHealthRollup {
+		res.HealthRollup, err = cs.objectHealthRollup(ctx, clustersClient, msg.ClusterName, unstructuredObj, helmInventory)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &pb.GetObjectResponse{Object: res}, nil
 }
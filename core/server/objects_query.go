@@ -0,0 +1,247 @@
+package server
+
+import (
+	"cmp"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+
+	pb "github.com/weaveworks/weave-gitops/pkg/api/core"
+	"github.com/weaveworks/weave-gitops/pkg/health"
+)
+
+const (
+	objectStatusReady     = "Ready"
+	objectStatusNotReady  = "NotReady"
+	objectStatusSuspended = "Suspended"
+)
+
+// objectQuery filters and sorts the objects listed by ListObjects. This is
+// done once the objects have been fetched from the clusters, so pagination
+// is applied to the query's results rather than by the clusters.
+type objectQuery struct {
+	fieldSelector fields.Selector
+	search        func(name string) bool
+	status        string
+	sortBy        string
+	descending    bool
+	healthChecker health.HealthChecker
+}
+
+// queriedObject is an object that matched the query, along with what it's sorted on.
+type queriedObject struct {
+	object *pb.Object
+	source unstructured.Unstructured
+	status string
+	// inventory is the inventory of a HelmRelease, fetched for the object so
+	// its health rollup doesn't fetch it again.
+	inventory *objectInventory
+}
+
+var objectSortKeys = map[string]func(a, b queriedObject) int{
+	"name": func(a, b queriedObject) int {
+		return cmp.Compare(a.source.GetName(), b.source.GetName())
+	},
+	"namespace": func(a, b queriedObject) int {
+		return cmp.Compare(a.source.GetNamespace(), b.source.GetNamespace())
+	},
+	"clusterName": func(a, b queriedObject) int {
+		return cmp.Compare(a.object.ClusterName, b.object.ClusterName)
+	},
+	"status": func(a, b queriedObject) int {
+		return cmp.Compare(a.status, b.status)
+	},
+	"created": func(a, b queriedObject) int {
+		return a.source.GetCreationTimestamp().Compare(b.source.GetCreationTimestamp().Time)
+	},
+}
+
+func newObjectQuery(msg *pb.ListObjectsRequest, healthChecker health.HealthChecker) (*objectQuery, error) {
+	q := &objectQuery{
+		healthChecker: healthChecker,
+	}
+
+	if msg.FieldSelector != "" {
+		selector, err := fields.ParseSelector(msg.FieldSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid field selector: %w", err)
+		}
+
+		q.fieldSelector = selector
+	}
+
+	if msg.Search != "" {
+		if msg.SearchRegex {
+			re, err := regexp.Compile(msg.Search)
+			if err != nil {
+				return nil, fmt.Errorf("invalid search: %w", err)
+			}
+
+			q.search = re.MatchString
+		} else {
+			search := strings.ToLower(msg.Search)
+			q.search = func(name string) bool {
+				return strings.Contains(strings.ToLower(name), search)
+			}
+		}
+	}
+
+	switch msg.Status {
+	case "", objectStatusReady, objectStatusNotReady, objectStatusSuspended:
+		q.status = msg.Status
+	default:
+		return nil, fmt.Errorf("invalid status %q, must be one of %s, %s or %s", msg.Status, objectStatusReady, objectStatusNotReady, objectStatusSuspended)
+	}
+
+	if msg.SortBy != "" {
+		q.sortBy = strings.TrimPrefix(msg.SortBy, "-")
+		q.descending = strings.HasPrefix(msg.SortBy, "-")
+
+		if _, ok := objectSortKeys[q.sortBy]; !ok {
+			return nil, fmt.Errorf("invalid sort key %q", q.sortBy)
+		}
+	}
+
+	return q, nil
+}
+
+// empty returns true if the query neither filters nor sorts the objects.
+func (q *objectQuery) empty() bool {
+	return q.fieldSelector == nil && q.search == nil && q.status == "" && q.sortBy == ""
+}
+
+// matches returns whether obj is kept by the query, along with its status.
+func (q *objectQuery) matches(obj unstructured.Unstructured) (string, bool) {
+	if q.search != nil && !q.search(obj.GetName()) {
+		return "", false
+	}
+
+	if q.fieldSelector != nil && !q.fieldSelector.Matches(objectFields(obj, q.fieldSelector)) {
+		return "", false
+	}
+
+	var status string
+	if q.status != "" || q.sortBy == "status" {
+		status = objectStatus(obj, q.healthChecker)
+	}
+
+	if q.status != "" && status != q.status {
+		return "", false
+	}
+
+	return status, true
+}
+
+// objectStatus derives the status of obj from its Ready condition, as Flux
+// objects report it, or from its health for other objects. Suspended objects
+// are reported as such whatever their conditions.
+func objectStatus(obj unstructured.Unstructured, healthChecker health.HealthChecker) string {
+	if suspended, _, _ := unstructured.NestedBool(obj.Object, "spec", "suspend"); suspended {
+		return objectStatusSuspended
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != objectStatusReady {
+			continue
+		}
+
+		if condition["status"] == string(metav1.ConditionTrue) {
+			return objectStatusReady
+		}
+
+		return objectStatusNotReady
+	}
+
+	healthStatus, _ := healthChecker.Check(obj)
+	if healthStatus.Status == health.HealthStatusHealthy {
+		return objectStatusReady
+	}
+
+	return objectStatusNotReady
+}
+
+// objectFields returns the values of the fields used by selector, read
+// from obj using their dotted paths.
+func objectFields(obj unstructured.Unstructured, selector fields.Selector) fields.Set {
+	set := fields.Set{}
+
+	for _, req := range selector.Requirements() {
+		value, found, err := unstructured.NestedFieldNoCopy(obj.Object, strings.Split(req.Field, ".")...)
+		if err != nil || !found {
+			continue
+		}
+
+		set[req.Field] = fmt.Sprint(value)
+	}
+
+	return set
+}
+
+// sort sorts objects in place by the query's sort key, falling back to
+// cluster, namespace and name so the order is stable between pages.
+func (q *objectQuery) sort(objects []queriedObject) {
+	keys := []func(a, b queriedObject) int{}
+
+	if q.sortBy != "" {
+		key := objectSortKeys[q.sortBy]
+		if q.descending {
+			keys = append(keys, func(a, b queriedObject) int { return key(b, a) })
+		} else {
+			keys = append(keys, key)
+		}
+	}
+
+	keys = append(keys, objectSortKeys["clusterName"], objectSortKeys["namespace"], objectSortKeys["name"])
+
+	slices.SortStableFunc(objects, func(a, b queriedObject) int {
+		for _, key := range keys {
+			if c := key(a, b); c != 0 {
+				return c
+			}
+		}
+
+		return 0
+	})
+}
+
+// paginateObjects returns the page of objects starting at the offset in
+// pageToken, and the token for the next page.
+func paginateObjects[T any](objects []T, pagination *pb.Pagination) ([]T, string, error) {
+	if pagination == nil || pagination.PageSize <= 0 {
+		return objects, "", nil
+	}
+
+	offset := 0
+
+	if pagination.PageToken != "" {
+		decoded, err := base64.StdEncoding.DecodeString(pagination.PageToken)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid page token: %w", err)
+		}
+
+		offset, err = strconv.Atoi(string(decoded))
+		if err != nil || offset < 0 {
+			return nil, "", fmt.Errorf("invalid page token %q", pagination.PageToken)
+		}
+	}
+
+	if offset >= len(objects) {
+		return []T{}, "", nil
+	}
+
+	end := offset + int(pagination.PageSize)
+	if end >= len(objects) {
+		return objects[offset:], "", nil
+	}
+
+	return objects[offset:end], base64.StdEncoding.EncodeToString([]byte(strconv.Itoa(end))), nil
+}
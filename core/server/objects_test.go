@@ -5,6 +5,7 @@ import (
 	"compress/gzip"
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"testing"
 
 	helmv2 "github.com/fluxcd/helm-controller/api/v2"
@@ -14,6 +15,8 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -484,6 +487,215 @@ func TestListObjectMultiple(t *testing.T) {
 	g.Expect(res.Objects[1].Payload).To(ContainSubstring("helm-name"))
 }
 
+func TestListObjectPagination(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ctx := t.Context()
+
+	scheme, err := kube.CreateScheme()
+	g.Expect(err).To(BeNil())
+
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-namespace",
+		},
+	}
+
+	objects := []runtime.Object{ns}
+
+	for i := 0; i < 5; i++ {
+		objects = append(objects, &helmv2.HelmRelease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("helm-%d", i),
+				Namespace: ns.Name,
+			},
+			Spec: helmv2.HelmReleaseSpec{},
+		})
+	}
+
+	client := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objects...).Build()
+	cfg := makeServerConfig(t, client, "")
+	c := makeServer(ctx, t, cfg)
+
+	names := []string{}
+	pageToken := ""
+
+	for _, pageLen := range []int{2, 2, 1} {
+		res, err := c.ListObjects(ctx, &pb.ListObjectsRequest{
+			Kind: helmv2.HelmReleaseKind,
+			Pagination: &pb.Pagination{
+				PageSize:  2,
+				PageToken: pageToken,
+			},
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(res.Errors).To(BeEmpty())
+		g.Expect(res.Objects).To(HaveLen(pageLen))
+
+		for _, o := range res.Objects {
+			obj := &unstructured.Unstructured{}
+			g.Expect(obj.UnmarshalJSON([]byte(o.Payload))).To(Succeed())
+
+			names = append(names, obj.GetName())
+		}
+
+		pageToken = res.NextPageToken
+	}
+
+	g.Expect(pageToken).To(BeEmpty())
+	g.Expect(names).To(Equal([]string{"helm-0", "helm-1", "helm-2", "helm-3", "helm-4"}))
+}
+
+func TestListObjectQuery(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ctx := t.Context()
+
+	scheme, err := kube.CreateScheme()
+	g.Expect(err).To(BeNil())
+
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-namespace",
+		},
+	}
+
+	makeKust := func(name string, ready metav1.ConditionStatus, suspend bool, path string) *kustomizev1.Kustomization {
+		return &kustomizev1.Kustomization{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: ns.Name,
+			},
+			Spec: kustomizev1.KustomizationSpec{
+				Path:    path,
+				Suspend: suspend,
+			},
+			Status: kustomizev1.KustomizationStatus{
+				Conditions: []metav1.Condition{{
+					Type:   "Ready",
+					Status: ready,
+				}},
+			},
+		}
+	}
+
+	client := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(
+		ns,
+		makeKust("podinfo-a", metav1.ConditionTrue, false, "./apps"),
+		makeKust("podinfo-b", metav1.ConditionFalse, false, "./apps"),
+		makeKust("podinfo-c", metav1.ConditionTrue, false, "./infra"),
+		makeKust("nginx", metav1.ConditionTrue, true, "./apps"),
+	).Build()
+	cfg := makeServerConfig(t, client, "")
+	c := makeServer(ctx, t, cfg)
+
+	tests := []struct {
+		name     string
+		request  *pb.ListObjectsRequest
+		expected []string
+	}{
+		{
+			name:     "search",
+			request:  &pb.ListObjectsRequest{Search: "PODINFO"},
+			expected: []string{"podinfo-a", "podinfo-b", "podinfo-c"},
+		},
+		{
+			name:     "regex search",
+			request:  &pb.ListObjectsRequest{Search: "^podinfo-[ab]$", SearchRegex: true},
+			expected: []string{"podinfo-a", "podinfo-b"},
+		},
+		{
+			name:     "ready",
+			request:  &pb.ListObjectsRequest{Status: "Ready"},
+			expected: []string{"podinfo-a", "podinfo-c"},
+		},
+		{
+			name:     "not ready",
+			request:  &pb.ListObjectsRequest{Status: "NotReady"},
+			expected: []string{"podinfo-b"},
+		},
+		{
+			name:     "suspended",
+			request:  &pb.ListObjectsRequest{Status: "Suspended"},
+			expected: []string{"nginx"},
+		},
+		{
+			name:     "field selector",
+			request:  &pb.ListObjectsRequest{FieldSelector: "spec.path=./apps,metadata.name!=nginx"},
+			expected: []string{"podinfo-a", "podinfo-b"},
+		},
+		{
+			name:     "sort descending",
+			request:  &pb.ListObjectsRequest{SortBy: "-name"},
+			expected: []string{"podinfo-c", "podinfo-b", "podinfo-a", "nginx"},
+		},
+		{
+			name:     "paginated after filtering",
+			request:  &pb.ListObjectsRequest{Search: "podinfo", SortBy: "-name", Pagination: &pb.Pagination{PageSize: 2}},
+			expected: []string{"podinfo-c", "podinfo-b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			tt.request.Kind = kustomizev1.KustomizationKind
+
+			res, err := c.ListObjects(ctx, tt.request)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(res.Errors).To(BeEmpty())
+
+			names := []string{}
+
+			for _, o := range res.Objects {
+				obj := &unstructured.Unstructured{}
+				g.Expect(obj.UnmarshalJSON([]byte(o.Payload))).To(Succeed())
+
+				names = append(names, obj.GetName())
+			}
+
+			if tt.request.SortBy == "" {
+				g.Expect(names).To(ConsistOf(tt.expected))
+			} else {
+				g.Expect(names).To(Equal(tt.expected))
+			}
+		})
+	}
+
+	res, err := c.ListObjects(ctx, &pb.ListObjectsRequest{
+		Kind:       kustomizev1.KustomizationKind,
+		Search:     "podinfo",
+		SortBy:     "-name",
+		Pagination: &pb.Pagination{PageSize: 2},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	res, err = c.ListObjects(ctx, &pb.ListObjectsRequest{
+		Kind:       kustomizev1.KustomizationKind,
+		Search:     "podinfo",
+		SortBy:     "-name",
+		Pagination: &pb.Pagination{PageSize: 2, PageToken: res.NextPageToken},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res.Objects).To(HaveLen(1))
+	g.Expect(res.Objects[0].Payload).To(ContainSubstring(`"name":"podinfo-a"`))
+	g.Expect(res.NextPageToken).To(BeEmpty())
+
+	for _, req := range []*pb.ListObjectsRequest{
+		{Status: "Sleeping"},
+		{SortBy: "colour"},
+		{Search: "(", SearchRegex: true},
+		{FieldSelector: "spec.path"},
+		{Pagination: &pb.Pagination{PageToken: "eyJjbHVzdGVyIjoiRGVmYXVsdCJ9"}},
+	} {
+		req.Kind = kustomizev1.KustomizationKind
+
+		_, err := c.ListObjects(ctx, req)
+		g.Expect(err).To(MatchError(ContainSubstring("InvalidArgument")))
+	}
+}
+
 func TestListObjectSingleWithClusterName(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -749,6 +961,65 @@ func TestListObject_HelmReleaseCantGetSecret(t *testing.T) {
 	g.Expect(res.Objects).To(HaveLen(1))
 }
 
+func TestListObject_HelmReleaseInventoriesOfPage(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	scheme, err := kube.CreateScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	ctx := t.Context()
+
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-namespace",
+		},
+	}
+
+	objects := []runtime.Object{ns}
+
+	for i := 0; i < 3; i++ {
+		name := fmt.Sprintf("helm-%d", i)
+
+		objects = append(objects,
+			&helmv2.HelmRelease{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: ns.Name,
+				},
+				Spec: helmv2.HelmReleaseSpec{},
+				Status: helmv2.HelmReleaseStatus{
+					History: helmv2.Snapshots{{
+						Name:      name,
+						Version:   1,
+						Namespace: ns.Name,
+					}},
+				},
+			},
+			// No data, so that every inventory fails to be fetched.
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "sh.helm.release.v1." + name + ".v1",
+					Namespace: ns.Name,
+				},
+			},
+		)
+	}
+
+	client := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objects...).Build()
+	cfg := makeServerConfig(t, client, "")
+	c := makeServer(ctx, t, cfg)
+
+	for _, req := range []*pb.ListObjectsRequest{
+		{Namespace: ns.Name, Kind: helmv2.HelmReleaseKind, Pagination: &pb.Pagination{PageSize: 1}},
+		{Namespace: ns.Name, Kind: helmv2.HelmReleaseKind, Pagination: &pb.Pagination{PageSize: 1}, SortBy: "name"},
+	} {
+		res, err := c.ListObjects(ctx, req)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(res.Objects).To(HaveLen(1))
+		g.Expect(res.Errors).To(HaveLen(1))
+	}
+}
+
 func TestListObjectsSecret(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -0,0 +1,393 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/core/fluxsync"
+	pb "github.com/weaveworks/weave-gitops/pkg/api/core"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+const (
+	operationTypeSync = "sync"
+
+	operationStatusRunning   = "Running"
+	operationStatusSucceeded = "Succeeded"
+	operationStatusFailed    = "Failed"
+
+	objectResultPending = "Pending"
+)
+
+const (
+	// asyncSyncTimeout is how long each object of an asynchronous sync is
+	// waited for, long enough for large HelmReleases.
+	asyncSyncTimeout = 15 * time.Minute
+	// operationRetention is how long finished operations are kept for.
+	operationRetention = time.Hour
+	// maxOperations is how many operations are kept at most, the oldest
+	// finished ones being dropped first.
+	maxOperations = 1000
+	// maxRunningOperationsPerUser is how many operations each user can have
+	// running at the same time, as each of them runs in the background for
+	// up to asyncSyncTimeout per object.
+	maxRunningOperationsPerUser = 10
+)
+
+// trackedOperation is an operation, along with who can see it.
+type trackedOperation struct {
+	owner     string
+	operation *pb.Operation
+	started   time.Time
+	finished  time.Time
+}
+
+// operationTracker keeps the asynchronous operations in memory, so they're
+// lost when the server restarts.
+type operationTracker struct {
+	mu         sync.Mutex
+	operations map[string]*trackedOperation
+	now        func() time.Time
+}
+
+func newOperationTracker() *operationTracker {
+	return &operationTracker{
+		operations: map[string]*trackedOperation{},
+		now:        time.Now,
+	}
+}
+
+// start tracks a new running operation on objects, owned by owner. It fails
+// if owner already has maxRunningOperationsPerUser operations running.
+func (t *operationTracker) start(owner, operationType string, objects []*pb.ObjectRef) (*pb.Operation, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.prune()
+
+	running := 0
+
+	for _, tracked := range t.operations {
+		if tracked.owner == owner && tracked.finished.IsZero() {
+			running++
+		}
+	}
+
+	if running >= maxRunningOperationsPerUser {
+		return nil, status.Errorf(codes.ResourceExhausted, "%d operations are already running, wait for some of them to finish", running)
+	}
+
+	now := t.now()
+
+	op := &pb.Operation{
+		Id:        rand.Text(),
+		Type:      operationType,
+		Status:    operationStatusRunning,
+		CreatedBy: owner,
+		StartedAt: now.Format(time.RFC3339),
+	}
+
+	for _, ref := range objects {
+		op.Objects = append(op.Objects, &pb.OperationObject{
+			Result: objectResult(ref, objectResultPending, nil),
+		})
+	}
+
+	t.operations[op.Id] = &trackedOperation{
+		owner:     owner,
+		operation: op,
+		started:   now,
+	}
+
+	return proto.Clone(op).(*pb.Operation), nil
+}
+
+// update records the progress of an object, adding it to the operation if
+// it wasn't known yet, like the dependencies found while syncing a tree.
+func (t *operationTracker) update(id string, object *pb.OperationObject) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tracked, ok := t.operations[id]
+	if !ok {
+		return
+	}
+
+	op := tracked.operation
+	key := syncTreeKeyOf(object.Result.Object)
+
+	i := slices.IndexFunc(op.Objects, func(o *pb.OperationObject) bool {
+		return syncTreeKeyOf(o.Result.Object) == key
+	})
+	if i < 0 {
+		op.Objects = append(op.Objects, object)
+	} else {
+		op.Objects[i] = object
+	}
+
+	op.Completed = 0
+
+	for _, o := range op.Objects {
+		if o.Result.Status != objectResultPending {
+			op.Completed++
+		}
+	}
+}
+
+// finish marks the operation as done. It failed if err is set or if any of
+// its objects wasn't synced, and objects still pending are failed with err.
+func (t *operationTracker) finish(id string, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tracked, ok := t.operations[id]
+	if !ok {
+		return
+	}
+
+	op := tracked.operation
+	op.Status = operationStatusSucceeded
+
+	if err != nil {
+		op.Status = operationStatusFailed
+	}
+
+	for _, o := range op.Objects {
+		if o.Result.Status == objectResultPending {
+			o.Result.Status = objectResultFailed
+			if err != nil {
+				o.Result.Error = err.Error()
+			}
+
+			op.Completed++
+		}
+
+		if o.Result.Status != objectResultSucceeded {
+			op.Status = operationStatusFailed
+		}
+	}
+
+	tracked.finished = t.now()
+	op.FinishedAt = tracked.finished.Format(time.RFC3339)
+}
+
+// get returns the operation if it's owned by owner.
+func (t *operationTracker) get(owner, id string) (*pb.Operation, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tracked, ok := t.operations[id]
+	if !ok || owner == "" || tracked.owner != owner {
+		return nil, false
+	}
+
+	return proto.Clone(tracked.operation).(*pb.Operation), true
+}
+
+// list returns the operations owned by owner, most recent first.
+func (t *operationTracker) list(owner string) []*pb.Operation {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.prune()
+
+	owned := []*trackedOperation{}
+
+	for _, tracked := range t.operations {
+		if owner != "" && tracked.owner == owner {
+			owned = append(owned, tracked)
+		}
+	}
+
+	slices.SortFunc(owned, func(a, b *trackedOperation) int {
+		if c := b.started.Compare(a.started); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.operation.Id, b.operation.Id)
+	})
+
+	operations := make([]*pb.Operation, len(owned))
+	for i, tracked := range owned {
+		operations[i] = proto.Clone(tracked.operation).(*pb.Operation)
+	}
+
+	return operations
+}
+
+// prune drops the operations that finished more than operationRetention
+// ago, and the oldest finished ones if there are too many. Running
+// operations are always kept. It must be called with the lock held.
+func (t *operationTracker) prune() {
+	finished := []*trackedOperation{}
+
+	for id, tracked := range t.operations {
+		if tracked.finished.IsZero() {
+			continue
+		}
+
+		if t.now().Sub(tracked.finished) > operationRetention {
+			delete(t.operations, id)
+			continue
+		}
+
+		finished = append(finished, tracked)
+	}
+
+	if len(t.operations) < maxOperations {
+		return
+	}
+
+	slices.SortFunc(finished, func(a, b *trackedOperation) int {
+		return a.finished.Compare(b.finished)
+	})
+
+	for _, tracked := range finished[:min(len(finished), len(t.operations)-maxOperations+1)] {
+		delete(t.operations, tracked.operation.Id)
+	}
+}
+
+// startSyncOperation syncs the objects in the background, returning the id
+// of the operation tracking its progress. Operations are only visible to the
+// user who started them, so users without an ID, like those passing their
+// token through without a TokenReview, can't start any.
+func (cs *coreServer) startSyncOperation(ctx context.Context, msg *pb.SyncFluxObjectRequest) (string, error) {
+	principal := auth.Principal(ctx)
+	if principal == nil || principal.ID == "" {
+		return "", status.Error(codes.FailedPrecondition, "asynchronous syncs need an identified user, sync synchronously instead")
+	}
+
+	op, err := cs.operations.start(principal.ID, operationTypeSync, msg.Objects)
+	if err != nil {
+		return "", err
+	}
+
+	// The sync outlives the request, but still runs as the user.
+	ctx = context.WithoutCancel(ctx)
+
+	go func() {
+		started := time.Now()
+
+		clustersClient, err := cs.clustersManager.GetImpersonatedClient(ctx, principal)
+		if err != nil {
+			cs.operations.finish(op.Id, err)
+			return
+		}
+
+		_, err = cs.syncObjects(ctx, msg, syncOptions{
+			withSource: msg.WithSource,
+			timeout:    asyncSyncTimeout,
+			onResult: func(result *pb.ObjectResult, obj fluxsync.Reconcilable) {
+				cs.operations.update(op.Id, cs.operationObject(ctx, clustersClient, result, obj, started))
+			},
+		})
+
+		cs.operations.finish(op.Id, err)
+	}()
+
+	return op.Id, nil
+}
+
+// operationObject adds the Ready condition of a synced object and the events
+// recorded for it since the operation started to its result.
+func (cs *coreServer) operationObject(ctx context.Context, clustersClient clustersmngr.Client, result *pb.ObjectResult, obj fluxsync.Reconcilable, since time.Time) *pb.OperationObject {
+	object := &pb.OperationObject{Result: result}
+
+	if obj == nil {
+		return object
+	}
+
+	for _, cond := range obj.GetConditions() {
+		if cond.Type == meta.ReadyCondition {
+			object.ReadyCondition = &pb.Condition{
+				Type:      cond.Type,
+				Status:    string(cond.Status),
+				Reason:    cond.Reason,
+				Message:   cond.Message,
+				Timestamp: cond.LastTransitionTime.Format(time.RFC3339),
+			}
+		}
+	}
+
+	c, err := clustersClient.Scoped(result.Object.ClusterName)
+	if err != nil {
+		return object
+	}
+
+	fields := client.MatchingFields{
+		"involvedObject.kind": obj.GroupVersionKind().Kind,
+		"involvedObject.name": obj.GetName(),
+	}
+	if obj.GetUID() != "" {
+		fields["involvedObject.uid"] = string(obj.GetUID())
+	}
+
+	events := &corev1.EventList{}
+	if err := c.List(ctx, events, client.InNamespace(obj.GetNamespace()), fields); err != nil {
+		cs.logger.Error(err, "failed to list events for operation", "kind", result.Object.Kind, "name", obj.GetName(), "namespace", obj.GetNamespace())
+		return object
+	}
+
+	for _, e := range events.Items {
+		involved := e.InvolvedObject
+		if involved.Kind != obj.GroupVersionKind().Kind || involved.Name != obj.GetName() || involved.Namespace != obj.GetNamespace() {
+			continue
+		}
+
+		if eventTime(e).Before(since.Truncate(time.Second)) {
+			continue
+		}
+
+		object.Events = append(object.Events, eventToProto(e))
+	}
+
+	return object
+}
+
+// eventTime returns when the event was last seen.
+func eventTime(e corev1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	default:
+		return e.CreationTimestamp.Time
+	}
+}
+
+// operationOwner returns the ID of the user making the call, which owns the
+// operations they start, or nothing if they aren't identified.
+func operationOwner(ctx context.Context) string {
+	principal := auth.Principal(ctx)
+	if principal == nil {
+		return ""
+	}
+
+	return principal.ID
+}
+
+func (cs *coreServer) GetOperation(ctx context.Context, msg *pb.GetOperationRequest) (*pb.GetOperationResponse, error) {
+	op, ok := cs.operations.get(operationOwner(ctx), msg.Id)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "operation %q not found", msg.Id)
+	}
+
+	return &pb.GetOperationResponse{Operation: op}, nil
+}
+
+func (cs *coreServer) ListOperations(ctx context.Context, msg *pb.ListOperationsRequest) (*pb.ListOperationsResponse, error) {
+	return &pb.ListOperationsResponse{Operations: cs.operations.list(operationOwner(ctx))}, nil
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/weaveworks/weave-gitops/pkg/api/core"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+func TestOperationTracker(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tracker := newOperationTracker()
+	tracker.now = func() time.Time { return now }
+
+	apps := &pb.ObjectRef{ClusterName: "Default", Kind: "Kustomization", Name: "apps", Namespace: "flux-system"}
+	infra := &pb.ObjectRef{ClusterName: "Default", Kind: "Kustomization", Name: "infra", Namespace: "flux-system"}
+
+	op, err := tracker.start("anne", operationTypeSync, []*pb.ObjectRef{apps})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(op.Status).To(Equal(operationStatusRunning))
+	g.Expect(op.Objects).To(HaveLen(1))
+	g.Expect(op.Objects[0].Result.Status).To(Equal(objectResultPending))
+
+	tracker.update(op.Id, &pb.OperationObject{Result: objectResult(infra, objectResultSucceeded, nil)})
+
+	got, ok := tracker.get("anne", op.Id)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(got.Objects).To(HaveLen(2), "objects found while running should be added")
+	g.Expect(got.Completed).To(Equal(int32(1)))
+
+	_, ok = tracker.get("bob", op.Id)
+	g.Expect(ok).To(BeFalse())
+
+	_, ok = tracker.get("", op.Id)
+	g.Expect(ok).To(BeFalse(), "users without an id shouldn't see any operation")
+
+	tracker.finish(op.Id, errors.New("cluster unreachable"))
+
+	got, _ = tracker.get("anne", op.Id)
+	g.Expect(got.Status).To(Equal(operationStatusFailed))
+	g.Expect(got.Completed).To(Equal(int32(2)))
+	g.Expect(got.Objects[0].Result.Status).To(Equal(objectResultFailed))
+	g.Expect(got.Objects[0].Result.Error).To(Equal("cluster unreachable"))
+
+	now = now.Add(time.Minute)
+	running, err := tracker.start("anne", operationTypeSync, []*pb.ObjectRef{apps})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(tracker.list("anne")).To(HaveLen(2))
+	g.Expect(tracker.list("anne")[0].Id).To(Equal(running.Id), "most recent operations should come first")
+
+	now = now.Add(operationRetention + time.Second)
+
+	ops := tracker.list("anne")
+	g.Expect(ops).To(HaveLen(1), "finished operations should be dropped after the retention period")
+	g.Expect(ops[0].Id).To(Equal(running.Id), "running operations should be kept")
+}
+
+func TestOperationTrackerLimitsRunningOperations(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tracker := newOperationTracker()
+	apps := &pb.ObjectRef{ClusterName: "Default", Kind: "Kustomization", Name: "apps", Namespace: "flux-system"}
+
+	var ids []string
+
+	for range maxRunningOperationsPerUser {
+		op, err := tracker.start("anne", operationTypeSync, []*pb.ObjectRef{apps})
+		g.Expect(err).NotTo(HaveOccurred())
+
+		ids = append(ids, op.Id)
+	}
+
+	_, err := tracker.start("anne", operationTypeSync, []*pb.ObjectRef{apps})
+	g.Expect(status.Code(err)).To(Equal(codes.ResourceExhausted))
+
+	_, err = tracker.start("bob", operationTypeSync, []*pb.ObjectRef{apps})
+	g.Expect(err).NotTo(HaveOccurred(), "the limit should be per user")
+
+	tracker.finish(ids[0], nil)
+
+	_, err = tracker.start("anne", operationTypeSync, []*pb.ObjectRef{apps})
+	g.Expect(err).NotTo(HaveOccurred(), "finished operations shouldn't count")
+}
+
+func TestStartSyncOperationWithoutUserID(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cs := &coreServer{operations: newOperationTracker()}
+
+	// Passthrough users without a TokenReview have no ID to own operations.
+	ctx := auth.WithPrincipal(t.Context(), &auth.UserPrincipal{})
+
+	_, err := cs.SyncFluxObject(ctx, &pb.SyncFluxObjectRequest{Async: true})
+	g.Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+	g.Expect(cs.operations.operations).To(BeEmpty())
+}
+
+func TestOperationsWithoutPrincipal(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cs := &coreServer{operations: newOperationTracker()}
+
+	op, err := cs.operations.start("anne", operationTypeSync, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	_, err = cs.GetOperation(t.Context(), &pb.GetOperationRequest{Id: op.Id})
+	g.Expect(status.Code(err)).To(Equal(codes.NotFound))
+
+	res, err := cs.ListOperations(t.Context(), &pb.ListOperationsRequest{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res.Operations).To(BeEmpty())
+}
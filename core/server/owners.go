@@ -0,0 +1,332 @@
+package server
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/distribution/reference"
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/core/logger"
+	pb "github.com/weaveworks/weave-gitops/pkg/api/core"
+)
+
+// workloadKinds are the kinds searched for the workloads running an image.
+var workloadKinds = []schema.GroupVersionKind{
+	{Group: "apps", Version: "v1", Kind: "Deployment"},
+	{Group: "apps", Version: "v1", Kind: "StatefulSet"},
+	{Group: "apps", Version: "v1", Kind: "DaemonSet"},
+	{Group: "batch", Version: "v1", Kind: "CronJob"},
+	{Group: "batch", Version: "v1", Kind: "Job"},
+}
+
+// workloadPodSpecPaths are the paths of the pod specs of the workload kinds.
+var workloadPodSpecPaths = map[string][]string{
+	"Deployment":  {"spec", "template", "spec"},
+	"StatefulSet": {"spec", "template", "spec"},
+	"DaemonSet":   {"spec", "template", "spec"},
+	"Job":         {"spec", "template", "spec"},
+	"CronJob":     {"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+// ownedKey identifies an object across the clusters.
+type ownedKey struct {
+	clusterName string
+	groupKind   schema.GroupKind
+	namespace   string
+	name        string
+}
+
+// FindOwners looks for the objects of a kind and name, or the workloads
+// running an image, in the namespaces the user can access, and returns the
+// Kustomizations and HelmReleases managing them. Objects the user can't list
+// are still found from the inventories of the Kustomizations.
+func (cs *coreServer) FindOwners(ctx context.Context, msg *pb.FindOwnersRequest) (*pb.FindOwnersResponse, error) {
+	var gvks []schema.GroupVersionKind
+
+	switch {
+	case msg.Image != "":
+		gvks = workloadKinds
+	case msg.Kind != "" && msg.Name != "":
+		gvk, err := cs.primaryKinds.LookupFold(msg.Kind)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		gvks = []schema.GroupVersionKind{*gvk}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "either kind and name, or image, are required")
+	}
+
+	clustersClient, respErrors := cs.impersonatedClient(ctx, msg.ClusterName)
+
+	inventories, listErrors := cs.kustomizationInventories(ctx, clustersClient)
+	respErrors = append(respErrors, listErrors...)
+
+	found := map[ownedKey]*pb.ObjectOwners{}
+
+	for _, gvk := range gvks {
+		items, listErrors := cs.clusteredItems(ctx, clustersClient, gvk)
+		respErrors = append(respErrors, listErrors...)
+
+		for clusterName, objs := range items {
+			for _, obj := range objs {
+				if msg.Namespace != "" && obj.GetNamespace() != msg.Namespace {
+					continue
+				}
+
+				if msg.Image != "" {
+					if !slices.ContainsFunc(workloadImages(obj), func(image string) bool { return imageMatches(image, msg.Image) }) {
+						continue
+					}
+				} else if obj.GetName() != msg.Name {
+					continue
+				}
+
+				key := ownedKey{clusterName: clusterName, groupKind: gvk.GroupKind(), namespace: obj.GetNamespace(), name: obj.GetName()}
+
+				addOwners(found, key, labelOwners(clusterName, obj)...)
+				addOwners(found, key, inventories[key]...)
+			}
+		}
+	}
+
+	if msg.Image == "" {
+		for key, owners := range inventories {
+			if key.groupKind == gvks[0].GroupKind() && key.name == msg.Name && (msg.Namespace == "" || key.namespace == msg.Namespace) {
+				addOwners(found, key, owners...)
+			}
+		}
+	}
+
+	objects := make([]*pb.ObjectOwners, 0, len(found))
+	for _, o := range found {
+		objects = append(objects, o)
+	}
+
+	slices.SortFunc(objects, func(a, b *pb.ObjectOwners) int {
+		return compareObjectRefs(a.Object, b.Object)
+	})
+
+	return &pb.FindOwnersResponse{
+		Objects: objects,
+		Errors:  respErrors,
+	}, nil
+}
+
+// kustomizationInventories indexes the Kustomizations the user can list by
+// the objects in their inventories.
+func (cs *coreServer) kustomizationInventories(ctx context.Context, clustersClient clustersmngr.Client) (map[ownedKey][]*pb.ObjectRef, []*pb.ListError) {
+	items, respErrors := cs.clusteredItems(ctx, clustersClient, kustomizev1.GroupVersion.WithKind(kustomizev1.KustomizationKind))
+
+	inventories := map[ownedKey][]*pb.ObjectRef{}
+
+	for clusterName, kustomizations := range items {
+		for _, ks := range kustomizations {
+			entries, found, _ := unstructured.NestedSlice(ks.Object, "status", "inventory", "entries")
+			if !found {
+				continue
+			}
+
+			owner := &pb.ObjectRef{
+				ClusterName: clusterName,
+				Kind:        kustomizev1.KustomizationKind,
+				Name:        ks.GetName(),
+				Namespace:   ks.GetNamespace(),
+			}
+
+			for _, e := range entries {
+				entry, ok := e.(map[string]interface{})
+				if !ok {
+					continue
+				}
+
+				id, _ := entry["id"].(string)
+
+				objMetadata, err := object.ParseObjMetadata(id)
+				if err != nil {
+					continue
+				}
+
+				key := ownedKey{clusterName: clusterName, groupKind: objMetadata.GroupKind, namespace: objMetadata.Namespace, name: objMetadata.Name}
+				inventories[key] = append(inventories[key], owner)
+			}
+		}
+	}
+
+	return inventories, respErrors
+}
+
+// clusteredItems lists the objects of gvk in the namespaces the user can
+// access, by cluster. Kinds that aren't installed on a cluster or that the
+// user can't list are skipped.
+func (cs *coreServer) clusteredItems(ctx context.Context, clustersClient clustersmngr.Client, gvk schema.GroupVersionKind) (map[string][]unstructured.Unstructured, []*pb.ListError) {
+	var respErrors []*pb.ListError
+
+	clist := clustersmngr.NewClusteredList(func() client.ObjectList {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk)
+
+		return list
+	})
+
+	if err := clustersClient.ClusteredList(ctx, clist, true); err != nil {
+		var errs clustersmngr.ClusteredListError
+		if !errors.As(err, &errs) {
+			return nil, []*pb.ListError{{Message: err.Error()}}
+		}
+
+		for _, e := range errs.Errors {
+			if meta.IsNoMatchError(e.Err) || k8serrors.IsForbidden(e.Err) {
+				cs.logger.V(logger.LogLevelDebug).Info("skipping objects", "cluster", e.Cluster, "namespace", e.Namespace, "gvk", gvk.String(), "error", e.Err)
+				continue
+			}
+
+			respErrors = append(respErrors, &pb.ListError{ClusterName: e.Cluster, Namespace: e.Namespace, Message: e.Err.Error()})
+		}
+	}
+
+	items := map[string][]unstructured.Unstructured{}
+
+	for clusterName, lists := range clist.Lists() {
+		for _, l := range lists {
+			list, ok := l.(*unstructured.UnstructuredList)
+			if !ok {
+				continue
+			}
+
+			items[clusterName] = append(items[clusterName], list.Items...)
+		}
+	}
+
+	return items, respErrors
+}
+
+// labelOwners returns the automations obj is labelled as managed by.
+func labelOwners(clusterName string, obj unstructured.Unstructured) []*pb.ObjectRef {
+	labels := obj.GetLabels()
+
+	var owners []*pb.ObjectRef
+
+	for _, owner := range []struct {
+		kind, nameKey, namespaceKey string
+	}{
+		{kustomizev1.KustomizationKind, KustomizeNameKey, KustomizeNamespaceKey},
+		{helmv2.HelmReleaseKind, HelmNameKey, HelmNamespaceKey},
+	} {
+		name, ok := labels[owner.nameKey]
+		if !ok {
+			continue
+		}
+
+		namespace, ok := labels[owner.namespaceKey]
+		if !ok {
+			namespace = obj.GetNamespace()
+		}
+
+		owners = append(owners, &pb.ObjectRef{
+			ClusterName: clusterName,
+			Kind:        owner.kind,
+			Name:        name,
+			Namespace:   namespace,
+		})
+	}
+
+	return owners
+}
+
+// addOwners adds the object with key to found if it's not there yet, along
+// with the owners it doesn't have yet.
+func addOwners(found map[ownedKey]*pb.ObjectOwners, key ownedKey, owners ...*pb.ObjectRef) {
+	o, ok := found[key]
+	if !ok {
+		o = &pb.ObjectOwners{
+			Object: &pb.ObjectRef{
+				ClusterName: key.clusterName,
+				Kind:        key.groupKind.Kind,
+				Name:        key.name,
+				Namespace:   key.namespace,
+			},
+		}
+		found[key] = o
+	}
+
+	for _, owner := range owners {
+		if !slices.ContainsFunc(o.Owners, func(existing *pb.ObjectRef) bool { return compareObjectRefs(existing, owner) == 0 }) {
+			o.Owners = append(o.Owners, owner)
+		}
+	}
+}
+
+func compareObjectRefs(a, b *pb.ObjectRef) int {
+	return cmp.Or(
+		cmp.Compare(a.ClusterName, b.ClusterName),
+		cmp.Compare(a.Kind, b.Kind),
+		cmp.Compare(a.Namespace, b.Namespace),
+		cmp.Compare(a.Name, b.Name),
+	)
+}
+
+// workloadImages returns the images of the containers of a workload.
+func workloadImages(obj unstructured.Unstructured) []string {
+	path, ok := workloadPodSpecPaths[obj.GetKind()]
+	if !ok {
+		return nil
+	}
+
+	var images []string
+
+	for _, field := range []string{"initContainers", "containers"} {
+		containers, _, _ := unstructured.NestedSlice(obj.Object, append(slices.Clone(path), field)...)
+		for _, c := range containers {
+			container, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			if image, _ := container["image"].(string); image != "" {
+				images = append(images, image)
+			}
+		}
+	}
+
+	return images
+}
+
+// imageMatches returns whether image is query once both are normalized like
+// the images listed, e.g. docker.io/library/nginx matches nginx:1.25. The tag
+// and digest only have to match when query has them.
+func imageMatches(image, query string) bool {
+	ref := parseImageRef(image)
+
+	named, err := reference.ParseNormalizedNamed(query)
+	if err != nil {
+		return ref.repository == query
+	}
+
+	if ref.repository != named.Name() {
+		return false
+	}
+
+	if tagged, ok := named.(reference.Tagged); ok && ref.tag != tagged.Tag() {
+		return false
+	}
+
+	if digested, ok := named.(reference.Digested); ok && ref.digest != digested.Digest().String() {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,198 @@
+package server
+
+import (
+	"testing"
+
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/cluster/clusterfakes"
+	"github.com/weaveworks/weave-gitops/core/clustersmngr/clustersmngrfakes"
+	pb "github.com/weaveworks/weave-gitops/pkg/api/core"
+	"github.com/weaveworks/weave-gitops/pkg/kube"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+func TestFindOwners(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := auth.WithPrincipal(t.Context(), &auth.UserPrincipal{ID: "anne"})
+
+	podSpec := func(image string) corev1.PodTemplateSpec {
+		return corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: image}}},
+		}
+	}
+
+	podinfo := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "podinfo",
+			Namespace: "apps",
+			Labels: map[string]string{
+				KustomizeNameKey:      "apps",
+				KustomizeNamespaceKey: "flux-system",
+			},
+		},
+		Spec: appsv1.DeploymentSpec{Template: podSpec("ghcr.io/stefanprodan/podinfo:6.5.0")},
+	}
+	redis := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "redis",
+			Namespace: "apps",
+			Labels: map[string]string{
+				HelmNameKey:      "redis",
+				HelmNamespaceKey: "apps",
+			},
+		},
+		Spec: appsv1.StatefulSetSpec{Template: podSpec("redis:7")},
+	}
+	debug := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: "apps"},
+		Spec:       appsv1.DeploymentSpec{Template: podSpec("ghcr.io/stefanprodan/podinfo-debug:6.5.0")},
+	}
+	apps := &kustomizev1.Kustomization{
+		ObjectMeta: metav1.ObjectMeta{Name: "apps", Namespace: "flux-system"},
+		Status: kustomizev1.KustomizationStatus{
+			Inventory: &kustomizev1.ResourceInventory{
+				Entries: []kustomizev1.ResourceRef{
+					{ID: "apps_podinfo_apps_Deployment", Version: "v1"},
+					{ID: "secrets_podinfo__Secret", Version: "v1"},
+				},
+			},
+		},
+	}
+
+	scheme, err := kube.CreateScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(podinfo, redis, debug, apps).Build()
+
+	defaultCluster := &clusterfakes.FakeCluster{}
+	defaultCluster.GetNameReturns("Default")
+
+	pool := clustersmngr.NewClustersClientsPool()
+	g.Expect(pool.Add(k8sClient, defaultCluster)).To(Succeed())
+
+	namespaces := map[string][]corev1.Namespace{
+		"Default": {
+			{ObjectMeta: metav1.ObjectMeta{Name: "apps"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "flux-system"}},
+		},
+	}
+
+	clustersManager := &clustersmngrfakes.FakeClustersManager{}
+	clustersManager.GetImpersonatedClientReturns(clustersmngr.NewClient(pool, namespaces, logr.Discard()), nil)
+
+	kinds, err := DefaultPrimaryKinds()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	cs := &coreServer{
+		logger:          logr.Discard(),
+		clustersManager: clustersManager,
+		primaryKinds:    kinds,
+	}
+
+	appsKustomization := &pb.ObjectRef{ClusterName: "Default", Kind: "Kustomization", Name: "apps", Namespace: "flux-system"}
+
+	t.Run("by kind and name", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		res, err := cs.FindOwners(ctx, &pb.FindOwnersRequest{Kind: "deployment", Name: "podinfo"})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(res.Errors).To(BeEmpty())
+		g.Expect(res.Objects).To(HaveLen(1))
+		g.Expect(res.Objects[0].Object).To(Equal(&pb.ObjectRef{ClusterName: "Default", Kind: "Deployment", Name: "podinfo", Namespace: "apps"}))
+		g.Expect(res.Objects[0].Owners).To(Equal([]*pb.ObjectRef{appsKustomization}))
+	})
+
+	t.Run("from inventories only", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		// The user can't list the secrets namespace, but sees the Kustomization.
+		res, err := cs.FindOwners(ctx, &pb.FindOwnersRequest{Kind: "Secret", Name: "podinfo"})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(res.Objects).To(HaveLen(1))
+		g.Expect(res.Objects[0].Object.Namespace).To(Equal("secrets"))
+		g.Expect(res.Objects[0].Owners).To(Equal([]*pb.ObjectRef{appsKustomization}))
+	})
+
+	t.Run("by image", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		res, err := cs.FindOwners(ctx, &pb.FindOwnersRequest{Image: "ghcr.io/stefanprodan/podinfo"})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(res.Objects).To(HaveLen(1))
+		g.Expect(res.Objects[0].Object.Name).To(Equal("podinfo"))
+
+		res, err = cs.FindOwners(ctx, &pb.FindOwnersRequest{Image: "redis:7"})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(res.Objects).To(HaveLen(1))
+		g.Expect(res.Objects[0].Owners).To(Equal([]*pb.ObjectRef{{ClusterName: "Default", Kind: "HelmRelease", Name: "redis", Namespace: "apps"}}))
+
+		res, err = cs.FindOwners(ctx, &pb.FindOwnersRequest{Image: "docker.io/library/redis"})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(res.Objects).To(HaveLen(1))
+		g.Expect(res.Objects[0].Object.Name).To(Equal("redis"))
+
+		res, err = cs.FindOwners(ctx, &pb.FindOwnersRequest{Image: "redis:6"})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(res.Objects).To(BeEmpty())
+	})
+
+	t.Run("unmanaged object", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		res, err := cs.FindOwners(ctx, &pb.FindOwnersRequest{Kind: "Deployment", Name: "debug", Namespace: "apps"})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(res.Objects).To(HaveLen(1))
+		g.Expect(res.Objects[0].Owners).To(BeEmpty())
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		_, err := cs.FindOwners(ctx, &pb.FindOwnersRequest{Kind: "Deployment"})
+		g.Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+		_, err = cs.FindOwners(ctx, &pb.FindOwnersRequest{Kind: "Widget", Name: "podinfo"})
+		g.Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+	})
+}
+
+func TestImageMatches(t *testing.T) {
+	const digest = "sha256:8f2f1f2a0b1e1c4f1c5c0e5d4a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d"
+
+	tests := []struct {
+		image string
+		query string
+		want  bool
+	}{
+		{"nginx:1.25", "nginx", true},
+		{"nginx:1.25", "docker.io/library/nginx", true},
+		{"nginx:1.25", "index.docker.io/library/nginx:1.25", true},
+		{"docker.io/library/nginx:1.25", "nginx:1.25", true},
+		{"nginx:1.25", "nginx:1.24", false},
+		{"nginx", "nginx:latest", true},
+		{"nginx@" + digest, "nginx@" + digest, true},
+		{"nginx:1.25@" + digest, "nginx:1.25", true},
+		{"nginx:1.25", "nginx@" + digest, false},
+		{"nginx-unprivileged:1.25", "nginx", false},
+		{"bitnami/nginx:1.25", "nginx", false},
+		{"ghcr.io/stefanprodan/podinfo:6.5.0", "ghcr.io/stefanprodan/podinfo", true},
+		{"Invalid:Image", "Invalid:Image", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image+" "+tt.query, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			g.Expect(imageMatches(tt.image, tt.query)).To(Equal(tt.want))
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -169,3 +170,19 @@ func (pk *PrimaryKinds) Lookup(kind string) (*schema.GroupVersionKind, error) {
 
 	return &gvk, nil
 }
+
+// LookupFold is like Lookup, but falls back to matching the kind name
+// case-insensitively, e.g. for deployment as typed on the command line.
+func (pk *PrimaryKinds) LookupFold(kind string) (*schema.GroupVersionKind, error) {
+	if gvk, err := pk.Lookup(kind); err == nil {
+		return gvk, nil
+	}
+
+	for name, gvk := range pk.kinds {
+		if strings.EqualFold(name, kind) {
+			return &gvk, nil
+		}
+	}
+
+	return nil, fmt.Errorf("looking up objects of kind %v not supported", kind)
+}
@@ -92,4 +92,13 @@ func TestDefaultPrimaryKinds(t *testing.T) {
 	t.Run("should return v1 GitRepository", func(t *testing.T) {
 		g.Expect(primaryKinds.kinds["GitRepository"]).To(Equal(schema.GroupVersionKind{Group: "source.toolkit.fluxcd.io", Version: "v1", Kind: "GitRepository"}))
 	})
+
+	t.Run("should look up kinds case-insensitively", func(t *testing.T) {
+		gvk, err := primaryKinds.LookupFold("gitrepository")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(gvk.Kind).To(Equal("GitRepository"))
+
+		_, err = primaryKinds.LookupFold("gitrepo")
+		g.Expect(err).To(HaveOccurred())
+	})
 }
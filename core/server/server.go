@@ -11,6 +11,7 @@ import (
 	"github.com/weaveworks/weave-gitops/core/clustersmngr"
 	"github.com/weaveworks/weave-gitops/core/nsaccess"
 	pb "github.com/weaveworks/weave-gitops/pkg/api/core"
+	"github.com/weaveworks/weave-gitops/pkg/audit"
 	"github.com/weaveworks/weave-gitops/pkg/health"
 	"github.com/weaveworks/weave-gitops/pkg/services/crd"
 )
@@ -25,6 +26,10 @@ func Hydrate(ctx context.Context, mux *runtime.ServeMux, cfg CoreServerConfig) e
 		return fmt.Errorf("could not register new app server: %w", err)
 	}
 
+	if err = registerStreamingHandlers(mux, appsServer); err != nil {
+		return fmt.Errorf("could not register streaming handlers: %w", err)
+	}
+
 	return nil
 }
 
@@ -39,6 +44,14 @@ type coreServer struct {
 	primaryKinds    *PrimaryKinds
 	crd             crd.Fetcher
 	healthChecker   health.HealthChecker
+	operations      *operationTracker
+	audit           *audit.Recorder
+	healthHistory   *health.History
+	// trustedProxies are trusted for the source IP of the audit events.
+	trustedProxies audit.TrustedProxies
+	// sourceControllerAddress is the only host the artifacts are fetched
+	// from.
+	sourceControllerAddress string
 }
 
 type CoreServerConfig struct {
@@ -50,6 +63,19 @@ type CoreServerConfig struct {
 	PrimaryKinds    *PrimaryKinds
 	CRDService      crd.Fetcher
 	HealthChecker   health.HealthChecker
+	// Audit records the changes users make to objects, nothing is recorded
+	// if nil.
+	Audit *audit.Recorder
+	// TrustedProxies are trusted to add the address of the users to
+	// X-Forwarded-For, for the source IP of the audit events.
+	TrustedProxies audit.TrustedProxies
+	// HealthHistory is the recorded health of the Flux-managed objects,
+	// GetHealthHistory is unavailable if nil.
+	HealthHistory *health.History
+	// SourceControllerAddress is the host, and port if any, source-controller
+	// serves the artifacts at. GetDrift doesn't fetch artifacts from anywhere
+	// else.
+	SourceControllerAddress string
 }
 
 func NewCoreConfig(log logr.Logger, cfg *rest.Config, clusterName string, clustersManager clustersmngr.ClustersManager, healthChecker health.HealthChecker) (CoreServerConfig, error) {
@@ -59,13 +85,14 @@ func NewCoreConfig(log logr.Logger, cfg *rest.Config, clusterName string, cluste
 	}
 
 	return CoreServerConfig{
-		log:             log.WithName("core-server"),
-		RestCfg:         cfg,
-		clusterName:     clusterName,
-		NSAccess:        nsaccess.NewChecker(nsaccess.DefautltWegoAppRules),
-		ClustersManager: clustersManager,
-		PrimaryKinds:    kinds,
-		HealthChecker:   healthChecker,
+		log:                     log.WithName("core-server"),
+		RestCfg:                 cfg,
+		clusterName:             clusterName,
+		NSAccess:                nsaccess.NewChecker(nsaccess.DefautltWegoAppRules),
+		ClustersManager:         clustersManager,
+		PrimaryKinds:            kinds,
+		HealthChecker:           healthChecker,
+		SourceControllerAddress: DefaultSourceControllerAddress,
 	}, nil
 }
 
@@ -75,11 +102,16 @@ func NewCoreServer(ctx context.Context, cfg CoreServerConfig) (pb.CoreServer, er
 	}
 
 	return &coreServer{
-		logger:          cfg.log,
-		nsChecker:       cfg.NSAccess,
-		clustersManager: cfg.ClustersManager,
-		primaryKinds:    cfg.PrimaryKinds,
-		crd:             cfg.CRDService,
-		healthChecker:   cfg.HealthChecker,
+		logger:                  cfg.log,
+		nsChecker:               cfg.NSAccess,
+		clustersManager:         cfg.ClustersManager,
+		primaryKinds:            cfg.PrimaryKinds,
+		crd:                     cfg.CRDService,
+		healthChecker:           cfg.HealthChecker,
+		operations:              newOperationTracker(),
+		audit:                   cfg.Audit,
+		trustedProxies:          cfg.TrustedProxies,
+		healthHistory:           cfg.HealthHistory,
+		sourceControllerAddress: cfg.SourceControllerAddress,
 	}, nil
 }
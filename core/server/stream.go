@@ -0,0 +1,115 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/weaveworks/weave-gitops/pkg/api/core"
+)
+
+// The generated RegisterCoreHandlerServer doesn't support server-streaming
+// RPCs, so these are registered by hand on the gateway mux, replacing the
+// generated "not supported" handlers.
+func registerStreamingHandlers(mux *runtime.ServeMux, srv pb.CoreServer) error {
+	return mux.HandlePath(http.MethodGet, "/v1/objects/watch", func(w http.ResponseWriter, req *http.Request, _ map[string]string) {
+		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+
+		var msg pb.WatchObjectsRequest
+
+		if err := req.ParseForm(); err != nil {
+			runtime.HTTPError(req.Context(), mux, outboundMarshaler, w, req, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+
+		if err := runtime.PopulateQueryParameters(&msg, req.Form, &utilities.DoubleArray{}); err != nil {
+			runtime.HTTPError(req.Context(), mux, outboundMarshaler, w, req, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+
+		// Responses are flushed as they are produced, so make sure the gzip
+		// middleware doesn't hold them back until its minimum size is reached.
+		w.Header().Set("Content-Encoding", "identity")
+
+		serveStream(mux, outboundMarshaler, w, req, func(stream grpc.ServerStreamingServer[pb.WatchObjectsResponse]) error {
+			return srv.WatchObjects(&msg, stream)
+		})
+	})
+}
+
+// serveStream runs a server-streaming handler in-process and forwards every
+// message it sends to the HTTP client, as chunked JSON or, when requested
+// through the Accept header, as server-sent events.
+func serveStream[T any, PT interface {
+	*T
+	proto.Message
+}](mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, req *http.Request, handler func(grpc.ServerStreamingServer[T]) error) {
+	ctx, cancel := context.WithCancel(req.Context())
+	defer cancel()
+
+	stream := &inProcessStream[T]{
+		ctx:  ctx,
+		msgs: make(chan *T),
+		done: make(chan error, 1),
+	}
+
+	go func() {
+		stream.done <- handler(stream)
+	}()
+
+	ctx = runtime.NewServerMetadataContext(ctx, runtime.ServerMetadata{})
+
+	runtime.ForwardResponseStream(ctx, mux, marshaler, w, req, func() (proto.Message, error) {
+		select {
+		case msg := <-stream.msgs:
+			return PT(msg), nil
+		case err := <-stream.done:
+			if err == nil || ctx.Err() != nil {
+				return nil, io.EOF
+			}
+
+			return nil, err
+		}
+	}, mux.GetForwardResponseOptions()...)
+}
+
+// inProcessStream implements grpc.ServerStreamingServer on top of a channel,
+// so the gateway can consume the messages sent by a streaming handler.
+type inProcessStream[T any] struct {
+	grpc.ServerStream
+
+	ctx  context.Context
+	msgs chan *T
+	done chan error
+}
+
+func (s *inProcessStream[T]) Send(msg *T) error {
+	select {
+	case s.msgs <- msg:
+		return nil
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
+func (s *inProcessStream[T]) Context() context.Context {
+	return s.ctx
+}
+
+func (s *inProcessStream[T]) SetHeader(metadata.MD) error {
+	return nil
+}
+
+func (s *inProcessStream[T]) SendHeader(metadata.MD) error {
+	return nil
+}
+
+func (s *inProcessStream[T]) SetTrailer(metadata.MD) {}
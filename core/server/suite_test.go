@@ -137,32 +137,59 @@ const (
 
 func withClientsPoolInterceptor(clustersManager clustersmngr.ClustersManager) grpc.ServerOption {
 	return grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		if err := clustersManager.UpdateClusters(ctx); err != nil {
-			return nil, fmt.Errorf("failed to update clusters: %w", err)
-		}
-		if err := clustersManager.UpdateNamespaces(ctx); err != nil {
-			return nil, fmt.Errorf("failed to update namespaces: %w", err)
+		ctx, err := withTestPrincipal(ctx, clustersManager)
+		if err != nil {
+			return nil, err
 		}
 
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, fmt.Errorf("getting metadata from context failed")
-		}
+		return handler(ctx, req)
+	})
+}
 
-		var user string
-		if len(md[MetadataUserKey]) > 0 {
-			user = md[MetadataUserKey][0]
+func withClientsPoolStreamInterceptor(clustersManager clustersmngr.ClustersManager) grpc.ServerOption {
+	return grpc.StreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := withTestPrincipal(ss.Context(), clustersManager)
+		if err != nil {
+			return err
 		}
-		groups := md[MetadataGroupsKey]
-		principal := auth.UserPrincipal{ID: user, Groups: groups}
-		clustersManager.UpdateUserNamespaces(ctx, &principal)
 
-		ctx = auth.WithPrincipal(ctx, &principal)
-
-		return handler(ctx, req)
+		return handler(srv, &principalServerStream{ServerStream: ss, ctx: ctx})
 	})
 }
 
+type principalServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *principalServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func withTestPrincipal(ctx context.Context, clustersManager clustersmngr.ClustersManager) (context.Context, error) {
+	if err := clustersManager.UpdateClusters(ctx); err != nil {
+		return nil, fmt.Errorf("failed to update clusters: %w", err)
+	}
+	if err := clustersManager.UpdateNamespaces(ctx); err != nil {
+		return nil, fmt.Errorf("failed to update namespaces: %w", err)
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("getting metadata from context failed")
+	}
+
+	var user string
+	if len(md[MetadataUserKey]) > 0 {
+		user = md[MetadataUserKey][0]
+	}
+	groups := md[MetadataGroupsKey]
+	principal := auth.UserPrincipal{ID: user, Groups: groups}
+	clustersManager.UpdateUserNamespaces(ctx, &principal)
+
+	return auth.WithPrincipal(ctx, &principal), nil
+}
+
 func makeServerConfig(t *testing.T, fakeClient client.Client, clusterName string) server.CoreServerConfig {
 	t.Helper()
 	log := logr.Discard()
@@ -213,6 +240,7 @@ func makeServer(ctx context.Context, t *testing.T, cfg server.CoreServerConfig)
 
 	s := grpc.NewServer(
 		withClientsPoolInterceptor(cfg.ClustersManager),
+		withClientsPoolStreamInterceptor(cfg.ClustersManager),
 	)
 
 	pb.RegisterCoreServer(s, core)
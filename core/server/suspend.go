@@ -3,6 +3,8 @@ package server
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -17,72 +19,95 @@ const (
 	SuspendedCommentAnnotation = "metadata.weave.works/suspended-comment"
 )
 
+// ToggleSuspendResource suspends or resumes the objects, reporting the
+// outcome for each of them. An error is only returned if none of them could
+// be toggled, so partial failures can be told apart from the results.
 func (cs *coreServer) ToggleSuspendResource(ctx context.Context, msg *pb.ToggleSuspendResourceRequest) (*pb.ToggleSuspendResourceResponse, error) {
 	principal := auth.Principal(ctx)
 	respErrors := multierror.Error{}
 
+	results := make([]*pb.ObjectResult, 0, len(msg.Objects))
+
 	for _, obj := range msg.Objects {
-		clusterName := obj.ClusterName
-		clustersClient, err := cs.clustersManager.GetImpersonatedClient(ctx, principal)
-		if err != nil {
-			respErrors = *multierror.Append(fmt.Errorf("error getting impersonating client: %w", err), respErrors.Errors...)
-			continue
-		}
+		start := time.Now()
 
-		c, err := clustersClient.Scoped(clusterName)
+		err := cs.toggleSuspend(ctx, principal, obj, msg.Suspend, msg.Comment)
 		if err != nil {
-			respErrors = *multierror.Append(fmt.Errorf("getting cluster client: %w", err), respErrors.Errors...)
-			continue
+			respErrors = *multierror.Append(err, respErrors.Errors...)
 		}
 
-		key := client.ObjectKey{
-			Name:      obj.Name,
-			Namespace: obj.Namespace,
-		}
+		result := timedObjectResult(obj, start, err)
+		cs.auditObjectResult(ctx, "ToggleSuspendResource", result, msg.Comment, map[string]string{
+			"suspend": strconv.FormatBool(msg.Suspend),
+		})
 
-		gvk, err := cs.primaryKinds.Lookup(obj.Kind)
-		if err != nil {
-			respErrors = *multierror.Append(fmt.Errorf("looking up GVK for %q: %w", obj.Kind, err), respErrors.Errors...)
-			continue
-		}
+		results = append(results, result)
+	}
 
-		obj := fluxsync.ToReconcileable(*gvk)
+	if respErrors.ErrorOrNil() != nil && !anyObjectSucceeded(results) {
+		return nil, respErrors.ErrorOrNil()
+	}
 
-		log := cs.logger.WithValues(
-			"user", principal.ID,
-			"kind", obj.GroupVersionKind().Kind,
-			"name", key.Name,
-			"namespace", key.Namespace,
-			"principal", principal.ID,
-			"cluster", clusterName,
-		)
+	return &pb.ToggleSuspendResourceResponse{Results: results}, nil
+}
 
-		if err := c.Get(ctx, key, obj.AsClientObject()); err != nil {
-			respErrors = *multierror.Append(fmt.Errorf("getting reconcilable object: %w", err), respErrors.Errors...)
-			continue
-		}
+func (cs *coreServer) toggleSuspend(ctx context.Context, principal *auth.UserPrincipal, ref *pb.ObjectRef, suspend bool, comment string) error {
+	clusterName := ref.ClusterName
 
-		patch := client.MergeFrom(obj.DeepCopyClientObject())
+	clustersClient, err := cs.clustersManager.GetImpersonatedClient(ctx, principal)
+	if err != nil {
+		return fmt.Errorf("error getting impersonating client: %w", err)
+	}
 
-		err = obj.SetSuspended(msg.Suspend)
-		if err != nil {
-			return nil, err
-		}
+	c, err := clustersClient.Scoped(clusterName)
+	if err != nil {
+		return fmt.Errorf("getting cluster client: %w", err)
+	}
+
+	key := client.ObjectKey{
+		Name:      ref.Name,
+		Namespace: ref.Namespace,
+	}
 
-		changeSuspendAnnotations(obj, msg.Suspend, msg.Comment, principal)
+	gvk, err := cs.primaryKinds.Lookup(ref.Kind)
+	if err != nil {
+		return fmt.Errorf("looking up GVK for %q: %w", ref.Kind, err)
+	}
 
-		if msg.Suspend {
-			log.Info("Suspending resource")
-		} else {
-			log.Info("Resuming resource")
-		}
+	obj := fluxsync.ToReconcileable(*gvk)
 
-		if err := c.Patch(ctx, obj.AsClientObject(), patch); err != nil {
-			respErrors = *multierror.Append(fmt.Errorf("patching object: %w", err), respErrors.Errors...)
-		}
+	log := cs.logger.WithValues(
+		"user", principal.ID,
+		"kind", obj.GroupVersionKind().Kind,
+		"name", key.Name,
+		"namespace", key.Namespace,
+		"principal", principal.ID,
+		"cluster", clusterName,
+	)
+
+	if err := c.Get(ctx, key, obj.AsClientObject()); err != nil {
+		return fmt.Errorf("getting reconcilable object: %w", err)
+	}
+
+	patch := client.MergeFrom(obj.DeepCopyClientObject())
+
+	if err := obj.SetSuspended(suspend); err != nil {
+		return err
+	}
+
+	changeSuspendAnnotations(obj, suspend, comment, principal)
+
+	if suspend {
+		log.Info("Suspending resource")
+	} else {
+		log.Info("Resuming resource")
+	}
+
+	if err := c.Patch(ctx, obj.AsClientObject(), patch); err != nil {
+		return fmt.Errorf("patching object: %w", err)
 	}
 
-	return &pb.ToggleSuspendResourceResponse{}, respErrors.ErrorOrNil()
+	return nil
 }
 
 func changeSuspendAnnotations(obj fluxsync.Reconcilable, suspend bool, comment string, principal *auth.UserPrincipal) {
@@ -10,9 +10,12 @@ import (
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	. "github.com/onsi/gomega"
 	"google.golang.org/grpc/metadata"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	api "github.com/weaveworks/weave-gitops/pkg/api/core"
 	"github.com/weaveworks/weave-gitops/pkg/kube"
@@ -201,3 +204,50 @@ func checkSuspendAnnotations(t *testing.T, principalID string, annotations map[s
 		}
 	}
 }
+
+func TestSuspendPartialFailure(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ctx := t.Context()
+
+	scheme, err := kube.CreateScheme()
+	g.Expect(err).To(BeNil())
+
+	ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "flux-system"}}
+	gr := makeGitRepo("git-repo-1", ns)
+
+	k := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(&ns, gr).Build()
+	c := makeServer(ctx, t, makeServerConfig(t, k, ""))
+
+	md := metadata.Pairs(MetadataUserKey, "anne", MetadataGroupsKey, "system:masters")
+	outgoingCtx := metadata.NewOutgoingContext(ctx, md)
+
+	res, err := c.ToggleSuspendResource(outgoingCtx, &api.ToggleSuspendResourceRequest{
+		Objects: []*api.ObjectRef{{
+			Kind:        sourcev1.GitRepositoryKind,
+			Name:        gr.Name,
+			Namespace:   gr.Namespace,
+			ClusterName: "Default",
+		}, {
+			Kind:        sourcev1.GitRepositoryKind,
+			Name:        "missing",
+			Namespace:   gr.Namespace,
+			ClusterName: "Default",
+		}},
+		Suspend: true,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res.Results).To(HaveLen(2))
+
+	g.Expect(res.Results[0].Object.Name).To(Equal(gr.Name))
+	g.Expect(res.Results[0].Status).To(Equal("Succeeded"))
+	g.Expect(res.Results[0].Error).To(BeEmpty())
+
+	g.Expect(res.Results[1].Object.Name).To(Equal("missing"))
+	g.Expect(res.Results[1].Status).To(Equal("Failed"))
+	g.Expect(res.Results[1].Error).To(ContainSubstring("not found"))
+
+	suspended := &sourcev1.GitRepository{}
+	g.Expect(k.Get(ctx, client.ObjectKeyFromObject(gr), suspended)).To(Succeed())
+	g.Expect(suspended.Spec.Suspend).To(BeTrue())
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -12,104 +14,228 @@ import (
 	"github.com/weaveworks/weave-gitops/pkg/server/auth"
 )
 
+const (
+	objectResultSucceeded = "Succeeded"
+	objectResultFailed    = "Failed"
+	objectResultSkipped   = "Skipped"
+)
+
+// syncOptions are the options shared by the objects of a sync.
+type syncOptions struct {
+	withSource bool
+	// timeout is how long to wait for each object to sync, the default if 0.
+	timeout time.Duration
+	// onResult is called with each object's result, and the object if it
+	// could be fetched, as soon as it's known.
+	onResult func(result *pb.ObjectResult, obj fluxsync.Reconcilable)
+}
+
+func (o syncOptions) waitForSync(ctx context.Context, c client.Client, key client.ObjectKey, obj fluxsync.Reconcilable) error {
+	if o.timeout == 0 {
+		return fluxsync.WaitForSync(ctx, c, key, obj)
+	}
+
+	return fluxsync.WaitForSyncWithTimeout(ctx, c, key, obj, o.timeout)
+}
+
+func (o syncOptions) report(result *pb.ObjectResult, obj fluxsync.Reconcilable) {
+	if o.onResult != nil {
+		o.onResult(result, obj)
+	}
+}
+
+// SyncFluxObject reconciles the objects, reporting the outcome for each of
+// them. An error is only returned if none of them could be synced, so
+// partial failures can be told apart from the results.
+// With async set, the sync carries on in the background and its progress
+// is tracked by the operation whose id is returned.
 func (cs *coreServer) SyncFluxObject(ctx context.Context, msg *pb.SyncFluxObjectRequest) (*pb.SyncFluxObjectResponse, error) {
+	if msg.Async {
+		id, err := cs.startSyncOperation(ctx, msg)
+		if err != nil {
+			return nil, err
+		}
+
+		return &pb.SyncFluxObjectResponse{OperationId: id}, nil
+	}
+
+	results, err := cs.syncObjects(ctx, msg, syncOptions{withSource: msg.WithSource})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.SyncFluxObjectResponse{Results: results}, nil
+}
+
+func (cs *coreServer) syncObjects(ctx context.Context, msg *pb.SyncFluxObjectRequest, opts syncOptions) ([]*pb.ObjectResult, error) {
 	principal := auth.Principal(ctx)
-	var syncErr error
 
-	for _, sync := range msg.Objects {
-		clustersClient, err := cs.clustersManager.GetImpersonatedClient(ctx, principal)
-		if err != nil {
-			syncErr = errors.Join(syncErr, fmt.Errorf("error getting impersonating client: %w", err))
-			continue
+	onResult := opts.onResult
+	opts.onResult = func(result *pb.ObjectResult, obj fluxsync.Reconcilable) {
+		cs.auditObjectResult(ctx, "SyncFluxObject", result, "", map[string]string{
+			"withSource": strconv.FormatBool(msg.WithSource),
+		})
+
+		if onResult != nil {
+			onResult(result, obj)
 		}
+	}
 
-		c, err := clustersClient.Scoped(sync.ClusterName)
+	if msg.WithDependencies {
+		clustersClient, err := cs.clustersManager.GetImpersonatedClient(ctx, principal)
 		if err != nil {
-			syncErr = errors.Join(syncErr, fmt.Errorf("getting cluster client: %w", err))
-			continue
+			return nil, fmt.Errorf("error getting impersonating client: %w", err)
 		}
 
-		key := client.ObjectKey{
-			Name:      sync.Name,
-			Namespace: sync.Namespace,
-		}
+		return cs.syncTree(ctx, clustersClient, msg.Objects, opts)
+	}
+
+	var syncErr error
+
+	results := make([]*pb.ObjectResult, 0, len(msg.Objects))
+
+	for _, sync := range msg.Objects {
+		start := time.Now()
+
+		obj, err := cs.syncObject(ctx, principal, sync, opts)
+		syncErr = errors.Join(syncErr, err)
+
+		result := timedObjectResult(sync, start, err)
+		opts.report(result, obj)
+
+		results = append(results, result)
+	}
 
-		gvk, err := cs.primaryKinds.Lookup(sync.Kind)
+	if syncErr != nil && !anyObjectSucceeded(results) {
+		return nil, syncErr
+	}
+
+	return results, nil
+}
+
+// syncObject syncs an object, returning it if it could be fetched.
+func (cs *coreServer) syncObject(ctx context.Context, principal *auth.UserPrincipal, sync *pb.ObjectRef, opts syncOptions) (fluxsync.Reconcilable, error) {
+	clustersClient, err := cs.clustersManager.GetImpersonatedClient(ctx, principal)
+	if err != nil {
+		return nil, fmt.Errorf("error getting impersonating client: %w", err)
+	}
+
+	c, err := clustersClient.Scoped(sync.ClusterName)
+	if err != nil {
+		return nil, fmt.Errorf("getting cluster client: %w", err)
+	}
+
+	key := client.ObjectKey{
+		Name:      sync.Name,
+		Namespace: sync.Namespace,
+	}
+
+	gvk, err := cs.primaryKinds.Lookup(sync.Kind)
+	if err != nil {
+		return nil, fmt.Errorf("looking up GVK for %q: %w", sync.Kind, err)
+	}
+
+	obj := fluxsync.ToReconcileable(*gvk)
+	if err := c.Get(ctx, key, obj.AsClientObject()); err != nil {
+		return nil, fmt.Errorf("error getting object: %w", err)
+	}
+
+	automation, isAutomation := obj.(fluxsync.Automation)
+	if opts.withSource && isAutomation {
+		sourceRef := automation.SourceRef()
+
+		sourceGVK, err := cs.primaryKinds.Lookup(sourceRef.Kind())
 		if err != nil {
-			syncErr = errors.Join(syncErr, fmt.Errorf("looking up GVK for %q: %w", sync.Kind, err))
-			continue
+			return obj, err
 		}
 
-		obj := fluxsync.ToReconcileable(*gvk)
-		if err := c.Get(ctx, key, obj.AsClientObject()); err != nil {
-			syncErr = errors.Join(syncErr, fmt.Errorf("error getting object: %w", err))
-			continue
+		sourceObj := fluxsync.ToReconcileable(*sourceGVK)
+		sourceNs := sourceRef.Namespace()
+
+		// sourceRef.Namespace is an optional field in flux
+		// From the flux type reference:
+		// "Namespace of the referent, defaults to the namespace of the Kubernetes resource object that contains the reference."
+		// https://github.com/fluxcd/kustomize-controller/blob/4da17e1ffb9c2b9e057ff3440f66500394a4f765/api/v1beta2/reference_types.go#L37
+		if sourceNs == "" {
+			sourceNs = sync.Namespace
 		}
 
-		automation, isAutomation := obj.(fluxsync.Automation)
-		if msg.WithSource && isAutomation {
-			sourceRef := automation.SourceRef()
-
-			sourceGVK, err := cs.primaryKinds.Lookup(sourceRef.Kind())
-			if err != nil {
-				return nil, err
-			}
-
-			sourceObj := fluxsync.ToReconcileable(*sourceGVK)
-			sourceNs := sourceRef.Namespace()
-
-			// sourceRef.Namespace is an optional field in flux
-			// From the flux type reference:
-			// "Namespace of the referent, defaults to the namespace of the Kubernetes resource object that contains the reference."
-			// https://github.com/fluxcd/kustomize-controller/blob/4da17e1ffb9c2b9e057ff3440f66500394a4f765/api/v1beta2/reference_types.go#L37
-			if sourceNs == "" {
-				sourceNs = sync.Namespace
-			}
-
-			sourceKey := client.ObjectKey{
-				Name:      sourceRef.Name(),
-				Namespace: sourceNs,
-			}
-
-			sourceGvk := sourceObj.GroupVersionKind()
-
-			log := cs.logger.WithValues(
-				"user", principal.ID,
-				"kind", sourceRef.Kind(),
-				"name", sourceRef.Name(),
-				"namespace", sourceNs,
-			)
-			log.Info("Syncing resource")
-
-			if err := fluxsync.RequestReconciliation(ctx, c, sourceKey, sourceGvk); err != nil {
-				syncErr = errors.Join(syncErr, fmt.Errorf("requesting source reconciliation: %w", err))
-				continue
-			}
-
-			if err := fluxsync.WaitForSync(ctx, c, sourceKey, sourceObj); err != nil {
-				syncErr = errors.Join(syncErr, fmt.Errorf("syncing source: %w", err))
-				continue
-			}
+		sourceKey := client.ObjectKey{
+			Name:      sourceRef.Name(),
+			Namespace: sourceNs,
 		}
 
+		sourceGvk := sourceObj.GroupVersionKind()
+
 		log := cs.logger.WithValues(
 			"user", principal.ID,
-			"kind", obj.GroupVersionKind().Kind,
-			"name", key.Name,
-			"namespace", key.Namespace,
+			"kind", sourceRef.Kind(),
+			"name", sourceRef.Name(),
+			"namespace", sourceNs,
 		)
 		log.Info("Syncing resource")
 
-		if err := fluxsync.RequestReconciliation(ctx, c, key, *gvk); err != nil {
-			syncErr = errors.Join(syncErr, fmt.Errorf("requesting reconciliation: %w", err))
-			continue
+		if err := fluxsync.RequestReconciliation(ctx, c, sourceKey, sourceGvk); err != nil {
+			return obj, fmt.Errorf("requesting source reconciliation: %w", err)
+		}
+
+		if err := opts.waitForSync(ctx, c, sourceKey, sourceObj); err != nil {
+			return obj, fmt.Errorf("syncing source: %w", err)
 		}
+	}
+
+	log := cs.logger.WithValues(
+		"user", principal.ID,
+		"kind", obj.GroupVersionKind().Kind,
+		"name", key.Name,
+		"namespace", key.Namespace,
+	)
+	log.Info("Syncing resource")
+
+	if err := fluxsync.RequestReconciliation(ctx, c, key, *gvk); err != nil {
+		return obj, fmt.Errorf("requesting reconciliation: %w", err)
+	}
+
+	if err := opts.waitForSync(ctx, c, key, obj); err != nil {
+		return obj, fmt.Errorf("syncing automation: %w", err)
+	}
+
+	return obj, nil
+}
+
+func objectResult(ref *pb.ObjectRef, status string, err error) *pb.ObjectResult {
+	result := &pb.ObjectResult{
+		Object: ref,
+		Status: status,
+	}
+
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+// timedObjectResult returns the result of an operation started at start,
+// which failed if err is set.
+func timedObjectResult(ref *pb.ObjectRef, start time.Time, err error) *pb.ObjectResult {
+	status := objectResultSucceeded
+	if err != nil {
+		status = objectResultFailed
+	}
+
+	result := objectResult(ref, status, err)
+	result.DurationMs = time.Since(start).Milliseconds()
+
+	return result
+}
 
-		if err := fluxsync.WaitForSync(ctx, c, key, obj); err != nil {
-			syncErr = errors.Join(syncErr, fmt.Errorf("syncing automation: %w", err))
-			continue
+func anyObjectSucceeded(results []*pb.ObjectResult) bool {
+	for _, result := range results {
+		if result.Status == objectResultSucceeded {
+			return true
 		}
 	}
 
-	return &pb.SyncFluxObjectResponse{}, syncErr
+	return false
 }
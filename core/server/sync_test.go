@@ -3,6 +3,7 @@ package server_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -16,8 +17,11 @@ import (
 	"google.golang.org/grpc/metadata"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/weaveworks/weave-gitops/core/fluxsync"
 	pb "github.com/weaveworks/weave-gitops/pkg/api/core"
@@ -512,3 +516,255 @@ func makeImageUpdateAutomation(name string, ns corev1.Namespace) *imgautomationv
 
 	return iua
 }
+
+func TestSyncWithDependencies(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ctx := t.Context()
+
+	scheme, err := kube.CreateScheme()
+	g.Expect(err).To(BeNil())
+
+	ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "flux-system"}}
+
+	gitRepo := makeGitRepo("flux-system", ns)
+
+	infra := makeKustomization("infra", ns, gitRepo)
+
+	apps := makeKustomization("apps", ns, gitRepo)
+	apps.Spec.DependsOn = []kustomizev1.DependencyReference{{Name: "infra"}}
+
+	broken := makeKustomization("broken", ns, gitRepo)
+	broken.Spec.DependsOn = []kustomizev1.DependencyReference{{Name: "missing"}}
+
+	cycle := makeKustomization("cycle", ns, gitRepo)
+	cycle.Spec.DependsOn = []kustomizev1.DependencyReference{{Name: "cycle"}}
+
+	k := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(&ns, gitRepo, infra, apps, broken, cycle).Build()
+	cfg := makeServerConfig(t, k, "")
+	c := makeServer(ctx, t, cfg)
+
+	reconciled := simulateFluxControllers(t, k,
+		fluxsync.GitRepositoryAdapter{GitRepository: gitRepo},
+		fluxsync.KustomizationAdapter{Kustomization: infra},
+		fluxsync.KustomizationAdapter{Kustomization: apps},
+	)
+
+	md := metadata.Pairs(MetadataUserKey, "anne", MetadataGroupsKey, "system:masters")
+	outgoingCtx := metadata.NewOutgoingContext(ctx, md)
+
+	res, err := c.SyncFluxObject(outgoingCtx, &pb.SyncFluxObjectRequest{
+		Objects: []*pb.ObjectRef{
+			{ClusterName: "Default", Kind: kustomizev1.KustomizationKind, Name: apps.Name, Namespace: ns.Name},
+			{ClusterName: "Default", Kind: kustomizev1.KustomizationKind, Name: broken.Name, Namespace: ns.Name},
+			{ClusterName: "Default", Kind: kustomizev1.KustomizationKind, Name: cycle.Name, Namespace: ns.Name},
+		},
+		WithDependencies: true,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	results := map[string]*pb.ObjectResult{}
+	for _, r := range res.Results {
+		results[r.Object.Name] = r
+	}
+
+	g.Expect(results).To(HaveLen(6))
+	g.Expect(results["flux-system"].Status).To(Equal("Succeeded"))
+	g.Expect(results["infra"].Status).To(Equal("Succeeded"))
+	g.Expect(results["apps"].Status).To(Equal("Succeeded"))
+	g.Expect(results["missing"].Status).To(Equal("Failed"))
+	g.Expect(results["missing"].Error).To(ContainSubstring("not found"))
+	g.Expect(results["broken"].Status).To(Equal("Skipped"))
+	g.Expect(results["cycle"].Status).To(Equal("Failed"))
+	g.Expect(results["cycle"].Error).To(ContainSubstring("dependency cycle"))
+
+	g.Expect(reconciled()).To(Equal([]string{"flux-system", "infra", "apps"}))
+
+	// Nothing could be synced, so the request fails.
+	_, err = c.SyncFluxObject(ctx, &pb.SyncFluxObjectRequest{
+		Objects: []*pb.ObjectRef{
+			{ClusterName: "Default", Kind: kustomizev1.KustomizationKind, Name: broken.Name, Namespace: ns.Name},
+			{ClusterName: "Default", Kind: kustomizev1.KustomizationKind, Name: cycle.Name, Namespace: ns.Name},
+		},
+		WithDependencies: true,
+	})
+	g.Expect(err).To(MatchError(ContainSubstring("dependency cycle")))
+}
+
+// simulateFluxControllers plays the part of the flux controllers for objects,
+// handling their reconcile requests as they come and recording an event for
+// each. It returns a function that stops the simulation and returns the
+// names of the objects reconciled, in order.
+func simulateFluxControllers(t *testing.T, k client.Client, objects ...fluxsync.Reconcilable) func() []string {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+
+	var reconciled []string
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			for _, obj := range objects {
+				if err := k.Get(ctx, client.ObjectKeyFromObject(obj), obj.AsClientObject()); err != nil {
+					continue
+				}
+
+				requestedAt := obj.GetAnnotations()[meta.ReconcileRequestAnnotation]
+				if requestedAt == "" || requestedAt == obj.GetLastHandledReconcileRequest() {
+					continue
+				}
+
+				u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj.AsClientObject())
+				if err != nil {
+					continue
+				}
+
+				if err := unstructured.SetNestedField(u, requestedAt, "status", "lastHandledReconcileAt"); err != nil {
+					continue
+				}
+
+				updated := &unstructured.Unstructured{Object: u}
+				updated.SetGroupVersionKind(obj.GroupVersionKind())
+
+				if err := k.Update(ctx, updated); err != nil {
+					continue
+				}
+
+				reconciled = append(reconciled, obj.GetName())
+
+				_ = k.Create(ctx, &corev1.Event{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fmt.Sprintf("%s.%d", obj.GetName(), len(reconciled)),
+						Namespace: obj.GetNamespace(),
+					},
+					InvolvedObject: corev1.ObjectReference{
+						Kind:      obj.GroupVersionKind().Kind,
+						Name:      obj.GetName(),
+						Namespace: obj.GetNamespace(),
+						UID:       obj.GetUID(),
+					},
+					Type:          corev1.EventTypeNormal,
+					Reason:        "ReconciliationSucceeded",
+					Message:       "Reconciliation finished",
+					LastTimestamp: metav1.Now(),
+				})
+			}
+		}
+	}()
+
+	return func() []string {
+		cancel()
+		<-done
+
+		return reconciled
+	}
+}
+
+func TestSyncAsync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ctx := t.Context()
+
+	scheme, err := kube.CreateScheme()
+	g.Expect(err).To(BeNil())
+
+	ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "flux-system"}}
+
+	gitRepo := makeGitRepo("flux-system", ns)
+
+	kust := makeKustomization("apps", ns, gitRepo)
+	kust.Status.Conditions = []metav1.Condition{{
+		Type:    meta.ReadyCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  meta.ReconciliationSucceededReason,
+		Message: "Applied revision: main@sha1:abc",
+	}}
+
+	kust.UID = "apps-uid"
+
+	// Another object's events shouldn't show up in the operation.
+	other := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "other", Namespace: ns.Name},
+		InvolvedObject: corev1.ObjectReference{Kind: kustomizev1.KustomizationKind, Name: "other", Namespace: ns.Name},
+		Reason:         "ReconciliationFailed",
+		LastTimestamp:  metav1.NewTime(time.Now().Add(time.Minute)),
+	}
+
+	k := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(&ns, gitRepo, kust, other).
+		WithIndex(&corev1.Event{}, "involvedObject.kind", func(o client.Object) []string {
+			return []string{o.(*corev1.Event).InvolvedObject.Kind}
+		}).
+		WithIndex(&corev1.Event{}, "involvedObject.name", func(o client.Object) []string {
+			return []string{o.(*corev1.Event).InvolvedObject.Name}
+		}).
+		WithIndex(&corev1.Event{}, "involvedObject.uid", func(o client.Object) []string {
+			return []string{string(o.(*corev1.Event).InvolvedObject.UID)}
+		}).
+		Build()
+	c := makeServer(ctx, t, makeServerConfig(t, k, ""))
+
+	reconciled := simulateFluxControllers(t, k,
+		fluxsync.GitRepositoryAdapter{GitRepository: gitRepo},
+		fluxsync.KustomizationAdapter{Kustomization: kust},
+	)
+	defer reconciled()
+
+	anneCtx := metadata.NewOutgoingContext(ctx, metadata.Pairs(MetadataUserKey, "anne", MetadataGroupsKey, "system:masters"))
+	bobCtx := metadata.NewOutgoingContext(ctx, metadata.Pairs(MetadataUserKey, "bob", MetadataGroupsKey, "system:masters"))
+
+	res, err := c.SyncFluxObject(anneCtx, &pb.SyncFluxObjectRequest{
+		Objects: []*pb.ObjectRef{
+			{ClusterName: "Default", Kind: kustomizev1.KustomizationKind, Name: kust.Name, Namespace: ns.Name},
+		},
+		WithSource: true,
+		Async:      true,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res.OperationId).NotTo(BeEmpty())
+	g.Expect(res.Results).To(BeEmpty())
+
+	var op *pb.Operation
+
+	g.Eventually(func() string {
+		opRes, err := c.GetOperation(anneCtx, &pb.GetOperationRequest{Id: res.OperationId})
+		g.Expect(err).NotTo(HaveOccurred())
+
+		op = opRes.Operation
+
+		return op.Status
+	}, 30*time.Second, 200*time.Millisecond).Should(Equal("Succeeded"))
+
+	g.Expect(op.Type).To(Equal("sync"))
+	g.Expect(op.CreatedBy).To(Equal("anne"))
+	g.Expect(op.FinishedAt).NotTo(BeEmpty())
+	g.Expect(op.Completed).To(Equal(int32(1)))
+	g.Expect(op.Objects).To(HaveLen(1))
+	g.Expect(op.Objects[0].Result.Status).To(Equal("Succeeded"))
+	g.Expect(op.Objects[0].ReadyCondition.Status).To(Equal("True"))
+	g.Expect(op.Objects[0].ReadyCondition.Message).To(Equal("Applied revision: main@sha1:abc"))
+	g.Expect(op.Objects[0].Events).To(HaveLen(1))
+	g.Expect(op.Objects[0].Events[0].Reason).To(Equal("ReconciliationSucceeded"))
+
+	list, err := c.ListOperations(anneCtx, &pb.ListOperationsRequest{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(list.Operations).To(HaveLen(1))
+
+	list, err = c.ListOperations(bobCtx, &pb.ListOperationsRequest{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(list.Operations).To(BeEmpty(), "operations should only be visible to the user who started them")
+
+	_, err = c.GetOperation(bobCtx, &pb.GetOperationRequest{Id: res.OperationId})
+	g.Expect(err).To(MatchError(ContainSubstring("not found")))
+}
@@ -0,0 +1,297 @@
+package server
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/core/fluxsync"
+	pb "github.com/weaveworks/weave-gitops/pkg/api/core"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+// syncTreeConcurrency is how many objects of a sync tree are reconciled at the same time.
+const syncTreeConcurrency = 10
+
+// syncTreeNode is an object to reconcile, once the objects it needs have been.
+type syncTreeNode struct {
+	ref          *pb.ObjectRef
+	obj          fluxsync.Reconcilable
+	dependencies []syncTreeKey
+	// err is set if the object can't be synced, for example if it wasn't found.
+	err    error
+	result *pb.ObjectResult
+	done   chan struct{}
+}
+
+// syncTreeKey identifies an object across the clusters.
+type syncTreeKey struct {
+	clusterName string
+	kind        string
+	namespace   string
+	name        string
+}
+
+func syncTreeKeyOf(ref *pb.ObjectRef) syncTreeKey {
+	return syncTreeKey{clusterName: ref.ClusterName, kind: ref.Kind, namespace: ref.Namespace, name: ref.Name}
+}
+
+func compareSyncTreeKeys(a, b syncTreeKey) int {
+	return cmp.Or(
+		cmp.Compare(a.clusterName, b.clusterName),
+		cmp.Compare(a.kind, b.kind),
+		cmp.Compare(a.namespace, b.namespace),
+		cmp.Compare(a.name, b.name),
+	)
+}
+
+// syncTree reconciles the objects along with their sources and the automations
+// they depend on. Each object is reconciled once everything it needs has been,
+// and objects that don't need each other are reconciled in parallel.
+// Like syncObjects, an error is only returned if none of them could be synced.
+func (cs *coreServer) syncTree(ctx context.Context, clustersClient clustersmngr.Client, objects []*pb.ObjectRef, opts syncOptions) ([]*pb.ObjectResult, error) {
+	nodes, order := cs.buildSyncTree(ctx, clustersClient, objects)
+
+	for _, node := range nodes {
+		node.done = make(chan struct{})
+	}
+
+	// Objects left out of the order are in, or depend on, a dependsOn cycle
+	// and will never be reconciled by Flux.
+	ordered := map[syncTreeKey]bool{}
+	for _, key := range order {
+		ordered[key] = true
+	}
+
+	for key, node := range nodes {
+		if !ordered[key] {
+			node.result = objectResult(node.ref, objectResultFailed, errors.New("object is in or depends on a dependency cycle"))
+			opts.report(node.result, node.obj)
+			close(node.done)
+		}
+	}
+
+	principal := auth.Principal(ctx)
+	sem := make(chan struct{}, syncTreeConcurrency)
+	wg := sync.WaitGroup{}
+
+	for _, key := range order {
+		node := nodes[key]
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			defer close(node.done)
+			defer func() { opts.report(node.result, node.obj) }()
+
+			for _, dep := range node.dependencies {
+				depNode := nodes[dep]
+				<-depNode.done
+
+				if depNode.result.Status != objectResultSucceeded {
+					node.result = objectResult(node.ref, objectResultSkipped, fmt.Errorf("dependency %s/%s %s was not synced", depNode.ref.Kind, depNode.ref.Namespace, depNode.ref.Name))
+					return
+				}
+			}
+
+			if node.err != nil {
+				node.result = objectResult(node.ref, objectResultFailed, node.err)
+				return
+			}
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			start := time.Now()
+
+			c, err := clustersClient.Scoped(node.ref.ClusterName)
+			if err != nil {
+				node.result = timedObjectResult(node.ref, start, fmt.Errorf("getting cluster client: %w", err))
+				return
+			}
+
+			key := client.ObjectKeyFromObject(node.obj)
+
+			cs.logger.WithValues(
+				"user", principal.ID,
+				"kind", node.ref.Kind,
+				"name", key.Name,
+				"namespace", key.Namespace,
+			).Info("Syncing resource")
+
+			if err := fluxsync.RequestReconciliation(ctx, c, key, node.obj.GroupVersionKind()); err != nil {
+				node.result = timedObjectResult(node.ref, start, fmt.Errorf("requesting reconciliation: %w", err))
+				return
+			}
+
+			node.result = timedObjectResult(node.ref, start, opts.waitForSync(ctx, c, key, node.obj))
+		}()
+	}
+
+	wg.Wait()
+
+	results := make([]*pb.ObjectResult, 0, len(nodes))
+	for _, key := range order {
+		results = append(results, nodes[key].result)
+	}
+
+	for _, key := range slices.SortedFunc(maps.Keys(nodes), compareSyncTreeKeys) {
+		if !ordered[key] {
+			results = append(results, nodes[key].result)
+		}
+	}
+
+	if !anyObjectSucceeded(results) {
+		var syncErr error
+
+		for _, result := range results {
+			if result.Error != "" {
+				syncErr = errors.Join(syncErr, fmt.Errorf("%s %s/%s: %s", result.Object.Kind, result.Object.Namespace, result.Object.Name, result.Error))
+			}
+		}
+
+		if syncErr != nil {
+			return nil, syncErr
+		}
+	}
+
+	return results, nil
+}
+
+// buildSyncTree fetches the objects and, recursively, the sources and
+// dependencies of the automations among them. It returns them along with
+// a topological order, dependencies first.
+func (cs *coreServer) buildSyncTree(ctx context.Context, clustersClient clustersmngr.Client, objects []*pb.ObjectRef) (map[syncTreeKey]*syncTreeNode, []syncTreeKey) {
+	nodes := map[syncTreeKey]*syncTreeNode{}
+	queue := append([]*pb.ObjectRef{}, objects...)
+
+	for len(queue) > 0 {
+		ref := queue[0]
+		queue = queue[1:]
+
+		key := syncTreeKeyOf(ref)
+		if _, ok := nodes[key]; ok {
+			continue
+		}
+
+		node := &syncTreeNode{ref: ref}
+		nodes[key] = node
+
+		node.obj, node.err = cs.getReconcilable(ctx, clustersClient, ref)
+		if node.err != nil {
+			continue
+		}
+
+		automation, ok := node.obj.(fluxsync.Automation)
+		if !ok {
+			continue
+		}
+
+		// Namespaces of references default to the namespace of the object holding them.
+		refNamespace := func(namespace string) string {
+			if namespace == "" {
+				return ref.Namespace
+			}
+
+			return namespace
+		}
+
+		var deps []*pb.ObjectRef
+
+		if sourceRef := automation.SourceRef(); sourceRef.Name() != "" {
+			deps = append(deps, &pb.ObjectRef{
+				ClusterName: ref.ClusterName,
+				Kind:        sourceRef.Kind(),
+				Name:        sourceRef.Name(),
+				Namespace:   refNamespace(sourceRef.Namespace()),
+			})
+		}
+
+		for _, dep := range automation.GetDependsOn() {
+			deps = append(deps, &pb.ObjectRef{
+				ClusterName: ref.ClusterName,
+				Kind:        ref.Kind,
+				Name:        dep.Name,
+				Namespace:   refNamespace(dep.Namespace),
+			})
+		}
+
+		for _, dep := range deps {
+			node.dependencies = append(node.dependencies, syncTreeKeyOf(dep))
+		}
+
+		queue = append(queue, deps...)
+	}
+
+	return nodes, syncTreeOrder(nodes)
+}
+
+func (cs *coreServer) getReconcilable(ctx context.Context, clustersClient clustersmngr.Client, ref *pb.ObjectRef) (fluxsync.Reconcilable, error) {
+	gvk, err := cs.primaryKinds.Lookup(ref.Kind)
+	if err != nil {
+		return nil, fmt.Errorf("looking up GVK for %q: %w", ref.Kind, err)
+	}
+
+	c, err := clustersClient.Scoped(ref.ClusterName)
+	if err != nil {
+		return nil, fmt.Errorf("getting cluster client: %w", err)
+	}
+
+	obj := fluxsync.ToReconcileable(*gvk)
+	if err := c.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: ref.Namespace}, obj.AsClientObject()); err != nil {
+		return nil, fmt.Errorf("error getting object: %w", err)
+	}
+
+	return obj, nil
+}
+
+// syncTreeOrder sorts the nodes so dependencies come first, using Kahn's
+// algorithm. Nodes in or depending on a cycle are left out.
+func syncTreeOrder(nodes map[syncTreeKey]*syncTreeNode) []syncTreeKey {
+	pending := map[syncTreeKey]int{}
+	dependents := map[syncTreeKey][]syncTreeKey{}
+
+	for _, key := range slices.SortedFunc(maps.Keys(nodes), compareSyncTreeKeys) {
+		node := nodes[key]
+		pending[key] = len(node.dependencies)
+
+		for _, dep := range node.dependencies {
+			dependents[dep] = append(dependents[dep], key)
+		}
+	}
+
+	ready := []syncTreeKey{}
+
+	for _, key := range slices.SortedFunc(maps.Keys(pending), compareSyncTreeKeys) {
+		if pending[key] == 0 {
+			ready = append(ready, key)
+		}
+	}
+
+	order := []syncTreeKey{}
+
+	for len(ready) > 0 {
+		key := ready[0]
+		ready = ready[1:]
+
+		order = append(order, key)
+
+		for _, dependent := range dependents[key] {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	return order
+}
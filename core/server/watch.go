@@ -0,0 +1,147 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	"github.com/hashicorp/go-multierror"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/weaveworks/weave-gitops/core/clustersmngr"
+	"github.com/weaveworks/weave-gitops/core/logger"
+	"github.com/weaveworks/weave-gitops/core/server/types"
+	pb "github.com/weaveworks/weave-gitops/pkg/api/core"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+func (cs *coreServer) WatchObjects(msg *pb.WatchObjectsRequest, stream pb.Core_WatchObjectsServer) error {
+	ctx := stream.Context()
+
+	gvk, err := cs.primaryKinds.Lookup(msg.Kind)
+	if err != nil {
+		return err
+	}
+
+	var clustersClient clustersmngr.Client
+
+	if msg.ClusterName != "" {
+		clustersClient, err = cs.clustersManager.GetImpersonatedClientForCluster(ctx, auth.Principal(ctx), msg.ClusterName)
+	} else {
+		clustersClient, err = cs.clustersManager.GetImpersonatedClient(ctx, auth.Principal(ctx))
+	}
+
+	if err != nil {
+		var merr *multierror.Error
+		if !errors.As(err, &merr) {
+			return fmt.Errorf("error getting impersonating client: %w", err)
+		}
+
+		for _, err := range merr.Errors {
+			var cerr *clustersmngr.ClientError
+			if errors.As(err, &cerr) {
+				if err := stream.Send(watchErrorResponse(cerr.ClusterName, "", cerr)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	listOptions := []client.ListOption{
+		client.InNamespace(msg.Namespace),
+	}
+	if len(msg.Labels) > 0 {
+		listOptions = append(listOptions, client.MatchingLabels(msg.Labels))
+	}
+
+	events := clustersClient.ClusteredWatch(ctx, func() client.ObjectList {
+		list := unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(*gvk)
+		return &list
+	}, true, listOptions...)
+
+	queriedNamespaces := clustersClient.Namespaces()
+
+	for ev := range events {
+		if ev.Err != nil {
+			if err := stream.Send(watchErrorResponse(ev.Cluster, ev.Namespace, ev.Err)); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		o, err := cs.watchedObjectToProto(ctx, clustersClient, ev.Object, gvk, ev.Cluster, queriedNamespaces)
+		if err != nil {
+			if err := stream.Send(watchErrorResponse(ev.Cluster, ev.Namespace, err)); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := stream.Send(&pb.WatchObjectsResponse{Type: string(ev.Type), Object: o}); err != nil {
+			return err
+		}
+	}
+
+	return ctx.Err()
+}
+
+func (cs *coreServer) watchedObjectToProto(ctx context.Context, clustersClient clustersmngr.Client, watched client.Object, gvk *schema.GroupVersionKind, clusterName string, queriedNamespaces map[string][]v1.Namespace) (*pb.Object, error) {
+	unstructuredObj, ok := watched.(*unstructured.Unstructured)
+	if !ok {
+		// some clients hand back typed objects even when watching an
+		// unstructured list.
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(watched)
+		if err != nil {
+			return nil, fmt.Errorf("converting watched object: %w", err)
+		}
+
+		unstructuredObj = &unstructured.Unstructured{Object: content}
+		unstructuredObj.SetGroupVersionKind(*gvk)
+	}
+
+	tenant := GetTenant(unstructuredObj.GetNamespace(), clusterName, queriedNamespaces)
+
+	var (
+		obj       client.Object = unstructuredObj
+		inventory []*pb.GroupVersionKind
+		err       error
+	)
+
+	switch unstructuredObj.GetKind() {
+	case "Secret":
+		obj, err = sanitizeSecret(unstructuredObj)
+		if err != nil {
+			return nil, fmt.Errorf("error sanitizing secrets: %w", err)
+		}
+	case helmv2.HelmReleaseKind:
+		inventory, _, err = getUnstructuredHelmReleaseInventory(ctx, *unstructuredObj, clustersClient, clusterName)
+		if err != nil {
+			inventory = nil // We can still display most things without inventory
+
+			cs.logger.V(logger.LogLevelDebug).Info("Couldn't grab inventory for helm release", "error", err)
+		}
+	}
+
+	o, err := types.K8sObjectToProto(obj, clusterName, tenant, inventory, "")
+	if err != nil {
+		return nil, fmt.Errorf("converting items: %w", err)
+	}
+
+	return o, nil
+}
+
+func watchErrorResponse(clusterName, namespace string, err error) *pb.WatchObjectsResponse {
+	return &pb.WatchObjectsResponse{
+		Type:  string(watch.Error),
+		Error: &pb.ListError{ClusterName: clusterName, Namespace: namespace, Message: err.Error()},
+	}
+}
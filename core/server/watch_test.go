@@ -0,0 +1,202 @@
+package server_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/encoding/protojson"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/weaveworks/weave-gitops/core/server"
+	pb "github.com/weaveworks/weave-gitops/pkg/api/core"
+	"github.com/weaveworks/weave-gitops/pkg/kube"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+func TestWatchObjects(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	scheme, err := kube.CreateScheme()
+	g.Expect(err).To(BeNil())
+
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-namespace",
+		},
+	}
+	kust := &kustomizev1.Kustomization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myapp",
+			Namespace: ns.Name,
+		},
+		Spec: kustomizev1.KustomizationSpec{
+			SourceRef: kustomizev1.CrossNamespaceSourceReference{
+				Kind: sourcev1.GitRepositoryKind,
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(ns).Build()
+
+	cfg := makeServerConfig(t, fakeClient, "")
+	c := makeServer(ctx, t, cfg)
+
+	stream, err := c.WatchObjects(ctx, &pb.WatchObjectsRequest{
+		Kind: kustomizev1.KustomizationKind,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(fakeClient.Create(ctx, kust)).To(Succeed())
+
+	responses := make(chan *pb.WatchObjectsResponse, 10)
+
+	go func() {
+		for {
+			res, err := stream.Recv()
+			if err != nil {
+				close(responses)
+				return
+			}
+			responses <- res
+		}
+	}()
+
+	touchUntilReceived(ctx, t, fakeClient, kust, responses)
+
+	var res *pb.WatchObjectsResponse
+	g.Eventually(responses).Should(Receive(&res))
+	g.Expect(res.Error).To(BeNil())
+	g.Expect(res.Type).To(BeElementOf(string(watch.Added), string(watch.Modified)))
+	g.Expect(res.Object.ClusterName).To(Equal("Default"))
+	g.Expect(res.Object.Payload).To(ContainSubstring(`"name":"myapp"`))
+
+	badStream, err := c.WatchObjects(ctx, &pb.WatchObjectsRequest{
+		Kind: "NotAKind",
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	_, err = badStream.Recv()
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestWatchObjectsHTTP(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	scheme, err := kube.CreateScheme()
+	g.Expect(err).To(BeNil())
+
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-namespace",
+		},
+	}
+	kust := &kustomizev1.Kustomization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myapp",
+			Namespace: ns.Name,
+		},
+		Spec: kustomizev1.KustomizationSpec{
+			SourceRef: kustomizev1.CrossNamespaceSourceReference{
+				Kind: sourcev1.GitRepositoryKind,
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(ns, kust).Build()
+
+	cfg := makeServerConfig(t, fakeClient, "")
+
+	mux := runtime.NewServeMux()
+	g.Expect(server.Hydrate(ctx, mux, cfg)).To(Succeed())
+
+	principal := &auth.UserPrincipal{ID: "anne"}
+	g.Expect(cfg.ClustersManager.UpdateClusters(ctx)).To(Succeed())
+	g.Expect(cfg.ClustersManager.UpdateNamespaces(ctx)).To(Succeed())
+	cfg.ClustersManager.UpdateUserNamespaces(ctx, principal)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+	}))
+	// The watch has to be cancelled before the server can be closed.
+	t.Cleanup(ts.Close)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/v1/objects/watch?kind=Kustomization", nil)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	responses := make(chan *pb.WatchObjectsResponse, 10)
+
+	// The response headers are only written along with the first message,
+	// so the request has to be made while the object is being updated.
+	go func() {
+		defer close(responses)
+
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return
+		}
+		defer res.Body.Close()
+
+		scanner := bufio.NewScanner(res.Body)
+		scanner.Buffer(nil, 1024*1024)
+
+		for scanner.Scan() {
+			chunk := struct {
+				Result json.RawMessage `json:"result"`
+			}{}
+			if err := json.Unmarshal(scanner.Bytes(), &chunk); err != nil {
+				continue
+			}
+
+			watchRes := &pb.WatchObjectsResponse{}
+			if err := protojson.Unmarshal(chunk.Result, watchRes); err != nil {
+				continue
+			}
+			responses <- watchRes
+		}
+	}()
+
+	touchUntilReceived(ctx, t, fakeClient, kust, responses)
+
+	var watchRes *pb.WatchObjectsResponse
+	g.Eventually(responses).Should(Receive(&watchRes))
+	g.Expect(watchRes.Type).To(Equal(string(watch.Modified)))
+	g.Expect(watchRes.Object.ClusterName).To(Equal("Default"))
+}
+
+// touchUntilReceived keeps updating obj until there's a response waiting to be read,
+// as the fake client doesn't replay existing objects when a watch is started.
+func touchUntilReceived(ctx context.Context, t *testing.T, k client.Client, obj client.Object, responses chan *pb.WatchObjectsResponse) {
+	t.Helper()
+
+	g := NewGomegaWithT(t)
+
+	g.Eventually(func() int {
+		latest := obj.DeepCopyObject().(client.Object)
+		if err := k.Get(ctx, client.ObjectKeyFromObject(obj), latest); err == nil {
+			latest.SetAnnotations(map[string]string{"touched": strconv.FormatInt(time.Now().UnixNano(), 10)})
+			_ = k.Update(ctx, latest)
+		}
+
+		return len(responses)
+	}).WithPolling(100 * time.Millisecond).Should(BeNumerically(">", 0))
+}
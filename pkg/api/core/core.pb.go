@@ -148,43 +148,28 @@ func (x *GetInventoryResponse) GetEntries() []*InventoryEntry {
 	return nil
 }
 
-type PolicyValidation struct {
-	state           protoimpl.MessageState        `protogen:"open.v1"`
-	Id              string                        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Message         string                        `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	ClusterId       string                        `protobuf:"bytes,3,opt,name=cluster_id,json=clusterId,proto3" json:"cluster_id,omitempty"`
-	Category        string                        `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
-	Severity        string                        `protobuf:"bytes,5,opt,name=severity,proto3" json:"severity,omitempty"`
-	CreatedAt       string                        `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	Entity          string                        `protobuf:"bytes,7,opt,name=entity,proto3" json:"entity,omitempty"`
-	EntityKind      string                        `protobuf:"bytes,8,opt,name=entity_kind,json=entityKind,proto3" json:"entity_kind,omitempty"`
-	Namespace       string                        `protobuf:"bytes,9,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	ViolatingEntity string                        `protobuf:"bytes,10,opt,name=violating_entity,json=violatingEntity,proto3" json:"violating_entity,omitempty"`
-	Description     string                        `protobuf:"bytes,11,opt,name=description,proto3" json:"description,omitempty"`
-	HowToSolve      string                        `protobuf:"bytes,12,opt,name=how_to_solve,json=howToSolve,proto3" json:"how_to_solve,omitempty"`
-	Name            string                        `protobuf:"bytes,13,opt,name=name,proto3" json:"name,omitempty"`
-	ClusterName     string                        `protobuf:"bytes,14,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
-	Occurrences     []*PolicyValidationOccurrence `protobuf:"bytes,15,rep,name=occurrences,proto3" json:"occurrences,omitempty"`
-	PolicyId        string                        `protobuf:"bytes,16,opt,name=policy_id,json=policyId,proto3" json:"policy_id,omitempty"`
-	Parameters      []*PolicyValidationParam      `protobuf:"bytes,17,rep,name=parameters,proto3" json:"parameters,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type GetDependencyGraphRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	ClusterName   string                 `protobuf:"bytes,2,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PolicyValidation) Reset() {
-	*x = PolicyValidation{}
+func (x *GetDependencyGraphRequest) Reset() {
+	*x = GetDependencyGraphRequest{}
 	mi := &file_api_core_core_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PolicyValidation) String() string {
+func (x *GetDependencyGraphRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PolicyValidation) ProtoMessage() {}
+func (*GetDependencyGraphRequest) ProtoMessage() {}
 
-func (x *PolicyValidation) ProtoReflect() protoreflect.Message {
+func (x *GetDependencyGraphRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_core_core_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -196,158 +181,147 @@ func (x *PolicyValidation) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PolicyValidation.ProtoReflect.Descriptor instead.
-func (*PolicyValidation) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetDependencyGraphRequest.ProtoReflect.Descriptor instead.
+func (*GetDependencyGraphRequest) Descriptor() ([]byte, []int) {
 	return file_api_core_core_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *PolicyValidation) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *PolicyValidation) GetMessage() string {
+func (x *GetDependencyGraphRequest) GetNamespace() string {
 	if x != nil {
-		return x.Message
+		return x.Namespace
 	}
 	return ""
 }
 
-func (x *PolicyValidation) GetClusterId() string {
+func (x *GetDependencyGraphRequest) GetClusterName() string {
 	if x != nil {
-		return x.ClusterId
+		return x.ClusterName
 	}
 	return ""
 }
 
-func (x *PolicyValidation) GetCategory() string {
-	if x != nil {
-		return x.Category
-	}
-	return ""
+type DependencyGraphNode struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is unique in the graph, made of the cluster, kind, namespace and name
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind        string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Name        string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace   string `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	ClusterName string `protobuf:"bytes,5,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	// status is one of Ready, NotReady or Suspended, empty if missing
+	Status string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	// missing is set when the object is referenced but could not be found
+	Missing       bool `protobuf:"varint,7,opt,name=missing,proto3" json:"missing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PolicyValidation) GetSeverity() string {
-	if x != nil {
-		return x.Severity
-	}
-	return ""
+func (x *DependencyGraphNode) Reset() {
+	*x = DependencyGraphNode{}
+	mi := &file_api_core_core_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *PolicyValidation) GetCreatedAt() string {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return ""
+func (x *DependencyGraphNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *PolicyValidation) GetEntity() string {
-	if x != nil {
-		return x.Entity
-	}
-	return ""
-}
+func (*DependencyGraphNode) ProtoMessage() {}
 
-func (x *PolicyValidation) GetEntityKind() string {
+func (x *DependencyGraphNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[3]
 	if x != nil {
-		return x.EntityKind
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *PolicyValidation) GetNamespace() string {
-	if x != nil {
-		return x.Namespace
-	}
-	return ""
+// Deprecated: Use DependencyGraphNode.ProtoReflect.Descriptor instead.
+func (*DependencyGraphNode) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *PolicyValidation) GetViolatingEntity() string {
+func (x *DependencyGraphNode) GetId() string {
 	if x != nil {
-		return x.ViolatingEntity
+		return x.Id
 	}
 	return ""
 }
 
-func (x *PolicyValidation) GetDescription() string {
+func (x *DependencyGraphNode) GetKind() string {
 	if x != nil {
-		return x.Description
+		return x.Kind
 	}
 	return ""
 }
 
-func (x *PolicyValidation) GetHowToSolve() string {
+func (x *DependencyGraphNode) GetName() string {
 	if x != nil {
-		return x.HowToSolve
+		return x.Name
 	}
 	return ""
 }
 
-func (x *PolicyValidation) GetName() string {
+func (x *DependencyGraphNode) GetNamespace() string {
 	if x != nil {
-		return x.Name
+		return x.Namespace
 	}
 	return ""
 }
 
-func (x *PolicyValidation) GetClusterName() string {
+func (x *DependencyGraphNode) GetClusterName() string {
 	if x != nil {
 		return x.ClusterName
 	}
 	return ""
 }
 
-func (x *PolicyValidation) GetOccurrences() []*PolicyValidationOccurrence {
-	if x != nil {
-		return x.Occurrences
-	}
-	return nil
-}
-
-func (x *PolicyValidation) GetPolicyId() string {
+func (x *DependencyGraphNode) GetStatus() string {
 	if x != nil {
-		return x.PolicyId
+		return x.Status
 	}
 	return ""
 }
 
-func (x *PolicyValidation) GetParameters() []*PolicyValidationParam {
+func (x *DependencyGraphNode) GetMissing() bool {
 	if x != nil {
-		return x.Parameters
+		return x.Missing
 	}
-	return nil
+	return false
 }
 
-type ListPolicyValidationsRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	ClusterName    string                 `protobuf:"bytes,1,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
-	Pagination     *Pagination            `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
-	Application    string                 `protobuf:"bytes,3,opt,name=application,proto3" json:"application,omitempty"`
-	Namespace      string                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	Kind           string                 `protobuf:"bytes,5,opt,name=kind,proto3" json:"kind,omitempty"`
-	PolicyId       string                 `protobuf:"bytes,6,opt,name=policy_id,json=policyId,proto3" json:"policy_id,omitempty"`
-	ValidationType string                 `protobuf:"bytes,7,opt,name=validation_type,json=validationType,proto3" json:"validation_type,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type DependencyGraphEdge struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Source string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Target string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// type is one of source (source to automation), dependsOn (automation
+	// to its dependency) or inventory (automation to the automation or
+	// source it created)
+	Type          string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListPolicyValidationsRequest) Reset() {
-	*x = ListPolicyValidationsRequest{}
-	mi := &file_api_core_core_proto_msgTypes[3]
+func (x *DependencyGraphEdge) Reset() {
+	*x = DependencyGraphEdge{}
+	mi := &file_api_core_core_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListPolicyValidationsRequest) String() string {
+func (x *DependencyGraphEdge) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListPolicyValidationsRequest) ProtoMessage() {}
+func (*DependencyGraphEdge) ProtoMessage() {}
 
-func (x *ListPolicyValidationsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[3]
+func (x *DependencyGraphEdge) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -358,85 +332,102 @@ func (x *ListPolicyValidationsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListPolicyValidationsRequest.ProtoReflect.Descriptor instead.
-func (*ListPolicyValidationsRequest) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use DependencyGraphEdge.ProtoReflect.Descriptor instead.
+func (*DependencyGraphEdge) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *ListPolicyValidationsRequest) GetClusterName() string {
+func (x *DependencyGraphEdge) GetSource() string {
 	if x != nil {
-		return x.ClusterName
+		return x.Source
 	}
 	return ""
 }
 
-func (x *ListPolicyValidationsRequest) GetPagination() *Pagination {
+func (x *DependencyGraphEdge) GetTarget() string {
 	if x != nil {
-		return x.Pagination
+		return x.Target
 	}
-	return nil
+	return ""
 }
 
-func (x *ListPolicyValidationsRequest) GetApplication() string {
+func (x *DependencyGraphEdge) GetType() string {
 	if x != nil {
-		return x.Application
+		return x.Type
 	}
 	return ""
 }
 
-func (x *ListPolicyValidationsRequest) GetNamespace() string {
-	if x != nil {
-		return x.Namespace
-	}
-	return ""
+type DependencyGraphCycle struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// nodes are the ids of the nodes whose dependsOn form a cycle
+	Nodes         []string `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListPolicyValidationsRequest) GetKind() string {
-	if x != nil {
-		return x.Kind
-	}
-	return ""
+func (x *DependencyGraphCycle) Reset() {
+	*x = DependencyGraphCycle{}
+	mi := &file_api_core_core_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListPolicyValidationsRequest) GetPolicyId() string {
+func (x *DependencyGraphCycle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DependencyGraphCycle) ProtoMessage() {}
+
+func (x *DependencyGraphCycle) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[5]
 	if x != nil {
-		return x.PolicyId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *ListPolicyValidationsRequest) GetValidationType() string {
+// Deprecated: Use DependencyGraphCycle.ProtoReflect.Descriptor instead.
+func (*DependencyGraphCycle) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DependencyGraphCycle) GetNodes() []string {
 	if x != nil {
-		return x.ValidationType
+		return x.Nodes
 	}
-	return ""
+	return nil
 }
 
-type ListPolicyValidationsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Violations    []*PolicyValidation    `protobuf:"bytes,1,rep,name=violations,proto3" json:"violations,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
-	NextPageToken string                 `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
-	Errors        []*ListError           `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+type GetDependencyGraphResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Nodes         []*DependencyGraphNode  `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Edges         []*DependencyGraphEdge  `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+	Cycles        []*DependencyGraphCycle `protobuf:"bytes,3,rep,name=cycles,proto3" json:"cycles,omitempty"`
+	Errors        []*ListError            `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListPolicyValidationsResponse) Reset() {
-	*x = ListPolicyValidationsResponse{}
-	mi := &file_api_core_core_proto_msgTypes[4]
+func (x *GetDependencyGraphResponse) Reset() {
+	*x = GetDependencyGraphResponse{}
+	mi := &file_api_core_core_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListPolicyValidationsResponse) String() string {
+func (x *GetDependencyGraphResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListPolicyValidationsResponse) ProtoMessage() {}
+func (*GetDependencyGraphResponse) ProtoMessage() {}
 
-func (x *ListPolicyValidationsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[4]
+func (x *GetDependencyGraphResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -447,63 +438,63 @@ func (x *ListPolicyValidationsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListPolicyValidationsResponse.ProtoReflect.Descriptor instead.
-func (*ListPolicyValidationsResponse) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use GetDependencyGraphResponse.ProtoReflect.Descriptor instead.
+func (*GetDependencyGraphResponse) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *ListPolicyValidationsResponse) GetViolations() []*PolicyValidation {
+func (x *GetDependencyGraphResponse) GetNodes() []*DependencyGraphNode {
 	if x != nil {
-		return x.Violations
+		return x.Nodes
 	}
 	return nil
 }
 
-func (x *ListPolicyValidationsResponse) GetTotal() int32 {
+func (x *GetDependencyGraphResponse) GetEdges() []*DependencyGraphEdge {
 	if x != nil {
-		return x.Total
+		return x.Edges
 	}
-	return 0
+	return nil
 }
 
-func (x *ListPolicyValidationsResponse) GetNextPageToken() string {
+func (x *GetDependencyGraphResponse) GetCycles() []*DependencyGraphCycle {
 	if x != nil {
-		return x.NextPageToken
+		return x.Cycles
 	}
-	return ""
+	return nil
 }
 
-func (x *ListPolicyValidationsResponse) GetErrors() []*ListError {
+func (x *GetDependencyGraphResponse) GetErrors() []*ListError {
 	if x != nil {
 		return x.Errors
 	}
 	return nil
 }
 
-type GetPolicyValidationRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	ValidationId   string                 `protobuf:"bytes,1,opt,name=validation_id,json=validationId,proto3" json:"validation_id,omitempty"`
-	ClusterName    string                 `protobuf:"bytes,2,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
-	ValidationType string                 `protobuf:"bytes,3,opt,name=validation_type,json=validationType,proto3" json:"validation_type,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type GetDriftRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	ClusterName   string                 `protobuf:"bytes,3,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPolicyValidationRequest) Reset() {
-	*x = GetPolicyValidationRequest{}
-	mi := &file_api_core_core_proto_msgTypes[5]
+func (x *GetDriftRequest) Reset() {
+	*x = GetDriftRequest{}
+	mi := &file_api_core_core_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPolicyValidationRequest) String() string {
+func (x *GetDriftRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPolicyValidationRequest) ProtoMessage() {}
+func (*GetDriftRequest) ProtoMessage() {}
 
-func (x *GetPolicyValidationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[5]
+func (x *GetDriftRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -514,98 +505,64 @@ func (x *GetPolicyValidationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPolicyValidationRequest.ProtoReflect.Descriptor instead.
-func (*GetPolicyValidationRequest) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use GetDriftRequest.ProtoReflect.Descriptor instead.
+func (*GetDriftRequest) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GetPolicyValidationRequest) GetValidationId() string {
+func (x *GetDriftRequest) GetName() string {
 	if x != nil {
-		return x.ValidationId
+		return x.Name
 	}
 	return ""
 }
 
-func (x *GetPolicyValidationRequest) GetClusterName() string {
+func (x *GetDriftRequest) GetNamespace() string {
 	if x != nil {
-		return x.ClusterName
+		return x.Namespace
 	}
 	return ""
 }
 
-func (x *GetPolicyValidationRequest) GetValidationType() string {
+func (x *GetDriftRequest) GetClusterName() string {
 	if x != nil {
-		return x.ValidationType
+		return x.ClusterName
 	}
 	return ""
 }
 
-type GetPolicyValidationResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Validation    *PolicyValidation      `protobuf:"bytes,1,opt,name=validation,proto3" json:"validation,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetPolicyValidationResponse) Reset() {
-	*x = GetPolicyValidationResponse{}
-	mi := &file_api_core_core_proto_msgTypes[6]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetPolicyValidationResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetPolicyValidationResponse) ProtoMessage() {}
-
-func (x *GetPolicyValidationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[6]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetPolicyValidationResponse.ProtoReflect.Descriptor instead.
-func (*GetPolicyValidationResponse) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{6}
-}
-
-func (x *GetPolicyValidationResponse) GetValidation() *PolicyValidation {
-	if x != nil {
-		return x.Validation
-	}
-	return nil
-}
-
-type PolicyValidationOccurrence struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+type DriftEntry struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Kind      string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Name      string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// action is what applying the object would do, one of created,
+	// configured, unchanged or skipped
+	Action string `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	// diff is a unified diff from the live object to the applied one,
+	// empty when unchanged
+	Diff string `protobuf:"bytes,5,opt,name=diff,proto3" json:"diff,omitempty"`
+	// error is set if the object could not be dry-run
+	Error         string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PolicyValidationOccurrence) Reset() {
-	*x = PolicyValidationOccurrence{}
-	mi := &file_api_core_core_proto_msgTypes[7]
+func (x *DriftEntry) Reset() {
+	*x = DriftEntry{}
+	mi := &file_api_core_core_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PolicyValidationOccurrence) String() string {
+func (x *DriftEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PolicyValidationOccurrence) ProtoMessage() {}
+func (*DriftEntry) ProtoMessage() {}
 
-func (x *PolicyValidationOccurrence) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[7]
+func (x *DriftEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -616,115 +573,76 @@ func (x *PolicyValidationOccurrence) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PolicyValidationOccurrence.ProtoReflect.Descriptor instead.
-func (*PolicyValidationOccurrence) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use DriftEntry.ProtoReflect.Descriptor instead.
+func (*DriftEntry) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *PolicyValidationOccurrence) GetMessage() string {
+func (x *DriftEntry) GetKind() string {
 	if x != nil {
-		return x.Message
+		return x.Kind
 	}
 	return ""
 }
 
-type PolicyValidationParam struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
-	Value         *anypb.Any             `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
-	Required      bool                   `protobuf:"varint,4,opt,name=required,proto3" json:"required,omitempty"`
-	ConfigRef     string                 `protobuf:"bytes,5,opt,name=config_ref,json=configRef,proto3" json:"config_ref,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *PolicyValidationParam) Reset() {
-	*x = PolicyValidationParam{}
-	mi := &file_api_core_core_proto_msgTypes[8]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *PolicyValidationParam) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*PolicyValidationParam) ProtoMessage() {}
-
-func (x *PolicyValidationParam) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[8]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use PolicyValidationParam.ProtoReflect.Descriptor instead.
-func (*PolicyValidationParam) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{8}
-}
-
-func (x *PolicyValidationParam) GetName() string {
+func (x *DriftEntry) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *PolicyValidationParam) GetType() string {
+func (x *DriftEntry) GetNamespace() string {
 	if x != nil {
-		return x.Type
+		return x.Namespace
 	}
 	return ""
 }
 
-func (x *PolicyValidationParam) GetValue() *anypb.Any {
+func (x *DriftEntry) GetAction() string {
 	if x != nil {
-		return x.Value
+		return x.Action
 	}
-	return nil
+	return ""
 }
 
-func (x *PolicyValidationParam) GetRequired() bool {
+func (x *DriftEntry) GetDiff() string {
 	if x != nil {
-		return x.Required
+		return x.Diff
 	}
-	return false
+	return ""
 }
 
-func (x *PolicyValidationParam) GetConfigRef() string {
+func (x *DriftEntry) GetError() string {
 	if x != nil {
-		return x.ConfigRef
+		return x.Error
 	}
 	return ""
 }
 
-type PolicyParamRepeatedString struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Value         []string               `protobuf:"bytes,1,rep,name=value,proto3" json:"value,omitempty"`
+type GetDriftResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// revision is the revision of the source artifact the manifests were built from
+	Revision      string        `protobuf:"bytes,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	Entries       []*DriftEntry `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PolicyParamRepeatedString) Reset() {
-	*x = PolicyParamRepeatedString{}
+func (x *GetDriftResponse) Reset() {
+	*x = GetDriftResponse{}
 	mi := &file_api_core_core_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PolicyParamRepeatedString) String() string {
+func (x *GetDriftResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PolicyParamRepeatedString) ProtoMessage() {}
+func (*GetDriftResponse) ProtoMessage() {}
 
-func (x *PolicyParamRepeatedString) ProtoReflect() protoreflect.Message {
+func (x *GetDriftResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_core_core_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -736,40 +654,49 @@ func (x *PolicyParamRepeatedString) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PolicyParamRepeatedString.ProtoReflect.Descriptor instead.
-func (*PolicyParamRepeatedString) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetDriftResponse.ProtoReflect.Descriptor instead.
+func (*GetDriftResponse) Descriptor() ([]byte, []int) {
 	return file_api_core_core_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *PolicyParamRepeatedString) GetValue() []string {
+func (x *GetDriftResponse) GetRevision() string {
 	if x != nil {
-		return x.Value
+		return x.Revision
+	}
+	return ""
+}
+
+func (x *GetDriftResponse) GetEntries() []*DriftEntry {
+	if x != nil {
+		return x.Entries
 	}
 	return nil
 }
 
-type Pagination struct {
+type GetHealthHistoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	ClusterName   string                 `protobuf:"bytes,4,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Pagination) Reset() {
-	*x = Pagination{}
+func (x *GetHealthHistoryRequest) Reset() {
+	*x = GetHealthHistoryRequest{}
 	mi := &file_api_core_core_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Pagination) String() string {
+func (x *GetHealthHistoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Pagination) ProtoMessage() {}
+func (*GetHealthHistoryRequest) ProtoMessage() {}
 
-func (x *Pagination) ProtoReflect() protoreflect.Message {
+func (x *GetHealthHistoryRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_core_core_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -781,48 +708,64 @@ func (x *Pagination) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Pagination.ProtoReflect.Descriptor instead.
-func (*Pagination) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetHealthHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetHealthHistoryRequest) Descriptor() ([]byte, []int) {
 	return file_api_core_core_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *Pagination) GetPageSize() int32 {
+func (x *GetHealthHistoryRequest) GetName() string {
 	if x != nil {
-		return x.PageSize
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *Pagination) GetPageToken() string {
+func (x *GetHealthHistoryRequest) GetNamespace() string {
 	if x != nil {
-		return x.PageToken
+		return x.Namespace
 	}
 	return ""
 }
 
-type ListError struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ClusterName   string                 `protobuf:"bytes,1,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
-	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+func (x *GetHealthHistoryRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *GetHealthHistoryRequest) GetClusterName() string {
+	if x != nil {
+		return x.ClusterName
+	}
+	return ""
+}
+
+type HealthHistoryEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// status is one of Healthy, Progressing, Unhealthy or Unknown
+	Status  string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// timestamp is when the object changed to the status
+	Timestamp     string `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListError) Reset() {
-	*x = ListError{}
+func (x *HealthHistoryEntry) Reset() {
+	*x = HealthHistoryEntry{}
 	mi := &file_api_core_core_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListError) String() string {
+func (x *HealthHistoryEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListError) ProtoMessage() {}
+func (*HealthHistoryEntry) ProtoMessage() {}
 
-func (x *ListError) ProtoReflect() protoreflect.Message {
+func (x *HealthHistoryEntry) ProtoReflect() protoreflect.Message {
 	mi := &file_api_core_core_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -834,54 +777,58 @@ func (x *ListError) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListError.ProtoReflect.Descriptor instead.
-func (*ListError) Descriptor() ([]byte, []int) {
+// Deprecated: Use HealthHistoryEntry.ProtoReflect.Descriptor instead.
+func (*HealthHistoryEntry) Descriptor() ([]byte, []int) {
 	return file_api_core_core_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *ListError) GetClusterName() string {
+func (x *HealthHistoryEntry) GetStatus() string {
 	if x != nil {
-		return x.ClusterName
+		return x.Status
 	}
 	return ""
 }
 
-func (x *ListError) GetNamespace() string {
+func (x *HealthHistoryEntry) GetMessage() string {
 	if x != nil {
-		return x.Namespace
+		return x.Message
 	}
 	return ""
 }
 
-func (x *ListError) GetMessage() string {
+func (x *HealthHistoryEntry) GetTimestamp() string {
 	if x != nil {
-		return x.Message
+		return x.Timestamp
 	}
 	return ""
 }
 
-type ListFluxRuntimeObjectsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	ClusterName   string                 `protobuf:"bytes,2,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+type GetHealthHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// entries are the health changes of the object, oldest first
+	Entries []*HealthHistoryEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// changes is how many times the health changed within the flapping window
+	Changes int32 `protobuf:"varint,2,opt,name=changes,proto3" json:"changes,omitempty"`
+	// flapping is set when the health changed too often within the window
+	Flapping      bool `protobuf:"varint,3,opt,name=flapping,proto3" json:"flapping,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListFluxRuntimeObjectsRequest) Reset() {
-	*x = ListFluxRuntimeObjectsRequest{}
+func (x *GetHealthHistoryResponse) Reset() {
+	*x = GetHealthHistoryResponse{}
 	mi := &file_api_core_core_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListFluxRuntimeObjectsRequest) String() string {
+func (x *GetHealthHistoryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListFluxRuntimeObjectsRequest) ProtoMessage() {}
+func (*GetHealthHistoryResponse) ProtoMessage() {}
 
-func (x *ListFluxRuntimeObjectsRequest) ProtoReflect() protoreflect.Message {
+func (x *GetHealthHistoryResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_core_core_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -893,47 +840,69 @@ func (x *ListFluxRuntimeObjectsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListFluxRuntimeObjectsRequest.ProtoReflect.Descriptor instead.
-func (*ListFluxRuntimeObjectsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetHealthHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetHealthHistoryResponse) Descriptor() ([]byte, []int) {
 	return file_api_core_core_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *ListFluxRuntimeObjectsRequest) GetNamespace() string {
+func (x *GetHealthHistoryResponse) GetEntries() []*HealthHistoryEntry {
 	if x != nil {
-		return x.Namespace
+		return x.Entries
 	}
-	return ""
+	return nil
 }
 
-func (x *ListFluxRuntimeObjectsRequest) GetClusterName() string {
+func (x *GetHealthHistoryResponse) GetChanges() int32 {
 	if x != nil {
-		return x.ClusterName
+		return x.Changes
 	}
-	return ""
+	return 0
 }
 
-type ListFluxRuntimeObjectsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Deployments   []*Deployment          `protobuf:"bytes,1,rep,name=deployments,proto3" json:"deployments,omitempty"`
-	Errors        []*ListError           `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *GetHealthHistoryResponse) GetFlapping() bool {
+	if x != nil {
+		return x.Flapping
+	}
+	return false
 }
 
-func (x *ListFluxRuntimeObjectsResponse) Reset() {
-	*x = ListFluxRuntimeObjectsResponse{}
+type PolicyValidation struct {
+	state           protoimpl.MessageState        `protogen:"open.v1"`
+	Id              string                        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Message         string                        `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ClusterId       string                        `protobuf:"bytes,3,opt,name=cluster_id,json=clusterId,proto3" json:"cluster_id,omitempty"`
+	Category        string                        `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	Severity        string                        `protobuf:"bytes,5,opt,name=severity,proto3" json:"severity,omitempty"`
+	CreatedAt       string                        `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Entity          string                        `protobuf:"bytes,7,opt,name=entity,proto3" json:"entity,omitempty"`
+	EntityKind      string                        `protobuf:"bytes,8,opt,name=entity_kind,json=entityKind,proto3" json:"entity_kind,omitempty"`
+	Namespace       string                        `protobuf:"bytes,9,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	ViolatingEntity string                        `protobuf:"bytes,10,opt,name=violating_entity,json=violatingEntity,proto3" json:"violating_entity,omitempty"`
+	Description     string                        `protobuf:"bytes,11,opt,name=description,proto3" json:"description,omitempty"`
+	HowToSolve      string                        `protobuf:"bytes,12,opt,name=how_to_solve,json=howToSolve,proto3" json:"how_to_solve,omitempty"`
+	Name            string                        `protobuf:"bytes,13,opt,name=name,proto3" json:"name,omitempty"`
+	ClusterName     string                        `protobuf:"bytes,14,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	Occurrences     []*PolicyValidationOccurrence `protobuf:"bytes,15,rep,name=occurrences,proto3" json:"occurrences,omitempty"`
+	PolicyId        string                        `protobuf:"bytes,16,opt,name=policy_id,json=policyId,proto3" json:"policy_id,omitempty"`
+	Parameters      []*PolicyValidationParam      `protobuf:"bytes,17,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PolicyValidation) Reset() {
+	*x = PolicyValidation{}
 	mi := &file_api_core_core_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListFluxRuntimeObjectsResponse) String() string {
+func (x *PolicyValidation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListFluxRuntimeObjectsResponse) ProtoMessage() {}
+func (*PolicyValidation) ProtoMessage() {}
 
-func (x *ListFluxRuntimeObjectsResponse) ProtoReflect() protoreflect.Message {
+func (x *PolicyValidation) ProtoReflect() protoreflect.Message {
 	mi := &file_api_core_core_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -945,48 +914,1672 @@ func (x *ListFluxRuntimeObjectsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListFluxRuntimeObjectsResponse.ProtoReflect.Descriptor instead.
-func (*ListFluxRuntimeObjectsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use PolicyValidation.ProtoReflect.Descriptor instead.
+func (*PolicyValidation) Descriptor() ([]byte, []int) {
 	return file_api_core_core_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *ListFluxRuntimeObjectsResponse) GetDeployments() []*Deployment {
+func (x *PolicyValidation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PolicyValidation) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PolicyValidation) GetClusterId() string {
+	if x != nil {
+		return x.ClusterId
+	}
+	return ""
+}
+
+func (x *PolicyValidation) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *PolicyValidation) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *PolicyValidation) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *PolicyValidation) GetEntity() string {
+	if x != nil {
+		return x.Entity
+	}
+	return ""
+}
+
+func (x *PolicyValidation) GetEntityKind() string {
+	if x != nil {
+		return x.EntityKind
+	}
+	return ""
+}
+
+func (x *PolicyValidation) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *PolicyValidation) GetViolatingEntity() string {
+	if x != nil {
+		return x.ViolatingEntity
+	}
+	return ""
+}
+
+func (x *PolicyValidation) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PolicyValidation) GetHowToSolve() string {
+	if x != nil {
+		return x.HowToSolve
+	}
+	return ""
+}
+
+func (x *PolicyValidation) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PolicyValidation) GetClusterName() string {
+	if x != nil {
+		return x.ClusterName
+	}
+	return ""
+}
+
+func (x *PolicyValidation) GetOccurrences() []*PolicyValidationOccurrence {
+	if x != nil {
+		return x.Occurrences
+	}
+	return nil
+}
+
+func (x *PolicyValidation) GetPolicyId() string {
+	if x != nil {
+		return x.PolicyId
+	}
+	return ""
+}
+
+func (x *PolicyValidation) GetParameters() []*PolicyValidationParam {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+type ListPolicyValidationsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ClusterName    string                 `protobuf:"bytes,1,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	Pagination     *Pagination            `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	Application    string                 `protobuf:"bytes,3,opt,name=application,proto3" json:"application,omitempty"`
+	Namespace      string                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Kind           string                 `protobuf:"bytes,5,opt,name=kind,proto3" json:"kind,omitempty"`
+	PolicyId       string                 `protobuf:"bytes,6,opt,name=policy_id,json=policyId,proto3" json:"policy_id,omitempty"`
+	ValidationType string                 `protobuf:"bytes,7,opt,name=validation_type,json=validationType,proto3" json:"validation_type,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListPolicyValidationsRequest) Reset() {
+	*x = ListPolicyValidationsRequest{}
+	mi := &file_api_core_core_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPolicyValidationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPolicyValidationsRequest) ProtoMessage() {}
+
+func (x *ListPolicyValidationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPolicyValidationsRequest.ProtoReflect.Descriptor instead.
+func (*ListPolicyValidationsRequest) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListPolicyValidationsRequest) GetClusterName() string {
+	if x != nil {
+		return x.ClusterName
+	}
+	return ""
+}
+
+func (x *ListPolicyValidationsRequest) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+func (x *ListPolicyValidationsRequest) GetApplication() string {
+	if x != nil {
+		return x.Application
+	}
+	return ""
+}
+
+func (x *ListPolicyValidationsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ListPolicyValidationsRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ListPolicyValidationsRequest) GetPolicyId() string {
+	if x != nil {
+		return x.PolicyId
+	}
+	return ""
+}
+
+func (x *ListPolicyValidationsRequest) GetValidationType() string {
+	if x != nil {
+		return x.ValidationType
+	}
+	return ""
+}
+
+type ListPolicyValidationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Violations    []*PolicyValidation    `protobuf:"bytes,1,rep,name=violations,proto3" json:"violations,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	Errors        []*ListError           `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPolicyValidationsResponse) Reset() {
+	*x = ListPolicyValidationsResponse{}
+	mi := &file_api_core_core_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPolicyValidationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPolicyValidationsResponse) ProtoMessage() {}
+
+func (x *ListPolicyValidationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPolicyValidationsResponse.ProtoReflect.Descriptor instead.
+func (*ListPolicyValidationsResponse) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListPolicyValidationsResponse) GetViolations() []*PolicyValidation {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+func (x *ListPolicyValidationsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListPolicyValidationsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListPolicyValidationsResponse) GetErrors() []*ListError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type GetPolicyValidationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ValidationId   string                 `protobuf:"bytes,1,opt,name=validation_id,json=validationId,proto3" json:"validation_id,omitempty"`
+	ClusterName    string                 `protobuf:"bytes,2,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	ValidationType string                 `protobuf:"bytes,3,opt,name=validation_type,json=validationType,proto3" json:"validation_type,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetPolicyValidationRequest) Reset() {
+	*x = GetPolicyValidationRequest{}
+	mi := &file_api_core_core_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPolicyValidationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPolicyValidationRequest) ProtoMessage() {}
+
+func (x *GetPolicyValidationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPolicyValidationRequest.ProtoReflect.Descriptor instead.
+func (*GetPolicyValidationRequest) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetPolicyValidationRequest) GetValidationId() string {
+	if x != nil {
+		return x.ValidationId
+	}
+	return ""
+}
+
+func (x *GetPolicyValidationRequest) GetClusterName() string {
+	if x != nil {
+		return x.ClusterName
+	}
+	return ""
+}
+
+func (x *GetPolicyValidationRequest) GetValidationType() string {
+	if x != nil {
+		return x.ValidationType
+	}
+	return ""
+}
+
+type GetPolicyValidationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Validation    *PolicyValidation      `protobuf:"bytes,1,opt,name=validation,proto3" json:"validation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPolicyValidationResponse) Reset() {
+	*x = GetPolicyValidationResponse{}
+	mi := &file_api_core_core_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPolicyValidationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPolicyValidationResponse) ProtoMessage() {}
+
+func (x *GetPolicyValidationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPolicyValidationResponse.ProtoReflect.Descriptor instead.
+func (*GetPolicyValidationResponse) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetPolicyValidationResponse) GetValidation() *PolicyValidation {
+	if x != nil {
+		return x.Validation
+	}
+	return nil
+}
+
+type PolicyValidationOccurrence struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PolicyValidationOccurrence) Reset() {
+	*x = PolicyValidationOccurrence{}
+	mi := &file_api_core_core_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolicyValidationOccurrence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyValidationOccurrence) ProtoMessage() {}
+
+func (x *PolicyValidationOccurrence) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyValidationOccurrence.ProtoReflect.Descriptor instead.
+func (*PolicyValidationOccurrence) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PolicyValidationOccurrence) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type PolicyValidationParam struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Value         *anypb.Any             `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Required      bool                   `protobuf:"varint,4,opt,name=required,proto3" json:"required,omitempty"`
+	ConfigRef     string                 `protobuf:"bytes,5,opt,name=config_ref,json=configRef,proto3" json:"config_ref,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PolicyValidationParam) Reset() {
+	*x = PolicyValidationParam{}
+	mi := &file_api_core_core_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolicyValidationParam) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyValidationParam) ProtoMessage() {}
+
+func (x *PolicyValidationParam) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyValidationParam.ProtoReflect.Descriptor instead.
+func (*PolicyValidationParam) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PolicyValidationParam) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PolicyValidationParam) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PolicyValidationParam) GetValue() *anypb.Any {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *PolicyValidationParam) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *PolicyValidationParam) GetConfigRef() string {
+	if x != nil {
+		return x.ConfigRef
+	}
+	return ""
+}
+
+type PolicyParamRepeatedString struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         []string               `protobuf:"bytes,1,rep,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PolicyParamRepeatedString) Reset() {
+	*x = PolicyParamRepeatedString{}
+	mi := &file_api_core_core_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolicyParamRepeatedString) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyParamRepeatedString) ProtoMessage() {}
+
+func (x *PolicyParamRepeatedString) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyParamRepeatedString.ProtoReflect.Descriptor instead.
+func (*PolicyParamRepeatedString) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PolicyParamRepeatedString) GetValue() []string {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type Pagination struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pagination) Reset() {
+	*x = Pagination{}
+	mi := &file_api_core_core_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pagination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pagination) ProtoMessage() {}
+
+func (x *Pagination) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pagination.ProtoReflect.Descriptor instead.
+func (*Pagination) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Pagination) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *Pagination) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClusterName   string                 `protobuf:"bytes,1,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListError) Reset() {
+	*x = ListError{}
+	mi := &file_api_core_core_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListError) ProtoMessage() {}
+
+func (x *ListError) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListError.ProtoReflect.Descriptor instead.
+func (*ListError) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListError) GetClusterName() string {
+	if x != nil {
+		return x.ClusterName
+	}
+	return ""
+}
+
+func (x *ListError) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ListError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ListFluxRuntimeObjectsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	ClusterName   string                 `protobuf:"bytes,2,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFluxRuntimeObjectsRequest) Reset() {
+	*x = ListFluxRuntimeObjectsRequest{}
+	mi := &file_api_core_core_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFluxRuntimeObjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFluxRuntimeObjectsRequest) ProtoMessage() {}
+
+func (x *ListFluxRuntimeObjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFluxRuntimeObjectsRequest.ProtoReflect.Descriptor instead.
+func (*ListFluxRuntimeObjectsRequest) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListFluxRuntimeObjectsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ListFluxRuntimeObjectsRequest) GetClusterName() string {
+	if x != nil {
+		return x.ClusterName
+	}
+	return ""
+}
+
+type ListFluxRuntimeObjectsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deployments   []*Deployment          `protobuf:"bytes,1,rep,name=deployments,proto3" json:"deployments,omitempty"`
+	Errors        []*ListError           `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFluxRuntimeObjectsResponse) Reset() {
+	*x = ListFluxRuntimeObjectsResponse{}
+	mi := &file_api_core_core_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFluxRuntimeObjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFluxRuntimeObjectsResponse) ProtoMessage() {}
+
+func (x *ListFluxRuntimeObjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFluxRuntimeObjectsResponse.ProtoReflect.Descriptor instead.
+func (*ListFluxRuntimeObjectsResponse) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListFluxRuntimeObjectsResponse) GetDeployments() []*Deployment {
+	if x != nil {
+		return x.Deployments
+	}
+	return nil
+}
+
+func (x *ListFluxRuntimeObjectsResponse) GetErrors() []*ListError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type ListRuntimeObjectsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	ClusterName   string                 `protobuf:"bytes,2,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRuntimeObjectsRequest) Reset() {
+	*x = ListRuntimeObjectsRequest{}
+	mi := &file_api_core_core_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRuntimeObjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRuntimeObjectsRequest) ProtoMessage() {}
+
+func (x *ListRuntimeObjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRuntimeObjectsRequest.ProtoReflect.Descriptor instead.
+func (*ListRuntimeObjectsRequest) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListRuntimeObjectsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ListRuntimeObjectsRequest) GetClusterName() string {
+	if x != nil {
+		return x.ClusterName
+	}
+	return ""
+}
+
+type ListRuntimeObjectsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deployments   []*Deployment          `protobuf:"bytes,1,rep,name=deployments,proto3" json:"deployments,omitempty"`
+	Errors        []*ListError           `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRuntimeObjectsResponse) Reset() {
+	*x = ListRuntimeObjectsResponse{}
+	mi := &file_api_core_core_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRuntimeObjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRuntimeObjectsResponse) ProtoMessage() {}
+
+func (x *ListRuntimeObjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRuntimeObjectsResponse.ProtoReflect.Descriptor instead.
+func (*ListRuntimeObjectsResponse) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListRuntimeObjectsResponse) GetDeployments() []*Deployment {
+	if x != nil {
+		return x.Deployments
+	}
+	return nil
+}
+
+func (x *ListRuntimeObjectsResponse) GetErrors() []*ListError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type ListFluxCrdsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClusterName   string                 `protobuf:"bytes,1,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFluxCrdsRequest) Reset() {
+	*x = ListFluxCrdsRequest{}
+	mi := &file_api_core_core_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFluxCrdsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFluxCrdsRequest) ProtoMessage() {}
+
+func (x *ListFluxCrdsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFluxCrdsRequest.ProtoReflect.Descriptor instead.
+func (*ListFluxCrdsRequest) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListFluxCrdsRequest) GetClusterName() string {
+	if x != nil {
+		return x.ClusterName
+	}
+	return ""
+}
+
+type ListFluxCrdsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Crds          []*Crd                 `protobuf:"bytes,1,rep,name=crds,proto3" json:"crds,omitempty"`
+	Errors        []*ListError           `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFluxCrdsResponse) Reset() {
+	*x = ListFluxCrdsResponse{}
+	mi := &file_api_core_core_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFluxCrdsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFluxCrdsResponse) ProtoMessage() {}
+
+func (x *ListFluxCrdsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFluxCrdsResponse.ProtoReflect.Descriptor instead.
+func (*ListFluxCrdsResponse) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListFluxCrdsResponse) GetCrds() []*Crd {
+	if x != nil {
+		return x.Crds
+	}
+	return nil
+}
+
+func (x *ListFluxCrdsResponse) GetErrors() []*ListError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type ListRuntimeCrdsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClusterName   string                 `protobuf:"bytes,1,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRuntimeCrdsRequest) Reset() {
+	*x = ListRuntimeCrdsRequest{}
+	mi := &file_api_core_core_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRuntimeCrdsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRuntimeCrdsRequest) ProtoMessage() {}
+
+func (x *ListRuntimeCrdsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRuntimeCrdsRequest.ProtoReflect.Descriptor instead.
+func (*ListRuntimeCrdsRequest) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListRuntimeCrdsRequest) GetClusterName() string {
+	if x != nil {
+		return x.ClusterName
+	}
+	return ""
+}
+
+type ListRuntimeCrdsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Crds          []*Crd                 `protobuf:"bytes,1,rep,name=crds,proto3" json:"crds,omitempty"`
+	Errors        []*ListError           `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRuntimeCrdsResponse) Reset() {
+	*x = ListRuntimeCrdsResponse{}
+	mi := &file_api_core_core_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRuntimeCrdsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRuntimeCrdsResponse) ProtoMessage() {}
+
+func (x *ListRuntimeCrdsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRuntimeCrdsResponse.ProtoReflect.Descriptor instead.
+func (*ListRuntimeCrdsResponse) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListRuntimeCrdsResponse) GetCrds() []*Crd {
+	if x != nil {
+		return x.Crds
+	}
+	return nil
+}
+
+func (x *ListRuntimeCrdsResponse) GetErrors() []*ListError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type GetObjectRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace   string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Kind        string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	ClusterName string                 `protobuf:"bytes,4,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	// with_health_rollup fills the health_rollup of Kustomizations and
+	// HelmReleases
+	WithHealthRollup bool `protobuf:"varint,5,opt,name=with_health_rollup,json=withHealthRollup,proto3" json:"with_health_rollup,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetObjectRequest) Reset() {
+	*x = GetObjectRequest{}
+	mi := &file_api_core_core_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetObjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetObjectRequest) ProtoMessage() {}
+
+func (x *GetObjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetObjectRequest.ProtoReflect.Descriptor instead.
+func (*GetObjectRequest) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetObjectRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetObjectRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *GetObjectRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *GetObjectRequest) GetClusterName() string {
+	if x != nil {
+		return x.ClusterName
+	}
+	return ""
+}
+
+func (x *GetObjectRequest) GetWithHealthRollup() bool {
+	if x != nil {
+		return x.WithHealthRollup
+	}
+	return false
+}
+
+type GetObjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Object        *Object                `protobuf:"bytes,1,opt,name=object,proto3" json:"object,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetObjectResponse) Reset() {
+	*x = GetObjectResponse{}
+	mi := &file_api_core_core_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetObjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetObjectResponse) ProtoMessage() {}
+
+func (x *GetObjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetObjectResponse.ProtoReflect.Descriptor instead.
+func (*GetObjectResponse) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetObjectResponse) GetObject() *Object {
+	if x != nil {
+		return x.Object
+	}
+	return nil
+}
+
+type ListObjectsRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Namespace   string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Kind        string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	ClusterName string                 `protobuf:"bytes,3,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	Labels      map[string]string      `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Pagination  *Pagination            `protobuf:"bytes,5,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	// field_selector filters on the objects' fields, e.g. spec.suspend=true,
+	// and supports the =, == and != operators
+	FieldSelector string `protobuf:"bytes,6,opt,name=field_selector,json=fieldSelector,proto3" json:"field_selector,omitempty"`
+	// search only keeps objects whose name contains it, or matches it as a
+	// regular expression when search_regex is set
+	Search      string `protobuf:"bytes,7,opt,name=search,proto3" json:"search,omitempty"`
+	SearchRegex bool   `protobuf:"varint,8,opt,name=search_regex,json=searchRegex,proto3" json:"search_regex,omitempty"`
+	// status is one of Ready, NotReady or Suspended
+	Status string `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	// sort_by is one of name, namespace, clusterName, status or created,
+	// prefixed with - to sort in descending order
+	SortBy string `protobuf:"bytes,10,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	// with_health_rollup fills the health_rollup of the Kustomizations and
+	// HelmReleases, which reads their whole inventory from the clusters
+	WithHealthRollup bool `protobuf:"varint,11,opt,name=with_health_rollup,json=withHealthRollup,proto3" json:"with_health_rollup,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListObjectsRequest) Reset() {
+	*x = ListObjectsRequest{}
+	mi := &file_api_core_core_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListObjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListObjectsRequest) ProtoMessage() {}
+
+func (x *ListObjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListObjectsRequest.ProtoReflect.Descriptor instead.
+func (*ListObjectsRequest) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListObjectsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ListObjectsRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ListObjectsRequest) GetClusterName() string {
+	if x != nil {
+		return x.ClusterName
+	}
+	return ""
+}
+
+func (x *ListObjectsRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ListObjectsRequest) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+func (x *ListObjectsRequest) GetFieldSelector() string {
+	if x != nil {
+		return x.FieldSelector
+	}
+	return ""
+}
+
+func (x *ListObjectsRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListObjectsRequest) GetSearchRegex() bool {
+	if x != nil {
+		return x.SearchRegex
+	}
+	return false
+}
+
+func (x *ListObjectsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListObjectsRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListObjectsRequest) GetWithHealthRollup() bool {
+	if x != nil {
+		return x.WithHealthRollup
+	}
+	return false
+}
+
+type WatchObjectsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	ClusterName   string                 `protobuf:"bytes,3,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchObjectsRequest) Reset() {
+	*x = WatchObjectsRequest{}
+	mi := &file_api_core_core_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchObjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchObjectsRequest) ProtoMessage() {}
+
+func (x *WatchObjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchObjectsRequest.ProtoReflect.Descriptor instead.
+func (*WatchObjectsRequest) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *WatchObjectsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *WatchObjectsRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *WatchObjectsRequest) GetClusterName() string {
+	if x != nil {
+		return x.ClusterName
+	}
+	return ""
+}
+
+func (x *WatchObjectsRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type WatchObjectsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// type is one of ADDED, MODIFIED, DELETED or ERROR
+	Type          string     `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Object        *Object    `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
+	Error         *ListError `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchObjectsResponse) Reset() {
+	*x = WatchObjectsResponse{}
+	mi := &file_api_core_core_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchObjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchObjectsResponse) ProtoMessage() {}
+
+func (x *WatchObjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchObjectsResponse.ProtoReflect.Descriptor instead.
+func (*WatchObjectsResponse) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *WatchObjectsResponse) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *WatchObjectsResponse) GetObject() *Object {
+	if x != nil {
+		return x.Object
+	}
+	return nil
+}
+
+func (x *WatchObjectsResponse) GetError() *ListError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type ClusterNamespaceList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClusterName   string                 `protobuf:"bytes,1,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	Namespaces    []string               `protobuf:"bytes,2,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClusterNamespaceList) Reset() {
+	*x = ClusterNamespaceList{}
+	mi := &file_api_core_core_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClusterNamespaceList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClusterNamespaceList) ProtoMessage() {}
+
+func (x *ClusterNamespaceList) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClusterNamespaceList.ProtoReflect.Descriptor instead.
+func (*ClusterNamespaceList) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ClusterNamespaceList) GetClusterName() string {
+	if x != nil {
+		return x.ClusterName
+	}
+	return ""
+}
+
+func (x *ClusterNamespaceList) GetNamespaces() []string {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
+type ListObjectsResponse struct {
+	state              protoimpl.MessageState  `protogen:"open.v1"`
+	Objects            []*Object               `protobuf:"bytes,1,rep,name=objects,proto3" json:"objects,omitempty"`
+	Errors             []*ListError            `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	SearchedNamespaces []*ClusterNamespaceList `protobuf:"bytes,3,rep,name=searched_namespaces,json=searchedNamespaces,proto3" json:"searched_namespaces,omitempty"`
+	NextPageToken      string                  `protobuf:"bytes,4,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ListObjectsResponse) Reset() {
+	*x = ListObjectsResponse{}
+	mi := &file_api_core_core_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListObjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListObjectsResponse) ProtoMessage() {}
+
+func (x *ListObjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListObjectsResponse.ProtoReflect.Descriptor instead.
+func (*ListObjectsResponse) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListObjectsResponse) GetObjects() []*Object {
+	if x != nil {
+		return x.Objects
+	}
+	return nil
+}
+
+func (x *ListObjectsResponse) GetErrors() []*ListError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ListObjectsResponse) GetSearchedNamespaces() []*ClusterNamespaceList {
+	if x != nil {
+		return x.SearchedNamespaces
+	}
+	return nil
+}
+
+func (x *ListObjectsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetReconciledObjectsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AutomationName string                 `protobuf:"bytes,1,opt,name=automation_name,json=automationName,proto3" json:"automation_name,omitempty"`
+	Namespace      string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	AutomationKind string                 `protobuf:"bytes,3,opt,name=automation_kind,json=automationKind,proto3" json:"automation_kind,omitempty"`
+	Kinds          []*GroupVersionKind    `protobuf:"bytes,4,rep,name=kinds,proto3" json:"kinds,omitempty"`
+	ClusterName    string                 `protobuf:"bytes,5,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetReconciledObjectsRequest) Reset() {
+	*x = GetReconciledObjectsRequest{}
+	mi := &file_api_core_core_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReconciledObjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReconciledObjectsRequest) ProtoMessage() {}
+
+func (x *GetReconciledObjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[38]
 	if x != nil {
-		return x.Deployments
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *ListFluxRuntimeObjectsResponse) GetErrors() []*ListError {
+// Deprecated: Use GetReconciledObjectsRequest.ProtoReflect.Descriptor instead.
+func (*GetReconciledObjectsRequest) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetReconciledObjectsRequest) GetAutomationName() string {
 	if x != nil {
-		return x.Errors
+		return x.AutomationName
+	}
+	return ""
+}
+
+func (x *GetReconciledObjectsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *GetReconciledObjectsRequest) GetAutomationKind() string {
+	if x != nil {
+		return x.AutomationKind
+	}
+	return ""
+}
+
+func (x *GetReconciledObjectsRequest) GetKinds() []*GroupVersionKind {
+	if x != nil {
+		return x.Kinds
 	}
 	return nil
 }
 
-type ListRuntimeObjectsRequest struct {
+func (x *GetReconciledObjectsRequest) GetClusterName() string {
+	if x != nil {
+		return x.ClusterName
+	}
+	return ""
+}
+
+type GetReconciledObjectsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	ClusterName   string                 `protobuf:"bytes,2,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	Objects       []*Object              `protobuf:"bytes,1,rep,name=objects,proto3" json:"objects,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListRuntimeObjectsRequest) Reset() {
-	*x = ListRuntimeObjectsRequest{}
-	mi := &file_api_core_core_proto_msgTypes[14]
+func (x *GetReconciledObjectsResponse) Reset() {
+	*x = GetReconciledObjectsResponse{}
+	mi := &file_api_core_core_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListRuntimeObjectsRequest) String() string {
+func (x *GetReconciledObjectsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListRuntimeObjectsRequest) ProtoMessage() {}
+func (*GetReconciledObjectsResponse) ProtoMessage() {}
 
-func (x *ListRuntimeObjectsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[14]
+func (x *GetReconciledObjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -997,48 +2590,176 @@ func (x *ListRuntimeObjectsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListRuntimeObjectsRequest.ProtoReflect.Descriptor instead.
-func (*ListRuntimeObjectsRequest) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use GetReconciledObjectsResponse.ProtoReflect.Descriptor instead.
+func (*GetReconciledObjectsResponse) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *ListRuntimeObjectsRequest) GetNamespace() string {
+func (x *GetReconciledObjectsResponse) GetObjects() []*Object {
+	if x != nil {
+		return x.Objects
+	}
+	return nil
+}
+
+type FindOwnersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// kind and name of the object to find the owners of, e.g. Deployment
+	// and podinfo
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// namespace only searches a namespace, all of them when empty
+	Namespace string `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// cluster_name only searches a cluster, all of them when empty
+	ClusterName string `protobuf:"bytes,4,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	// image finds the owners of the workloads running the image instead,
+	// with any tag unless it has one
+	Image         string `protobuf:"bytes,5,opt,name=image,proto3" json:"image,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindOwnersRequest) Reset() {
+	*x = FindOwnersRequest{}
+	mi := &file_api_core_core_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindOwnersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindOwnersRequest) ProtoMessage() {}
+
+func (x *FindOwnersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindOwnersRequest.ProtoReflect.Descriptor instead.
+func (*FindOwnersRequest) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *FindOwnersRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *FindOwnersRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FindOwnersRequest) GetNamespace() string {
 	if x != nil {
 		return x.Namespace
 	}
 	return ""
 }
 
-func (x *ListRuntimeObjectsRequest) GetClusterName() string {
+func (x *FindOwnersRequest) GetClusterName() string {
 	if x != nil {
 		return x.ClusterName
 	}
 	return ""
 }
 
-type ListRuntimeObjectsResponse struct {
+func (x *FindOwnersRequest) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+type ObjectOwners struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Object *ObjectRef             `protobuf:"bytes,1,opt,name=object,proto3" json:"object,omitempty"`
+	// owners are the Kustomizations and HelmReleases managing the object
+	Owners        []*ObjectRef `protobuf:"bytes,2,rep,name=owners,proto3" json:"owners,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ObjectOwners) Reset() {
+	*x = ObjectOwners{}
+	mi := &file_api_core_core_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObjectOwners) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObjectOwners) ProtoMessage() {}
+
+func (x *ObjectOwners) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObjectOwners.ProtoReflect.Descriptor instead.
+func (*ObjectOwners) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ObjectOwners) GetObject() *ObjectRef {
+	if x != nil {
+		return x.Object
+	}
+	return nil
+}
+
+func (x *ObjectOwners) GetOwners() []*ObjectRef {
+	if x != nil {
+		return x.Owners
+	}
+	return nil
+}
+
+type FindOwnersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Deployments   []*Deployment          `protobuf:"bytes,1,rep,name=deployments,proto3" json:"deployments,omitempty"`
+	Objects       []*ObjectOwners        `protobuf:"bytes,1,rep,name=objects,proto3" json:"objects,omitempty"`
 	Errors        []*ListError           `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListRuntimeObjectsResponse) Reset() {
-	*x = ListRuntimeObjectsResponse{}
-	mi := &file_api_core_core_proto_msgTypes[15]
+func (x *FindOwnersResponse) Reset() {
+	*x = FindOwnersResponse{}
+	mi := &file_api_core_core_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListRuntimeObjectsResponse) String() string {
+func (x *FindOwnersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListRuntimeObjectsResponse) ProtoMessage() {}
+func (*FindOwnersResponse) ProtoMessage() {}
 
-func (x *ListRuntimeObjectsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[15]
+func (x *FindOwnersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1049,47 +2770,56 @@ func (x *ListRuntimeObjectsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListRuntimeObjectsResponse.ProtoReflect.Descriptor instead.
-func (*ListRuntimeObjectsResponse) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use FindOwnersResponse.ProtoReflect.Descriptor instead.
+func (*FindOwnersResponse) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *ListRuntimeObjectsResponse) GetDeployments() []*Deployment {
+func (x *FindOwnersResponse) GetObjects() []*ObjectOwners {
 	if x != nil {
-		return x.Deployments
+		return x.Objects
 	}
 	return nil
 }
 
-func (x *ListRuntimeObjectsResponse) GetErrors() []*ListError {
+func (x *FindOwnersResponse) GetErrors() []*ListError {
 	if x != nil {
 		return x.Errors
 	}
 	return nil
 }
 
-type ListFluxCrdsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ClusterName   string                 `protobuf:"bytes,1,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+type ListImagesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// namespace only lists a namespace, all of them when empty
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// cluster_name only lists a cluster, all of them when empty
+	ClusterName string `protobuf:"bytes,2,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	// registry, repository and tag only list the matching images, e.g.
+	// docker.io, ghcr.io/stefanprodan/podinfo and 6.5.0. They're normalized
+	// like the images, e.g. redis is docker.io/library/redis.
+	Registry      string `protobuf:"bytes,3,opt,name=registry,proto3" json:"registry,omitempty"`
+	Repository    string `protobuf:"bytes,4,opt,name=repository,proto3" json:"repository,omitempty"`
+	Tag           string `protobuf:"bytes,5,opt,name=tag,proto3" json:"tag,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListFluxCrdsRequest) Reset() {
-	*x = ListFluxCrdsRequest{}
-	mi := &file_api_core_core_proto_msgTypes[16]
+func (x *ListImagesRequest) Reset() {
+	*x = ListImagesRequest{}
+	mi := &file_api_core_core_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListFluxCrdsRequest) String() string {
+func (x *ListImagesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListFluxCrdsRequest) ProtoMessage() {}
+func (*ListImagesRequest) ProtoMessage() {}
 
-func (x *ListFluxCrdsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[16]
+func (x *ListImagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1100,41 +2830,70 @@ func (x *ListFluxCrdsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListFluxCrdsRequest.ProtoReflect.Descriptor instead.
-func (*ListFluxCrdsRequest) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use ListImagesRequest.ProtoReflect.Descriptor instead.
+func (*ListImagesRequest) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *ListFluxCrdsRequest) GetClusterName() string {
+func (x *ListImagesRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ListImagesRequest) GetClusterName() string {
 	if x != nil {
 		return x.ClusterName
 	}
 	return ""
 }
 
-type ListFluxCrdsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Crds          []*Crd                 `protobuf:"bytes,1,rep,name=crds,proto3" json:"crds,omitempty"`
-	Errors        []*ListError           `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+func (x *ListImagesRequest) GetRegistry() string {
+	if x != nil {
+		return x.Registry
+	}
+	return ""
+}
+
+func (x *ListImagesRequest) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *ListImagesRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+type ImageWorkload struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Workload *ObjectRef             `protobuf:"bytes,1,opt,name=workload,proto3" json:"workload,omitempty"`
+	// owners are the Kustomizations and HelmReleases managing the workload
+	Owners        []*ObjectRef `protobuf:"bytes,2,rep,name=owners,proto3" json:"owners,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListFluxCrdsResponse) Reset() {
-	*x = ListFluxCrdsResponse{}
-	mi := &file_api_core_core_proto_msgTypes[17]
+func (x *ImageWorkload) Reset() {
+	*x = ImageWorkload{}
+	mi := &file_api_core_core_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListFluxCrdsResponse) String() string {
+func (x *ImageWorkload) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListFluxCrdsResponse) ProtoMessage() {}
+func (*ImageWorkload) ProtoMessage() {}
 
-func (x *ListFluxCrdsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[17]
+func (x *ImageWorkload) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1145,47 +2904,54 @@ func (x *ListFluxCrdsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListFluxCrdsResponse.ProtoReflect.Descriptor instead.
-func (*ListFluxCrdsResponse) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use ImageWorkload.ProtoReflect.Descriptor instead.
+func (*ImageWorkload) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *ListFluxCrdsResponse) GetCrds() []*Crd {
+func (x *ImageWorkload) GetWorkload() *ObjectRef {
 	if x != nil {
-		return x.Crds
+		return x.Workload
 	}
 	return nil
 }
 
-func (x *ListFluxCrdsResponse) GetErrors() []*ListError {
+func (x *ImageWorkload) GetOwners() []*ObjectRef {
 	if x != nil {
-		return x.Errors
+		return x.Owners
 	}
 	return nil
 }
 
-type ListRuntimeCrdsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ClusterName   string                 `protobuf:"bytes,1,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+type Image struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// registry, repository and tag are normalized, e.g. the image redis is
+	// docker.io, docker.io/library/redis and latest
+	Registry   string `protobuf:"bytes,1,opt,name=registry,proto3" json:"registry,omitempty"`
+	Repository string `protobuf:"bytes,2,opt,name=repository,proto3" json:"repository,omitempty"`
+	Tag        string `protobuf:"bytes,3,opt,name=tag,proto3" json:"tag,omitempty"`
+	// digest is set when the image is pinned to one
+	Digest        string           `protobuf:"bytes,4,opt,name=digest,proto3" json:"digest,omitempty"`
+	Workloads     []*ImageWorkload `protobuf:"bytes,5,rep,name=workloads,proto3" json:"workloads,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListRuntimeCrdsRequest) Reset() {
-	*x = ListRuntimeCrdsRequest{}
-	mi := &file_api_core_core_proto_msgTypes[18]
+func (x *Image) Reset() {
+	*x = Image{}
+	mi := &file_api_core_core_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListRuntimeCrdsRequest) String() string {
+func (x *Image) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListRuntimeCrdsRequest) ProtoMessage() {}
+func (*Image) ProtoMessage() {}
 
-func (x *ListRuntimeCrdsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[18]
+func (x *Image) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1196,41 +2962,69 @@ func (x *ListRuntimeCrdsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListRuntimeCrdsRequest.ProtoReflect.Descriptor instead.
-func (*ListRuntimeCrdsRequest) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use Image.ProtoReflect.Descriptor instead.
+func (*Image) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *ListRuntimeCrdsRequest) GetClusterName() string {
+func (x *Image) GetRegistry() string {
 	if x != nil {
-		return x.ClusterName
+		return x.Registry
 	}
 	return ""
 }
 
-type ListRuntimeCrdsResponse struct {
+func (x *Image) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *Image) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *Image) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+func (x *Image) GetWorkloads() []*ImageWorkload {
+	if x != nil {
+		return x.Workloads
+	}
+	return nil
+}
+
+type ListImagesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Crds          []*Crd                 `protobuf:"bytes,1,rep,name=crds,proto3" json:"crds,omitempty"`
+	Images        []*Image               `protobuf:"bytes,1,rep,name=images,proto3" json:"images,omitempty"`
 	Errors        []*ListError           `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListRuntimeCrdsResponse) Reset() {
-	*x = ListRuntimeCrdsResponse{}
-	mi := &file_api_core_core_proto_msgTypes[19]
+func (x *ListImagesResponse) Reset() {
+	*x = ListImagesResponse{}
+	mi := &file_api_core_core_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListRuntimeCrdsResponse) String() string {
+func (x *ListImagesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListRuntimeCrdsResponse) ProtoMessage() {}
+func (*ListImagesResponse) ProtoMessage() {}
 
-func (x *ListRuntimeCrdsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[19]
+func (x *ListImagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1241,50 +3035,50 @@ func (x *ListRuntimeCrdsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListRuntimeCrdsResponse.ProtoReflect.Descriptor instead.
-func (*ListRuntimeCrdsResponse) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use ListImagesResponse.ProtoReflect.Descriptor instead.
+func (*ListImagesResponse) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *ListRuntimeCrdsResponse) GetCrds() []*Crd {
+func (x *ListImagesResponse) GetImages() []*Image {
 	if x != nil {
-		return x.Crds
+		return x.Images
 	}
 	return nil
 }
 
-func (x *ListRuntimeCrdsResponse) GetErrors() []*ListError {
+func (x *ListImagesResponse) GetErrors() []*ListError {
 	if x != nil {
 		return x.Errors
 	}
 	return nil
 }
 
-type GetObjectRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
-	ClusterName   string                 `protobuf:"bytes,4,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type GetChildObjectsRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	GroupVersionKind *GroupVersionKind      `protobuf:"bytes,1,opt,name=group_version_kind,json=groupVersionKind,proto3" json:"group_version_kind,omitempty"`
+	Namespace        string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	ParentUid        string                 `protobuf:"bytes,3,opt,name=parent_uid,json=parentUid,proto3" json:"parent_uid,omitempty"`
+	ClusterName      string                 `protobuf:"bytes,4,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *GetObjectRequest) Reset() {
-	*x = GetObjectRequest{}
-	mi := &file_api_core_core_proto_msgTypes[20]
+func (x *GetChildObjectsRequest) Reset() {
+	*x = GetChildObjectsRequest{}
+	mi := &file_api_core_core_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetObjectRequest) String() string {
+func (x *GetChildObjectsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetObjectRequest) ProtoMessage() {}
+func (*GetChildObjectsRequest) ProtoMessage() {}
 
-func (x *GetObjectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[20]
+func (x *GetChildObjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1295,61 +3089,61 @@ func (x *GetObjectRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetObjectRequest.ProtoReflect.Descriptor instead.
-func (*GetObjectRequest) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use GetChildObjectsRequest.ProtoReflect.Descriptor instead.
+func (*GetChildObjectsRequest) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *GetObjectRequest) GetName() string {
+func (x *GetChildObjectsRequest) GetGroupVersionKind() *GroupVersionKind {
 	if x != nil {
-		return x.Name
+		return x.GroupVersionKind
 	}
-	return ""
+	return nil
 }
 
-func (x *GetObjectRequest) GetNamespace() string {
+func (x *GetChildObjectsRequest) GetNamespace() string {
 	if x != nil {
 		return x.Namespace
 	}
 	return ""
 }
 
-func (x *GetObjectRequest) GetKind() string {
+func (x *GetChildObjectsRequest) GetParentUid() string {
 	if x != nil {
-		return x.Kind
+		return x.ParentUid
 	}
 	return ""
 }
 
-func (x *GetObjectRequest) GetClusterName() string {
+func (x *GetChildObjectsRequest) GetClusterName() string {
 	if x != nil {
 		return x.ClusterName
 	}
 	return ""
 }
 
-type GetObjectResponse struct {
+type GetChildObjectsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Object        *Object                `protobuf:"bytes,1,opt,name=object,proto3" json:"object,omitempty"`
+	Objects       []*Object              `protobuf:"bytes,1,rep,name=objects,proto3" json:"objects,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetObjectResponse) Reset() {
-	*x = GetObjectResponse{}
-	mi := &file_api_core_core_proto_msgTypes[21]
+func (x *GetChildObjectsResponse) Reset() {
+	*x = GetChildObjectsResponse{}
+	mi := &file_api_core_core_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetObjectResponse) String() string {
+func (x *GetChildObjectsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetObjectResponse) ProtoMessage() {}
+func (*GetChildObjectsResponse) ProtoMessage() {}
 
-func (x *GetObjectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[21]
+func (x *GetChildObjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1360,43 +3154,39 @@ func (x *GetObjectResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetObjectResponse.ProtoReflect.Descriptor instead.
-func (*GetObjectResponse) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use GetChildObjectsResponse.ProtoReflect.Descriptor instead.
+func (*GetChildObjectsResponse) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *GetObjectResponse) GetObject() *Object {
+func (x *GetChildObjectsResponse) GetObjects() []*Object {
 	if x != nil {
-		return x.Object
+		return x.Objects
 	}
 	return nil
 }
 
-type ListObjectsRequest struct {
+type GetFluxNamespaceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
-	ClusterName   string                 `protobuf:"bytes,3,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
-	Labels        map[string]string      `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListObjectsRequest) Reset() {
-	*x = ListObjectsRequest{}
-	mi := &file_api_core_core_proto_msgTypes[22]
+func (x *GetFluxNamespaceRequest) Reset() {
+	*x = GetFluxNamespaceRequest{}
+	mi := &file_api_core_core_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListObjectsRequest) String() string {
+func (x *GetFluxNamespaceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListObjectsRequest) ProtoMessage() {}
+func (*GetFluxNamespaceRequest) ProtoMessage() {}
 
-func (x *ListObjectsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[22]
+func (x *GetFluxNamespaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1407,62 +3197,76 @@ func (x *ListObjectsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListObjectsRequest.ProtoReflect.Descriptor instead.
-func (*ListObjectsRequest) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use GetFluxNamespaceRequest.ProtoReflect.Descriptor instead.
+func (*GetFluxNamespaceRequest) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *ListObjectsRequest) GetNamespace() string {
-	if x != nil {
-		return x.Namespace
-	}
-	return ""
+type GetFluxNamespaceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListObjectsRequest) GetKind() string {
-	if x != nil {
-		return x.Kind
-	}
-	return ""
+func (x *GetFluxNamespaceResponse) Reset() {
+	*x = GetFluxNamespaceResponse{}
+	mi := &file_api_core_core_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListObjectsRequest) GetClusterName() string {
+func (x *GetFluxNamespaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFluxNamespaceResponse) ProtoMessage() {}
+
+func (x *GetFluxNamespaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[50]
 	if x != nil {
-		return x.ClusterName
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *ListObjectsRequest) GetLabels() map[string]string {
+// Deprecated: Use GetFluxNamespaceResponse.ProtoReflect.Descriptor instead.
+func (*GetFluxNamespaceResponse) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetFluxNamespaceResponse) GetName() string {
 	if x != nil {
-		return x.Labels
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-type ClusterNamespaceList struct {
+type ListNamespacesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ClusterName   string                 `protobuf:"bytes,1,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
-	Namespaces    []string               `protobuf:"bytes,2,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ClusterNamespaceList) Reset() {
-	*x = ClusterNamespaceList{}
-	mi := &file_api_core_core_proto_msgTypes[23]
+func (x *ListNamespacesRequest) Reset() {
+	*x = ListNamespacesRequest{}
+	mi := &file_api_core_core_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ClusterNamespaceList) String() string {
+func (x *ListNamespacesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClusterNamespaceList) ProtoMessage() {}
+func (*ListNamespacesRequest) ProtoMessage() {}
 
-func (x *ClusterNamespaceList) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[23]
+func (x *ListNamespacesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1472,50 +3276,34 @@ func (x *ClusterNamespaceList) ProtoReflect() protoreflect.Message {
 	}
 	return mi.MessageOf(x)
 }
-
-// Deprecated: Use ClusterNamespaceList.ProtoReflect.Descriptor instead.
-func (*ClusterNamespaceList) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{23}
-}
-
-func (x *ClusterNamespaceList) GetClusterName() string {
-	if x != nil {
-		return x.ClusterName
-	}
-	return ""
-}
-
-func (x *ClusterNamespaceList) GetNamespaces() []string {
-	if x != nil {
-		return x.Namespaces
-	}
-	return nil
+
+// Deprecated: Use ListNamespacesRequest.ProtoReflect.Descriptor instead.
+func (*ListNamespacesRequest) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{51}
 }
 
-type ListObjectsResponse struct {
-	state              protoimpl.MessageState  `protogen:"open.v1"`
-	Objects            []*Object               `protobuf:"bytes,1,rep,name=objects,proto3" json:"objects,omitempty"`
-	Errors             []*ListError            `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
-	SearchedNamespaces []*ClusterNamespaceList `protobuf:"bytes,3,rep,name=searched_namespaces,json=searchedNamespaces,proto3" json:"searched_namespaces,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+type ListNamespacesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespaces    []*Namespace           `protobuf:"bytes,1,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListObjectsResponse) Reset() {
-	*x = ListObjectsResponse{}
-	mi := &file_api_core_core_proto_msgTypes[24]
+func (x *ListNamespacesResponse) Reset() {
+	*x = ListNamespacesResponse{}
+	mi := &file_api_core_core_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListObjectsResponse) String() string {
+func (x *ListNamespacesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListObjectsResponse) ProtoMessage() {}
+func (*ListNamespacesResponse) ProtoMessage() {}
 
-func (x *ListObjectsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[24]
+func (x *ListNamespacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1526,58 +3314,40 @@ func (x *ListObjectsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListObjectsResponse.ProtoReflect.Descriptor instead.
-func (*ListObjectsResponse) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{24}
-}
-
-func (x *ListObjectsResponse) GetObjects() []*Object {
-	if x != nil {
-		return x.Objects
-	}
-	return nil
-}
-
-func (x *ListObjectsResponse) GetErrors() []*ListError {
-	if x != nil {
-		return x.Errors
-	}
-	return nil
+// Deprecated: Use ListNamespacesResponse.ProtoReflect.Descriptor instead.
+func (*ListNamespacesResponse) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *ListObjectsResponse) GetSearchedNamespaces() []*ClusterNamespaceList {
+func (x *ListNamespacesResponse) GetNamespaces() []*Namespace {
 	if x != nil {
-		return x.SearchedNamespaces
+		return x.Namespaces
 	}
 	return nil
 }
 
-type GetReconciledObjectsRequest struct {
+type ListEventsRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
-	AutomationName string                 `protobuf:"bytes,1,opt,name=automation_name,json=automationName,proto3" json:"automation_name,omitempty"`
-	Namespace      string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	AutomationKind string                 `protobuf:"bytes,3,opt,name=automation_kind,json=automationKind,proto3" json:"automation_kind,omitempty"`
-	Kinds          []*GroupVersionKind    `protobuf:"bytes,4,rep,name=kinds,proto3" json:"kinds,omitempty"`
-	ClusterName    string                 `protobuf:"bytes,5,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	InvolvedObject *ObjectRef             `protobuf:"bytes,1,opt,name=involved_object,json=involvedObject,proto3" json:"involved_object,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
-func (x *GetReconciledObjectsRequest) Reset() {
-	*x = GetReconciledObjectsRequest{}
-	mi := &file_api_core_core_proto_msgTypes[25]
+func (x *ListEventsRequest) Reset() {
+	*x = ListEventsRequest{}
+	mi := &file_api_core_core_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetReconciledObjectsRequest) String() string {
+func (x *ListEventsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetReconciledObjectsRequest) ProtoMessage() {}
+func (*ListEventsRequest) ProtoMessage() {}
 
-func (x *GetReconciledObjectsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[25]
+func (x *ListEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1588,68 +3358,40 @@ func (x *GetReconciledObjectsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetReconciledObjectsRequest.ProtoReflect.Descriptor instead.
-func (*GetReconciledObjectsRequest) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{25}
-}
-
-func (x *GetReconciledObjectsRequest) GetAutomationName() string {
-	if x != nil {
-		return x.AutomationName
-	}
-	return ""
-}
-
-func (x *GetReconciledObjectsRequest) GetNamespace() string {
-	if x != nil {
-		return x.Namespace
-	}
-	return ""
-}
-
-func (x *GetReconciledObjectsRequest) GetAutomationKind() string {
-	if x != nil {
-		return x.AutomationKind
-	}
-	return ""
+// Deprecated: Use ListEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListEventsRequest) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *GetReconciledObjectsRequest) GetKinds() []*GroupVersionKind {
+func (x *ListEventsRequest) GetInvolvedObject() *ObjectRef {
 	if x != nil {
-		return x.Kinds
+		return x.InvolvedObject
 	}
 	return nil
 }
 
-func (x *GetReconciledObjectsRequest) GetClusterName() string {
-	if x != nil {
-		return x.ClusterName
-	}
-	return ""
-}
-
-type GetReconciledObjectsResponse struct {
+type ListEventsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Objects       []*Object              `protobuf:"bytes,1,rep,name=objects,proto3" json:"objects,omitempty"`
+	Events        []*Event               `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetReconciledObjectsResponse) Reset() {
-	*x = GetReconciledObjectsResponse{}
-	mi := &file_api_core_core_proto_msgTypes[26]
+func (x *ListEventsResponse) Reset() {
+	*x = ListEventsResponse{}
+	mi := &file_api_core_core_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetReconciledObjectsResponse) String() string {
+func (x *ListEventsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetReconciledObjectsResponse) ProtoMessage() {}
+func (*ListEventsResponse) ProtoMessage() {}
 
-func (x *GetReconciledObjectsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[26]
+func (x *ListEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1660,43 +3402,48 @@ func (x *GetReconciledObjectsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetReconciledObjectsResponse.ProtoReflect.Descriptor instead.
-func (*GetReconciledObjectsResponse) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use ListEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListEventsResponse) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *GetReconciledObjectsResponse) GetObjects() []*Object {
+func (x *ListEventsResponse) GetEvents() []*Event {
 	if x != nil {
-		return x.Objects
+		return x.Events
 	}
 	return nil
 }
 
-type GetChildObjectsRequest struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	GroupVersionKind *GroupVersionKind      `protobuf:"bytes,1,opt,name=group_version_kind,json=groupVersionKind,proto3" json:"group_version_kind,omitempty"`
-	Namespace        string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	ParentUid        string                 `protobuf:"bytes,3,opt,name=parent_uid,json=parentUid,proto3" json:"parent_uid,omitempty"`
-	ClusterName      string                 `protobuf:"bytes,4,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+type SyncFluxObjectRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Objects    []*ObjectRef           `protobuf:"bytes,1,rep,name=objects,proto3" json:"objects,omitempty"`
+	WithSource bool                   `protobuf:"varint,2,opt,name=with_source,json=withSource,proto3" json:"with_source,omitempty"`
+	// with_dependencies syncs the objects' sources and the automations they
+	// depend on first, in dependency order, reporting each object's result.
+	WithDependencies bool `protobuf:"varint,3,opt,name=with_dependencies,json=withDependencies,proto3" json:"with_dependencies,omitempty"`
+	// async returns an operation id straight away instead of waiting for
+	// the objects to be synced, see GetOperation. Operations are owned by
+	// the user's ID, so users without one can't start them.
+	Async         bool `protobuf:"varint,4,opt,name=async,proto3" json:"async,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetChildObjectsRequest) Reset() {
-	*x = GetChildObjectsRequest{}
-	mi := &file_api_core_core_proto_msgTypes[27]
+func (x *SyncFluxObjectRequest) Reset() {
+	*x = SyncFluxObjectRequest{}
+	mi := &file_api_core_core_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetChildObjectsRequest) String() string {
+func (x *SyncFluxObjectRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetChildObjectsRequest) ProtoMessage() {}
+func (*SyncFluxObjectRequest) ProtoMessage() {}
 
-func (x *GetChildObjectsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[27]
+func (x *SyncFluxObjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1707,61 +3454,62 @@ func (x *GetChildObjectsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetChildObjectsRequest.ProtoReflect.Descriptor instead.
-func (*GetChildObjectsRequest) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use SyncFluxObjectRequest.ProtoReflect.Descriptor instead.
+func (*SyncFluxObjectRequest) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *GetChildObjectsRequest) GetGroupVersionKind() *GroupVersionKind {
+func (x *SyncFluxObjectRequest) GetObjects() []*ObjectRef {
 	if x != nil {
-		return x.GroupVersionKind
+		return x.Objects
 	}
 	return nil
 }
 
-func (x *GetChildObjectsRequest) GetNamespace() string {
+func (x *SyncFluxObjectRequest) GetWithSource() bool {
 	if x != nil {
-		return x.Namespace
+		return x.WithSource
 	}
-	return ""
+	return false
 }
 
-func (x *GetChildObjectsRequest) GetParentUid() string {
+func (x *SyncFluxObjectRequest) GetWithDependencies() bool {
 	if x != nil {
-		return x.ParentUid
+		return x.WithDependencies
 	}
-	return ""
+	return false
 }
 
-func (x *GetChildObjectsRequest) GetClusterName() string {
+func (x *SyncFluxObjectRequest) GetAsync() bool {
 	if x != nil {
-		return x.ClusterName
+		return x.Async
 	}
-	return ""
+	return false
 }
 
-type GetChildObjectsResponse struct {
+type SyncFluxObjectResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Objects       []*Object              `protobuf:"bytes,1,rep,name=objects,proto3" json:"objects,omitempty"`
+	Results       []*ObjectResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	OperationId   string                 `protobuf:"bytes,2,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetChildObjectsResponse) Reset() {
-	*x = GetChildObjectsResponse{}
-	mi := &file_api_core_core_proto_msgTypes[28]
+func (x *SyncFluxObjectResponse) Reset() {
+	*x = SyncFluxObjectResponse{}
+	mi := &file_api_core_core_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetChildObjectsResponse) String() string {
+func (x *SyncFluxObjectResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetChildObjectsResponse) ProtoMessage() {}
+func (*SyncFluxObjectResponse) ProtoMessage() {}
 
-func (x *GetChildObjectsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[28]
+func (x *SyncFluxObjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1772,76 +3520,52 @@ func (x *GetChildObjectsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetChildObjectsResponse.ProtoReflect.Descriptor instead.
-func (*GetChildObjectsResponse) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use SyncFluxObjectResponse.ProtoReflect.Descriptor instead.
+func (*SyncFluxObjectResponse) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *GetChildObjectsResponse) GetObjects() []*Object {
+func (x *SyncFluxObjectResponse) GetResults() []*ObjectResult {
 	if x != nil {
-		return x.Objects
+		return x.Results
 	}
 	return nil
 }
 
-type GetFluxNamespaceRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetFluxNamespaceRequest) Reset() {
-	*x = GetFluxNamespaceRequest{}
-	mi := &file_api_core_core_proto_msgTypes[29]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetFluxNamespaceRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetFluxNamespaceRequest) ProtoMessage() {}
-
-func (x *GetFluxNamespaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[29]
+func (x *SyncFluxObjectResponse) GetOperationId() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.OperationId
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetFluxNamespaceRequest.ProtoReflect.Descriptor instead.
-func (*GetFluxNamespaceRequest) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{29}
+	return ""
 }
 
-type GetFluxNamespaceResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+// OperationObject is the progress of an object in an operation.
+type OperationObject struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// result.status is Pending until the object has been processed
+	Result         *ObjectResult `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	ReadyCondition *Condition    `protobuf:"bytes,2,opt,name=ready_condition,json=readyCondition,proto3" json:"ready_condition,omitempty"`
+	// events are the events recorded for the object during the operation
+	Events        []*Event `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetFluxNamespaceResponse) Reset() {
-	*x = GetFluxNamespaceResponse{}
-	mi := &file_api_core_core_proto_msgTypes[30]
+func (x *OperationObject) Reset() {
+	*x = OperationObject{}
+	mi := &file_api_core_core_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetFluxNamespaceResponse) String() string {
+func (x *OperationObject) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetFluxNamespaceResponse) ProtoMessage() {}
+func (*OperationObject) ProtoMessage() {}
 
-func (x *GetFluxNamespaceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[30]
+func (x *OperationObject) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1852,39 +3576,63 @@ func (x *GetFluxNamespaceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetFluxNamespaceResponse.ProtoReflect.Descriptor instead.
-func (*GetFluxNamespaceResponse) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use OperationObject.ProtoReflect.Descriptor instead.
+func (*OperationObject) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *GetFluxNamespaceResponse) GetName() string {
+func (x *OperationObject) GetResult() *ObjectResult {
 	if x != nil {
-		return x.Name
+		return x.Result
 	}
-	return ""
+	return nil
 }
 
-type ListNamespacesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+func (x *OperationObject) GetReadyCondition() *Condition {
+	if x != nil {
+		return x.ReadyCondition
+	}
+	return nil
+}
+
+func (x *OperationObject) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type Operation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type  string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// status is one of Running, Succeeded or Failed
+	Status     string             `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedBy  string             `protobuf:"bytes,4,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	StartedAt  string             `protobuf:"bytes,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt string             `protobuf:"bytes,6,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	Objects    []*OperationObject `protobuf:"bytes,7,rep,name=objects,proto3" json:"objects,omitempty"`
+	// completed is how many of the objects have been processed
+	Completed     int32 `protobuf:"varint,8,opt,name=completed,proto3" json:"completed,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListNamespacesRequest) Reset() {
-	*x = ListNamespacesRequest{}
-	mi := &file_api_core_core_proto_msgTypes[31]
+func (x *Operation) Reset() {
+	*x = Operation{}
+	mi := &file_api_core_core_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListNamespacesRequest) String() string {
+func (x *Operation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListNamespacesRequest) ProtoMessage() {}
+func (*Operation) ProtoMessage() {}
 
-func (x *ListNamespacesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[31]
+func (x *Operation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1895,77 +3643,89 @@ func (x *ListNamespacesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListNamespacesRequest.ProtoReflect.Descriptor instead.
-func (*ListNamespacesRequest) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use Operation.ProtoReflect.Descriptor instead.
+func (*Operation) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{58}
 }
 
-type ListNamespacesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Namespaces    []*Namespace           `protobuf:"bytes,1,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Operation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
 }
 
-func (x *ListNamespacesResponse) Reset() {
-	*x = ListNamespacesResponse{}
-	mi := &file_api_core_core_proto_msgTypes[32]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Operation) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
 }
 
-func (x *ListNamespacesResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Operation) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
 }
 
-func (*ListNamespacesResponse) ProtoMessage() {}
+func (x *Operation) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
 
-func (x *ListNamespacesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[32]
+func (x *Operation) GetStartedAt() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.StartedAt
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use ListNamespacesResponse.ProtoReflect.Descriptor instead.
-func (*ListNamespacesResponse) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{32}
+func (x *Operation) GetFinishedAt() string {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return ""
 }
 
-func (x *ListNamespacesResponse) GetNamespaces() []*Namespace {
+func (x *Operation) GetObjects() []*OperationObject {
 	if x != nil {
-		return x.Namespaces
+		return x.Objects
 	}
 	return nil
 }
 
-type ListEventsRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	InvolvedObject *ObjectRef             `protobuf:"bytes,1,opt,name=involved_object,json=involvedObject,proto3" json:"involved_object,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *Operation) GetCompleted() int32 {
+	if x != nil {
+		return x.Completed
+	}
+	return 0
 }
 
-func (x *ListEventsRequest) Reset() {
-	*x = ListEventsRequest{}
-	mi := &file_api_core_core_proto_msgTypes[33]
+type GetOperationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOperationRequest) Reset() {
+	*x = GetOperationRequest{}
+	mi := &file_api_core_core_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListEventsRequest) String() string {
+func (x *GetOperationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListEventsRequest) ProtoMessage() {}
+func (*GetOperationRequest) ProtoMessage() {}
 
-func (x *ListEventsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[33]
+func (x *GetOperationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1976,40 +3736,40 @@ func (x *ListEventsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListEventsRequest.ProtoReflect.Descriptor instead.
-func (*ListEventsRequest) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use GetOperationRequest.ProtoReflect.Descriptor instead.
+func (*GetOperationRequest) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *ListEventsRequest) GetInvolvedObject() *ObjectRef {
+func (x *GetOperationRequest) GetId() string {
 	if x != nil {
-		return x.InvolvedObject
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-type ListEventsResponse struct {
+type GetOperationResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Events        []*Event               `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	Operation     *Operation             `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListEventsResponse) Reset() {
-	*x = ListEventsResponse{}
-	mi := &file_api_core_core_proto_msgTypes[34]
+func (x *GetOperationResponse) Reset() {
+	*x = GetOperationResponse{}
+	mi := &file_api_core_core_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListEventsResponse) String() string {
+func (x *GetOperationResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListEventsResponse) ProtoMessage() {}
+func (*GetOperationResponse) ProtoMessage() {}
 
-func (x *ListEventsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[34]
+func (x *GetOperationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2020,41 +3780,39 @@ func (x *ListEventsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListEventsResponse.ProtoReflect.Descriptor instead.
-func (*ListEventsResponse) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{34}
+// Deprecated: Use GetOperationResponse.ProtoReflect.Descriptor instead.
+func (*GetOperationResponse) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *ListEventsResponse) GetEvents() []*Event {
+func (x *GetOperationResponse) GetOperation() *Operation {
 	if x != nil {
-		return x.Events
+		return x.Operation
 	}
 	return nil
 }
 
-type SyncFluxObjectRequest struct {
+type ListOperationsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Objects       []*ObjectRef           `protobuf:"bytes,1,rep,name=objects,proto3" json:"objects,omitempty"`
-	WithSource    bool                   `protobuf:"varint,2,opt,name=with_source,json=withSource,proto3" json:"with_source,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SyncFluxObjectRequest) Reset() {
-	*x = SyncFluxObjectRequest{}
-	mi := &file_api_core_core_proto_msgTypes[35]
+func (x *ListOperationsRequest) Reset() {
+	*x = ListOperationsRequest{}
+	mi := &file_api_core_core_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SyncFluxObjectRequest) String() string {
+func (x *ListOperationsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SyncFluxObjectRequest) ProtoMessage() {}
+func (*ListOperationsRequest) ProtoMessage() {}
 
-func (x *SyncFluxObjectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[35]
+func (x *ListOperationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2065,46 +3823,33 @@ func (x *SyncFluxObjectRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SyncFluxObjectRequest.ProtoReflect.Descriptor instead.
-func (*SyncFluxObjectRequest) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{35}
-}
-
-func (x *SyncFluxObjectRequest) GetObjects() []*ObjectRef {
-	if x != nil {
-		return x.Objects
-	}
-	return nil
-}
-
-func (x *SyncFluxObjectRequest) GetWithSource() bool {
-	if x != nil {
-		return x.WithSource
-	}
-	return false
+// Deprecated: Use ListOperationsRequest.ProtoReflect.Descriptor instead.
+func (*ListOperationsRequest) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{61}
 }
 
-type SyncFluxObjectResponse struct {
+type ListOperationsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operations    []*Operation           `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SyncFluxObjectResponse) Reset() {
-	*x = SyncFluxObjectResponse{}
-	mi := &file_api_core_core_proto_msgTypes[36]
+func (x *ListOperationsResponse) Reset() {
+	*x = ListOperationsResponse{}
+	mi := &file_api_core_core_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SyncFluxObjectResponse) String() string {
+func (x *ListOperationsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SyncFluxObjectResponse) ProtoMessage() {}
+func (*ListOperationsResponse) ProtoMessage() {}
 
-func (x *SyncFluxObjectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[36]
+func (x *ListOperationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_core_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2115,9 +3860,16 @@ func (x *SyncFluxObjectResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SyncFluxObjectResponse.ProtoReflect.Descriptor instead.
-func (*SyncFluxObjectResponse) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{36}
+// Deprecated: Use ListOperationsResponse.ProtoReflect.Descriptor instead.
+func (*ListOperationsResponse) Descriptor() ([]byte, []int) {
+	return file_api_core_core_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ListOperationsResponse) GetOperations() []*Operation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
 }
 
 type GetVersionRequest struct {
@@ -2128,7 +3880,7 @@ type GetVersionRequest struct {
 
 func (x *GetVersionRequest) Reset() {
 	*x = GetVersionRequest{}
-	mi := &file_api_core_core_proto_msgTypes[37]
+	mi := &file_api_core_core_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2140,7 +3892,7 @@ func (x *GetVersionRequest) String() string {
 func (*GetVersionRequest) ProtoMessage() {}
 
 func (x *GetVersionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[37]
+	mi := &file_api_core_core_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2153,7 +3905,7 @@ func (x *GetVersionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetVersionRequest.ProtoReflect.Descriptor instead.
 func (*GetVersionRequest) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{37}
+	return file_api_core_core_proto_rawDescGZIP(), []int{63}
 }
 
 type GetVersionResponse struct {
@@ -2169,7 +3921,7 @@ type GetVersionResponse struct {
 
 func (x *GetVersionResponse) Reset() {
 	*x = GetVersionResponse{}
-	mi := &file_api_core_core_proto_msgTypes[38]
+	mi := &file_api_core_core_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2181,7 +3933,7 @@ func (x *GetVersionResponse) String() string {
 func (*GetVersionResponse) ProtoMessage() {}
 
 func (x *GetVersionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[38]
+	mi := &file_api_core_core_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2194,7 +3946,7 @@ func (x *GetVersionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetVersionResponse.ProtoReflect.Descriptor instead.
 func (*GetVersionResponse) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{38}
+	return file_api_core_core_proto_rawDescGZIP(), []int{64}
 }
 
 func (x *GetVersionResponse) GetSemver() string {
@@ -2240,7 +3992,7 @@ type GetFeatureFlagsRequest struct {
 
 func (x *GetFeatureFlagsRequest) Reset() {
 	*x = GetFeatureFlagsRequest{}
-	mi := &file_api_core_core_proto_msgTypes[39]
+	mi := &file_api_core_core_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2252,7 +4004,7 @@ func (x *GetFeatureFlagsRequest) String() string {
 func (*GetFeatureFlagsRequest) ProtoMessage() {}
 
 func (x *GetFeatureFlagsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[39]
+	mi := &file_api_core_core_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2265,7 +4017,7 @@ func (x *GetFeatureFlagsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFeatureFlagsRequest.ProtoReflect.Descriptor instead.
 func (*GetFeatureFlagsRequest) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{39}
+	return file_api_core_core_proto_rawDescGZIP(), []int{65}
 }
 
 type GetFeatureFlagsResponse struct {
@@ -2277,7 +4029,7 @@ type GetFeatureFlagsResponse struct {
 
 func (x *GetFeatureFlagsResponse) Reset() {
 	*x = GetFeatureFlagsResponse{}
-	mi := &file_api_core_core_proto_msgTypes[40]
+	mi := &file_api_core_core_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2289,7 +4041,7 @@ func (x *GetFeatureFlagsResponse) String() string {
 func (*GetFeatureFlagsResponse) ProtoMessage() {}
 
 func (x *GetFeatureFlagsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[40]
+	mi := &file_api_core_core_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2302,7 +4054,7 @@ func (x *GetFeatureFlagsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFeatureFlagsResponse.ProtoReflect.Descriptor instead.
 func (*GetFeatureFlagsResponse) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{40}
+	return file_api_core_core_proto_rawDescGZIP(), []int{66}
 }
 
 func (x *GetFeatureFlagsResponse) GetFlags() map[string]string {
@@ -2323,7 +4075,7 @@ type ToggleSuspendResourceRequest struct {
 
 func (x *ToggleSuspendResourceRequest) Reset() {
 	*x = ToggleSuspendResourceRequest{}
-	mi := &file_api_core_core_proto_msgTypes[41]
+	mi := &file_api_core_core_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2335,7 +4087,7 @@ func (x *ToggleSuspendResourceRequest) String() string {
 func (*ToggleSuspendResourceRequest) ProtoMessage() {}
 
 func (x *ToggleSuspendResourceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[41]
+	mi := &file_api_core_core_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2348,7 +4100,7 @@ func (x *ToggleSuspendResourceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ToggleSuspendResourceRequest.ProtoReflect.Descriptor instead.
 func (*ToggleSuspendResourceRequest) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{41}
+	return file_api_core_core_proto_rawDescGZIP(), []int{67}
 }
 
 func (x *ToggleSuspendResourceRequest) GetObjects() []*ObjectRef {
@@ -2374,13 +4126,14 @@ func (x *ToggleSuspendResourceRequest) GetComment() string {
 
 type ToggleSuspendResourceResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*ObjectResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ToggleSuspendResourceResponse) Reset() {
 	*x = ToggleSuspendResourceResponse{}
-	mi := &file_api_core_core_proto_msgTypes[42]
+	mi := &file_api_core_core_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2392,7 +4145,7 @@ func (x *ToggleSuspendResourceResponse) String() string {
 func (*ToggleSuspendResourceResponse) ProtoMessage() {}
 
 func (x *ToggleSuspendResourceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[42]
+	mi := &file_api_core_core_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2405,7 +4158,14 @@ func (x *ToggleSuspendResourceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ToggleSuspendResourceResponse.ProtoReflect.Descriptor instead.
 func (*ToggleSuspendResourceResponse) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{42}
+	return file_api_core_core_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ToggleSuspendResourceResponse) GetResults() []*ObjectResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
 }
 
 type GetSessionLogsRequest struct {
@@ -2421,7 +4181,7 @@ type GetSessionLogsRequest struct {
 
 func (x *GetSessionLogsRequest) Reset() {
 	*x = GetSessionLogsRequest{}
-	mi := &file_api_core_core_proto_msgTypes[43]
+	mi := &file_api_core_core_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2433,7 +4193,7 @@ func (x *GetSessionLogsRequest) String() string {
 func (*GetSessionLogsRequest) ProtoMessage() {}
 
 func (x *GetSessionLogsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[43]
+	mi := &file_api_core_core_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2446,7 +4206,7 @@ func (x *GetSessionLogsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSessionLogsRequest.ProtoReflect.Descriptor instead.
 func (*GetSessionLogsRequest) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{43}
+	return file_api_core_core_proto_rawDescGZIP(), []int{69}
 }
 
 func (x *GetSessionLogsRequest) GetSessionNamespace() string {
@@ -2497,7 +4257,7 @@ type LogEntry struct {
 
 func (x *LogEntry) Reset() {
 	*x = LogEntry{}
-	mi := &file_api_core_core_proto_msgTypes[44]
+	mi := &file_api_core_core_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2509,7 +4269,7 @@ func (x *LogEntry) String() string {
 func (*LogEntry) ProtoMessage() {}
 
 func (x *LogEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[44]
+	mi := &file_api_core_core_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2522,7 +4282,7 @@ func (x *LogEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogEntry.ProtoReflect.Descriptor instead.
 func (*LogEntry) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{44}
+	return file_api_core_core_proto_rawDescGZIP(), []int{70}
 }
 
 func (x *LogEntry) GetTimestamp() string {
@@ -2572,7 +4332,7 @@ type GetSessionLogsResponse struct {
 
 func (x *GetSessionLogsResponse) Reset() {
 	*x = GetSessionLogsResponse{}
-	mi := &file_api_core_core_proto_msgTypes[45]
+	mi := &file_api_core_core_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2584,7 +4344,7 @@ func (x *GetSessionLogsResponse) String() string {
 func (*GetSessionLogsResponse) ProtoMessage() {}
 
 func (x *GetSessionLogsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[45]
+	mi := &file_api_core_core_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2597,7 +4357,7 @@ func (x *GetSessionLogsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSessionLogsResponse.ProtoReflect.Descriptor instead.
 func (*GetSessionLogsResponse) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{45}
+	return file_api_core_core_proto_rawDescGZIP(), []int{71}
 }
 
 func (x *GetSessionLogsResponse) GetLogs() []*LogEntry {
@@ -2637,7 +4397,7 @@ type IsCRDAvailableRequest struct {
 
 func (x *IsCRDAvailableRequest) Reset() {
 	*x = IsCRDAvailableRequest{}
-	mi := &file_api_core_core_proto_msgTypes[46]
+	mi := &file_api_core_core_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2649,7 +4409,7 @@ func (x *IsCRDAvailableRequest) String() string {
 func (*IsCRDAvailableRequest) ProtoMessage() {}
 
 func (x *IsCRDAvailableRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[46]
+	mi := &file_api_core_core_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2662,7 +4422,7 @@ func (x *IsCRDAvailableRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IsCRDAvailableRequest.ProtoReflect.Descriptor instead.
 func (*IsCRDAvailableRequest) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{46}
+	return file_api_core_core_proto_rawDescGZIP(), []int{72}
 }
 
 func (x *IsCRDAvailableRequest) GetName() string {
@@ -2681,7 +4441,7 @@ type IsCRDAvailableResponse struct {
 
 func (x *IsCRDAvailableResponse) Reset() {
 	*x = IsCRDAvailableResponse{}
-	mi := &file_api_core_core_proto_msgTypes[47]
+	mi := &file_api_core_core_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2693,7 +4453,7 @@ func (x *IsCRDAvailableResponse) String() string {
 func (*IsCRDAvailableResponse) ProtoMessage() {}
 
 func (x *IsCRDAvailableResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[47]
+	mi := &file_api_core_core_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2706,7 +4466,7 @@ func (x *IsCRDAvailableResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IsCRDAvailableResponse.ProtoReflect.Descriptor instead.
 func (*IsCRDAvailableResponse) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{47}
+	return file_api_core_core_proto_rawDescGZIP(), []int{73}
 }
 
 func (x *IsCRDAvailableResponse) GetClusters() map[string]bool {
@@ -2726,7 +4486,7 @@ type ListPoliciesRequest struct {
 
 func (x *ListPoliciesRequest) Reset() {
 	*x = ListPoliciesRequest{}
-	mi := &file_api_core_core_proto_msgTypes[48]
+	mi := &file_api_core_core_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2738,7 +4498,7 @@ func (x *ListPoliciesRequest) String() string {
 func (*ListPoliciesRequest) ProtoMessage() {}
 
 func (x *ListPoliciesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[48]
+	mi := &file_api_core_core_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2751,7 +4511,7 @@ func (x *ListPoliciesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPoliciesRequest.ProtoReflect.Descriptor instead.
 func (*ListPoliciesRequest) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{48}
+	return file_api_core_core_proto_rawDescGZIP(), []int{74}
 }
 
 func (x *ListPoliciesRequest) GetClusterName() string {
@@ -2780,7 +4540,7 @@ type ListPoliciesResponse struct {
 
 func (x *ListPoliciesResponse) Reset() {
 	*x = ListPoliciesResponse{}
-	mi := &file_api_core_core_proto_msgTypes[49]
+	mi := &file_api_core_core_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2792,7 +4552,7 @@ func (x *ListPoliciesResponse) String() string {
 func (*ListPoliciesResponse) ProtoMessage() {}
 
 func (x *ListPoliciesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[49]
+	mi := &file_api_core_core_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2805,7 +4565,7 @@ func (x *ListPoliciesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPoliciesResponse.ProtoReflect.Descriptor instead.
 func (*ListPoliciesResponse) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{49}
+	return file_api_core_core_proto_rawDescGZIP(), []int{75}
 }
 
 func (x *ListPoliciesResponse) GetPolicies() []*PolicyObj {
@@ -2846,7 +4606,7 @@ type GetPolicyRequest struct {
 
 func (x *GetPolicyRequest) Reset() {
 	*x = GetPolicyRequest{}
-	mi := &file_api_core_core_proto_msgTypes[50]
+	mi := &file_api_core_core_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2858,7 +4618,7 @@ func (x *GetPolicyRequest) String() string {
 func (*GetPolicyRequest) ProtoMessage() {}
 
 func (x *GetPolicyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[50]
+	mi := &file_api_core_core_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2871,7 +4631,7 @@ func (x *GetPolicyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPolicyRequest.ProtoReflect.Descriptor instead.
 func (*GetPolicyRequest) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{50}
+	return file_api_core_core_proto_rawDescGZIP(), []int{76}
 }
 
 func (x *GetPolicyRequest) GetPolicyName() string {
@@ -2898,7 +4658,7 @@ type GetPolicyResponse struct {
 
 func (x *GetPolicyResponse) Reset() {
 	*x = GetPolicyResponse{}
-	mi := &file_api_core_core_proto_msgTypes[51]
+	mi := &file_api_core_core_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2910,7 +4670,7 @@ func (x *GetPolicyResponse) String() string {
 func (*GetPolicyResponse) ProtoMessage() {}
 
 func (x *GetPolicyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[51]
+	mi := &file_api_core_core_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2923,7 +4683,7 @@ func (x *GetPolicyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPolicyResponse.ProtoReflect.Descriptor instead.
 func (*GetPolicyResponse) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{51}
+	return file_api_core_core_proto_rawDescGZIP(), []int{77}
 }
 
 func (x *GetPolicyResponse) GetPolicy() *PolicyObj {
@@ -2964,7 +4724,7 @@ type PolicyObj struct {
 
 func (x *PolicyObj) Reset() {
 	*x = PolicyObj{}
-	mi := &file_api_core_core_proto_msgTypes[52]
+	mi := &file_api_core_core_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2976,7 +4736,7 @@ func (x *PolicyObj) String() string {
 func (*PolicyObj) ProtoMessage() {}
 
 func (x *PolicyObj) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[52]
+	mi := &file_api_core_core_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2989,7 +4749,7 @@ func (x *PolicyObj) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PolicyObj.ProtoReflect.Descriptor instead.
 func (*PolicyObj) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{52}
+	return file_api_core_core_proto_rawDescGZIP(), []int{78}
 }
 
 func (x *PolicyObj) GetName() string {
@@ -3114,7 +4874,7 @@ type PolicyStandard struct {
 
 func (x *PolicyStandard) Reset() {
 	*x = PolicyStandard{}
-	mi := &file_api_core_core_proto_msgTypes[53]
+	mi := &file_api_core_core_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3126,7 +4886,7 @@ func (x *PolicyStandard) String() string {
 func (*PolicyStandard) ProtoMessage() {}
 
 func (x *PolicyStandard) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[53]
+	mi := &file_api_core_core_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3139,7 +4899,7 @@ func (x *PolicyStandard) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PolicyStandard.ProtoReflect.Descriptor instead.
 func (*PolicyStandard) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{53}
+	return file_api_core_core_proto_rawDescGZIP(), []int{79}
 }
 
 func (x *PolicyStandard) GetId() string {
@@ -3170,7 +4930,7 @@ type PolicyParam struct {
 
 func (x *PolicyParam) Reset() {
 	*x = PolicyParam{}
-	mi := &file_api_core_core_proto_msgTypes[54]
+	mi := &file_api_core_core_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3182,7 +4942,7 @@ func (x *PolicyParam) String() string {
 func (*PolicyParam) ProtoMessage() {}
 
 func (x *PolicyParam) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[54]
+	mi := &file_api_core_core_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3195,7 +4955,7 @@ func (x *PolicyParam) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PolicyParam.ProtoReflect.Descriptor instead.
 func (*PolicyParam) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{54}
+	return file_api_core_core_proto_rawDescGZIP(), []int{80}
 }
 
 func (x *PolicyParam) GetName() string {
@@ -3237,7 +4997,7 @@ type PolicyTargets struct {
 
 func (x *PolicyTargets) Reset() {
 	*x = PolicyTargets{}
-	mi := &file_api_core_core_proto_msgTypes[55]
+	mi := &file_api_core_core_proto_msgTypes[81]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3249,7 +5009,7 @@ func (x *PolicyTargets) String() string {
 func (*PolicyTargets) ProtoMessage() {}
 
 func (x *PolicyTargets) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[55]
+	mi := &file_api_core_core_proto_msgTypes[81]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3262,7 +5022,7 @@ func (x *PolicyTargets) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PolicyTargets.ProtoReflect.Descriptor instead.
 func (*PolicyTargets) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{55}
+	return file_api_core_core_proto_rawDescGZIP(), []int{81}
 }
 
 func (x *PolicyTargets) GetKinds() []string {
@@ -3295,7 +5055,7 @@ type PolicyTargetLabel struct {
 
 func (x *PolicyTargetLabel) Reset() {
 	*x = PolicyTargetLabel{}
-	mi := &file_api_core_core_proto_msgTypes[56]
+	mi := &file_api_core_core_proto_msgTypes[82]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3307,7 +5067,7 @@ func (x *PolicyTargetLabel) String() string {
 func (*PolicyTargetLabel) ProtoMessage() {}
 
 func (x *PolicyTargetLabel) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_core_proto_msgTypes[56]
+	mi := &file_api_core_core_proto_msgTypes[82]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3320,7 +5080,7 @@ func (x *PolicyTargetLabel) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PolicyTargetLabel.ProtoReflect.Descriptor instead.
 func (*PolicyTargetLabel) Descriptor() ([]byte, []int) {
-	return file_api_core_core_proto_rawDescGZIP(), []int{56}
+	return file_api_core_core_proto_rawDescGZIP(), []int{82}
 }
 
 func (x *PolicyTargetLabel) GetValues() map[string]string {
@@ -3342,7 +5102,57 @@ const file_api_core_core_proto_rawDesc = "" +
 	"\fcluster_name\x18\x04 \x01(\tR\vclusterName\x12#\n" +
 	"\rwith_children\x18\x05 \x01(\bR\fwithChildren\"P\n" +
 	"\x14GetInventoryResponse\x128\n" +
-	"\aentries\x18\x01 \x03(\v2\x1e.gitops_core.v1.InventoryEntryR\aentries\"\xe1\x04\n" +
+	"\aentries\x18\x01 \x03(\v2\x1e.gitops_core.v1.InventoryEntryR\aentries\"\\\n" +
+	"\x19GetDependencyGraphRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12!\n" +
+	"\fcluster_name\x18\x02 \x01(\tR\vclusterName\"\xc0\x01\n" +
+	"\x13DependencyGraphNode\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x04 \x01(\tR\tnamespace\x12!\n" +
+	"\fcluster_name\x18\x05 \x01(\tR\vclusterName\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x18\n" +
+	"\amissing\x18\a \x01(\bR\amissing\"Y\n" +
+	"\x13DependencyGraphEdge\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\tR\x06target\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\",\n" +
+	"\x14DependencyGraphCycle\x12\x14\n" +
+	"\x05nodes\x18\x01 \x03(\tR\x05nodes\"\x83\x02\n" +
+	"\x1aGetDependencyGraphResponse\x129\n" +
+	"\x05nodes\x18\x01 \x03(\v2#.gitops_core.v1.DependencyGraphNodeR\x05nodes\x129\n" +
+	"\x05edges\x18\x02 \x03(\v2#.gitops_core.v1.DependencyGraphEdgeR\x05edges\x12<\n" +
+	"\x06cycles\x18\x03 \x03(\v2$.gitops_core.v1.DependencyGraphCycleR\x06cycles\x121\n" +
+	"\x06errors\x18\x04 \x03(\v2\x19.gitops_core.v1.ListErrorR\x06errors\"f\n" +
+	"\x0fGetDriftRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12!\n" +
+	"\fcluster_name\x18\x03 \x01(\tR\vclusterName\"\x94\x01\n" +
+	"\n" +
+	"DriftEntry\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\x12\x12\n" +
+	"\x04diff\x18\x05 \x01(\tR\x04diff\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\"d\n" +
+	"\x10GetDriftResponse\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\tR\brevision\x124\n" +
+	"\aentries\x18\x02 \x03(\v2\x1a.gitops_core.v1.DriftEntryR\aentries\"\x82\x01\n" +
+	"\x17GetHealthHistoryRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12!\n" +
+	"\fcluster_name\x18\x04 \x01(\tR\vclusterName\"d\n" +
+	"\x12HealthHistoryEntry\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1c\n" +
+	"\ttimestamp\x18\x03 \x01(\tR\ttimestamp\"\x8e\x01\n" +
+	"\x18GetHealthHistoryResponse\x12<\n" +
+	"\aentries\x18\x01 \x03(\v2\".gitops_core.v1.HealthHistoryEntryR\aentries\x12\x18\n" +
+	"\achanges\x18\x02 \x01(\x05R\achanges\x12\x1a\n" +
+	"\bflapping\x18\x03 \x01(\bR\bflapping\"\xe1\x04\n" +
 	"\x10PolicyValidation\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1d\n" +
@@ -3434,31 +5244,55 @@ const file_api_core_core_proto_rawDesc = "" +
 	"\fcluster_name\x18\x01 \x01(\tR\vclusterName\"u\n" +
 	"\x17ListRuntimeCrdsResponse\x12'\n" +
 	"\x04crds\x18\x01 \x03(\v2\x13.gitops_core.v1.CrdR\x04crds\x121\n" +
-	"\x06errors\x18\x02 \x03(\v2\x19.gitops_core.v1.ListErrorR\x06errors\"{\n" +
+	"\x06errors\x18\x02 \x03(\v2\x19.gitops_core.v1.ListErrorR\x06errors\"\xa9\x01\n" +
 	"\x10GetObjectRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
 	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x12\n" +
 	"\x04kind\x18\x03 \x01(\tR\x04kind\x12!\n" +
-	"\fcluster_name\x18\x04 \x01(\tR\vclusterName\"C\n" +
+	"\fcluster_name\x18\x04 \x01(\tR\vclusterName\x12,\n" +
+	"\x12with_health_rollup\x18\x05 \x01(\bR\x10withHealthRollup\"C\n" +
 	"\x11GetObjectResponse\x12.\n" +
-	"\x06object\x18\x01 \x01(\v2\x16.gitops_core.v1.ObjectR\x06object\"\xec\x01\n" +
+	"\x06object\x18\x01 \x01(\v2\x16.gitops_core.v1.ObjectR\x06object\"\xe9\x03\n" +
 	"\x12ListObjectsRequest\x12\x1c\n" +
 	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x12\n" +
 	"\x04kind\x18\x02 \x01(\tR\x04kind\x12!\n" +
 	"\fcluster_name\x18\x03 \x01(\tR\vclusterName\x12F\n" +
-	"\x06labels\x18\x04 \x03(\v2..gitops_core.v1.ListObjectsRequest.LabelsEntryR\x06labels\x1a9\n" +
+	"\x06labels\x18\x04 \x03(\v2..gitops_core.v1.ListObjectsRequest.LabelsEntryR\x06labels\x12:\n" +
+	"\n" +
+	"pagination\x18\x05 \x01(\v2\x1a.gitops_core.v1.PaginationR\n" +
+	"pagination\x12%\n" +
+	"\x0efield_selector\x18\x06 \x01(\tR\rfieldSelector\x12\x16\n" +
+	"\x06search\x18\a \x01(\tR\x06search\x12!\n" +
+	"\fsearch_regex\x18\b \x01(\bR\vsearchRegex\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12\x17\n" +
+	"\asort_by\x18\n" +
+	" \x01(\tR\x06sortBy\x12,\n" +
+	"\x12with_health_rollup\x18\v \x01(\bR\x10withHealthRollup\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xee\x01\n" +
+	"\x13WatchObjectsRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12!\n" +
+	"\fcluster_name\x18\x03 \x01(\tR\vclusterName\x12G\n" +
+	"\x06labels\x18\x04 \x03(\v2/.gitops_core.v1.WatchObjectsRequest.LabelsEntryR\x06labels\x1a9\n" +
 	"\vLabelsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"Y\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8b\x01\n" +
+	"\x14WatchObjectsResponse\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12.\n" +
+	"\x06object\x18\x02 \x01(\v2\x16.gitops_core.v1.ObjectR\x06object\x12/\n" +
+	"\x05error\x18\x03 \x01(\v2\x19.gitops_core.v1.ListErrorR\x05error\"Y\n" +
 	"\x14ClusterNamespaceList\x12!\n" +
 	"\fcluster_name\x18\x01 \x01(\tR\vclusterName\x12\x1e\n" +
 	"\n" +
 	"namespaces\x18\x02 \x03(\tR\n" +
-	"namespaces\"\xd1\x01\n" +
+	"namespaces\"\xf9\x01\n" +
 	"\x13ListObjectsResponse\x120\n" +
 	"\aobjects\x18\x01 \x03(\v2\x16.gitops_core.v1.ObjectR\aobjects\x121\n" +
 	"\x06errors\x18\x02 \x03(\v2\x19.gitops_core.v1.ListErrorR\x06errors\x12U\n" +
-	"\x13searched_namespaces\x18\x03 \x03(\v2$.gitops_core.v1.ClusterNamespaceListR\x12searchedNamespaces\"\xe8\x01\n" +
+	"\x13searched_namespaces\x18\x03 \x03(\v2$.gitops_core.v1.ClusterNamespaceListR\x12searchedNamespaces\x12&\n" +
+	"\x0fnext_page_token\x18\x04 \x01(\tR\rnextPageToken\"\xe8\x01\n" +
 	"\x1bGetReconciledObjectsRequest\x12'\n" +
 	"\x0fautomation_name\x18\x01 \x01(\tR\x0eautomationName\x12\x1c\n" +
 	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12'\n" +
@@ -3466,7 +5300,41 @@ const file_api_core_core_proto_rawDesc = "" +
 	"\x05kinds\x18\x04 \x03(\v2 .gitops_core.v1.GroupVersionKindR\x05kinds\x12!\n" +
 	"\fcluster_name\x18\x05 \x01(\tR\vclusterName\"P\n" +
 	"\x1cGetReconciledObjectsResponse\x120\n" +
-	"\aobjects\x18\x01 \x03(\v2\x16.gitops_core.v1.ObjectR\aobjects\"\xc8\x01\n" +
+	"\aobjects\x18\x01 \x03(\v2\x16.gitops_core.v1.ObjectR\aobjects\"\x92\x01\n" +
+	"\x11FindOwnersRequest\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\x12!\n" +
+	"\fcluster_name\x18\x04 \x01(\tR\vclusterName\x12\x14\n" +
+	"\x05image\x18\x05 \x01(\tR\x05image\"t\n" +
+	"\fObjectOwners\x121\n" +
+	"\x06object\x18\x01 \x01(\v2\x19.gitops_core.v1.ObjectRefR\x06object\x121\n" +
+	"\x06owners\x18\x02 \x03(\v2\x19.gitops_core.v1.ObjectRefR\x06owners\"\x7f\n" +
+	"\x12FindOwnersResponse\x126\n" +
+	"\aobjects\x18\x01 \x03(\v2\x1c.gitops_core.v1.ObjectOwnersR\aobjects\x121\n" +
+	"\x06errors\x18\x02 \x03(\v2\x19.gitops_core.v1.ListErrorR\x06errors\"\xa2\x01\n" +
+	"\x11ListImagesRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12!\n" +
+	"\fcluster_name\x18\x02 \x01(\tR\vclusterName\x12\x1a\n" +
+	"\bregistry\x18\x03 \x01(\tR\bregistry\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x04 \x01(\tR\n" +
+	"repository\x12\x10\n" +
+	"\x03tag\x18\x05 \x01(\tR\x03tag\"y\n" +
+	"\rImageWorkload\x125\n" +
+	"\bworkload\x18\x01 \x01(\v2\x19.gitops_core.v1.ObjectRefR\bworkload\x121\n" +
+	"\x06owners\x18\x02 \x03(\v2\x19.gitops_core.v1.ObjectRefR\x06owners\"\xaa\x01\n" +
+	"\x05Image\x12\x1a\n" +
+	"\bregistry\x18\x01 \x01(\tR\bregistry\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x02 \x01(\tR\n" +
+	"repository\x12\x10\n" +
+	"\x03tag\x18\x03 \x01(\tR\x03tag\x12\x16\n" +
+	"\x06digest\x18\x04 \x01(\tR\x06digest\x12;\n" +
+	"\tworkloads\x18\x05 \x03(\v2\x1d.gitops_core.v1.ImageWorkloadR\tworkloads\"v\n" +
+	"\x12ListImagesResponse\x12-\n" +
+	"\x06images\x18\x01 \x03(\v2\x15.gitops_core.v1.ImageR\x06images\x121\n" +
+	"\x06errors\x18\x02 \x03(\v2\x19.gitops_core.v1.ListErrorR\x06errors\"\xc8\x01\n" +
 	"\x16GetChildObjectsRequest\x12N\n" +
 	"\x12group_version_kind\x18\x01 \x01(\v2 .gitops_core.v1.GroupVersionKindR\x10groupVersionKind\x12\x1c\n" +
 	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x1d\n" +
@@ -3486,12 +5354,41 @@ const file_api_core_core_proto_rawDesc = "" +
 	"\x11ListEventsRequest\x12B\n" +
 	"\x0finvolved_object\x18\x01 \x01(\v2\x19.gitops_core.v1.ObjectRefR\x0einvolvedObject\"C\n" +
 	"\x12ListEventsResponse\x12-\n" +
-	"\x06events\x18\x01 \x03(\v2\x15.gitops_core.v1.EventR\x06events\"m\n" +
+	"\x06events\x18\x01 \x03(\v2\x15.gitops_core.v1.EventR\x06events\"\xb0\x01\n" +
 	"\x15SyncFluxObjectRequest\x123\n" +
 	"\aobjects\x18\x01 \x03(\v2\x19.gitops_core.v1.ObjectRefR\aobjects\x12\x1f\n" +
 	"\vwith_source\x18\x02 \x01(\bR\n" +
-	"withSource\"\x18\n" +
-	"\x16SyncFluxObjectResponse\"\x13\n" +
+	"withSource\x12+\n" +
+	"\x11with_dependencies\x18\x03 \x01(\bR\x10withDependencies\x12\x14\n" +
+	"\x05async\x18\x04 \x01(\bR\x05async\"s\n" +
+	"\x16SyncFluxObjectResponse\x126\n" +
+	"\aresults\x18\x01 \x03(\v2\x1c.gitops_core.v1.ObjectResultR\aresults\x12!\n" +
+	"\foperation_id\x18\x02 \x01(\tR\voperationId\"\xba\x01\n" +
+	"\x0fOperationObject\x124\n" +
+	"\x06result\x18\x01 \x01(\v2\x1c.gitops_core.v1.ObjectResultR\x06result\x12B\n" +
+	"\x0fready_condition\x18\x02 \x01(\v2\x19.gitops_core.v1.ConditionR\x0ereadyCondition\x12-\n" +
+	"\x06events\x18\x03 \x03(\v2\x15.gitops_core.v1.EventR\x06events\"\xff\x01\n" +
+	"\tOperation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x04 \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x05 \x01(\tR\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\x06 \x01(\tR\n" +
+	"finishedAt\x129\n" +
+	"\aobjects\x18\a \x03(\v2\x1f.gitops_core.v1.OperationObjectR\aobjects\x12\x1c\n" +
+	"\tcompleted\x18\b \x01(\x05R\tcompleted\"%\n" +
+	"\x13GetOperationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"O\n" +
+	"\x14GetOperationResponse\x127\n" +
+	"\toperation\x18\x01 \x01(\v2\x19.gitops_core.v1.OperationR\toperation\"\x17\n" +
+	"\x15ListOperationsRequest\"S\n" +
+	"\x16ListOperationsResponse\x129\n" +
+	"\n" +
+	"operations\x18\x01 \x03(\v2\x19.gitops_core.v1.OperationR\n" +
+	"operations\"\x13\n" +
 	"\x11GetVersionRequest\"\x9e\x01\n" +
 	"\x12GetVersionResponse\x12\x16\n" +
 	"\x06semver\x18\x01 \x01(\tR\x06semver\x12\x16\n" +
@@ -3510,8 +5407,9 @@ const file_api_core_core_proto_rawDesc = "" +
 	"\x1cToggleSuspendResourceRequest\x123\n" +
 	"\aobjects\x18\x01 \x03(\v2\x19.gitops_core.v1.ObjectRefR\aobjects\x12\x18\n" +
 	"\asuspend\x18\x02 \x01(\bR\asuspend\x12\x18\n" +
-	"\acomment\x18\x03 \x01(\tR\acomment\"\x1f\n" +
-	"\x1dToggleSuspendResourceResponse\"\xcf\x01\n" +
+	"\acomment\x18\x03 \x01(\tR\acomment\"W\n" +
+	"\x1dToggleSuspendResourceResponse\x126\n" +
+	"\aresults\x18\x01 \x03(\v2\x1c.gitops_core.v1.ObjectResultR\aresults\"\xcf\x01\n" +
 	"\x15GetSessionLogsRequest\x12+\n" +
 	"\x11session_namespace\x18\x01 \x01(\tR\x10sessionNamespace\x12\x1d\n" +
 	"\n" +
@@ -3598,29 +5496,41 @@ const file_api_core_core_proto_rawDesc = "" +
 	"\x06values\x18\x01 \x03(\v2-.gitops_core.v1.PolicyTargetLabel.ValuesEntryR\x06values\x1a9\n" +
 	"\vValuesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x012\xf9\x15\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x012\xa6\x1d\n" +
 	"\x04Core\x12k\n" +
 	"\tGetObject\x12 .gitops_core.v1.GetObjectRequest\x1a!.gitops_core.v1.GetObjectResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/object/{name}\x12n\n" +
-	"\vListObjects\x12\".gitops_core.v1.ListObjectsRequest\x1a#.gitops_core.v1.ListObjectsResponse\"\x16\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/v1/objects\x12\x99\x01\n" +
+	"\vListObjects\x12\".gitops_core.v1.ListObjectsRequest\x1a#.gitops_core.v1.ListObjectsResponse\"\x16\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/v1/objects\x12v\n" +
+	"\fWatchObjects\x12#.gitops_core.v1.WatchObjectsRequest\x1a$.gitops_core.v1.WatchObjectsResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/objects/watch0\x01\x12\x99\x01\n" +
 	"\x16ListFluxRuntimeObjects\x12-.gitops_core.v1.ListFluxRuntimeObjectsRequest\x1a..gitops_core.v1.ListFluxRuntimeObjectsResponse\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/v1/flux_runtime_objects\x12p\n" +
 	"\fListFluxCrds\x12#.gitops_core.v1.ListFluxCrdsRequest\x1a$.gitops_core.v1.ListFluxCrdsResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/flux_crds\x12\x88\x01\n" +
 	"\x12ListRuntimeObjects\x12).gitops_core.v1.ListRuntimeObjectsRequest\x1a*.gitops_core.v1.ListRuntimeObjectsResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/runtime_objects\x12|\n" +
 	"\x0fListRuntimeCrds\x12&.gitops_core.v1.ListRuntimeCrdsRequest\x1a'.gitops_core.v1.ListRuntimeCrdsResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/runtime_crds\x12\x94\x01\n" +
-	"\x14GetReconciledObjects\x12+.gitops_core.v1.GetReconciledObjectsRequest\x1a,.gitops_core.v1.GetReconciledObjectsResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/reconciled_objects\x12\x80\x01\n" +
+	"\x14GetReconciledObjects\x12+.gitops_core.v1.GetReconciledObjectsRequest\x1a,.gitops_core.v1.GetReconciledObjectsResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/reconciled_objects\x12g\n" +
+	"\n" +
+	"FindOwners\x12!.gitops_core.v1.FindOwnersRequest\x1a\".gitops_core.v1.FindOwnersResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
+	"/v1/owners\x12g\n" +
+	"\n" +
+	"ListImages\x12!.gitops_core.v1.ListImagesRequest\x1a\".gitops_core.v1.ListImagesResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
+	"/v1/images\x12\x80\x01\n" +
 	"\x0fGetChildObjects\x12&.gitops_core.v1.GetChildObjectsRequest\x1a'.gitops_core.v1.GetChildObjectsResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/child_objects\x12\x84\x01\n" +
 	"\x10GetFluxNamespace\x12'.gitops_core.v1.GetFluxNamespaceRequest\x1a(.gitops_core.v1.GetFluxNamespaceResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/v1/namespace/flux\x12w\n" +
 	"\x0eListNamespaces\x12%.gitops_core.v1.ListNamespacesRequest\x1a&.gitops_core.v1.ListNamespacesResponse\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/v1/namespaces\x12g\n" +
 	"\n" +
 	"ListEvents\x12!.gitops_core.v1.ListEventsRequest\x1a\".gitops_core.v1.ListEventsResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
 	"/v1/events\x12t\n" +
-	"\x0eSyncFluxObject\x12%.gitops_core.v1.SyncFluxObjectRequest\x1a&.gitops_core.v1.SyncFluxObjectResponse\"\x13\x82\xd3\xe4\x93\x02\r:\x01*\"\b/v1/sync\x12h\n" +
+	"\x0eSyncFluxObject\x12%.gitops_core.v1.SyncFluxObjectRequest\x1a&.gitops_core.v1.SyncFluxObjectResponse\"\x13\x82\xd3\xe4\x93\x02\r:\x01*\"\b/v1/sync\x12v\n" +
+	"\fGetOperation\x12#.gitops_core.v1.GetOperationRequest\x1a$.gitops_core.v1.GetOperationResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/operations/{id}\x12w\n" +
+	"\x0eListOperations\x12%.gitops_core.v1.ListOperationsRequest\x1a&.gitops_core.v1.ListOperationsResponse\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/v1/operations\x12h\n" +
 	"\n" +
 	"GetVersion\x12!.gitops_core.v1.GetVersionRequest\x1a\".gitops_core.v1.GetVersionResponse\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/v1/version\x12|\n" +
 	"\x0fGetFeatureFlags\x12&.gitops_core.v1.GetFeatureFlagsRequest\x1a'.gitops_core.v1.GetFeatureFlagsResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/featureflags\x12\x8c\x01\n" +
 	"\x15ToggleSuspendResource\x12,.gitops_core.v1.ToggleSuspendResourceRequest\x1a-.gitops_core.v1.ToggleSuspendResourceResponse\"\x16\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/v1/suspend\x12|\n" +
 	"\x0eGetSessionLogs\x12%.gitops_core.v1.GetSessionLogsRequest\x1a&.gitops_core.v1.GetSessionLogsResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/session_logs\x12}\n" +
 	"\x0eIsCRDAvailable\x12%.gitops_core.v1.IsCRDAvailableRequest\x1a&.gitops_core.v1.IsCRDAvailableResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/crd/is_available\x12p\n" +
-	"\fGetInventory\x12#.gitops_core.v1.GetInventoryRequest\x1a$.gitops_core.v1.GetInventoryResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/inventory\x12o\n" +
+	"\fGetInventory\x12#.gitops_core.v1.GetInventoryRequest\x1a$.gitops_core.v1.GetInventoryResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/inventory\x12\x89\x01\n" +
+	"\x12GetDependencyGraph\x12).gitops_core.v1.GetDependencyGraphRequest\x1a*.gitops_core.v1.GetDependencyGraphResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/dependency_graph\x12`\n" +
+	"\bGetDrift\x12\x1f.gitops_core.v1.GetDriftRequest\x1a .gitops_core.v1.GetDriftResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/drift\x12\x81\x01\n" +
+	"\x10GetHealthHistory\x12'.gitops_core.v1.GetHealthHistoryRequest\x1a(.gitops_core.v1.GetHealthHistoryResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/health/history\x12o\n" +
 	"\fListPolicies\x12#.gitops_core.v1.ListPoliciesRequest\x1a$.gitops_core.v1.ListPoliciesResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/policies\x12t\n" +
 	"\tGetPolicy\x12 .gitops_core.v1.GetPolicyRequest\x1a!.gitops_core.v1.GetPolicyResponse\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/v1/policies/{policy_name}\x12\x96\x01\n" +
 	"\x15ListPolicyValidations\x12,.gitops_core.v1.ListPolicyValidationsRequest\x1a-.gitops_core.v1.ListPolicyValidationsResponse\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/v1/policyvalidations\x12\x9d\x01\n" +
@@ -3639,172 +5549,244 @@ func file_api_core_core_proto_rawDescGZIP() []byte {
 	return file_api_core_core_proto_rawDescData
 }
 
-var file_api_core_core_proto_msgTypes = make([]protoimpl.MessageInfo, 61)
+var file_api_core_core_proto_msgTypes = make([]protoimpl.MessageInfo, 88)
 var file_api_core_core_proto_goTypes = []any{
 	(*GetInventoryRequest)(nil),            // 0: gitops_core.v1.GetInventoryRequest
 	(*GetInventoryResponse)(nil),           // 1: gitops_core.v1.GetInventoryResponse
-	(*PolicyValidation)(nil),               // 2: gitops_core.v1.PolicyValidation
-	(*ListPolicyValidationsRequest)(nil),   // 3: gitops_core.v1.ListPolicyValidationsRequest
-	(*ListPolicyValidationsResponse)(nil),  // 4: gitops_core.v1.ListPolicyValidationsResponse
-	(*GetPolicyValidationRequest)(nil),     // 5: gitops_core.v1.GetPolicyValidationRequest
-	(*GetPolicyValidationResponse)(nil),    // 6: gitops_core.v1.GetPolicyValidationResponse
-	(*PolicyValidationOccurrence)(nil),     // 7: gitops_core.v1.PolicyValidationOccurrence
-	(*PolicyValidationParam)(nil),          // 8: gitops_core.v1.PolicyValidationParam
-	(*PolicyParamRepeatedString)(nil),      // 9: gitops_core.v1.PolicyParamRepeatedString
-	(*Pagination)(nil),                     // 10: gitops_core.v1.Pagination
-	(*ListError)(nil),                      // 11: gitops_core.v1.ListError
-	(*ListFluxRuntimeObjectsRequest)(nil),  // 12: gitops_core.v1.ListFluxRuntimeObjectsRequest
-	(*ListFluxRuntimeObjectsResponse)(nil), // 13: gitops_core.v1.ListFluxRuntimeObjectsResponse
-	(*ListRuntimeObjectsRequest)(nil),      // 14: gitops_core.v1.ListRuntimeObjectsRequest
-	(*ListRuntimeObjectsResponse)(nil),     // 15: gitops_core.v1.ListRuntimeObjectsResponse
-	(*ListFluxCrdsRequest)(nil),            // 16: gitops_core.v1.ListFluxCrdsRequest
-	(*ListFluxCrdsResponse)(nil),           // 17: gitops_core.v1.ListFluxCrdsResponse
-	(*ListRuntimeCrdsRequest)(nil),         // 18: gitops_core.v1.ListRuntimeCrdsRequest
-	(*ListRuntimeCrdsResponse)(nil),        // 19: gitops_core.v1.ListRuntimeCrdsResponse
-	(*GetObjectRequest)(nil),               // 20: gitops_core.v1.GetObjectRequest
-	(*GetObjectResponse)(nil),              // 21: gitops_core.v1.GetObjectResponse
-	(*ListObjectsRequest)(nil),             // 22: gitops_core.v1.ListObjectsRequest
-	(*ClusterNamespaceList)(nil),           // 23: gitops_core.v1.ClusterNamespaceList
-	(*ListObjectsResponse)(nil),            // 24: gitops_core.v1.ListObjectsResponse
-	(*GetReconciledObjectsRequest)(nil),    // 25: gitops_core.v1.GetReconciledObjectsRequest
-	(*GetReconciledObjectsResponse)(nil),   // 26: gitops_core.v1.GetReconciledObjectsResponse
-	(*GetChildObjectsRequest)(nil),         // 27: gitops_core.v1.GetChildObjectsRequest
-	(*GetChildObjectsResponse)(nil),        // 28: gitops_core.v1.GetChildObjectsResponse
-	(*GetFluxNamespaceRequest)(nil),        // 29: gitops_core.v1.GetFluxNamespaceRequest
-	(*GetFluxNamespaceResponse)(nil),       // 30: gitops_core.v1.GetFluxNamespaceResponse
-	(*ListNamespacesRequest)(nil),          // 31: gitops_core.v1.ListNamespacesRequest
-	(*ListNamespacesResponse)(nil),         // 32: gitops_core.v1.ListNamespacesResponse
-	(*ListEventsRequest)(nil),              // 33: gitops_core.v1.ListEventsRequest
-	(*ListEventsResponse)(nil),             // 34: gitops_core.v1.ListEventsResponse
-	(*SyncFluxObjectRequest)(nil),          // 35: gitops_core.v1.SyncFluxObjectRequest
-	(*SyncFluxObjectResponse)(nil),         // 36: gitops_core.v1.SyncFluxObjectResponse
-	(*GetVersionRequest)(nil),              // 37: gitops_core.v1.GetVersionRequest
-	(*GetVersionResponse)(nil),             // 38: gitops_core.v1.GetVersionResponse
-	(*GetFeatureFlagsRequest)(nil),         // 39: gitops_core.v1.GetFeatureFlagsRequest
-	(*GetFeatureFlagsResponse)(nil),        // 40: gitops_core.v1.GetFeatureFlagsResponse
-	(*ToggleSuspendResourceRequest)(nil),   // 41: gitops_core.v1.ToggleSuspendResourceRequest
-	(*ToggleSuspendResourceResponse)(nil),  // 42: gitops_core.v1.ToggleSuspendResourceResponse
-	(*GetSessionLogsRequest)(nil),          // 43: gitops_core.v1.GetSessionLogsRequest
-	(*LogEntry)(nil),                       // 44: gitops_core.v1.LogEntry
-	(*GetSessionLogsResponse)(nil),         // 45: gitops_core.v1.GetSessionLogsResponse
-	(*IsCRDAvailableRequest)(nil),          // 46: gitops_core.v1.IsCRDAvailableRequest
-	(*IsCRDAvailableResponse)(nil),         // 47: gitops_core.v1.IsCRDAvailableResponse
-	(*ListPoliciesRequest)(nil),            // 48: gitops_core.v1.ListPoliciesRequest
-	(*ListPoliciesResponse)(nil),           // 49: gitops_core.v1.ListPoliciesResponse
-	(*GetPolicyRequest)(nil),               // 50: gitops_core.v1.GetPolicyRequest
-	(*GetPolicyResponse)(nil),              // 51: gitops_core.v1.GetPolicyResponse
-	(*PolicyObj)(nil),                      // 52: gitops_core.v1.PolicyObj
-	(*PolicyStandard)(nil),                 // 53: gitops_core.v1.PolicyStandard
-	(*PolicyParam)(nil),                    // 54: gitops_core.v1.PolicyParam
-	(*PolicyTargets)(nil),                  // 55: gitops_core.v1.PolicyTargets
-	(*PolicyTargetLabel)(nil),              // 56: gitops_core.v1.PolicyTargetLabel
-	nil,                                    // 57: gitops_core.v1.ListObjectsRequest.LabelsEntry
-	nil,                                    // 58: gitops_core.v1.GetFeatureFlagsResponse.FlagsEntry
-	nil,                                    // 59: gitops_core.v1.IsCRDAvailableResponse.ClustersEntry
-	nil,                                    // 60: gitops_core.v1.PolicyTargetLabel.ValuesEntry
-	(*InventoryEntry)(nil),                 // 61: gitops_core.v1.InventoryEntry
-	(*anypb.Any)(nil),                      // 62: google.protobuf.Any
-	(*Deployment)(nil),                     // 63: gitops_core.v1.Deployment
-	(*Crd)(nil),                            // 64: gitops_core.v1.Crd
-	(*Object)(nil),                         // 65: gitops_core.v1.Object
-	(*GroupVersionKind)(nil),               // 66: gitops_core.v1.GroupVersionKind
-	(*Namespace)(nil),                      // 67: gitops_core.v1.Namespace
-	(*ObjectRef)(nil),                      // 68: gitops_core.v1.ObjectRef
-	(*Event)(nil),                          // 69: gitops_core.v1.Event
+	(*GetDependencyGraphRequest)(nil),      // 2: gitops_core.v1.GetDependencyGraphRequest
+	(*DependencyGraphNode)(nil),            // 3: gitops_core.v1.DependencyGraphNode
+	(*DependencyGraphEdge)(nil),            // 4: gitops_core.v1.DependencyGraphEdge
+	(*DependencyGraphCycle)(nil),           // 5: gitops_core.v1.DependencyGraphCycle
+	(*GetDependencyGraphResponse)(nil),     // 6: gitops_core.v1.GetDependencyGraphResponse
+	(*GetDriftRequest)(nil),                // 7: gitops_core.v1.GetDriftRequest
+	(*DriftEntry)(nil),                     // 8: gitops_core.v1.DriftEntry
+	(*GetDriftResponse)(nil),               // 9: gitops_core.v1.GetDriftResponse
+	(*GetHealthHistoryRequest)(nil),        // 10: gitops_core.v1.GetHealthHistoryRequest
+	(*HealthHistoryEntry)(nil),             // 11: gitops_core.v1.HealthHistoryEntry
+	(*GetHealthHistoryResponse)(nil),       // 12: gitops_core.v1.GetHealthHistoryResponse
+	(*PolicyValidation)(nil),               // 13: gitops_core.v1.PolicyValidation
+	(*ListPolicyValidationsRequest)(nil),   // 14: gitops_core.v1.ListPolicyValidationsRequest
+	(*ListPolicyValidationsResponse)(nil),  // 15: gitops_core.v1.ListPolicyValidationsResponse
+	(*GetPolicyValidationRequest)(nil),     // 16: gitops_core.v1.GetPolicyValidationRequest
+	(*GetPolicyValidationResponse)(nil),    // 17: gitops_core.v1.GetPolicyValidationResponse
+	(*PolicyValidationOccurrence)(nil),     // 18: gitops_core.v1.PolicyValidationOccurrence
+	(*PolicyValidationParam)(nil),          // 19: gitops_core.v1.PolicyValidationParam
+	(*PolicyParamRepeatedString)(nil),      // 20: gitops_core.v1.PolicyParamRepeatedString
+	(*Pagination)(nil),                     // 21: gitops_core.v1.Pagination
+	(*ListError)(nil),                      // 22: gitops_core.v1.ListError
+	(*ListFluxRuntimeObjectsRequest)(nil),  // 23: gitops_core.v1.ListFluxRuntimeObjectsRequest
+	(*ListFluxRuntimeObjectsResponse)(nil), // 24: gitops_core.v1.ListFluxRuntimeObjectsResponse
+	(*ListRuntimeObjectsRequest)(nil),      // 25: gitops_core.v1.ListRuntimeObjectsRequest
+	(*ListRuntimeObjectsResponse)(nil),     // 26: gitops_core.v1.ListRuntimeObjectsResponse
+	(*ListFluxCrdsRequest)(nil),            // 27: gitops_core.v1.ListFluxCrdsRequest
+	(*ListFluxCrdsResponse)(nil),           // 28: gitops_core.v1.ListFluxCrdsResponse
+	(*ListRuntimeCrdsRequest)(nil),         // 29: gitops_core.v1.ListRuntimeCrdsRequest
+	(*ListRuntimeCrdsResponse)(nil),        // 30: gitops_core.v1.ListRuntimeCrdsResponse
+	(*GetObjectRequest)(nil),               // 31: gitops_core.v1.GetObjectRequest
+	(*GetObjectResponse)(nil),              // 32: gitops_core.v1.GetObjectResponse
+	(*ListObjectsRequest)(nil),             // 33: gitops_core.v1.ListObjectsRequest
+	(*WatchObjectsRequest)(nil),            // 34: gitops_core.v1.WatchObjectsRequest
+	(*WatchObjectsResponse)(nil),           // 35: gitops_core.v1.WatchObjectsResponse
+	(*ClusterNamespaceList)(nil),           // 36: gitops_core.v1.ClusterNamespaceList
+	(*ListObjectsResponse)(nil),            // 37: gitops_core.v1.ListObjectsResponse
+	(*GetReconciledObjectsRequest)(nil),    // 38: gitops_core.v1.GetReconciledObjectsRequest
+	(*GetReconciledObjectsResponse)(nil),   // 39: gitops_core.v1.GetReconciledObjectsResponse
+	(*FindOwnersRequest)(nil),              // 40: gitops_core.v1.FindOwnersRequest
+	(*ObjectOwners)(nil),                   // 41: gitops_core.v1.ObjectOwners
+	(*FindOwnersResponse)(nil),             // 42: gitops_core.v1.FindOwnersResponse
+	(*ListImagesRequest)(nil),              // 43: gitops_core.v1.ListImagesRequest
+	(*ImageWorkload)(nil),                  // 44: gitops_core.v1.ImageWorkload
+	(*Image)(nil),                          // 45: gitops_core.v1.Image
+	(*ListImagesResponse)(nil),             // 46: gitops_core.v1.ListImagesResponse
+	(*GetChildObjectsRequest)(nil),         // 47: gitops_core.v1.GetChildObjectsRequest
+	(*GetChildObjectsResponse)(nil),        // 48: gitops_core.v1.GetChildObjectsResponse
+	(*GetFluxNamespaceRequest)(nil),        // 49: gitops_core.v1.GetFluxNamespaceRequest
+	(*GetFluxNamespaceResponse)(nil),       // 50: gitops_core.v1.GetFluxNamespaceResponse
+	(*ListNamespacesRequest)(nil),          // 51: gitops_core.v1.ListNamespacesRequest
+	(*ListNamespacesResponse)(nil),         // 52: gitops_core.v1.ListNamespacesResponse
+	(*ListEventsRequest)(nil),              // 53: gitops_core.v1.ListEventsRequest
+	(*ListEventsResponse)(nil),             // 54: gitops_core.v1.ListEventsResponse
+	(*SyncFluxObjectRequest)(nil),          // 55: gitops_core.v1.SyncFluxObjectRequest
+	(*SyncFluxObjectResponse)(nil),         // 56: gitops_core.v1.SyncFluxObjectResponse
+	(*OperationObject)(nil),                // 57: gitops_core.v1.OperationObject
+	(*Operation)(nil),                      // 58: gitops_core.v1.Operation
+	(*GetOperationRequest)(nil),            // 59: gitops_core.v1.GetOperationRequest
+	(*GetOperationResponse)(nil),           // 60: gitops_core.v1.GetOperationResponse
+	(*ListOperationsRequest)(nil),          // 61: gitops_core.v1.ListOperationsRequest
+	(*ListOperationsResponse)(nil),         // 62: gitops_core.v1.ListOperationsResponse
+	(*GetVersionRequest)(nil),              // 63: gitops_core.v1.GetVersionRequest
+	(*GetVersionResponse)(nil),             // 64: gitops_core.v1.GetVersionResponse
+	(*GetFeatureFlagsRequest)(nil),         // 65: gitops_core.v1.GetFeatureFlagsRequest
+	(*GetFeatureFlagsResponse)(nil),        // 66: gitops_core.v1.GetFeatureFlagsResponse
+	(*ToggleSuspendResourceRequest)(nil),   // 67: gitops_core.v1.ToggleSuspendResourceRequest
+	(*ToggleSuspendResourceResponse)(nil),  // 68: gitops_core.v1.ToggleSuspendResourceResponse
+	(*GetSessionLogsRequest)(nil),          // 69: gitops_core.v1.GetSessionLogsRequest
+	(*LogEntry)(nil),                       // 70: gitops_core.v1.LogEntry
+	(*GetSessionLogsResponse)(nil),         // 71: gitops_core.v1.GetSessionLogsResponse
+	(*IsCRDAvailableRequest)(nil),          // 72: gitops_core.v1.IsCRDAvailableRequest
+	(*IsCRDAvailableResponse)(nil),         // 73: gitops_core.v1.IsCRDAvailableResponse
+	(*ListPoliciesRequest)(nil),            // 74: gitops_core.v1.ListPoliciesRequest
+	(*ListPoliciesResponse)(nil),           // 75: gitops_core.v1.ListPoliciesResponse
+	(*GetPolicyRequest)(nil),               // 76: gitops_core.v1.GetPolicyRequest
+	(*GetPolicyResponse)(nil),              // 77: gitops_core.v1.GetPolicyResponse
+	(*PolicyObj)(nil),                      // 78: gitops_core.v1.PolicyObj
+	(*PolicyStandard)(nil),                 // 79: gitops_core.v1.PolicyStandard
+	(*PolicyParam)(nil),                    // 80: gitops_core.v1.PolicyParam
+	(*PolicyTargets)(nil),                  // 81: gitops_core.v1.PolicyTargets
+	(*PolicyTargetLabel)(nil),              // 82: gitops_core.v1.PolicyTargetLabel
+	nil,                                    // 83: gitops_core.v1.ListObjectsRequest.LabelsEntry
+	nil,                                    // 84: gitops_core.v1.WatchObjectsRequest.LabelsEntry
+	nil,                                    // 85: gitops_core.v1.GetFeatureFlagsResponse.FlagsEntry
+	nil,                                    // 86: gitops_core.v1.IsCRDAvailableResponse.ClustersEntry
+	nil,                                    // 87: gitops_core.v1.PolicyTargetLabel.ValuesEntry
+	(*InventoryEntry)(nil),                 // 88: gitops_core.v1.InventoryEntry
+	(*anypb.Any)(nil),                      // 89: google.protobuf.Any
+	(*Deployment)(nil),                     // 90: gitops_core.v1.Deployment
+	(*Crd)(nil),                            // 91: gitops_core.v1.Crd
+	(*Object)(nil),                         // 92: gitops_core.v1.Object
+	(*GroupVersionKind)(nil),               // 93: gitops_core.v1.GroupVersionKind
+	(*ObjectRef)(nil),                      // 94: gitops_core.v1.ObjectRef
+	(*Namespace)(nil),                      // 95: gitops_core.v1.Namespace
+	(*Event)(nil),                          // 96: gitops_core.v1.Event
+	(*ObjectResult)(nil),                   // 97: gitops_core.v1.ObjectResult
+	(*Condition)(nil),                      // 98: gitops_core.v1.Condition
 }
 var file_api_core_core_proto_depIdxs = []int32{
-	61, // 0: gitops_core.v1.GetInventoryResponse.entries:type_name -> gitops_core.v1.InventoryEntry
-	7,  // 1: gitops_core.v1.PolicyValidation.occurrences:type_name -> gitops_core.v1.PolicyValidationOccurrence
-	8,  // 2: gitops_core.v1.PolicyValidation.parameters:type_name -> gitops_core.v1.PolicyValidationParam
-	10, // 3: gitops_core.v1.ListPolicyValidationsRequest.pagination:type_name -> gitops_core.v1.Pagination
-	2,  // 4: gitops_core.v1.ListPolicyValidationsResponse.violations:type_name -> gitops_core.v1.PolicyValidation
-	11, // 5: gitops_core.v1.ListPolicyValidationsResponse.errors:type_name -> gitops_core.v1.ListError
-	2,  // 6: gitops_core.v1.GetPolicyValidationResponse.validation:type_name -> gitops_core.v1.PolicyValidation
-	62, // 7: gitops_core.v1.PolicyValidationParam.value:type_name -> google.protobuf.Any
-	63, // 8: gitops_core.v1.ListFluxRuntimeObjectsResponse.deployments:type_name -> gitops_core.v1.Deployment
-	11, // 9: gitops_core.v1.ListFluxRuntimeObjectsResponse.errors:type_name -> gitops_core.v1.ListError
-	63, // 10: gitops_core.v1.ListRuntimeObjectsResponse.deployments:type_name -> gitops_core.v1.Deployment
-	11, // 11: gitops_core.v1.ListRuntimeObjectsResponse.errors:type_name -> gitops_core.v1.ListError
-	64, // 12: gitops_core.v1.ListFluxCrdsResponse.crds:type_name -> gitops_core.v1.Crd
-	11, // 13: gitops_core.v1.ListFluxCrdsResponse.errors:type_name -> gitops_core.v1.ListError
-	64, // 14: gitops_core.v1.ListRuntimeCrdsResponse.crds:type_name -> gitops_core.v1.Crd
-	11, // 15: gitops_core.v1.ListRuntimeCrdsResponse.errors:type_name -> gitops_core.v1.ListError
-	65, // 16: gitops_core.v1.GetObjectResponse.object:type_name -> gitops_core.v1.Object
-	57, // 17: gitops_core.v1.ListObjectsRequest.labels:type_name -> gitops_core.v1.ListObjectsRequest.LabelsEntry
-	65, // 18: gitops_core.v1.ListObjectsResponse.objects:type_name -> gitops_core.v1.Object
-	11, // 19: gitops_core.v1.ListObjectsResponse.errors:type_name -> gitops_core.v1.ListError
-	23, // 20: gitops_core.v1.ListObjectsResponse.searched_namespaces:type_name -> gitops_core.v1.ClusterNamespaceList
-	66, // 21: gitops_core.v1.GetReconciledObjectsRequest.kinds:type_name -> gitops_core.v1.GroupVersionKind
-	65, // 22: gitops_core.v1.GetReconciledObjectsResponse.objects:type_name -> gitops_core.v1.Object
-	66, // 23: gitops_core.v1.GetChildObjectsRequest.group_version_kind:type_name -> gitops_core.v1.GroupVersionKind
-	65, // 24: gitops_core.v1.GetChildObjectsResponse.objects:type_name -> gitops_core.v1.Object
-	67, // 25: gitops_core.v1.ListNamespacesResponse.namespaces:type_name -> gitops_core.v1.Namespace
-	68, // 26: gitops_core.v1.ListEventsRequest.involved_object:type_name -> gitops_core.v1.ObjectRef
-	69, // 27: gitops_core.v1.ListEventsResponse.events:type_name -> gitops_core.v1.Event
-	68, // 28: gitops_core.v1.SyncFluxObjectRequest.objects:type_name -> gitops_core.v1.ObjectRef
-	58, // 29: gitops_core.v1.GetFeatureFlagsResponse.flags:type_name -> gitops_core.v1.GetFeatureFlagsResponse.FlagsEntry
-	68, // 30: gitops_core.v1.ToggleSuspendResourceRequest.objects:type_name -> gitops_core.v1.ObjectRef
-	44, // 31: gitops_core.v1.GetSessionLogsResponse.logs:type_name -> gitops_core.v1.LogEntry
-	59, // 32: gitops_core.v1.IsCRDAvailableResponse.clusters:type_name -> gitops_core.v1.IsCRDAvailableResponse.ClustersEntry
-	10, // 33: gitops_core.v1.ListPoliciesRequest.pagination:type_name -> gitops_core.v1.Pagination
-	52, // 34: gitops_core.v1.ListPoliciesResponse.policies:type_name -> gitops_core.v1.PolicyObj
-	11, // 35: gitops_core.v1.ListPoliciesResponse.errors:type_name -> gitops_core.v1.ListError
-	52, // 36: gitops_core.v1.GetPolicyResponse.policy:type_name -> gitops_core.v1.PolicyObj
-	53, // 37: gitops_core.v1.PolicyObj.standards:type_name -> gitops_core.v1.PolicyStandard
-	54, // 38: gitops_core.v1.PolicyObj.parameters:type_name -> gitops_core.v1.PolicyParam
-	55, // 39: gitops_core.v1.PolicyObj.targets:type_name -> gitops_core.v1.PolicyTargets
-	62, // 40: gitops_core.v1.PolicyParam.value:type_name -> google.protobuf.Any
-	56, // 41: gitops_core.v1.PolicyTargets.labels:type_name -> gitops_core.v1.PolicyTargetLabel
-	60, // 42: gitops_core.v1.PolicyTargetLabel.values:type_name -> gitops_core.v1.PolicyTargetLabel.ValuesEntry
-	20, // 43: gitops_core.v1.Core.GetObject:input_type -> gitops_core.v1.GetObjectRequest
-	22, // 44: gitops_core.v1.Core.ListObjects:input_type -> gitops_core.v1.ListObjectsRequest
-	12, // 45: gitops_core.v1.Core.ListFluxRuntimeObjects:input_type -> gitops_core.v1.ListFluxRuntimeObjectsRequest
-	16, // 46: gitops_core.v1.Core.ListFluxCrds:input_type -> gitops_core.v1.ListFluxCrdsRequest
-	14, // 47: gitops_core.v1.Core.ListRuntimeObjects:input_type -> gitops_core.v1.ListRuntimeObjectsRequest
-	18, // 48: gitops_core.v1.Core.ListRuntimeCrds:input_type -> gitops_core.v1.ListRuntimeCrdsRequest
-	25, // 49: gitops_core.v1.Core.GetReconciledObjects:input_type -> gitops_core.v1.GetReconciledObjectsRequest
-	27, // 50: gitops_core.v1.Core.GetChildObjects:input_type -> gitops_core.v1.GetChildObjectsRequest
-	29, // 51: gitops_core.v1.Core.GetFluxNamespace:input_type -> gitops_core.v1.GetFluxNamespaceRequest
-	31, // 52: gitops_core.v1.Core.ListNamespaces:input_type -> gitops_core.v1.ListNamespacesRequest
-	33, // 53: gitops_core.v1.Core.ListEvents:input_type -> gitops_core.v1.ListEventsRequest
-	35, // 54: gitops_core.v1.Core.SyncFluxObject:input_type -> gitops_core.v1.SyncFluxObjectRequest
-	37, // 55: gitops_core.v1.Core.GetVersion:input_type -> gitops_core.v1.GetVersionRequest
-	39, // 56: gitops_core.v1.Core.GetFeatureFlags:input_type -> gitops_core.v1.GetFeatureFlagsRequest
-	41, // 57: gitops_core.v1.Core.ToggleSuspendResource:input_type -> gitops_core.v1.ToggleSuspendResourceRequest
-	43, // 58: gitops_core.v1.Core.GetSessionLogs:input_type -> gitops_core.v1.GetSessionLogsRequest
-	46, // 59: gitops_core.v1.Core.IsCRDAvailable:input_type -> gitops_core.v1.IsCRDAvailableRequest
-	0,  // 60: gitops_core.v1.Core.GetInventory:input_type -> gitops_core.v1.GetInventoryRequest
-	48, // 61: gitops_core.v1.Core.ListPolicies:input_type -> gitops_core.v1.ListPoliciesRequest
-	50, // 62: gitops_core.v1.Core.GetPolicy:input_type -> gitops_core.v1.GetPolicyRequest
-	3,  // 63: gitops_core.v1.Core.ListPolicyValidations:input_type -> gitops_core.v1.ListPolicyValidationsRequest
-	5,  // 64: gitops_core.v1.Core.GetPolicyValidation:input_type -> gitops_core.v1.GetPolicyValidationRequest
-	21, // 65: gitops_core.v1.Core.GetObject:output_type -> gitops_core.v1.GetObjectResponse
-	24, // 66: gitops_core.v1.Core.ListObjects:output_type -> gitops_core.v1.ListObjectsResponse
-	13, // 67: gitops_core.v1.Core.ListFluxRuntimeObjects:output_type -> gitops_core.v1.ListFluxRuntimeObjectsResponse
-	17, // 68: gitops_core.v1.Core.ListFluxCrds:output_type -> gitops_core.v1.ListFluxCrdsResponse
-	15, // 69: gitops_core.v1.Core.ListRuntimeObjects:output_type -> gitops_core.v1.ListRuntimeObjectsResponse
-	19, // 70: gitops_core.v1.Core.ListRuntimeCrds:output_type -> gitops_core.v1.ListRuntimeCrdsResponse
-	26, // 71: gitops_core.v1.Core.GetReconciledObjects:output_type -> gitops_core.v1.GetReconciledObjectsResponse
-	28, // 72: gitops_core.v1.Core.GetChildObjects:output_type -> gitops_core.v1.GetChildObjectsResponse
-	30, // 73: gitops_core.v1.Core.GetFluxNamespace:output_type -> gitops_core.v1.GetFluxNamespaceResponse
-	32, // 74: gitops_core.v1.Core.ListNamespaces:output_type -> gitops_core.v1.ListNamespacesResponse
-	34, // 75: gitops_core.v1.Core.ListEvents:output_type -> gitops_core.v1.ListEventsResponse
-	36, // 76: gitops_core.v1.Core.SyncFluxObject:output_type -> gitops_core.v1.SyncFluxObjectResponse
-	38, // 77: gitops_core.v1.Core.GetVersion:output_type -> gitops_core.v1.GetVersionResponse
-	40, // 78: gitops_core.v1.Core.GetFeatureFlags:output_type -> gitops_core.v1.GetFeatureFlagsResponse
-	42, // 79: gitops_core.v1.Core.ToggleSuspendResource:output_type -> gitops_core.v1.ToggleSuspendResourceResponse
-	45, // 80: gitops_core.v1.Core.GetSessionLogs:output_type -> gitops_core.v1.GetSessionLogsResponse
-	47, // 81: gitops_core.v1.Core.IsCRDAvailable:output_type -> gitops_core.v1.IsCRDAvailableResponse
-	1,  // 82: gitops_core.v1.Core.GetInventory:output_type -> gitops_core.v1.GetInventoryResponse
-	49, // 83: gitops_core.v1.Core.ListPolicies:output_type -> gitops_core.v1.ListPoliciesResponse
-	51, // 84: gitops_core.v1.Core.GetPolicy:output_type -> gitops_core.v1.GetPolicyResponse
-	4,  // 85: gitops_core.v1.Core.ListPolicyValidations:output_type -> gitops_core.v1.ListPolicyValidationsResponse
-	6,  // 86: gitops_core.v1.Core.GetPolicyValidation:output_type -> gitops_core.v1.GetPolicyValidationResponse
-	65, // [65:87] is the sub-list for method output_type
-	43, // [43:65] is the sub-list for method input_type
-	43, // [43:43] is the sub-list for extension type_name
-	43, // [43:43] is the sub-list for extension extendee
-	0,  // [0:43] is the sub-list for field type_name
+	88,  // 0: gitops_core.v1.GetInventoryResponse.entries:type_name -> gitops_core.v1.InventoryEntry
+	3,   // 1: gitops_core.v1.GetDependencyGraphResponse.nodes:type_name -> gitops_core.v1.DependencyGraphNode
+	4,   // 2: gitops_core.v1.GetDependencyGraphResponse.edges:type_name -> gitops_core.v1.DependencyGraphEdge
+	5,   // 3: gitops_core.v1.GetDependencyGraphResponse.cycles:type_name -> gitops_core.v1.DependencyGraphCycle
+	22,  // 4: gitops_core.v1.GetDependencyGraphResponse.errors:type_name -> gitops_core.v1.ListError
+	8,   // 5: gitops_core.v1.GetDriftResponse.entries:type_name -> gitops_core.v1.DriftEntry
+	11,  // 6: gitops_core.v1.GetHealthHistoryResponse.entries:type_name -> gitops_core.v1.HealthHistoryEntry
+	18,  // 7: gitops_core.v1.PolicyValidation.occurrences:type_name -> gitops_core.v1.PolicyValidationOccurrence
+	19,  // 8: gitops_core.v1.PolicyValidation.parameters:type_name -> gitops_core.v1.PolicyValidationParam
+	21,  // 9: gitops_core.v1.ListPolicyValidationsRequest.pagination:type_name -> gitops_core.v1.Pagination
+	13,  // 10: gitops_core.v1.ListPolicyValidationsResponse.violations:type_name -> gitops_core.v1.PolicyValidation
+	22,  // 11: gitops_core.v1.ListPolicyValidationsResponse.errors:type_name -> gitops_core.v1.ListError
+	13,  // 12: gitops_core.v1.GetPolicyValidationResponse.validation:type_name -> gitops_core.v1.PolicyValidation
+	89,  // 13: gitops_core.v1.PolicyValidationParam.value:type_name -> google.protobuf.Any
+	90,  // 14: gitops_core.v1.ListFluxRuntimeObjectsResponse.deployments:type_name -> gitops_core.v1.Deployment
+	22,  // 15: gitops_core.v1.ListFluxRuntimeObjectsResponse.errors:type_name -> gitops_core.v1.ListError
+	90,  // 16: gitops_core.v1.ListRuntimeObjectsResponse.deployments:type_name -> gitops_core.v1.Deployment
+	22,  // 17: gitops_core.v1.ListRuntimeObjectsResponse.errors:type_name -> gitops_core.v1.ListError
+	91,  // 18: gitops_core.v1.ListFluxCrdsResponse.crds:type_name -> gitops_core.v1.Crd
+	22,  // 19: gitops_core.v1.ListFluxCrdsResponse.errors:type_name -> gitops_core.v1.ListError
+	91,  // 20: gitops_core.v1.ListRuntimeCrdsResponse.crds:type_name -> gitops_core.v1.Crd
+	22,  // 21: gitops_core.v1.ListRuntimeCrdsResponse.errors:type_name -> gitops_core.v1.ListError
+	92,  // 22: gitops_core.v1.GetObjectResponse.object:type_name -> gitops_core.v1.Object
+	83,  // 23: gitops_core.v1.ListObjectsRequest.labels:type_name -> gitops_core.v1.ListObjectsRequest.LabelsEntry
+	21,  // 24: gitops_core.v1.ListObjectsRequest.pagination:type_name -> gitops_core.v1.Pagination
+	84,  // 25: gitops_core.v1.WatchObjectsRequest.labels:type_name -> gitops_core.v1.WatchObjectsRequest.LabelsEntry
+	92,  // 26: gitops_core.v1.WatchObjectsResponse.object:type_name -> gitops_core.v1.Object
+	22,  // 27: gitops_core.v1.WatchObjectsResponse.error:type_name -> gitops_core.v1.ListError
+	92,  // 28: gitops_core.v1.ListObjectsResponse.objects:type_name -> gitops_core.v1.Object
+	22,  // 29: gitops_core.v1.ListObjectsResponse.errors:type_name -> gitops_core.v1.ListError
+	36,  // 30: gitops_core.v1.ListObjectsResponse.searched_namespaces:type_name -> gitops_core.v1.ClusterNamespaceList
+	93,  // 31: gitops_core.v1.GetReconciledObjectsRequest.kinds:type_name -> gitops_core.v1.GroupVersionKind
+	92,  // 32: gitops_core.v1.GetReconciledObjectsResponse.objects:type_name -> gitops_core.v1.Object
+	94,  // 33: gitops_core.v1.ObjectOwners.object:type_name -> gitops_core.v1.ObjectRef
+	94,  // 34: gitops_core.v1.ObjectOwners.owners:type_name -> gitops_core.v1.ObjectRef
+	41,  // 35: gitops_core.v1.FindOwnersResponse.objects:type_name -> gitops_core.v1.ObjectOwners
+	22,  // 36: gitops_core.v1.FindOwnersResponse.errors:type_name -> gitops_core.v1.ListError
+	94,  // 37: gitops_core.v1.ImageWorkload.workload:type_name -> gitops_core.v1.ObjectRef
+	94,  // 38: gitops_core.v1.ImageWorkload.owners:type_name -> gitops_core.v1.ObjectRef
+	44,  // 39: gitops_core.v1.Image.workloads:type_name -> gitops_core.v1.ImageWorkload
+	45,  // 40: gitops_core.v1.ListImagesResponse.images:type_name -> gitops_core.v1.Image
+	22,  // 41: gitops_core.v1.ListImagesResponse.errors:type_name -> gitops_core.v1.ListError
+	93,  // 42: gitops_core.v1.GetChildObjectsRequest.group_version_kind:type_name -> gitops_core.v1.GroupVersionKind
+	92,  // 43: gitops_core.v1.GetChildObjectsResponse.objects:type_name -> gitops_core.v1.Object
+	95,  // 44: gitops_core.v1.ListNamespacesResponse.namespaces:type_name -> gitops_core.v1.Namespace
+	94,  // 45: gitops_core.v1.ListEventsRequest.involved_object:type_name -> gitops_core.v1.ObjectRef
+	96,  // 46: gitops_core.v1.ListEventsResponse.events:type_name -> gitops_core.v1.Event
+	94,  // 47: gitops_core.v1.SyncFluxObjectRequest.objects:type_name -> gitops_core.v1.ObjectRef
+	97,  // 48: gitops_core.v1.SyncFluxObjectResponse.results:type_name -> gitops_core.v1.ObjectResult
+	97,  // 49: gitops_core.v1.OperationObject.result:type_name -> gitops_core.v1.ObjectResult
+	98,  // 50: gitops_core.v1.OperationObject.ready_condition:type_name -> gitops_core.v1.Condition
+	96,  // 51: gitops_core.v1.OperationObject.events:type_name -> gitops_core.v1.Event
+	57,  // 52: gitops_core.v1.Operation.objects:type_name -> gitops_core.v1.OperationObject
+	58,  // 53: gitops_core.v1.GetOperationResponse.operation:type_name -> gitops_core.v1.Operation
+	58,  // 54: gitops_core.v1.ListOperationsResponse.operations:type_name -> gitops_core.v1.Operation
+	85,  // 55: gitops_core.v1.GetFeatureFlagsResponse.flags:type_name -> gitops_core.v1.GetFeatureFlagsResponse.FlagsEntry
+	94,  // 56: gitops_core.v1.ToggleSuspendResourceRequest.objects:type_name -> gitops_core.v1.ObjectRef
+	97,  // 57: gitops_core.v1.ToggleSuspendResourceResponse.results:type_name -> gitops_core.v1.ObjectResult
+	70,  // 58: gitops_core.v1.GetSessionLogsResponse.logs:type_name -> gitops_core.v1.LogEntry
+	86,  // 59: gitops_core.v1.IsCRDAvailableResponse.clusters:type_name -> gitops_core.v1.IsCRDAvailableResponse.ClustersEntry
+	21,  // 60: gitops_core.v1.ListPoliciesRequest.pagination:type_name -> gitops_core.v1.Pagination
+	78,  // 61: gitops_core.v1.ListPoliciesResponse.policies:type_name -> gitops_core.v1.PolicyObj
+	22,  // 62: gitops_core.v1.ListPoliciesResponse.errors:type_name -> gitops_core.v1.ListError
+	78,  // 63: gitops_core.v1.GetPolicyResponse.policy:type_name -> gitops_core.v1.PolicyObj
+	79,  // 64: gitops_core.v1.PolicyObj.standards:type_name -> gitops_core.v1.PolicyStandard
+	80,  // 65: gitops_core.v1.PolicyObj.parameters:type_name -> gitops_core.v1.PolicyParam
+	81,  // 66: gitops_core.v1.PolicyObj.targets:type_name -> gitops_core.v1.PolicyTargets
+	89,  // 67: gitops_core.v1.PolicyParam.value:type_name -> google.protobuf.Any
+	82,  // 68: gitops_core.v1.PolicyTargets.labels:type_name -> gitops_core.v1.PolicyTargetLabel
+	87,  // 69: gitops_core.v1.PolicyTargetLabel.values:type_name -> gitops_core.v1.PolicyTargetLabel.ValuesEntry
+	31,  // 70: gitops_core.v1.Core.GetObject:input_type -> gitops_core.v1.GetObjectRequest
+	33,  // 71: gitops_core.v1.Core.ListObjects:input_type -> gitops_core.v1.ListObjectsRequest
+	34,  // 72: gitops_core.v1.Core.WatchObjects:input_type -> gitops_core.v1.WatchObjectsRequest
+	23,  // 73: gitops_core.v1.Core.ListFluxRuntimeObjects:input_type -> gitops_core.v1.ListFluxRuntimeObjectsRequest
+	27,  // 74: gitops_core.v1.Core.ListFluxCrds:input_type -> gitops_core.v1.ListFluxCrdsRequest
+	25,  // 75: gitops_core.v1.Core.ListRuntimeObjects:input_type -> gitops_core.v1.ListRuntimeObjectsRequest
+	29,  // 76: gitops_core.v1.Core.ListRuntimeCrds:input_type -> gitops_core.v1.ListRuntimeCrdsRequest
+	38,  // 77: gitops_core.v1.Core.GetReconciledObjects:input_type -> gitops_core.v1.GetReconciledObjectsRequest
+	40,  // 78: gitops_core.v1.Core.FindOwners:input_type -> gitops_core.v1.FindOwnersRequest
+	43,  // 79: gitops_core.v1.Core.ListImages:input_type -> gitops_core.v1.ListImagesRequest
+	47,  // 80: gitops_core.v1.Core.GetChildObjects:input_type -> gitops_core.v1.GetChildObjectsRequest
+	49,  // 81: gitops_core.v1.Core.GetFluxNamespace:input_type -> gitops_core.v1.GetFluxNamespaceRequest
+	51,  // 82: gitops_core.v1.Core.ListNamespaces:input_type -> gitops_core.v1.ListNamespacesRequest
+	53,  // 83: gitops_core.v1.Core.ListEvents:input_type -> gitops_core.v1.ListEventsRequest
+	55,  // 84: gitops_core.v1.Core.SyncFluxObject:input_type -> gitops_core.v1.SyncFluxObjectRequest
+	59,  // 85: gitops_core.v1.Core.GetOperation:input_type -> gitops_core.v1.GetOperationRequest
+	61,  // 86: gitops_core.v1.Core.ListOperations:input_type -> gitops_core.v1.ListOperationsRequest
+	63,  // 87: gitops_core.v1.Core.GetVersion:input_type -> gitops_core.v1.GetVersionRequest
+	65,  // 88: gitops_core.v1.Core.GetFeatureFlags:input_type -> gitops_core.v1.GetFeatureFlagsRequest
+	67,  // 89: gitops_core.v1.Core.ToggleSuspendResource:input_type -> gitops_core.v1.ToggleSuspendResourceRequest
+	69,  // 90: gitops_core.v1.Core.GetSessionLogs:input_type -> gitops_core.v1.GetSessionLogsRequest
+	72,  // 91: gitops_core.v1.Core.IsCRDAvailable:input_type -> gitops_core.v1.IsCRDAvailableRequest
+	0,   // 92: gitops_core.v1.Core.GetInventory:input_type -> gitops_core.v1.GetInventoryRequest
+	2,   // 93: gitops_core.v1.Core.GetDependencyGraph:input_type -> gitops_core.v1.GetDependencyGraphRequest
+	7,   // 94: gitops_core.v1.Core.GetDrift:input_type -> gitops_core.v1.GetDriftRequest
+	10,  // 95: gitops_core.v1.Core.GetHealthHistory:input_type -> gitops_core.v1.GetHealthHistoryRequest
+	74,  // 96: gitops_core.v1.Core.ListPolicies:input_type -> gitops_core.v1.ListPoliciesRequest
+	76,  // 97: gitops_core.v1.Core.GetPolicy:input_type -> gitops_core.v1.GetPolicyRequest
+	14,  // 98: gitops_core.v1.Core.ListPolicyValidations:input_type -> gitops_core.v1.ListPolicyValidationsRequest
+	16,  // 99: gitops_core.v1.Core.GetPolicyValidation:input_type -> gitops_core.v1.GetPolicyValidationRequest
+	32,  // 100: gitops_core.v1.Core.GetObject:output_type -> gitops_core.v1.GetObjectResponse
+	37,  // 101: gitops_core.v1.Core.ListObjects:output_type -> gitops_core.v1.ListObjectsResponse
+	35,  // 102: gitops_core.v1.Core.WatchObjects:output_type -> gitops_core.v1.WatchObjectsResponse
+	24,  // 103: gitops_core.v1.Core.ListFluxRuntimeObjects:output_type -> gitops_core.v1.ListFluxRuntimeObjectsResponse
+	28,  // 104: gitops_core.v1.Core.ListFluxCrds:output_type -> gitops_core.v1.ListFluxCrdsResponse
+	26,  // 105: gitops_core.v1.Core.ListRuntimeObjects:output_type -> gitops_core.v1.ListRuntimeObjectsResponse
+	30,  // 106: gitops_core.v1.Core.ListRuntimeCrds:output_type -> gitops_core.v1.ListRuntimeCrdsResponse
+	39,  // 107: gitops_core.v1.Core.GetReconciledObjects:output_type -> gitops_core.v1.GetReconciledObjectsResponse
+	42,  // 108: gitops_core.v1.Core.FindOwners:output_type -> gitops_core.v1.FindOwnersResponse
+	46,  // 109: gitops_core.v1.Core.ListImages:output_type -> gitops_core.v1.ListImagesResponse
+	48,  // 110: gitops_core.v1.Core.GetChildObjects:output_type -> gitops_core.v1.GetChildObjectsResponse
+	50,  // 111: gitops_core.v1.Core.GetFluxNamespace:output_type -> gitops_core.v1.GetFluxNamespaceResponse
+	52,  // 112: gitops_core.v1.Core.ListNamespaces:output_type -> gitops_core.v1.ListNamespacesResponse
+	54,  // 113: gitops_core.v1.Core.ListEvents:output_type -> gitops_core.v1.ListEventsResponse
+	56,  // 114: gitops_core.v1.Core.SyncFluxObject:output_type -> gitops_core.v1.SyncFluxObjectResponse
+	60,  // 115: gitops_core.v1.Core.GetOperation:output_type -> gitops_core.v1.GetOperationResponse
+	62,  // 116: gitops_core.v1.Core.ListOperations:output_type -> gitops_core.v1.ListOperationsResponse
+	64,  // 117: gitops_core.v1.Core.GetVersion:output_type -> gitops_core.v1.GetVersionResponse
+	66,  // 118: gitops_core.v1.Core.GetFeatureFlags:output_type -> gitops_core.v1.GetFeatureFlagsResponse
+	68,  // 119: gitops_core.v1.Core.ToggleSuspendResource:output_type -> gitops_core.v1.ToggleSuspendResourceResponse
+	71,  // 120: gitops_core.v1.Core.GetSessionLogs:output_type -> gitops_core.v1.GetSessionLogsResponse
+	73,  // 121: gitops_core.v1.Core.IsCRDAvailable:output_type -> gitops_core.v1.IsCRDAvailableResponse
+	1,   // 122: gitops_core.v1.Core.GetInventory:output_type -> gitops_core.v1.GetInventoryResponse
+	6,   // 123: gitops_core.v1.Core.GetDependencyGraph:output_type -> gitops_core.v1.GetDependencyGraphResponse
+	9,   // 124: gitops_core.v1.Core.GetDrift:output_type -> gitops_core.v1.GetDriftResponse
+	12,  // 125: gitops_core.v1.Core.GetHealthHistory:output_type -> gitops_core.v1.GetHealthHistoryResponse
+	75,  // 126: gitops_core.v1.Core.ListPolicies:output_type -> gitops_core.v1.ListPoliciesResponse
+	77,  // 127: gitops_core.v1.Core.GetPolicy:output_type -> gitops_core.v1.GetPolicyResponse
+	15,  // 128: gitops_core.v1.Core.ListPolicyValidations:output_type -> gitops_core.v1.ListPolicyValidationsResponse
+	17,  // 129: gitops_core.v1.Core.GetPolicyValidation:output_type -> gitops_core.v1.GetPolicyValidationResponse
+	100, // [100:130] is the sub-list for method output_type
+	70,  // [70:100] is the sub-list for method input_type
+	70,  // [70:70] is the sub-list for extension type_name
+	70,  // [70:70] is the sub-list for extension extendee
+	0,   // [0:70] is the sub-list for field type_name
 }
 
 func init() { file_api_core_core_proto_init() }
@@ -3819,7 +5801,7 @@ func file_api_core_core_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_core_core_proto_rawDesc), len(file_api_core_core_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   61,
+			NumMessages:   88,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
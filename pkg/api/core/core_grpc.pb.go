@@ -25,22 +25,30 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	Core_GetObject_FullMethodName              = "/gitops_core.v1.Core/GetObject"
 	Core_ListObjects_FullMethodName            = "/gitops_core.v1.Core/ListObjects"
+	Core_WatchObjects_FullMethodName           = "/gitops_core.v1.Core/WatchObjects"
 	Core_ListFluxRuntimeObjects_FullMethodName = "/gitops_core.v1.Core/ListFluxRuntimeObjects"
 	Core_ListFluxCrds_FullMethodName           = "/gitops_core.v1.Core/ListFluxCrds"
 	Core_ListRuntimeObjects_FullMethodName     = "/gitops_core.v1.Core/ListRuntimeObjects"
 	Core_ListRuntimeCrds_FullMethodName        = "/gitops_core.v1.Core/ListRuntimeCrds"
 	Core_GetReconciledObjects_FullMethodName   = "/gitops_core.v1.Core/GetReconciledObjects"
+	Core_FindOwners_FullMethodName             = "/gitops_core.v1.Core/FindOwners"
+	Core_ListImages_FullMethodName             = "/gitops_core.v1.Core/ListImages"
 	Core_GetChildObjects_FullMethodName        = "/gitops_core.v1.Core/GetChildObjects"
 	Core_GetFluxNamespace_FullMethodName       = "/gitops_core.v1.Core/GetFluxNamespace"
 	Core_ListNamespaces_FullMethodName         = "/gitops_core.v1.Core/ListNamespaces"
 	Core_ListEvents_FullMethodName             = "/gitops_core.v1.Core/ListEvents"
 	Core_SyncFluxObject_FullMethodName         = "/gitops_core.v1.Core/SyncFluxObject"
+	Core_GetOperation_FullMethodName           = "/gitops_core.v1.Core/GetOperation"
+	Core_ListOperations_FullMethodName         = "/gitops_core.v1.Core/ListOperations"
 	Core_GetVersion_FullMethodName             = "/gitops_core.v1.Core/GetVersion"
 	Core_GetFeatureFlags_FullMethodName        = "/gitops_core.v1.Core/GetFeatureFlags"
 	Core_ToggleSuspendResource_FullMethodName  = "/gitops_core.v1.Core/ToggleSuspendResource"
 	Core_GetSessionLogs_FullMethodName         = "/gitops_core.v1.Core/GetSessionLogs"
 	Core_IsCRDAvailable_FullMethodName         = "/gitops_core.v1.Core/IsCRDAvailable"
 	Core_GetInventory_FullMethodName           = "/gitops_core.v1.Core/GetInventory"
+	Core_GetDependencyGraph_FullMethodName     = "/gitops_core.v1.Core/GetDependencyGraph"
+	Core_GetDrift_FullMethodName               = "/gitops_core.v1.Core/GetDrift"
+	Core_GetHealthHistory_FullMethodName       = "/gitops_core.v1.Core/GetHealthHistory"
 	Core_ListPolicies_FullMethodName           = "/gitops_core.v1.Core/ListPolicies"
 	Core_GetPolicy_FullMethodName              = "/gitops_core.v1.Core/GetPolicy"
 	Core_ListPolicyValidations_FullMethodName  = "/gitops_core.v1.Core/ListPolicyValidations"
@@ -58,6 +66,9 @@ type CoreClient interface {
 	GetObject(ctx context.Context, in *GetObjectRequest, opts ...grpc.CallOption) (*GetObjectResponse, error)
 	// ListObjects gets data about primary objects.
 	ListObjects(ctx context.Context, in *ListObjectsRequest, opts ...grpc.CallOption) (*ListObjectsResponse, error)
+	// WatchObjects streams changes to primary objects across all the
+	// clusters and namespaces the user has access to.
+	WatchObjects(ctx context.Context, in *WatchObjectsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchObjectsResponse], error)
 	// ListFluxRuntimeObjects lists the flux runtime deployments from a cluster.
 	ListFluxRuntimeObjects(ctx context.Context, in *ListFluxRuntimeObjectsRequest, opts ...grpc.CallOption) (*ListFluxRuntimeObjectsResponse, error)
 	ListFluxCrds(ctx context.Context, in *ListFluxCrdsRequest, opts ...grpc.CallOption) (*ListFluxCrdsResponse, error)
@@ -74,6 +85,15 @@ type CoreClient interface {
 	// This list is derived by looking at the Kustomization or HelmRelease
 	// specified in the request body.
 	GetReconciledObjects(ctx context.Context, in *GetReconciledObjectsRequest, opts ...grpc.CallOption) (*GetReconciledObjectsResponse, error)
+	// FindOwners returns the Flux automations managing an object, or the
+	// workloads running an image, across the clusters. The owners are found
+	// from the Kustomize and Helm ownership labels of the objects and from
+	// the Kustomization inventories.
+	FindOwners(ctx context.Context, in *FindOwnersRequest, opts ...grpc.CallOption) (*FindOwnersResponse, error)
+	// ListImages returns the images of the workloads across the clusters,
+	// grouped by repository and tag, with the Flux automations managing the
+	// workloads.
+	ListImages(ctx context.Context, in *ListImagesRequest, opts ...grpc.CallOption) (*ListImagesResponse, error)
 	// GetChildObjects returns the children of a given object,
 	// specified by a GroupVersionKind.
 	// Not all Kubernets objects have children. For example, a Deployment
@@ -87,6 +107,11 @@ type CoreClient interface {
 	ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (*ListEventsResponse, error)
 	// SyncResource forces a reconciliation of a Flux resource
 	SyncFluxObject(ctx context.Context, in *SyncFluxObjectRequest, opts ...grpc.CallOption) (*SyncFluxObjectResponse, error)
+	// GetOperation returns the progress of an asynchronous operation,
+	// such as a sync started with async set.
+	GetOperation(ctx context.Context, in *GetOperationRequest, opts ...grpc.CallOption) (*GetOperationResponse, error)
+	// ListOperations lists the asynchronous operations started by the user.
+	ListOperations(ctx context.Context, in *ListOperationsRequest, opts ...grpc.CallOption) (*ListOperationsResponse, error)
 	// GetVersion returns version information about the server
 	GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionResponse, error)
 	// GetFeatureFlags returns configuration information about the server
@@ -100,6 +125,19 @@ type CoreClient interface {
 	// installed or not on that cluster.
 	IsCRDAvailable(ctx context.Context, in *IsCRDAvailableRequest, opts ...grpc.CallOption) (*IsCRDAvailableResponse, error)
 	GetInventory(ctx context.Context, in *GetInventoryRequest, opts ...grpc.CallOption) (*GetInventoryResponse, error)
+	// GetDependencyGraph returns the graph of Kustomizations and HelmReleases,
+	// linked to their sources, to the automations they depend on and to the
+	// automations in their inventory. Cycles and missing references are
+	// reported so blocked automations can be traced back.
+	GetDependencyGraph(ctx context.Context, in *GetDependencyGraphRequest, opts ...grpc.CallOption) (*GetDependencyGraphResponse, error)
+	// GetDrift builds the manifests of a Kustomization from its source
+	// artifact and dry-runs them against the cluster, returning a diff for
+	// each object that would change. Secret data is redacted. Only
+	// Kustomizations of the management cluster are supported.
+	GetDrift(ctx context.Context, in *GetDriftRequest, opts ...grpc.CallOption) (*GetDriftResponse, error)
+	// GetHealthHistory returns the health changes the server recorded for a
+	// Flux-managed object, and whether it's flapping between statuses.
+	GetHealthHistory(ctx context.Context, in *GetHealthHistoryRequest, opts ...grpc.CallOption) (*GetHealthHistoryResponse, error)
 	// ListPolicies list policies available on the cluster
 	ListPolicies(ctx context.Context, in *ListPoliciesRequest, opts ...grpc.CallOption) (*ListPoliciesResponse, error)
 	// GetPolicy gets a policy by name
@@ -138,6 +176,25 @@ func (c *coreClient) ListObjects(ctx context.Context, in *ListObjectsRequest, op
 	return out, nil
 }
 
+func (c *coreClient) WatchObjects(ctx context.Context, in *WatchObjectsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchObjectsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Core_ServiceDesc.Streams[0], Core_WatchObjects_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchObjectsRequest, WatchObjectsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Core_WatchObjectsClient = grpc.ServerStreamingClient[WatchObjectsResponse]
+
 func (c *coreClient) ListFluxRuntimeObjects(ctx context.Context, in *ListFluxRuntimeObjectsRequest, opts ...grpc.CallOption) (*ListFluxRuntimeObjectsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListFluxRuntimeObjectsResponse)
@@ -188,6 +245,26 @@ func (c *coreClient) GetReconciledObjects(ctx context.Context, in *GetReconciled
 	return out, nil
 }
 
+func (c *coreClient) FindOwners(ctx context.Context, in *FindOwnersRequest, opts ...grpc.CallOption) (*FindOwnersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindOwnersResponse)
+	err := c.cc.Invoke(ctx, Core_FindOwners_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreClient) ListImages(ctx context.Context, in *ListImagesRequest, opts ...grpc.CallOption) (*ListImagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListImagesResponse)
+	err := c.cc.Invoke(ctx, Core_ListImages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *coreClient) GetChildObjects(ctx context.Context, in *GetChildObjectsRequest, opts ...grpc.CallOption) (*GetChildObjectsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetChildObjectsResponse)
@@ -238,6 +315,26 @@ func (c *coreClient) SyncFluxObject(ctx context.Context, in *SyncFluxObjectReque
 	return out, nil
 }
 
+func (c *coreClient) GetOperation(ctx context.Context, in *GetOperationRequest, opts ...grpc.CallOption) (*GetOperationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOperationResponse)
+	err := c.cc.Invoke(ctx, Core_GetOperation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreClient) ListOperations(ctx context.Context, in *ListOperationsRequest, opts ...grpc.CallOption) (*ListOperationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOperationsResponse)
+	err := c.cc.Invoke(ctx, Core_ListOperations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *coreClient) GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetVersionResponse)
@@ -298,6 +395,36 @@ func (c *coreClient) GetInventory(ctx context.Context, in *GetInventoryRequest,
 	return out, nil
 }
 
+func (c *coreClient) GetDependencyGraph(ctx context.Context, in *GetDependencyGraphRequest, opts ...grpc.CallOption) (*GetDependencyGraphResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDependencyGraphResponse)
+	err := c.cc.Invoke(ctx, Core_GetDependencyGraph_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreClient) GetDrift(ctx context.Context, in *GetDriftRequest, opts ...grpc.CallOption) (*GetDriftResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDriftResponse)
+	err := c.cc.Invoke(ctx, Core_GetDrift_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreClient) GetHealthHistory(ctx context.Context, in *GetHealthHistoryRequest, opts ...grpc.CallOption) (*GetHealthHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetHealthHistoryResponse)
+	err := c.cc.Invoke(ctx, Core_GetHealthHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *coreClient) ListPolicies(ctx context.Context, in *ListPoliciesRequest, opts ...grpc.CallOption) (*ListPoliciesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListPoliciesResponse)
@@ -349,6 +476,9 @@ type CoreServer interface {
 	GetObject(context.Context, *GetObjectRequest) (*GetObjectResponse, error)
 	// ListObjects gets data about primary objects.
 	ListObjects(context.Context, *ListObjectsRequest) (*ListObjectsResponse, error)
+	// WatchObjects streams changes to primary objects across all the
+	// clusters and namespaces the user has access to.
+	WatchObjects(*WatchObjectsRequest, grpc.ServerStreamingServer[WatchObjectsResponse]) error
 	// ListFluxRuntimeObjects lists the flux runtime deployments from a cluster.
 	ListFluxRuntimeObjects(context.Context, *ListFluxRuntimeObjectsRequest) (*ListFluxRuntimeObjectsResponse, error)
 	ListFluxCrds(context.Context, *ListFluxCrdsRequest) (*ListFluxCrdsResponse, error)
@@ -365,6 +495,15 @@ type CoreServer interface {
 	// This list is derived by looking at the Kustomization or HelmRelease
 	// specified in the request body.
 	GetReconciledObjects(context.Context, *GetReconciledObjectsRequest) (*GetReconciledObjectsResponse, error)
+	// FindOwners returns the Flux automations managing an object, or the
+	// workloads running an image, across the clusters. The owners are found
+	// from the Kustomize and Helm ownership labels of the objects and from
+	// the Kustomization inventories.
+	FindOwners(context.Context, *FindOwnersRequest) (*FindOwnersResponse, error)
+	// ListImages returns the images of the workloads across the clusters,
+	// grouped by repository and tag, with the Flux automations managing the
+	// workloads.
+	ListImages(context.Context, *ListImagesRequest) (*ListImagesResponse, error)
 	// GetChildObjects returns the children of a given object,
 	// specified by a GroupVersionKind.
 	// Not all Kubernets objects have children. For example, a Deployment
@@ -378,6 +517,11 @@ type CoreServer interface {
 	ListEvents(context.Context, *ListEventsRequest) (*ListEventsResponse, error)
 	// SyncResource forces a reconciliation of a Flux resource
 	SyncFluxObject(context.Context, *SyncFluxObjectRequest) (*SyncFluxObjectResponse, error)
+	// GetOperation returns the progress of an asynchronous operation,
+	// such as a sync started with async set.
+	GetOperation(context.Context, *GetOperationRequest) (*GetOperationResponse, error)
+	// ListOperations lists the asynchronous operations started by the user.
+	ListOperations(context.Context, *ListOperationsRequest) (*ListOperationsResponse, error)
 	// GetVersion returns version information about the server
 	GetVersion(context.Context, *GetVersionRequest) (*GetVersionResponse, error)
 	// GetFeatureFlags returns configuration information about the server
@@ -391,6 +535,19 @@ type CoreServer interface {
 	// installed or not on that cluster.
 	IsCRDAvailable(context.Context, *IsCRDAvailableRequest) (*IsCRDAvailableResponse, error)
 	GetInventory(context.Context, *GetInventoryRequest) (*GetInventoryResponse, error)
+	// GetDependencyGraph returns the graph of Kustomizations and HelmReleases,
+	// linked to their sources, to the automations they depend on and to the
+	// automations in their inventory. Cycles and missing references are
+	// reported so blocked automations can be traced back.
+	GetDependencyGraph(context.Context, *GetDependencyGraphRequest) (*GetDependencyGraphResponse, error)
+	// GetDrift builds the manifests of a Kustomization from its source
+	// artifact and dry-runs them against the cluster, returning a diff for
+	// each object that would change. Secret data is redacted. Only
+	// Kustomizations of the management cluster are supported.
+	GetDrift(context.Context, *GetDriftRequest) (*GetDriftResponse, error)
+	// GetHealthHistory returns the health changes the server recorded for a
+	// Flux-managed object, and whether it's flapping between statuses.
+	GetHealthHistory(context.Context, *GetHealthHistoryRequest) (*GetHealthHistoryResponse, error)
 	// ListPolicies list policies available on the cluster
 	ListPolicies(context.Context, *ListPoliciesRequest) (*ListPoliciesResponse, error)
 	// GetPolicy gets a policy by name
@@ -415,6 +572,9 @@ func (UnimplementedCoreServer) GetObject(context.Context, *GetObjectRequest) (*G
 func (UnimplementedCoreServer) ListObjects(context.Context, *ListObjectsRequest) (*ListObjectsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListObjects not implemented")
 }
+func (UnimplementedCoreServer) WatchObjects(*WatchObjectsRequest, grpc.ServerStreamingServer[WatchObjectsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchObjects not implemented")
+}
 func (UnimplementedCoreServer) ListFluxRuntimeObjects(context.Context, *ListFluxRuntimeObjectsRequest) (*ListFluxRuntimeObjectsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListFluxRuntimeObjects not implemented")
 }
@@ -430,6 +590,12 @@ func (UnimplementedCoreServer) ListRuntimeCrds(context.Context, *ListRuntimeCrds
 func (UnimplementedCoreServer) GetReconciledObjects(context.Context, *GetReconciledObjectsRequest) (*GetReconciledObjectsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetReconciledObjects not implemented")
 }
+func (UnimplementedCoreServer) FindOwners(context.Context, *FindOwnersRequest) (*FindOwnersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindOwners not implemented")
+}
+func (UnimplementedCoreServer) ListImages(context.Context, *ListImagesRequest) (*ListImagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListImages not implemented")
+}
 func (UnimplementedCoreServer) GetChildObjects(context.Context, *GetChildObjectsRequest) (*GetChildObjectsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetChildObjects not implemented")
 }
@@ -445,6 +611,12 @@ func (UnimplementedCoreServer) ListEvents(context.Context, *ListEventsRequest) (
 func (UnimplementedCoreServer) SyncFluxObject(context.Context, *SyncFluxObjectRequest) (*SyncFluxObjectResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SyncFluxObject not implemented")
 }
+func (UnimplementedCoreServer) GetOperation(context.Context, *GetOperationRequest) (*GetOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOperation not implemented")
+}
+func (UnimplementedCoreServer) ListOperations(context.Context, *ListOperationsRequest) (*ListOperationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOperations not implemented")
+}
 func (UnimplementedCoreServer) GetVersion(context.Context, *GetVersionRequest) (*GetVersionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetVersion not implemented")
 }
@@ -463,6 +635,15 @@ func (UnimplementedCoreServer) IsCRDAvailable(context.Context, *IsCRDAvailableRe
 func (UnimplementedCoreServer) GetInventory(context.Context, *GetInventoryRequest) (*GetInventoryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInventory not implemented")
 }
+func (UnimplementedCoreServer) GetDependencyGraph(context.Context, *GetDependencyGraphRequest) (*GetDependencyGraphResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDependencyGraph not implemented")
+}
+func (UnimplementedCoreServer) GetDrift(context.Context, *GetDriftRequest) (*GetDriftResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDrift not implemented")
+}
+func (UnimplementedCoreServer) GetHealthHistory(context.Context, *GetHealthHistoryRequest) (*GetHealthHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHealthHistory not implemented")
+}
 func (UnimplementedCoreServer) ListPolicies(context.Context, *ListPoliciesRequest) (*ListPoliciesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListPolicies not implemented")
 }
@@ -532,6 +713,17 @@ func _Core_ListObjects_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Core_WatchObjects_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchObjectsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CoreServer).WatchObjects(m, &grpc.GenericServerStream[WatchObjectsRequest, WatchObjectsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Core_WatchObjectsServer = grpc.ServerStreamingServer[WatchObjectsResponse]
+
 func _Core_ListFluxRuntimeObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListFluxRuntimeObjectsRequest)
 	if err := dec(in); err != nil {
@@ -622,6 +814,42 @@ func _Core_GetReconciledObjects_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Core_FindOwners_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindOwnersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreServer).FindOwners(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Core_FindOwners_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreServer).FindOwners(ctx, req.(*FindOwnersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Core_ListImages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListImagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreServer).ListImages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Core_ListImages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreServer).ListImages(ctx, req.(*ListImagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Core_GetChildObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetChildObjectsRequest)
 	if err := dec(in); err != nil {
@@ -712,6 +940,42 @@ func _Core_SyncFluxObject_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Core_GetOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreServer).GetOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Core_GetOperation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreServer).GetOperation(ctx, req.(*GetOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Core_ListOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOperationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreServer).ListOperations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Core_ListOperations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreServer).ListOperations(ctx, req.(*ListOperationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Core_GetVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetVersionRequest)
 	if err := dec(in); err != nil {
@@ -820,6 +1084,60 @@ func _Core_GetInventory_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Core_GetDependencyGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDependencyGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreServer).GetDependencyGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Core_GetDependencyGraph_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreServer).GetDependencyGraph(ctx, req.(*GetDependencyGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Core_GetDrift_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDriftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreServer).GetDrift(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Core_GetDrift_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreServer).GetDrift(ctx, req.(*GetDriftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Core_GetHealthHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHealthHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreServer).GetHealthHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Core_GetHealthHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreServer).GetHealthHistory(ctx, req.(*GetHealthHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Core_ListPolicies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListPoliciesRequest)
 	if err := dec(in); err != nil {
@@ -927,6 +1245,14 @@ var Core_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetReconciledObjects",
 			Handler:    _Core_GetReconciledObjects_Handler,
 		},
+		{
+			MethodName: "FindOwners",
+			Handler:    _Core_FindOwners_Handler,
+		},
+		{
+			MethodName: "ListImages",
+			Handler:    _Core_ListImages_Handler,
+		},
 		{
 			MethodName: "GetChildObjects",
 			Handler:    _Core_GetChildObjects_Handler,
@@ -947,6 +1273,14 @@ var Core_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SyncFluxObject",
 			Handler:    _Core_SyncFluxObject_Handler,
 		},
+		{
+			MethodName: "GetOperation",
+			Handler:    _Core_GetOperation_Handler,
+		},
+		{
+			MethodName: "ListOperations",
+			Handler:    _Core_ListOperations_Handler,
+		},
 		{
 			MethodName: "GetVersion",
 			Handler:    _Core_GetVersion_Handler,
@@ -971,6 +1305,18 @@ var Core_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetInventory",
 			Handler:    _Core_GetInventory_Handler,
 		},
+		{
+			MethodName: "GetDependencyGraph",
+			Handler:    _Core_GetDependencyGraph_Handler,
+		},
+		{
+			MethodName: "GetDrift",
+			Handler:    _Core_GetDrift_Handler,
+		},
+		{
+			MethodName: "GetHealthHistory",
+			Handler:    _Core_GetHealthHistory_Handler,
+		},
 		{
 			MethodName: "ListPolicies",
 			Handler:    _Core_ListPolicies_Handler,
@@ -988,6 +1334,12 @@ var Core_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _Core_GetPolicyValidation_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchObjects",
+			Handler:       _Core_WatchObjects_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "api/core/core.proto",
 }
@@ -288,6 +288,77 @@ func (x *ObjectRef) GetClusterName() string {
 	return ""
 }
 
+// ObjectResult is the outcome of an operation on a single object.
+type ObjectResult struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Object *ObjectRef             `protobuf:"bytes,1,opt,name=object,proto3" json:"object,omitempty"`
+	// status is one of Succeeded, Failed or Skipped
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Error  string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// duration_ms is how long the operation on the object took
+	DurationMs    int64 `protobuf:"varint,4,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ObjectResult) Reset() {
+	*x = ObjectResult{}
+	mi := &file_api_core_types_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObjectResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObjectResult) ProtoMessage() {}
+
+func (x *ObjectResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_core_types_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObjectResult.ProtoReflect.Descriptor instead.
+func (*ObjectResult) Descriptor() ([]byte, []int) {
+	return file_api_core_types_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ObjectResult) GetObject() *ObjectRef {
+	if x != nil {
+		return x.Object
+	}
+	return nil
+}
+
+func (x *ObjectResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ObjectResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ObjectResult) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
 type Condition struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
@@ -301,7 +372,7 @@ type Condition struct {
 
 func (x *Condition) Reset() {
 	*x = Condition{}
-	mi := &file_api_core_types_proto_msgTypes[2]
+	mi := &file_api_core_types_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -313,7 +384,7 @@ func (x *Condition) String() string {
 func (*Condition) ProtoMessage() {}
 
 func (x *Condition) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_types_proto_msgTypes[2]
+	mi := &file_api_core_types_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -326,7 +397,7 @@ func (x *Condition) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Condition.ProtoReflect.Descriptor instead.
 func (*Condition) Descriptor() ([]byte, []int) {
-	return file_api_core_types_proto_rawDescGZIP(), []int{2}
+	return file_api_core_types_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Condition) GetType() string {
@@ -376,7 +447,7 @@ type GitRepositoryRef struct {
 
 func (x *GitRepositoryRef) Reset() {
 	*x = GitRepositoryRef{}
-	mi := &file_api_core_types_proto_msgTypes[3]
+	mi := &file_api_core_types_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -388,7 +459,7 @@ func (x *GitRepositoryRef) String() string {
 func (*GitRepositoryRef) ProtoMessage() {}
 
 func (x *GitRepositoryRef) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_types_proto_msgTypes[3]
+	mi := &file_api_core_types_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -401,7 +472,7 @@ func (x *GitRepositoryRef) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GitRepositoryRef.ProtoReflect.Descriptor instead.
 func (*GitRepositoryRef) Descriptor() ([]byte, []int) {
-	return file_api_core_types_proto_rawDescGZIP(), []int{3}
+	return file_api_core_types_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GitRepositoryRef) GetBranch() string {
@@ -444,7 +515,7 @@ type GroupVersionKind struct {
 
 func (x *GroupVersionKind) Reset() {
 	*x = GroupVersionKind{}
-	mi := &file_api_core_types_proto_msgTypes[4]
+	mi := &file_api_core_types_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -456,7 +527,7 @@ func (x *GroupVersionKind) String() string {
 func (*GroupVersionKind) ProtoMessage() {}
 
 func (x *GroupVersionKind) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_types_proto_msgTypes[4]
+	mi := &file_api_core_types_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -469,7 +540,7 @@ func (x *GroupVersionKind) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupVersionKind.ProtoReflect.Descriptor instead.
 func (*GroupVersionKind) Descriptor() ([]byte, []int) {
-	return file_api_core_types_proto_rawDescGZIP(), []int{4}
+	return file_api_core_types_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GroupVersionKind) GetGroup() string {
@@ -503,7 +574,7 @@ type NamespacedObjectReference struct {
 
 func (x *NamespacedObjectReference) Reset() {
 	*x = NamespacedObjectReference{}
-	mi := &file_api_core_types_proto_msgTypes[5]
+	mi := &file_api_core_types_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -515,7 +586,7 @@ func (x *NamespacedObjectReference) String() string {
 func (*NamespacedObjectReference) ProtoMessage() {}
 
 func (x *NamespacedObjectReference) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_types_proto_msgTypes[5]
+	mi := &file_api_core_types_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -528,7 +599,7 @@ func (x *NamespacedObjectReference) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NamespacedObjectReference.ProtoReflect.Descriptor instead.
 func (*NamespacedObjectReference) Descriptor() ([]byte, []int) {
-	return file_api_core_types_proto_rawDescGZIP(), []int{5}
+	return file_api_core_types_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *NamespacedObjectReference) GetName() string {
@@ -555,7 +626,7 @@ type HealthStatus struct {
 
 func (x *HealthStatus) Reset() {
 	*x = HealthStatus{}
-	mi := &file_api_core_types_proto_msgTypes[6]
+	mi := &file_api_core_types_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -567,7 +638,7 @@ func (x *HealthStatus) String() string {
 func (*HealthStatus) ProtoMessage() {}
 
 func (x *HealthStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_types_proto_msgTypes[6]
+	mi := &file_api_core_types_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -580,7 +651,7 @@ func (x *HealthStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthStatus.ProtoReflect.Descriptor instead.
 func (*HealthStatus) Descriptor() ([]byte, []int) {
-	return file_api_core_types_proto_rawDescGZIP(), []int{6}
+	return file_api_core_types_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *HealthStatus) GetStatus() string {
@@ -610,7 +681,7 @@ type InventoryEntry struct {
 
 func (x *InventoryEntry) Reset() {
 	*x = InventoryEntry{}
-	mi := &file_api_core_types_proto_msgTypes[7]
+	mi := &file_api_core_types_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -622,7 +693,7 @@ func (x *InventoryEntry) String() string {
 func (*InventoryEntry) ProtoMessage() {}
 
 func (x *InventoryEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_types_proto_msgTypes[7]
+	mi := &file_api_core_types_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -635,7 +706,7 @@ func (x *InventoryEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InventoryEntry.ProtoReflect.Descriptor instead.
 func (*InventoryEntry) Descriptor() ([]byte, []int) {
-	return file_api_core_types_proto_rawDescGZIP(), []int{7}
+	return file_api_core_types_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *InventoryEntry) GetPayload() string {
@@ -674,21 +745,25 @@ func (x *InventoryEntry) GetChildren() []*InventoryEntry {
 }
 
 type Object struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Payload       string                 `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
-	ClusterName   string                 `protobuf:"bytes,2,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
-	Tenant        string                 `protobuf:"bytes,3,opt,name=tenant,proto3" json:"tenant,omitempty"`
-	Uid           string                 `protobuf:"bytes,4,opt,name=uid,proto3" json:"uid,omitempty"`
-	Inventory     []*GroupVersionKind    `protobuf:"bytes,5,rep,name=inventory,proto3" json:"inventory,omitempty"`
-	Info          string                 `protobuf:"bytes,6,opt,name=info,proto3" json:"info,omitempty"`
-	Health        *HealthStatus          `protobuf:"bytes,7,opt,name=health,proto3" json:"health,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Payload     string                 `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	ClusterName string                 `protobuf:"bytes,2,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	Tenant      string                 `protobuf:"bytes,3,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Uid         string                 `protobuf:"bytes,4,opt,name=uid,proto3" json:"uid,omitempty"`
+	Inventory   []*GroupVersionKind    `protobuf:"bytes,5,rep,name=inventory,proto3" json:"inventory,omitempty"`
+	Info        string                 `protobuf:"bytes,6,opt,name=info,proto3" json:"info,omitempty"`
+	Health      *HealthStatus          `protobuf:"bytes,7,opt,name=health,proto3" json:"health,omitempty"`
+	// health_rollup combines the Ready condition of a Kustomization or
+	// HelmRelease with the worst health of its inventory and their children,
+	// when it's requested
+	HealthRollup  *HealthStatus `protobuf:"bytes,8,opt,name=health_rollup,json=healthRollup,proto3" json:"health_rollup,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Object) Reset() {
 	*x = Object{}
-	mi := &file_api_core_types_proto_msgTypes[8]
+	mi := &file_api_core_types_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -700,7 +775,7 @@ func (x *Object) String() string {
 func (*Object) ProtoMessage() {}
 
 func (x *Object) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_types_proto_msgTypes[8]
+	mi := &file_api_core_types_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -713,7 +788,7 @@ func (x *Object) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Object.ProtoReflect.Descriptor instead.
 func (*Object) Descriptor() ([]byte, []int) {
-	return file_api_core_types_proto_rawDescGZIP(), []int{8}
+	return file_api_core_types_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *Object) GetPayload() string {
@@ -765,6 +840,13 @@ func (x *Object) GetHealth() *HealthStatus {
 	return nil
 }
 
+func (x *Object) GetHealthRollup() *HealthStatus {
+	if x != nil {
+		return x.HealthRollup
+	}
+	return nil
+}
+
 type Deployment struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -781,7 +863,7 @@ type Deployment struct {
 
 func (x *Deployment) Reset() {
 	*x = Deployment{}
-	mi := &file_api_core_types_proto_msgTypes[9]
+	mi := &file_api_core_types_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -793,7 +875,7 @@ func (x *Deployment) String() string {
 func (*Deployment) ProtoMessage() {}
 
 func (x *Deployment) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_types_proto_msgTypes[9]
+	mi := &file_api_core_types_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -806,7 +888,7 @@ func (x *Deployment) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Deployment.ProtoReflect.Descriptor instead.
 func (*Deployment) Descriptor() ([]byte, []int) {
-	return file_api_core_types_proto_rawDescGZIP(), []int{9}
+	return file_api_core_types_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *Deployment) GetName() string {
@@ -878,7 +960,7 @@ type Crd struct {
 
 func (x *Crd) Reset() {
 	*x = Crd{}
-	mi := &file_api_core_types_proto_msgTypes[10]
+	mi := &file_api_core_types_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -890,7 +972,7 @@ func (x *Crd) String() string {
 func (*Crd) ProtoMessage() {}
 
 func (x *Crd) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_types_proto_msgTypes[10]
+	mi := &file_api_core_types_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -903,7 +985,7 @@ func (x *Crd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Crd.ProtoReflect.Descriptor instead.
 func (*Crd) Descriptor() ([]byte, []int) {
-	return file_api_core_types_proto_rawDescGZIP(), []int{10}
+	return file_api_core_types_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *Crd) GetName() *Crd_Name {
@@ -954,7 +1036,7 @@ type Namespace struct {
 
 func (x *Namespace) Reset() {
 	*x = Namespace{}
-	mi := &file_api_core_types_proto_msgTypes[11]
+	mi := &file_api_core_types_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -966,7 +1048,7 @@ func (x *Namespace) String() string {
 func (*Namespace) ProtoMessage() {}
 
 func (x *Namespace) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_types_proto_msgTypes[11]
+	mi := &file_api_core_types_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -979,7 +1061,7 @@ func (x *Namespace) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Namespace.ProtoReflect.Descriptor instead.
 func (*Namespace) Descriptor() ([]byte, []int) {
-	return file_api_core_types_proto_rawDescGZIP(), []int{11}
+	return file_api_core_types_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *Namespace) GetName() string {
@@ -1033,7 +1115,7 @@ type Event struct {
 
 func (x *Event) Reset() {
 	*x = Event{}
-	mi := &file_api_core_types_proto_msgTypes[12]
+	mi := &file_api_core_types_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1045,7 +1127,7 @@ func (x *Event) String() string {
 func (*Event) ProtoMessage() {}
 
 func (x *Event) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_types_proto_msgTypes[12]
+	mi := &file_api_core_types_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1058,7 +1140,7 @@ func (x *Event) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Event.ProtoReflect.Descriptor instead.
 func (*Event) Descriptor() ([]byte, []int) {
-	return file_api_core_types_proto_rawDescGZIP(), []int{12}
+	return file_api_core_types_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *Event) GetType() string {
@@ -1127,7 +1209,7 @@ type Crd_Name struct {
 
 func (x *Crd_Name) Reset() {
 	*x = Crd_Name{}
-	mi := &file_api_core_types_proto_msgTypes[14]
+	mi := &file_api_core_types_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1139,7 +1221,7 @@ func (x *Crd_Name) String() string {
 func (*Crd_Name) ProtoMessage() {}
 
 func (x *Crd_Name) ProtoReflect() protoreflect.Message {
-	mi := &file_api_core_types_proto_msgTypes[14]
+	mi := &file_api_core_types_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1152,7 +1234,7 @@ func (x *Crd_Name) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Crd_Name.ProtoReflect.Descriptor instead.
 func (*Crd_Name) Descriptor() ([]byte, []int) {
-	return file_api_core_types_proto_rawDescGZIP(), []int{10, 0}
+	return file_api_core_types_proto_rawDescGZIP(), []int{11, 0}
 }
 
 func (x *Crd_Name) GetPlural() string {
@@ -1182,7 +1264,13 @@ const file_api_core_types_proto_rawDesc = "" +
 	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1c\n" +
 	"\tnamespace\x18\x03 \x01(\tR\tnamespace\x12!\n" +
-	"\fcluster_name\x18\x04 \x01(\tR\vclusterName\"\x87\x01\n" +
+	"\fcluster_name\x18\x04 \x01(\tR\vclusterName\"\x90\x01\n" +
+	"\fObjectResult\x121\n" +
+	"\x06object\x18\x01 \x01(\v2\x19.gitops_core.v1.ObjectRefR\x06object\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1f\n" +
+	"\vduration_ms\x18\x04 \x01(\x03R\n" +
+	"durationMs\"\x87\x01\n" +
 	"\tCondition\x12\x12\n" +
 	"\x04type\x18\x01 \x01(\tR\x04type\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12\x16\n" +
@@ -1209,7 +1297,7 @@ const file_api_core_types_proto_rawDesc = "" +
 	"\x06tenant\x18\x02 \x01(\tR\x06tenant\x12!\n" +
 	"\fcluster_name\x18\x03 \x01(\tR\vclusterName\x124\n" +
 	"\x06health\x18\x04 \x01(\v2\x1c.gitops_core.v1.HealthStatusR\x06health\x12:\n" +
-	"\bchildren\x18\x05 \x03(\v2\x1e.gitops_core.v1.InventoryEntryR\bchildren\"\xf9\x01\n" +
+	"\bchildren\x18\x05 \x03(\v2\x1e.gitops_core.v1.InventoryEntryR\bchildren\"\xbc\x02\n" +
 	"\x06Object\x12\x18\n" +
 	"\apayload\x18\x01 \x01(\tR\apayload\x12!\n" +
 	"\fcluster_name\x18\x02 \x01(\tR\vclusterName\x12\x16\n" +
@@ -1217,7 +1305,8 @@ const file_api_core_types_proto_rawDesc = "" +
 	"\x03uid\x18\x04 \x01(\tR\x03uid\x12>\n" +
 	"\tinventory\x18\x05 \x03(\v2 .gitops_core.v1.GroupVersionKindR\tinventory\x12\x12\n" +
 	"\x04info\x18\x06 \x01(\tR\x04info\x124\n" +
-	"\x06health\x18\a \x01(\v2\x1c.gitops_core.v1.HealthStatusR\x06health\"\xdf\x02\n" +
+	"\x06health\x18\a \x01(\v2\x1c.gitops_core.v1.HealthStatusR\x06health\x12A\n" +
+	"\rhealth_rollup\x18\b \x01(\v2\x1c.gitops_core.v1.HealthStatusR\fhealthRollup\"\xdf\x02\n" +
 	"\n" +
 	"Deployment\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
@@ -1299,43 +1388,46 @@ func file_api_core_types_proto_rawDescGZIP() []byte {
 }
 
 var file_api_core_types_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_api_core_types_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_api_core_types_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_api_core_types_proto_goTypes = []any{
 	(Kind)(0),                         // 0: gitops_core.v1.Kind
 	(HelmRepositoryType)(0),           // 1: gitops_core.v1.HelmRepositoryType
 	(*Interval)(nil),                  // 2: gitops_core.v1.Interval
 	(*ObjectRef)(nil),                 // 3: gitops_core.v1.ObjectRef
-	(*Condition)(nil),                 // 4: gitops_core.v1.Condition
-	(*GitRepositoryRef)(nil),          // 5: gitops_core.v1.GitRepositoryRef
-	(*GroupVersionKind)(nil),          // 6: gitops_core.v1.GroupVersionKind
-	(*NamespacedObjectReference)(nil), // 7: gitops_core.v1.NamespacedObjectReference
-	(*HealthStatus)(nil),              // 8: gitops_core.v1.HealthStatus
-	(*InventoryEntry)(nil),            // 9: gitops_core.v1.InventoryEntry
-	(*Object)(nil),                    // 10: gitops_core.v1.Object
-	(*Deployment)(nil),                // 11: gitops_core.v1.Deployment
-	(*Crd)(nil),                       // 12: gitops_core.v1.Crd
-	(*Namespace)(nil),                 // 13: gitops_core.v1.Namespace
-	(*Event)(nil),                     // 14: gitops_core.v1.Event
-	nil,                               // 15: gitops_core.v1.Deployment.LabelsEntry
-	(*Crd_Name)(nil),                  // 16: gitops_core.v1.Crd.Name
-	nil,                               // 17: gitops_core.v1.Namespace.AnnotationsEntry
-	nil,                               // 18: gitops_core.v1.Namespace.LabelsEntry
+	(*ObjectResult)(nil),              // 4: gitops_core.v1.ObjectResult
+	(*Condition)(nil),                 // 5: gitops_core.v1.Condition
+	(*GitRepositoryRef)(nil),          // 6: gitops_core.v1.GitRepositoryRef
+	(*GroupVersionKind)(nil),          // 7: gitops_core.v1.GroupVersionKind
+	(*NamespacedObjectReference)(nil), // 8: gitops_core.v1.NamespacedObjectReference
+	(*HealthStatus)(nil),              // 9: gitops_core.v1.HealthStatus
+	(*InventoryEntry)(nil),            // 10: gitops_core.v1.InventoryEntry
+	(*Object)(nil),                    // 11: gitops_core.v1.Object
+	(*Deployment)(nil),                // 12: gitops_core.v1.Deployment
+	(*Crd)(nil),                       // 13: gitops_core.v1.Crd
+	(*Namespace)(nil),                 // 14: gitops_core.v1.Namespace
+	(*Event)(nil),                     // 15: gitops_core.v1.Event
+	nil,                               // 16: gitops_core.v1.Deployment.LabelsEntry
+	(*Crd_Name)(nil),                  // 17: gitops_core.v1.Crd.Name
+	nil,                               // 18: gitops_core.v1.Namespace.AnnotationsEntry
+	nil,                               // 19: gitops_core.v1.Namespace.LabelsEntry
 }
 var file_api_core_types_proto_depIdxs = []int32{
-	8,  // 0: gitops_core.v1.InventoryEntry.health:type_name -> gitops_core.v1.HealthStatus
-	9,  // 1: gitops_core.v1.InventoryEntry.children:type_name -> gitops_core.v1.InventoryEntry
-	6,  // 2: gitops_core.v1.Object.inventory:type_name -> gitops_core.v1.GroupVersionKind
-	8,  // 3: gitops_core.v1.Object.health:type_name -> gitops_core.v1.HealthStatus
-	4,  // 4: gitops_core.v1.Deployment.conditions:type_name -> gitops_core.v1.Condition
-	15, // 5: gitops_core.v1.Deployment.labels:type_name -> gitops_core.v1.Deployment.LabelsEntry
-	16, // 6: gitops_core.v1.Crd.name:type_name -> gitops_core.v1.Crd.Name
-	17, // 7: gitops_core.v1.Namespace.annotations:type_name -> gitops_core.v1.Namespace.AnnotationsEntry
-	18, // 8: gitops_core.v1.Namespace.labels:type_name -> gitops_core.v1.Namespace.LabelsEntry
-	9,  // [9:9] is the sub-list for method output_type
-	9,  // [9:9] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	3,  // 0: gitops_core.v1.ObjectResult.object:type_name -> gitops_core.v1.ObjectRef
+	9,  // 1: gitops_core.v1.InventoryEntry.health:type_name -> gitops_core.v1.HealthStatus
+	10, // 2: gitops_core.v1.InventoryEntry.children:type_name -> gitops_core.v1.InventoryEntry
+	7,  // 3: gitops_core.v1.Object.inventory:type_name -> gitops_core.v1.GroupVersionKind
+	9,  // 4: gitops_core.v1.Object.health:type_name -> gitops_core.v1.HealthStatus
+	9,  // 5: gitops_core.v1.Object.health_rollup:type_name -> gitops_core.v1.HealthStatus
+	5,  // 6: gitops_core.v1.Deployment.conditions:type_name -> gitops_core.v1.Condition
+	16, // 7: gitops_core.v1.Deployment.labels:type_name -> gitops_core.v1.Deployment.LabelsEntry
+	17, // 8: gitops_core.v1.Crd.name:type_name -> gitops_core.v1.Crd.Name
+	18, // 9: gitops_core.v1.Namespace.annotations:type_name -> gitops_core.v1.Namespace.AnnotationsEntry
+	19, // 10: gitops_core.v1.Namespace.labels:type_name -> gitops_core.v1.Namespace.LabelsEntry
+	11, // [11:11] is the sub-list for method output_type
+	11, // [11:11] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_api_core_types_proto_init() }
@@ -1349,7 +1441,7 @@ func file_api_core_types_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_core_types_proto_rawDesc), len(file_api_core_types_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   17,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
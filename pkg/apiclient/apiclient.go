@@ -0,0 +1,146 @@
+package apiclient
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/weaveworks/weave-gitops/pkg/api/core"
+	"github.com/weaveworks/weave-gitops/pkg/oidc/login"
+)
+
+// Client calls the API of a gitops-server, as the user logged in to it with
+// gitops login.
+type Client struct {
+	server     string
+	httpClient *http.Client
+}
+
+// New returns a client for the gitops-server at server, including its
+// route prefix.
+func New(server string, httpClient *http.Client) (*Client, error) {
+	server, err := login.ServerKey(server)
+	if err != nil {
+		return nil, err
+	}
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &Client{
+		server:     server,
+		httpClient: httpClient,
+	}, nil
+}
+
+// NewHTTPClient returns the HTTP client to call a gitops-server with,
+// skipping the verification of its certificate if insecureSkipTLSVerify.
+func NewHTTPClient(insecureSkipTLSVerify bool) *http.Client {
+	if !insecureSkipTLSVerify {
+		return http.DefaultClient
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} // #nosec G402
+
+	return &http.Client{Transport: transport}
+}
+
+// FindOwners returns the Flux automations managing an object, or the
+// workloads running an image.
+func (c *Client) FindOwners(ctx context.Context, req *pb.FindOwnersRequest) (*pb.FindOwnersResponse, error) {
+	query := url.Values{}
+	setQuery(query, "kind", req.Kind)
+	setQuery(query, "name", req.Name)
+	setQuery(query, "namespace", req.Namespace)
+	setQuery(query, "clusterName", req.ClusterName)
+	setQuery(query, "image", req.Image)
+
+	res := &pb.FindOwnersResponse{}
+
+	return res, c.get(ctx, "/v1/owners", query, res)
+}
+
+// ListImages returns the images of the workloads, grouped by repository and
+// tag.
+func (c *Client) ListImages(ctx context.Context, req *pb.ListImagesRequest) (*pb.ListImagesResponse, error) {
+	query := url.Values{}
+	setQuery(query, "namespace", req.Namespace)
+	setQuery(query, "clusterName", req.ClusterName)
+	setQuery(query, "registry", req.Registry)
+	setQuery(query, "repository", req.Repository)
+	setQuery(query, "tag", req.Tag)
+
+	res := &pb.ListImagesResponse{}
+
+	return res, c.get(ctx, "/v1/images", query, res)
+}
+
+// get calls the API at path with the ID token of the login, and decodes the
+// response to out.
+func (c *Client) get(ctx context.Context, path string, query url.Values, out proto.Message) error {
+	token, err := login.Token(ctx, c.httpClient, c.server)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.server+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+
+		if err := json.Unmarshal(body, &apiErr); err != nil || apiErr.Message == "" {
+			return fmt.Errorf("server returned %s", resp.Status)
+		}
+
+		return fmt.Errorf("server returned %s: %s", resp.Status, apiErr.Message)
+	}
+
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(body, out); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+
+	return nil
+}
+
+// ObjectName returns the kind, namespace and name of ref, as printed by the
+// CLI.
+func ObjectName(ref *pb.ObjectRef) string {
+	if ref.Namespace == "" {
+		return ref.Kind + "/" + ref.Name
+	}
+
+	return ref.Kind + "/" + ref.Namespace + "/" + ref.Name
+}
+
+func setQuery(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
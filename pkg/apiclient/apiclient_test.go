@@ -0,0 +1,115 @@
+package apiclient_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	pb "github.com/weaveworks/weave-gitops/pkg/api/core"
+	"github.com/weaveworks/weave-gitops/pkg/apiclient"
+	"github.com/weaveworks/weave-gitops/pkg/config"
+)
+
+func TestFindOwners(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer id-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"code":16,"message":"Authentication required"}`))
+
+			return
+		}
+
+		if r.URL.Path != "/prefix/v1/owners" || r.URL.Query().Get("kind") != "Deployment" || r.URL.Query().Has("image") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{
+			"objects": [{
+				"object": {"clusterName": "Default", "kind": "Deployment", "name": "podinfo", "namespace": "apps"},
+				"owners": [{"clusterName": "Default", "kind": "Kustomization", "name": "apps", "namespace": "flux-system"}]
+			}],
+			"errors": [],
+			"unknownField": true
+		}`))
+	}))
+	t.Cleanup(s.Close)
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	config.SetConfig(nil)
+	t.Cleanup(func() { config.SetConfig(nil) })
+
+	c, err := apiclient.New(s.URL+"/prefix/", nil)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	req := &pb.FindOwnersRequest{Kind: "Deployment", Name: "podinfo"}
+
+	_, err = c.FindOwners(t.Context(), req)
+	g.Expect(err).To(HaveOccurred())
+
+	g.Expect(config.SaveConfig(&config.GitopsCLIConfig{
+		Logins: map[string]config.ServerLogin{
+			s.URL + "/prefix": {IDToken: "id-token", Expiry: time.Now().Add(time.Hour)},
+		},
+	})).To(Succeed())
+
+	res, err := c.FindOwners(t.Context(), req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res.Objects).To(HaveLen(1))
+	g.Expect(res.Objects[0].Owners[0].Name).To(Equal("apps"))
+
+	// The errors of the server are returned with their message.
+	config.SetConfig(nil)
+	g.Expect(config.SaveConfig(&config.GitopsCLIConfig{
+		Logins: map[string]config.ServerLogin{
+			s.URL + "/prefix": {IDToken: "revoked-token", Expiry: time.Now().Add(time.Hour)},
+		},
+	})).To(Succeed())
+
+	_, err = c.FindOwners(t.Context(), req)
+	g.Expect(err).To(MatchError("server returned 401 Unauthorized: Authentication required"))
+}
+
+func TestListImages(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/images" || r.URL.Query().Get("registry") != "ghcr.io" || r.URL.Query().Get("tag") != "6.5.0" || r.URL.Query().Has("namespace") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{
+			"images": [{
+				"registry": "ghcr.io",
+				"repository": "ghcr.io/stefanprodan/podinfo",
+				"tag": "6.5.0",
+				"workloads": [{"workload": {"clusterName": "Default", "kind": "Deployment", "name": "podinfo", "namespace": "apps"}}]
+			}]
+		}`))
+	}))
+	t.Cleanup(s.Close)
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	config.SetConfig(nil)
+	t.Cleanup(func() { config.SetConfig(nil) })
+
+	g.Expect(config.SaveConfig(&config.GitopsCLIConfig{
+		Logins: map[string]config.ServerLogin{
+			s.URL: {IDToken: "id-token", Expiry: time.Now().Add(time.Hour)},
+		},
+	})).To(Succeed())
+
+	c, err := apiclient.New(s.URL, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	res, err := c.ListImages(t.Context(), &pb.ListImagesRequest{Registry: "ghcr.io", Tag: "6.5.0"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res.Images).To(HaveLen(1))
+	g.Expect(apiclient.ObjectName(res.Images[0].Workloads[0].Workload)).To(Equal("Deployment/apps/podinfo"))
+}
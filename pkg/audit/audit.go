@@ -0,0 +1,225 @@
+// Package audit records the actions users take through the dashboard, like
+// suspending or syncing objects and signing in, to pluggable sinks.
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// EventTypeRPC is the type of the events of API calls changing objects.
+	EventTypeRPC = "rpc"
+	// EventTypeAuth is the type of the events of users signing in and out.
+	EventTypeAuth = "auth"
+
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+	// OutcomeSkipped is the outcome of actions that weren't attempted, like
+	// syncing the dependents of an object that failed to sync.
+	OutcomeSkipped = "skipped"
+)
+
+// sinkTimeout is how long an event can take to be written to a sink.
+const sinkTimeout = 10 * time.Second
+
+// ObjectReference is the object an event is about.
+type ObjectReference struct {
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace,omitempty"`
+	UID        string `json:"uid,omitempty"`
+}
+
+// Event is an action taken by a user.
+type Event struct {
+	Time time.Time `json:"time"`
+	Type string    `json:"type"`
+	// Action is the RPC called for rpc events, or the auth action like
+	// sign-in for auth events.
+	Action    string           `json:"action"`
+	Principal string           `json:"principal,omitempty"`
+	Groups    []string         `json:"groups,omitempty"`
+	SourceIP  string           `json:"sourceIP,omitempty"`
+	Cluster   string           `json:"cluster,omitempty"`
+	Object    *ObjectReference `json:"object,omitempty"`
+	Outcome   string           `json:"outcome"`
+	Error     string           `json:"error,omitempty"`
+	Comment   string           `json:"comment,omitempty"`
+	// Details holds the action specific parameters, like whether objects
+	// were suspended or resumed.
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// Outcome returns the outcome of an action that failed if err is set.
+func Outcome(err error) string {
+	if err != nil {
+		return OutcomeFailure
+	}
+
+	return OutcomeSuccess
+}
+
+// Sink is where the events are written to.
+type Sink interface {
+	Write(ctx context.Context, event Event) error
+}
+
+// Recorder writes the events to all its sinks. Failing to write an event is
+// logged rather than failing the action. A nil Recorder discards the events.
+type Recorder struct {
+	log   logr.Logger
+	sinks []Sink
+	now   func() time.Time
+}
+
+// NewRecorder creates a Recorder writing to sinks.
+func NewRecorder(log logr.Logger, sinks ...Sink) *Recorder {
+	return &Recorder{
+		log:   log.WithName("audit"),
+		sinks: sinks,
+		now:   time.Now,
+	}
+}
+
+// Record writes event to the sinks, setting its time. The sinks aren't
+// cancelled along with ctx, so the events of cancelled requests are kept.
+func (r *Recorder) Record(ctx context.Context, event Event) {
+	if r == nil || len(r.sinks) == 0 {
+		return
+	}
+
+	event.Time = r.now().UTC()
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), sinkTimeout)
+	defer cancel()
+
+	for _, sink := range r.sinks {
+		if err := sink.Write(ctx, event); err != nil {
+			r.log.Error(err, "failed to write audit event", "action", event.Action, "principal", event.Principal)
+		}
+	}
+}
+
+// Close closes the sinks that need closing, like the file and webhook sinks,
+// once no more events are recorded.
+func (r *Recorder) Close() error {
+	if r == nil {
+		return nil
+	}
+
+	var errs []error
+
+	for _, sink := range r.sinks {
+		if closer, ok := sink.(io.Closer); ok {
+			errs = append(errs, closer.Close())
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// TrustedProxies are the proxies trusted to add the address of their client
+// to X-Forwarded-For. None are trusted if empty.
+type TrustedProxies []netip.Prefix
+
+// ParseTrustedProxies parses the proxies trusted to add the address of their
+// client to X-Forwarded-For, as IP addresses or CIDRs.
+func ParseTrustedProxies(proxies []string) (TrustedProxies, error) {
+	prefixes := make(TrustedProxies, 0, len(proxies))
+
+	for _, proxy := range proxies {
+		prefix, err := netip.ParsePrefix(proxy)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(proxy)
+			if addrErr != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+			}
+
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+
+		prefixes = append(prefixes, prefix.Masked())
+	}
+
+	return prefixes, nil
+}
+
+// SourceIP returns the address of the client of the request. When the
+// request comes from a trusted proxy, it's the right-most X-Forwarded-For
+// address that isn't a trusted proxy, as the addresses left of it can be
+// set by anyone.
+func (p TrustedProxies) SourceIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	return p.clientIP(append(forwardedFor(r.Header.Values("X-Forwarded-For")), host))
+}
+
+// SourceIPFromContext returns the address of the client of an API call, from
+// the X-Forwarded-For metadata the gateway adds. The gateway appends the
+// address of the connection to it, so it's read like SourceIP.
+func (p TrustedProxies) SourceIPFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	return p.clientIP(forwardedFor(md.Get("x-forwarded-for")))
+}
+
+func forwardedFor(headers []string) []string {
+	hops := []string{}
+
+	for _, header := range headers {
+		for hop := range strings.SplitSeq(header, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+
+	return hops
+}
+
+// clientIP returns the right-most hop that isn't a trusted proxy, hops
+// ending with the address the request was received from.
+func (p TrustedProxies) clientIP(hops []string) string {
+	for i := len(hops) - 1; i >= 0; i-- {
+		if i == 0 || !p.trusts(hops[i]) {
+			return hops[i]
+		}
+	}
+
+	return ""
+}
+
+func (p TrustedProxies) trusts(hop string) bool {
+	addr, err := netip.ParseAddr(hop)
+	if err != nil {
+		return false
+	}
+
+	addr = addr.Unmap()
+
+	for _, prefix := range p {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
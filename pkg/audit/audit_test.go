@@ -0,0 +1,209 @@
+package audit_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/metadata"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/weaveworks/weave-gitops/pkg/audit"
+)
+
+type sinkFunc func(context.Context, audit.Event) error
+
+func (f sinkFunc) Write(ctx context.Context, event audit.Event) error {
+	return f(ctx, event)
+}
+
+func TestRecorder(t *testing.T) {
+	g := NewWithT(t)
+
+	var events []audit.Event
+
+	failing := sinkFunc(func(context.Context, audit.Event) error {
+		return errors.New("sink is down")
+	})
+	recording := sinkFunc(func(_ context.Context, event audit.Event) error {
+		events = append(events, event)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	audit.NewRecorder(logr.Discard(), failing, recording).Record(ctx, audit.Event{Action: "SyncFluxObject"})
+
+	g.Expect(events).To(HaveLen(1))
+	g.Expect(events[0].Action).To(Equal("SyncFluxObject"))
+	g.Expect(events[0].Time.IsZero()).To(BeFalse())
+
+	var recorder *audit.Recorder
+	recorder.Record(ctx, audit.Event{Action: "SyncFluxObject"})
+}
+
+func TestSourceIP(t *testing.T) {
+	g := NewWithT(t)
+
+	var untrusted audit.TrustedProxies
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	g.Expect(untrusted.SourceIP(req)).To(Equal("10.0.0.1"))
+
+	req.Header.Set("X-Forwarded-For", "192.168.0.1")
+	g.Expect(untrusted.SourceIP(req)).To(Equal("10.0.0.1"), "X-Forwarded-For should be ignored without trusted proxies")
+
+	proxies, err := audit.ParseTrustedProxies([]string{"10.0.0.0/8", "172.16.0.1"})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(proxies.SourceIP(req)).To(Equal("192.168.0.1"))
+
+	req.Header.Set("X-Forwarded-For", "1.2.3.4, 192.168.0.1, 172.16.0.1")
+	g.Expect(proxies.SourceIP(req)).To(Equal("192.168.0.1"), "addresses set before the trusted proxies should be ignored")
+
+	req.RemoteAddr = "192.168.0.2:1234"
+	g.Expect(proxies.SourceIP(req)).To(Equal("192.168.0.2"), "X-Forwarded-For should be ignored from untrusted clients")
+
+	g.Expect(proxies.SourceIPFromContext(context.Background())).To(BeEmpty())
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-forwarded-for", "1.2.3.4, 192.168.0.1, 10.0.0.1"))
+	g.Expect(proxies.SourceIPFromContext(ctx)).To(Equal("192.168.0.1"))
+	g.Expect(untrusted.SourceIPFromContext(ctx)).To(Equal("10.0.0.1"))
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-forwarded-for", "1.2.3.4, 192.168.0.2"))
+	g.Expect(proxies.SourceIPFromContext(ctx)).To(Equal("192.168.0.2"))
+
+	_, err = audit.ParseTrustedProxies([]string{"proxy"})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid trusted proxy")))
+}
+
+func TestFileSink(t *testing.T) {
+	g := NewWithT(t)
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	sink, err := audit.NewFileSink(path)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(sink.Write(context.Background(), audit.Event{Action: "SignIn", Outcome: audit.OutcomeSuccess})).To(Succeed())
+	g.Expect(sink.Write(context.Background(), audit.Event{Action: "Logout", Outcome: audit.OutcomeSuccess})).To(Succeed())
+	g.Expect(sink.Close()).To(Succeed())
+
+	data, err := os.ReadFile(path)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	g.Expect(lines).To(HaveLen(2))
+
+	var event audit.Event
+	g.Expect(json.Unmarshal([]byte(lines[1]), &event)).To(Succeed())
+	g.Expect(event.Action).To(Equal("Logout"))
+}
+
+func TestWebhookSink(t *testing.T) {
+	g := NewWithT(t)
+
+	var (
+		mu       sync.Mutex
+		received []audit.Event
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event audit.Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		received = append(received, event)
+	}))
+	t.Cleanup(srv.Close)
+
+	sink := audit.NewWebhookSink(logr.Discard(), srv.URL, srv.Client())
+	g.Expect(sink.Write(context.Background(), audit.Event{Action: "SignIn", Principal: "jane"})).To(Succeed())
+	g.Expect(sink.Write(context.Background(), audit.Event{Action: "Logout", Principal: "jane"})).To(Succeed())
+	g.Expect(sink.Close()).To(Succeed(), "closing should wait for the queued events")
+
+	g.Expect(received).To(HaveLen(2))
+	g.Expect(received[0].Action).To(Equal("SignIn"))
+	g.Expect(received[1].Action).To(Equal("Logout"))
+
+	g.Expect(sink.Write(context.Background(), audit.Event{Action: "SyncFluxObject"})).To(MatchError(audit.ErrWebhookSinkClosed),
+		"events written after closing, like by running operations, should be dropped")
+	g.Expect(sink.Close()).To(Succeed())
+
+	block := make(chan struct{})
+	blocking := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-block
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(blocking.Close)
+	t.Cleanup(func() { close(block) })
+
+	sink = audit.NewWebhookSink(logr.Discard(), blocking.URL, blocking.Client())
+
+	var err error
+	for range 2000 {
+		if err = sink.Write(context.Background(), audit.Event{}); err != nil {
+			break
+		}
+	}
+
+	g.Expect(err).To(MatchError(audit.ErrWebhookQueueFull), "a slow webhook should not block the writes")
+}
+
+func TestKubernetesEventSink(t *testing.T) {
+	g := NewWithT(t)
+
+	c := fake.NewClientBuilder().WithObjects(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: "flux-system", UID: "podinfo-uid"},
+	}).Build()
+
+	var clusters []string
+
+	sink := audit.NewKubernetesEventSink(func(_ context.Context, cluster string) (client.Client, error) {
+		clusters = append(clusters, cluster)
+		return c, nil
+	})
+
+	g.Expect(sink.Write(context.Background(), audit.Event{Action: "SignIn"})).To(Succeed())
+	g.Expect(clusters).To(BeEmpty())
+
+	g.Expect(sink.Write(context.Background(), audit.Event{
+		Action:    "ToggleSuspendResource",
+		Principal: "jane",
+		Cluster:   "Default",
+		Object:    &audit.ObjectReference{APIVersion: "v1", Kind: "ConfigMap", Name: "podinfo", Namespace: "flux-system"},
+		Outcome:   audit.OutcomeFailure,
+		Error:     "forbidden",
+		Comment:   "incident",
+	})).To(Succeed())
+	g.Expect(clusters).To(Equal([]string{"Default"}))
+
+	events := &corev1.EventList{}
+	g.Expect(c.List(context.Background(), events, client.InNamespace("flux-system"))).To(Succeed())
+	g.Expect(events.Items).To(HaveLen(1))
+
+	event := events.Items[0]
+	g.Expect(event.InvolvedObject).To(Equal(corev1.ObjectReference{APIVersion: "v1", Kind: "ConfigMap", Name: "podinfo", Namespace: "flux-system", UID: "podinfo-uid"}))
+	g.Expect(event.Type).To(Equal(corev1.EventTypeWarning))
+	g.Expect(event.Reason).To(Equal("ToggleSuspendResource"))
+	g.Expect(event.Message).To(Equal("ToggleSuspendResource by jane: failure: forbidden (incident)"))
+}
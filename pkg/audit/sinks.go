@@ -0,0 +1,312 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// EventReportingController is the controller the Kubernetes Events are
+	// reported by.
+	EventReportingController = "weave-gitops"
+
+	// webhookTimeout is how long POSTing an event to the webhook can take.
+	webhookTimeout = 10 * time.Second
+	// webhookQueueSize is how many events can wait to be POSTed to the
+	// webhook before new ones are dropped.
+	webhookQueueSize = 1000
+)
+
+// ErrWebhookQueueFull is returned when an event is dropped because the
+// webhook is falling behind.
+var ErrWebhookQueueFull = errors.New("audit webhook queue is full, dropping the event")
+
+// ErrWebhookSinkClosed is returned when an event is dropped because it's
+// written after the webhook sink is closed, like by an operation still
+// running when the server shuts down.
+var ErrWebhookSinkClosed = errors.New("audit webhook sink is closed, dropping the event")
+
+// Options configures the sinks of the audit log.
+type Options struct {
+	// File is the path of a file the events are appended to as JSON lines.
+	File string
+	// KubernetesEvents records the events about objects as Kubernetes Events
+	// on the objects.
+	KubernetesEvents bool
+	// WebhookURL is a URL the events are POSTed to as JSON.
+	WebhookURL string
+}
+
+// ClusterClientFunc returns a client for the cluster named cluster.
+type ClusterClientFunc func(ctx context.Context, cluster string) (client.Client, error)
+
+// NewSinks creates the sinks configured by opts, clientFor is used to record
+// Kubernetes Events in the cluster of the objects.
+func NewSinks(log logr.Logger, opts Options, clientFor ClusterClientFunc) ([]Sink, error) {
+	sinks := []Sink{}
+
+	if opts.File != "" {
+		sink, err := NewFileSink(opts.File)
+		if err != nil {
+			return nil, err
+		}
+
+		sinks = append(sinks, sink)
+	}
+
+	if opts.KubernetesEvents {
+		sinks = append(sinks, NewKubernetesEventSink(clientFor))
+	}
+
+	if opts.WebhookURL != "" {
+		sinks = append(sinks, NewWebhookSink(log, opts.WebhookURL, &http.Client{Timeout: webhookTimeout}))
+	}
+
+	return sinks, nil
+}
+
+// FileSink appends the events to a file as JSON lines.
+type FileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileSink opens path for appending, creating it if needed.
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening audit log file: %w", err)
+	}
+
+	return &FileSink{file: file}, nil
+}
+
+func (s *FileSink) Write(_ context.Context, event Event) error {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = s.file.Write(append(b, '\n'))
+
+	return err
+}
+
+// Close closes the file.
+func (s *FileSink) Close() error {
+	return s.file.Close()
+}
+
+// KubernetesEventSink records the events about namespaced objects as
+// Kubernetes Events on the objects, so they show up along with the events of
+// the Flux controllers. Other events are skipped.
+type KubernetesEventSink struct {
+	clientFor ClusterClientFunc
+}
+
+// NewKubernetesEventSink creates a KubernetesEventSink recording the Events
+// with the clients returned by clientFor.
+func NewKubernetesEventSink(clientFor ClusterClientFunc) *KubernetesEventSink {
+	return &KubernetesEventSink{clientFor: clientFor}
+}
+
+func (s *KubernetesEventSink) Write(ctx context.Context, event Event) error {
+	if event.Object == nil || event.Object.Namespace == "" {
+		return nil
+	}
+
+	c, err := s.clientFor(ctx, event.Cluster)
+	if err != nil {
+		return fmt.Errorf("getting client for cluster %q: %w", event.Cluster, err)
+	}
+
+	eventType := corev1.EventTypeNormal
+	if event.Outcome == OutcomeFailure {
+		eventType = corev1.EventTypeWarning
+	}
+
+	if event.Object.UID == "" && event.Object.APIVersion != "" {
+		event.Object.UID = objectUID(ctx, c, *event.Object)
+	}
+
+	timestamp := metav1.NewTime(event.Time)
+
+	k8sEvent := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s.%x", event.Object.Name, event.Time.UnixNano()),
+			Namespace: event.Object.Namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: event.Object.APIVersion,
+			Kind:       event.Object.Kind,
+			Name:       event.Object.Name,
+			Namespace:  event.Object.Namespace,
+			UID:        types.UID(event.Object.UID),
+		},
+		Reason:              event.Action,
+		Message:             kubernetesEventMessage(event),
+		Type:                eventType,
+		FirstTimestamp:      timestamp,
+		LastTimestamp:       timestamp,
+		Count:               1,
+		Source:              corev1.EventSource{Component: EventReportingController},
+		ReportingController: EventReportingController,
+	}
+
+	return c.Create(ctx, k8sEvent)
+}
+
+// objectUID returns the UID of the object, so the Event is only shown for
+// this instance of it, or nothing if it can't be read, like when it's been
+// deleted.
+func objectUID(ctx context.Context, c client.Client, ref ObjectReference) string {
+	obj := &metav1.PartialObjectMetadata{}
+	obj.SetGroupVersionKind(schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind))
+
+	if err := c.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: ref.Namespace}, obj); err != nil {
+		return ""
+	}
+
+	return string(obj.GetUID())
+}
+
+func kubernetesEventMessage(event Event) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s by %s: %s", event.Action, event.Principal, event.Outcome)
+
+	if event.Error != "" {
+		fmt.Fprintf(&b, ": %s", event.Error)
+	}
+
+	if event.Comment != "" {
+		fmt.Fprintf(&b, " (%s)", event.Comment)
+	}
+
+	return b.String()
+}
+
+// WebhookSink POSTs the events as JSON to a URL. The events are queued and
+// POSTed in the background, so a slow webhook doesn't hold up the actions
+// being recorded. Events are dropped when the queue is full, and failing to
+// POST them is logged, as are events written after Close.
+type WebhookSink struct {
+	log    logr.Logger
+	url    string
+	client *http.Client
+	queue  chan Event
+	done   chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewWebhookSink creates a WebhookSink POSTing to url with client, which
+// should have a timeout.
+func NewWebhookSink(log logr.Logger, url string, client *http.Client) *WebhookSink {
+	s := &WebhookSink{
+		log:    log.WithName("audit-webhook"),
+		url:    url,
+		client: client,
+		queue:  make(chan Event, webhookQueueSize),
+		done:   make(chan struct{}),
+	}
+
+	go s.run()
+
+	return s
+}
+
+func (s *WebhookSink) Write(_ context.Context, event Event) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return ErrWebhookSinkClosed
+	}
+
+	select {
+	case s.queue <- event:
+		return nil
+	default:
+		return ErrWebhookQueueFull
+	}
+}
+
+// Close stops taking events, waiting for up to webhookTimeout for the
+// queued ones to be POSTed.
+func (s *WebhookSink) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+
+	s.closed = true
+	close(s.queue)
+	s.mu.Unlock()
+
+	select {
+	case <-s.done:
+		return nil
+	case <-time.After(webhookTimeout):
+		return errors.New("timed out POSTing the queued audit events")
+	}
+}
+
+func (s *WebhookSink) run() {
+	defer close(s.done)
+
+	for event := range s.queue {
+		if err := s.post(event); err != nil {
+			s.log.Error(err, "failed to POST audit event", "action", event.Action, "principal", event.Principal)
+		}
+	}
+}
+
+func (s *WebhookSink) post(event Event) error {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("audit webhook returned %s", resp.Status)
+	}
+
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"math/rand/v2"
 	"os"
 	"path/filepath"
+	"time"
 )
 
 const (
@@ -29,6 +30,18 @@ var (
 type GitopsCLIConfig struct {
 	Analytics bool   `json:"analytics"`
 	UserID    string `json:"userId"`
+	// Logins are the credentials for the gitops-servers the user logged in to
+	// with `gitops login`, by server URL.
+	Logins map[string]ServerLogin `json:"logins,omitempty"`
+}
+
+// ServerLogin holds the tokens issued by a gitops-server to the CLI.
+type ServerLogin struct {
+	// Provider is the name of the OIDC provider the user logged in with.
+	Provider     string    `json:"provider,omitempty"`
+	IDToken      string    `json:"idToken"`
+	RefreshToken string    `json:"refreshToken,omitempty"`
+	Expiry       time.Time `json:"expiry"`
 }
 
 func (config *GitopsCLIConfig) String() string {
@@ -99,6 +112,12 @@ func SaveConfig(config *GitopsCLIConfig) error {
 
 	defer configFile.Close()
 
+	// The config holds the tokens of the logins.
+	err = configFile.Chmod(0o600)
+	if err != nil {
+		return fmt.Errorf("error setting config file permissions: %w", err)
+	}
+
 	err = configFile.Truncate(0)
 	if err != nil {
 		return fmt.Errorf("error truncating config file: %w", err)
@@ -153,7 +172,7 @@ func openConfigFile(configPath string, shouldCreate bool) (*os.File, error) {
 
 	if shouldCreate {
 		flag = os.O_RDWR | os.O_CREATE
-		perm = 0o666
+		perm = 0o600
 	} else {
 		flag = os.O_RDONLY
 		perm = 0o444
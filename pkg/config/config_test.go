@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -19,6 +21,34 @@ var _ = Describe("parseConfig", func() {
 		Expect(config.Analytics).To(BeTrue())
 		Expect(config.UserID).To(Equal("Hph7bg5SiK"))
 	})
+
+	It("parses the server logins", func() {
+		data := []byte(`{
+	"analytics": false,
+	"userId": "Hph7bg5SiK",
+	"logins": {
+		"https://gitops.example.com": {
+			"provider": "dex",
+			"idToken": "id-token",
+			"refreshToken": "refresh-token",
+			"expiry": "2026-01-02T15:04:05Z"
+		}
+	}
+}`)
+		config := &GitopsCLIConfig{}
+
+		err := parseConfig(data, config)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config.Logins).To(Equal(map[string]ServerLogin{
+			"https://gitops.example.com": {
+				Provider:     "dex",
+				IDToken:      "id-token",
+				RefreshToken: "refresh-token",
+				Expiry:       time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC),
+			},
+		}))
+	})
 })
 
 var _ = Describe("GenerateUserID", func() {
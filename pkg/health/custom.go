@@ -0,0 +1,256 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/ext"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// customChecksCostLimit bounds the cost of evaluating a single check, so a
+// bad expression can't stall the queries.
+const customChecksCostLimit = 1000000
+
+// CustomChecksReloadInterval is how often the ConfigMap of the custom checks
+// is read for changes.
+const CustomChecksReloadInterval = 30 * time.Second
+
+var healthStatusCodes = []HealthStatusCode{
+	HealthStatusProgressing,
+	HealthStatusHealthy,
+	HealthStatusUnhealthy,
+	HealthStatusUnknown,
+}
+
+// CustomCheck computes the health of the objects of a kind with a CEL
+// expression, e.g. for cert-manager Certificates
+//
+//	group: cert-manager.io
+//	kind: Certificate
+//	expression: |
+//	  object.?status.?conditions.orValue([]).exists(c, c.type == "Ready" && c.status == "True")
+//	    ? {"status": "Healthy"}
+//	    : {"status": "Progressing", "message": "waiting for the certificate to be issued"}
+//
+// The object is available as `object`. The expression must return a map with
+// the health status code as `status` and optionally a `message`. The check
+// applies to all the versions of the kind when the version is empty.
+type CustomCheck struct {
+	Group      string `json:"group"`
+	Version    string `json:"version,omitempty"`
+	Kind       string `json:"kind"`
+	Expression string `json:"expression"`
+
+	program cel.Program
+}
+
+// ParseCustomChecks reads the custom checks in the data of a ConfigMap, one
+// check per key, sorted by key. The checks that are invalid are left out and
+// reported in the error.
+func ParseCustomChecks(data map[string]string) ([]*CustomCheck, error) {
+	var (
+		checks []*CustomCheck
+		errs   []error
+	)
+
+	for _, key := range slices.Sorted(maps.Keys(data)) {
+		check := &CustomCheck{}
+
+		if err := yaml.UnmarshalStrict([]byte(data[key]), check); err != nil {
+			errs = append(errs, fmt.Errorf("health check %q: %w", key, err))
+			continue
+		}
+
+		if err := check.Compile(); err != nil {
+			errs = append(errs, fmt.Errorf("health check %q: %w", key, err))
+			continue
+		}
+
+		checks = append(checks, check)
+	}
+
+	return checks, errors.Join(errs...)
+}
+
+// Compile checks and compiles the expression of the check.
+func (c *CustomCheck) Compile() error {
+	if c.Kind == "" {
+		return errors.New("kind is required")
+	}
+
+	if c.Expression == "" {
+		return errors.New("expression is required")
+	}
+
+	env, err := cel.NewEnv(
+		cel.Variable("object", cel.MapType(cel.StringType, cel.DynType)),
+		cel.OptionalTypes(),
+		ext.Strings(),
+		ext.Lists(),
+		ext.Regex(),
+	)
+	if err != nil {
+		return fmt.Errorf("creating CEL environment: %w", err)
+	}
+
+	ast, issues := env.Compile(c.Expression)
+	if issues.Err() != nil {
+		return issues.Err()
+	}
+
+	if kind := ast.OutputType().Kind(); kind != types.MapKind && kind != types.DynKind {
+		return fmt.Errorf("expression must return a map, not %s", ast.OutputType())
+	}
+
+	c.program, err = env.Program(ast, cel.CostLimit(customChecksCostLimit))
+
+	return err
+}
+
+func (c *CustomCheck) matches(gvk schema.GroupVersionKind) bool {
+	return c.Kind == gvk.Kind && c.Group == gvk.Group && (c.Version == "" || c.Version == gvk.Version)
+}
+
+func (c *CustomCheck) check(obj unstructured.Unstructured) (HealthStatus, error) {
+	out, _, err := c.program.Eval(map[string]any{"object": obj.Object})
+	if err != nil {
+		err = fmt.Errorf("evaluating health check of %s: %w", c.Kind, err)
+		return HealthStatus{Status: HealthStatusUnknown, Message: err.Error()}, err
+	}
+
+	native, err := out.ConvertToNative(reflect.TypeOf(map[string]string{}))
+	if err != nil {
+		err = fmt.Errorf("health check of %s must return a map of strings: %w", c.Kind, err)
+		return HealthStatus{Status: HealthStatusUnknown, Message: err.Error()}, err
+	}
+
+	result := native.(map[string]string)
+
+	status := HealthStatusCode(result["status"])
+	if !slices.Contains(healthStatusCodes, status) {
+		err = fmt.Errorf("health check of %s returned invalid status %q", c.Kind, status)
+		return HealthStatus{Status: HealthStatusUnknown, Message: err.Error()}, err
+	}
+
+	return HealthStatus{Status: status, Message: result["message"]}, nil
+}
+
+// CustomHealthChecker checks the health of objects with the custom checks
+// matching their kind, and with the built-in checks otherwise.
+type CustomHealthChecker struct {
+	HealthChecker
+
+	mu              sync.RWMutex
+	checks          []*CustomCheck
+	resourceVersion string
+}
+
+// NewCustomHealthChecker returns a checker running checks before the
+// built-in ones.
+func NewCustomHealthChecker(checks ...*CustomCheck) *CustomHealthChecker {
+	return &CustomHealthChecker{
+		HealthChecker: NewHealthChecker(),
+		checks:        checks,
+	}
+}
+
+// SetChecks replaces the custom checks.
+func (hc *CustomHealthChecker) SetChecks(checks []*CustomCheck) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	hc.checks = checks
+}
+
+func (hc *CustomHealthChecker) Check(obj unstructured.Unstructured) (HealthStatus, error) {
+	hc.mu.RLock()
+	checks := hc.checks
+	hc.mu.RUnlock()
+
+	gvk := obj.GroupVersionKind()
+
+	for _, check := range checks {
+		if check.matches(gvk) {
+			return check.check(obj)
+		}
+	}
+
+	return hc.HealthChecker.Check(obj)
+}
+
+// Load reads the custom checks from the ConfigMap called key, there are none
+// when it doesn't exist. The checks are only parsed again when the ConfigMap
+// changed since the last load.
+func (hc *CustomHealthChecker) Load(ctx context.Context, cl client.Client, key client.ObjectKey) error {
+	cm := &corev1.ConfigMap{}
+
+	err := cl.Get(ctx, key, cm)
+	if apierrors.IsNotFound(err) {
+		hc.mu.Lock()
+		defer hc.mu.Unlock()
+
+		hc.checks, hc.resourceVersion = nil, ""
+
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("reading health checks ConfigMap: %w", err)
+	}
+
+	hc.mu.RLock()
+	unchanged := hc.resourceVersion != "" && hc.resourceVersion == cm.ResourceVersion
+	hc.mu.RUnlock()
+
+	if unchanged {
+		return nil
+	}
+
+	checks, err := ParseCustomChecks(cm.Data)
+
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	hc.checks, hc.resourceVersion = checks, cm.ResourceVersion
+
+	return err
+}
+
+// Watch loads the custom checks from the ConfigMap called key, and reloads
+// them when it changes until ctx is done.
+func (hc *CustomHealthChecker) Watch(ctx context.Context, log logr.Logger, cl client.Client, key client.ObjectKey) {
+	load := func() {
+		if err := hc.Load(ctx, cl, key); err != nil {
+			log.Error(err, "failed to load custom health checks", "configmap", key)
+		}
+	}
+
+	load()
+
+	ticker := time.NewTicker(CustomChecksReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			load()
+		}
+	}
+}
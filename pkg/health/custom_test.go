@@ -0,0 +1,189 @@
+package health
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+)
+
+const certificateCheck = `
+group: cert-manager.io
+kind: Certificate
+expression: |
+  object.?status.?conditions.orValue([]).exists(c, c.type == "Ready" && c.status == "True")
+    ? {"status": "Healthy"}
+    : {"status": "Progressing", "message": "waiting for the certificate to be issued"}
+`
+
+func readObject(t *testing.T, path string) unstructured.Unstructured {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var obj unstructured.Unstructured
+	if err := yaml.Unmarshal(data, &obj); err != nil {
+		t.Fatal(err)
+	}
+
+	return obj
+}
+
+func TestCustomHealthChecker(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	checks, err := ParseCustomChecks(map[string]string{
+		"certificate.yaml": certificateCheck,
+		"deployment.yaml": `
+group: apps
+version: v1
+kind: Deployment
+expression: '{"status": "Unhealthy", "message": "overridden"}'
+`,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(checks).To(HaveLen(2))
+
+	hc := NewCustomHealthChecker(checks...)
+
+	status, err := hc.Check(readObject(t, "testdata/certificate-ready.yaml"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(status).To(Equal(HealthStatus{Status: HealthStatusHealthy}))
+
+	status, err = hc.Check(readObject(t, "testdata/certificate-issuing.yaml"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(status).To(Equal(HealthStatus{Status: HealthStatusProgressing, Message: "waiting for the certificate to be issued"}))
+
+	// Custom checks take precedence over the built-in ones.
+	status, err = hc.Check(readObject(t, "testdata/deployment-healthy.yaml"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(status).To(Equal(HealthStatus{Status: HealthStatusUnhealthy, Message: "overridden"}))
+
+	// The other kinds are left to the built-in checks.
+	status, err = hc.Check(readObject(t, "testdata/pod-healthy.yaml"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(status.Status).To(Equal(HealthStatusHealthy))
+
+	hc.SetChecks(nil)
+
+	status, err = hc.Check(readObject(t, "testdata/deployment-healthy.yaml"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(status.Status).To(Equal(HealthStatusHealthy))
+}
+
+func TestCustomCheckErrors(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	checks, err := ParseCustomChecks(map[string]string{
+		"no-kind.yaml":      `expression: '{"status": "Healthy"}'`,
+		"invalid.yaml":      "kind: Certificate\nexpression: 'object.status ==='",
+		"not-a-map.yaml":    "kind: Certificate\nexpression: '\"Healthy\"'",
+		"unknown-key.yaml":  "kind: Certificate\nexpr: 'true'",
+		"bad-status.yaml":   "group: cert-manager.io\nkind: Certificate\nexpression: '{\"status\": \"Great\"}'",
+		"certificate.yaml":  certificateCheck,
+		"missing-key.yaml":  "group: cert-manager.io\nkind: Issuer\nexpression: '{\"status\": object.status.state}'",
+		"empty-expr.yaml":   "kind: Certificate",
+		"not-strings.yaml":  "group: cert-manager.io\nkind: ClusterIssuer\nexpression: '{\"status\": 1}'",
+		"other-group.yaml":  "group: example.com\nkind: Certificate\nexpression: '{\"status\": \"Unknown\"}'",
+		"other-version.yml": "group: cert-manager.io\nversion: v2\nkind: Certificate\nexpression: '{\"status\": \"Unknown\"}'",
+	})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring(`health check "no-kind.yaml": kind is required`))
+	g.Expect(err.Error()).To(ContainSubstring(`health check "invalid.yaml"`))
+	g.Expect(err.Error()).To(ContainSubstring(`health check "not-a-map.yaml": expression must return a map`))
+	g.Expect(err.Error()).To(ContainSubstring(`health check "unknown-key.yaml"`))
+	g.Expect(err.Error()).To(ContainSubstring(`health check "empty-expr.yaml": expression is required`))
+
+	// The valid checks are kept, in the order of their keys.
+	g.Expect(checks).To(HaveLen(6))
+	g.Expect(checks[0].Kind).To(Equal("Certificate"))
+	g.Expect(checks[0].Group).To(Equal("cert-manager.io"))
+
+	hc := NewCustomHealthChecker(checks...)
+
+	status, err := hc.Check(readObject(t, "testdata/certificate-ready.yaml"))
+	g.Expect(err).To(MatchError(ContainSubstring(`returned invalid status "Great"`)))
+	g.Expect(status.Status).To(Equal(HealthStatusUnknown))
+
+	issuer := unstructured.Unstructured{}
+	issuer.SetAPIVersion("cert-manager.io/v1")
+	issuer.SetKind("Issuer")
+
+	status, err = hc.Check(issuer)
+	g.Expect(err).To(MatchError(ContainSubstring("evaluating health check of Issuer")))
+	g.Expect(status.Status).To(Equal(HealthStatusUnknown))
+
+	issuer.SetKind("ClusterIssuer")
+
+	status, err = hc.Check(issuer)
+	g.Expect(err).To(MatchError(ContainSubstring("must return a map of strings")))
+	g.Expect(status.Status).To(Equal(HealthStatusUnknown))
+}
+
+func TestCustomHealthCheckerLoad(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := t.Context()
+
+	key := client.ObjectKey{Name: "health-checks", Namespace: "flux-system"}
+	cl := fake.NewClientBuilder().Build()
+	hc := NewCustomHealthChecker()
+	cert := readObject(t, "testdata/certificate-issuing.yaml")
+
+	// Without the ConfigMap the built-in checks apply.
+	g.Expect(hc.Load(ctx, cl, key)).To(Succeed())
+
+	status, _ := hc.Check(cert)
+	g.Expect(status.Status).To(Equal(HealthStatusHealthy))
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+		Data:       map[string]string{"certificate.yaml": certificateCheck},
+	}
+	g.Expect(cl.Create(ctx, cm)).To(Succeed())
+	g.Expect(hc.Load(ctx, cl, key)).To(Succeed())
+
+	status, _ = hc.Check(cert)
+	g.Expect(status.Status).To(Equal(HealthStatusProgressing))
+
+	// Invalid checks are reported and left out when the ConfigMap changes.
+	cm.Data["invalid.yaml"] = "kind: Issuer"
+	g.Expect(cl.Update(ctx, cm)).To(Succeed())
+	g.Expect(hc.Load(ctx, cl, key)).To(MatchError(ContainSubstring(`health check "invalid.yaml"`)))
+
+	status, _ = hc.Check(cert)
+	g.Expect(status.Status).To(Equal(HealthStatusProgressing))
+
+	g.Expect(cl.Delete(ctx, cm)).To(Succeed())
+	g.Expect(hc.Load(ctx, cl, key)).To(Succeed())
+
+	status, _ = hc.Check(cert)
+	g.Expect(status.Status).To(Equal(HealthStatusHealthy))
+}
+
+func TestCustomHealthCheckerWatch(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	key := client.ObjectKey{Name: "health-checks", Namespace: "flux-system"}
+	cl := fake.NewClientBuilder().WithObjects(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+		Data:       map[string]string{"certificate.yaml": certificateCheck},
+	}).Build()
+	hc := NewCustomHealthChecker()
+
+	go hc.Watch(t.Context(), logr.Discard(), cl, key)
+
+	g.Eventually(func() HealthStatusCode {
+		status, _ := hc.Check(readObject(t, "testdata/certificate-issuing.yaml"))
+		return status.Status
+	}).Should(Equal(HealthStatusProgressing))
+}
@@ -33,6 +33,20 @@ type HealthStatus struct {
 	Message string           `json:"message,omitempty"`
 }
 
+// healthSeverity orders the health status codes from the best to the worst.
+var healthSeverity = map[HealthStatusCode]int{
+	HealthStatusHealthy:     1,
+	HealthStatusUnknown:     2,
+	HealthStatusProgressing: 3,
+	HealthStatusUnhealthy:   4,
+}
+
+// Worse returns whether c is a worse health than other, in the order
+// Healthy, Unknown, Progressing and Unhealthy.
+func (c HealthStatusCode) Worse(other HealthStatusCode) bool {
+	return healthSeverity[c] > healthSeverity[other]
+}
+
 func NewHealthChecker() HealthChecker {
 	return &healthChecker{}
 }
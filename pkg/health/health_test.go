@@ -148,3 +148,13 @@ func TestHealthCheck(t *testing.T) {
 		})
 	}
 }
+
+func TestHealthStatusCodeWorse(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(HealthStatusUnhealthy.Worse(HealthStatusProgressing)).To(BeTrue())
+	g.Expect(HealthStatusProgressing.Worse(HealthStatusUnknown)).To(BeTrue())
+	g.Expect(HealthStatusUnknown.Worse(HealthStatusHealthy)).To(BeTrue())
+	g.Expect(HealthStatusHealthy.Worse(HealthStatusHealthy)).To(BeFalse())
+	g.Expect(HealthStatusHealthy.Worse(HealthStatusUnhealthy)).To(BeFalse())
+}
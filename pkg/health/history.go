@@ -0,0 +1,327 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultHistorySize is how many health changes are kept per object.
+	DefaultHistorySize = 50
+	// DefaultFlappingWindow is how far back health changes are counted to
+	// tell if an object is flapping.
+	DefaultFlappingWindow = time.Hour
+	// DefaultFlappingThreshold is how many health changes within the window
+	// make an object flapping.
+	DefaultFlappingThreshold = 5
+
+	// historyConfigMapKey is the key of the ConfigMap data the history is
+	// persisted to.
+	historyConfigMapKey = "history.json"
+	// maxHistoryConfigMapSize keeps the persisted history under the 1MiB
+	// limit on ConfigMaps.
+	maxHistoryConfigMapSize = 1000000
+)
+
+// HistoryEntry is a health status an object changed to, and when.
+type HistoryEntry struct {
+	Status  HealthStatusCode `json:"status"`
+	Message string           `json:"message,omitempty"`
+	Time    time.Time        `json:"time"`
+}
+
+// ObjectHistory is the health history of an object, oldest entry first.
+type ObjectHistory struct {
+	Entries []HistoryEntry
+	// Changes is how many times the health changed within the flapping
+	// window.
+	Changes int
+	// Flapping is set when the health changed at least as many times as the
+	// flapping threshold within the flapping window.
+	Flapping bool
+}
+
+type HistoryOptions struct {
+	// Size is how many health changes are kept per object.
+	Size int
+	// FlappingWindow is how far back health changes are counted.
+	FlappingWindow time.Duration
+	// FlappingThreshold is how many health changes within the window make
+	// an object flapping.
+	FlappingThreshold int
+}
+
+// History keeps a bounded history of the health changes of objects in
+// memory. Only changes of the health status are recorded, repeated samples
+// of the same status only mark the object as still being seen.
+type History struct {
+	opts HistoryOptions
+
+	mu      sync.RWMutex
+	objects map[string]*objectHistory
+	// dirty is set when the history changed since it was last saved.
+	dirty bool
+	now   func() time.Time
+}
+
+// objectHistory is a ring buffer of the health changes of an object.
+type objectHistory struct {
+	Entries  []HistoryEntry `json:"entries"`
+	Next     int            `json:"next"`
+	LastSeen time.Time      `json:"lastSeen"`
+	// Wrapped is set once older entries were dropped, the oldest entry is
+	// then a change rather than when the object was first seen.
+	Wrapped bool `json:"wrapped,omitempty"`
+}
+
+// NewHistory returns an empty history, the defaults are used for the zero
+// options.
+func NewHistory(opts HistoryOptions) *History {
+	if opts.Size <= 0 {
+		opts.Size = DefaultHistorySize
+	}
+
+	if opts.FlappingWindow <= 0 {
+		opts.FlappingWindow = DefaultFlappingWindow
+	}
+
+	if opts.FlappingThreshold <= 0 {
+		opts.FlappingThreshold = DefaultFlappingThreshold
+	}
+
+	return &History{
+		opts:    opts,
+		objects: map[string]*objectHistory{},
+		now:     time.Now,
+	}
+}
+
+// FlappingWindow returns how far back health changes are counted.
+func (h *History) FlappingWindow() time.Duration {
+	return h.opts.FlappingWindow
+}
+
+// HistoryKey returns the key of the history of an object.
+func HistoryKey(clusterName string, gk schema.GroupKind, namespace, name string) string {
+	return strings.Join([]string{clusterName, gk.String(), namespace, name}, "/")
+}
+
+// Record records the health status of the object with key, if it changed
+// since it was last recorded.
+func (h *History) Record(key string, status HealthStatus) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.now()
+
+	oh, ok := h.objects[key]
+	if !ok {
+		oh = &objectHistory{}
+		h.objects[key] = oh
+	}
+
+	oh.LastSeen = now
+
+	if latest, ok := oh.latest(); ok && latest.Status == status.Status {
+		return
+	}
+
+	entry := HistoryEntry{Status: status.Status, Message: status.Message, Time: now}
+
+	if len(oh.Entries) < h.opts.Size {
+		oh.Entries = append(oh.Entries, entry)
+	} else {
+		oh.Entries[oh.Next] = entry
+		oh.Next = (oh.Next + 1) % len(oh.Entries)
+		oh.Wrapped = true
+	}
+
+	h.dirty = true
+}
+
+// Get returns the history of the object with key, and false if it was never
+// recorded.
+func (h *History) Get(key string) (ObjectHistory, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	oh, ok := h.objects[key]
+	if !ok {
+		return ObjectHistory{}, false
+	}
+
+	entries := oh.ordered()
+	windowStart := h.now().Add(-h.opts.FlappingWindow)
+
+	// The first entry is when the object was first seen rather than a change,
+	// until it's dropped.
+	first := 1
+	if oh.Wrapped {
+		first = 0
+	}
+
+	changes := 0
+
+	for i := first; i < len(entries); i++ {
+		if !entries[i].Time.Before(windowStart) {
+			changes++
+		}
+	}
+
+	return ObjectHistory{
+		Entries:  entries,
+		Changes:  changes,
+		Flapping: changes >= h.opts.FlappingThreshold,
+	}, true
+}
+
+// Prune forgets the objects that weren't seen since before, e.g. because
+// they were deleted.
+func (h *History) Prune(before time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for key, oh := range h.objects {
+		if oh.LastSeen.Before(before) {
+			delete(h.objects, key)
+
+			h.dirty = true
+		}
+	}
+}
+
+// Load reads the history persisted in the ConfigMap called key, replacing
+// the recorded one. It's left empty when the ConfigMap doesn't exist.
+func (h *History) Load(ctx context.Context, cl client.Client, key client.ObjectKey) error {
+	cm := &corev1.ConfigMap{}
+
+	err := cl.Get(ctx, key, cm)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("reading health history ConfigMap: %w", err)
+	}
+
+	objects := map[string]*objectHistory{}
+
+	if data, ok := cm.Data[historyConfigMapKey]; ok {
+		if err := json.Unmarshal([]byte(data), &objects); err != nil {
+			return fmt.Errorf("decoding health history: %w", err)
+		}
+	}
+
+	for _, oh := range objects {
+		if oh.Next < 0 || oh.Next > len(oh.Entries) {
+			oh.Next = 0
+		}
+
+		// The size may have been lowered since the history was saved.
+		entries := oh.ordered()
+		if len(entries) > h.opts.Size {
+			entries = entries[len(entries)-h.opts.Size:]
+			oh.Wrapped = true
+		}
+
+		oh.Entries, oh.Next = entries, 0
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.objects = objects
+	h.dirty = false
+
+	return nil
+}
+
+// Save persists the history in the ConfigMap called key, creating it if it
+// doesn't exist. Nothing is written if the history didn't change since it
+// was last saved or loaded. The history is only locked while it's encoded,
+// so recording isn't blocked by the write.
+func (h *History) Save(ctx context.Context, cl client.Client, key client.ObjectKey) error {
+	h.mu.Lock()
+
+	if !h.dirty {
+		h.mu.Unlock()
+		return nil
+	}
+
+	data, err := json.Marshal(h.objects)
+	objects := len(h.objects)
+
+	if err == nil && len(data) <= maxHistoryConfigMapSize {
+		// Changes recorded while writing mark the history dirty again.
+		h.dirty = false
+	}
+
+	h.mu.Unlock()
+
+	if err != nil {
+		return fmt.Errorf("encoding health history: %w", err)
+	}
+
+	if len(data) > maxHistoryConfigMapSize {
+		return fmt.Errorf("health history of %d objects is too large for a ConfigMap, lower the history size", objects)
+	}
+
+	if err := writeHistory(ctx, cl, key, data); err != nil {
+		h.mu.Lock()
+		h.dirty = true
+		h.mu.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+// writeHistory writes the encoded history to the ConfigMap called key.
+func writeHistory(ctx context.Context, cl client.Client, key client.ObjectKey, data []byte) error {
+	cm := &corev1.ConfigMap{}
+
+	err := cl.Get(ctx, key, cm)
+	if apierrors.IsNotFound(err) {
+		cm.Name = key.Name
+		cm.Namespace = key.Namespace
+		cm.Data = map[string]string{historyConfigMapKey: string(data)}
+
+		err = cl.Create(ctx, cm)
+	} else if err == nil {
+		cm.Data = map[string]string{historyConfigMapKey: string(data)}
+
+		err = cl.Update(ctx, cm)
+	}
+
+	if err != nil {
+		return fmt.Errorf("saving health history ConfigMap: %w", err)
+	}
+
+	return nil
+}
+
+func (oh *objectHistory) latest() (HistoryEntry, bool) {
+	if len(oh.Entries) == 0 {
+		return HistoryEntry{}, false
+	}
+
+	return oh.Entries[(oh.Next+len(oh.Entries)-1)%len(oh.Entries)], true
+}
+
+// ordered returns a copy of the entries, oldest first.
+func (oh *objectHistory) ordered() []HistoryEntry {
+	entries := make([]HistoryEntry, 0, len(oh.Entries))
+	entries = append(entries, oh.Entries[oh.Next:]...)
+
+	return append(entries, oh.Entries[:oh.Next]...)
+}
@@ -0,0 +1,154 @@
+package health
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestHistory(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	h := NewHistory(HistoryOptions{Size: 4, FlappingThreshold: 3})
+	h.now = func() time.Time { return now }
+
+	key := HistoryKey("Default", schema.GroupKind{Group: "apps", Kind: "Deployment"}, "apps", "podinfo")
+	g.Expect(key).To(Equal("Default/Deployment.apps/apps/podinfo"))
+
+	_, ok := h.Get(key)
+	g.Expect(ok).To(BeFalse())
+
+	record := func(status HealthStatusCode) {
+		now = now.Add(time.Minute)
+		h.Record(key, HealthStatus{Status: status})
+	}
+
+	// Repeated samples of the same status aren't changes.
+	record(HealthStatusProgressing)
+	record(HealthStatusHealthy)
+	record(HealthStatusHealthy)
+	record(HealthStatusProgressing)
+
+	history, ok := h.Get(key)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(history.Entries).To(HaveLen(3))
+	g.Expect(history.Changes).To(Equal(2))
+	g.Expect(history.Flapping).To(BeFalse())
+
+	// The oldest changes are dropped once the history is full, and the
+	// oldest entry left is a change.
+	record(HealthStatusHealthy)
+
+	history, _ = h.Get(key)
+	g.Expect(history.Entries).To(HaveLen(4))
+	g.Expect(history.Changes).To(Equal(3))
+
+	record(HealthStatusProgressing)
+
+	history, _ = h.Get(key)
+	g.Expect(history.Entries).To(HaveLen(4))
+	g.Expect(history.Entries[0].Status).To(Equal(HealthStatusHealthy))
+	g.Expect(history.Entries[3].Status).To(Equal(HealthStatusProgressing))
+	g.Expect(history.Entries[3].Time).To(Equal(now))
+	g.Expect(history.Changes).To(Equal(4))
+	g.Expect(history.Flapping).To(BeTrue())
+
+	// Changes older than the flapping window aren't counted.
+	now = now.Add(DefaultFlappingWindow - 30*time.Second)
+
+	history, _ = h.Get(key)
+	g.Expect(history.Changes).To(Equal(1))
+	g.Expect(history.Flapping).To(BeFalse())
+
+	h.Prune(now.Add(-time.Hour))
+
+	_, ok = h.Get(key)
+	g.Expect(ok).To(BeTrue())
+
+	h.Prune(now)
+
+	_, ok = h.Get(key)
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestHistorySaveAndLoad(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := t.Context()
+
+	key := client.ObjectKey{Name: "health-history", Namespace: "flux-system"}
+	cl := fake.NewClientBuilder().Build()
+
+	h := NewHistory(HistoryOptions{})
+	g.Expect(h.Load(ctx, cl, key)).To(Succeed())
+
+	for _, status := range []HealthStatusCode{HealthStatusProgressing, HealthStatusHealthy, HealthStatusUnhealthy} {
+		h.Record("Default/Deployment.apps/apps/podinfo", HealthStatus{Status: status, Message: string(status)})
+	}
+
+	g.Expect(h.Save(ctx, cl, key)).To(Succeed())
+	g.Expect(h.Save(ctx, cl, key)).To(Succeed())
+
+	h.Record("Default/Deployment.apps/apps/podinfo", HealthStatus{Status: HealthStatusHealthy})
+	g.Expect(h.Save(ctx, cl, key)).To(Succeed())
+
+	// A smaller history only keeps the latest changes.
+	loaded := NewHistory(HistoryOptions{Size: 2})
+	g.Expect(loaded.Load(ctx, cl, key)).To(Succeed())
+
+	history, ok := loaded.Get("Default/Deployment.apps/apps/podinfo")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(history.Entries).To(HaveLen(2))
+	g.Expect(history.Entries[0].Status).To(Equal(HealthStatusUnhealthy))
+	g.Expect(history.Entries[0].Message).To(Equal("Unhealthy"))
+	g.Expect(history.Entries[1].Status).To(Equal(HealthStatusHealthy))
+	g.Expect(history.Changes).To(Equal(2))
+}
+
+func TestHistorySaveDoesNotBlockRecording(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := t.Context()
+
+	key := client.ObjectKey{Name: "health-history", Namespace: "flux-system"}
+	h := NewHistory(HistoryOptions{})
+
+	writes := 0
+
+	// Recording while the ConfigMap is written would deadlock if the history
+	// stayed locked.
+	cl := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			writes++
+
+			h.Record("Default/Deployment.apps/apps/podinfo", HealthStatus{Status: HealthStatusHealthy})
+
+			return c.Create(ctx, obj, opts...)
+		},
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			writes++
+
+			return c.Update(ctx, obj, opts...)
+		},
+	}).Build()
+
+	h.Record("Default/Deployment.apps/apps/podinfo", HealthStatus{Status: HealthStatusProgressing})
+	g.Expect(h.Save(ctx, cl, key)).To(Succeed())
+
+	// The change recorded during the write is saved next time.
+	g.Expect(h.Save(ctx, cl, key)).To(Succeed())
+	g.Expect(h.Save(ctx, cl, key)).To(Succeed())
+	g.Expect(writes).To(Equal(2))
+
+	loaded := NewHistory(HistoryOptions{})
+	g.Expect(loaded.Load(ctx, cl, key)).To(Succeed())
+
+	history, _ := loaded.Get("Default/Deployment.apps/apps/podinfo")
+	g.Expect(history.Entries).To(HaveLen(2))
+}
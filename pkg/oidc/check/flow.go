@@ -2,7 +2,9 @@ package check
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/coreos/go-oidc/v3/oidc"
@@ -17,14 +19,20 @@ import (
 )
 
 type Options struct {
-	ClientID                   string
-	ClientSecret               string
-	IssuerURL                  string
-	SecretName                 string
-	SecretNamespace            string
-	Scopes                     []string
-	ClaimUsername              string
-	ClaimGroups                string
+	ClientID        string
+	ClientSecret    string
+	IssuerURL       string
+	SecretName      string
+	SecretNamespace string
+	Scopes          []string
+	ClaimUsername   string
+	ClaimGroups     string
+	// ClaimExpressions computes the principal from the claims, read from the
+	// Secret when nil.
+	ClaimExpressions *auth.ClaimExpressions
+	// ClaimsFile is a JSON file of ID token claims. When set, the principal is
+	// computed from these claims instead of going through the login flow.
+	ClaimsFile                 string
 	OpenURL                    func(string) error
 	InsecureSkipSignatureCheck bool
 }
@@ -38,6 +46,7 @@ type Claims struct {
 // up a temporary web server, sets the server's address as redirect URI in the authentication request
 // and subsequently exchanges the authorization code for an ID token.
 // NOTE: Make sure to configure your OIDC provider so that it accepts "http://localhost:9876" as redirect URI.
+// When opts.ClaimsFile is set the flow is skipped, computing the principal from the claims in the file.
 func GetPrincipal(ctx context.Context, opts Options, log logger.Logger, c client.Client) (*auth.UserPrincipal, error) {
 	if opts.SecretName != "" {
 		if err := optsFromSecret(ctx, &opts, log, c); err != nil {
@@ -57,6 +66,41 @@ func GetPrincipal(ctx context.Context, opts Options, log logger.Logger, c client
 		opts.Scopes = auth.DefaultScopes
 	}
 
+	var (
+		claims claimsToken
+		err    error
+	)
+
+	if opts.ClaimsFile != "" {
+		claims, err = claimsFromFile(opts.ClaimsFile)
+	} else {
+		claims, err = claimsFromLogin(ctx, opts, log)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	cc := auth.ClaimsConfig{
+		Username:    opts.ClaimUsername,
+		Groups:      opts.ClaimGroups,
+		Expressions: opts.ClaimExpressions,
+	}
+	principal, err := cc.PrincipalFromClaims(claims)
+	if err != nil {
+		return nil, fmt.Errorf("failed deriving principal from claims: %w", err)
+	}
+
+	return principal, nil
+}
+
+type claimsToken interface {
+	Claims(v interface{}) error
+}
+
+// claimsFromLogin sends the user through the authorization code flow and
+// returns the claims of the ID token.
+func claimsFromLogin(ctx context.Context, opts Options, log logger.Logger) (claimsToken, error) {
 	provider, err := oidc.NewProvider(ctx, opts.IssuerURL)
 	if err != nil {
 		return nil, fmt.Errorf("could not create provider: %w", err)
@@ -92,16 +136,27 @@ func GetPrincipal(ctx context.Context, opts Options, log logger.Logger, c client
 		return nil, fmt.Errorf("failed retrieving claims: %w", err)
 	}
 
-	cc := auth.ClaimsConfig{
-		Username: opts.ClaimUsername,
-		Groups:   opts.ClaimGroups,
-	}
-	principal, err := cc.PrincipalFromClaims(claims)
+	return claims, nil
+}
+
+// jsonClaims are claims read from a file.
+type jsonClaims []byte
+
+func (c jsonClaims) Claims(v interface{}) error {
+	return json.Unmarshal(c, v)
+}
+
+func claimsFromFile(path string) (claimsToken, error) {
+	data, err := os.ReadFile(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed deriving principal from claims: %w", err)
+		return nil, fmt.Errorf("failed reading claims file: %w", err)
 	}
 
-	return principal, nil
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("claims file %s is not valid JSON", path)
+	}
+
+	return jsonClaims(data), nil
 }
 
 // optsFromSecret fetches the Secret referenced in opts and sets OIDC configuration values
@@ -142,6 +197,16 @@ func optsFromSecret(ctx context.Context, opts *Options, log logger.Logger, c cli
 	if opts.ClaimUsername == "" {
 		opts.ClaimUsername = string(oidcSecret.Data["claimUsername"])
 	}
+	if opts.ClaimGroups == "" {
+		opts.ClaimGroups = string(oidcSecret.Data["claimGroups"])
+	}
+	if opts.ClaimExpressions == nil {
+		exprs, err := auth.NewClaimExpressionsFromSecret(oidcSecret)
+		if err != nil {
+			return err
+		}
+		opts.ClaimExpressions = exprs
+	}
 	if len(opts.Scopes) == 0 {
 		cs := string(oidcSecret.Data["customScopes"])
 		if cs != "" {
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -338,3 +340,54 @@ func TestGetClaimsWithoutSecret(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPrincipalFromClaimsFile(t *testing.T) {
+	g := NewWithT(t)
+
+	claimsFile := filepath.Join(t.TempDir(), "claims.json")
+	g.Expect(os.WriteFile(claimsFile, []byte(`{
+  "sub": "1234",
+  "email": "user@example.org",
+  "realm_access": {"roles": ["k8s-admins", "offline_access"]}
+}`), 0o600)).To(Succeed())
+
+	c := fake.NewClientBuilder().
+		WithObjects(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "flux-system",
+				Name:      "test-oidc",
+			},
+			Data: map[string][]byte{
+				"clientID":     []byte("client"),
+				"clientSecret": []byte("csec"),
+				"issuerURL":    []byte("https://example.org"),
+				"redirectURL":  []byte("something else"),
+				"claimExpressions": []byte(`
+groups: claims.realm_access.roles.filter(r, r.startsWith("k8s-"))
+`),
+			},
+		}).
+		Build()
+
+	var logBuf strings.Builder
+	log := logger.NewCLILogger(&logBuf)
+
+	principal, err := check.GetPrincipal(t.Context(), check.Options{
+		SecretName:      "test-oidc",
+		SecretNamespace: "flux-system",
+		ClaimsFile:      claimsFile,
+	}, log, c)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(principal.ID).To(Equal("user@example.org"))
+	g.Expect(principal.Groups).To(Equal([]string{"k8s-admins"}))
+
+	principal, err = check.GetPrincipal(t.Context(), check.Options{
+		SecretName:       "test-oidc",
+		SecretNamespace:  "flux-system",
+		ClaimsFile:       claimsFile,
+		ClaimExpressions: &auth.ClaimExpressions{Username: `claims.sub`},
+	}, log, c)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(principal.ID).To(Equal("1234"))
+	g.Expect(principal.Groups).To(BeEmpty())
+}
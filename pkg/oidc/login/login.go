@@ -0,0 +1,379 @@
+package login
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/browser"
+
+	"github.com/weaveworks/weave-gitops/pkg/config"
+	"github.com/weaveworks/weave-gitops/pkg/logger"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+const (
+	// expiryLeeway is how long before they expire the tokens are refreshed.
+	expiryLeeway = time.Minute
+	// defaultDevicePollInterval is the pause between the device token
+	// requests when neither the provider nor the server set one.
+	defaultDevicePollInterval = 5 * time.Second
+)
+
+var (
+	// ErrNotLoggedIn is returned when there's no login for a server in the
+	// CLI config.
+	ErrNotLoggedIn = errors.New("not logged in, please run gitops login")
+	// ErrLoginExpired is returned when the login for a server expired and
+	// can't be refreshed.
+	ErrLoginExpired = errors.New("login expired, please run gitops login")
+)
+
+type Options struct {
+	// Server is the URL of the gitops-server, including its route prefix.
+	Server string
+	// Provider is the name of the OIDC provider to log in with, the first
+	// one of the server when empty.
+	Provider string
+	// DeviceCode logs in with the device code flow instead of the browser,
+	// for machines without one.
+	DeviceCode bool
+	// NoBrowser prints the login URL instead of opening the browser.
+	NoBrowser bool
+	// OpenURL opens the login URL, in the default browser when nil.
+	OpenURL    func(string) error
+	HTTPClient *http.Client
+}
+
+// Login logs in to the gitops-server of opts and returns the tokens it
+// issued to the CLI.
+func Login(ctx context.Context, opts Options, log logger.Logger) (*config.ServerLogin, error) {
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = http.DefaultClient
+	}
+
+	server, err := ServerKey(opts.Server)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.Server = server
+
+	var tokens *auth.CLITokens
+
+	if opts.DeviceCode {
+		tokens, err = deviceCodeLogin(ctx, opts, log)
+	} else {
+		tokens, err = browserLogin(ctx, opts, log)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return serverLogin(tokens), nil
+}
+
+// ServerKey returns the canonical URL of server, keying its login in the CLI
+// config.
+func ServerKey(server string) (string, error) {
+	u, err := url.Parse(server)
+	if err != nil {
+		return "", fmt.Errorf("invalid server URL: %w", err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("invalid server URL %q: must be an http or https URL", server)
+	}
+
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	u.RawQuery = ""
+	u.Fragment = ""
+
+	return u.String(), nil
+}
+
+// browserLogin logs in through the browser, receiving the tokens on a
+// loopback server. The gitops-server seals them to a key of this login.
+func browserLogin(ctx context.Context, opts Options, log logger.Logger) (*auth.CLITokens, error) {
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed starting listener: %w", err)
+	}
+
+	type result struct {
+		tokens *auth.CLITokens
+		err    error
+	}
+
+	results := make(chan result, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("state") != state {
+			http.Error(w, "invalid state", http.StatusBadRequest)
+			return
+		}
+
+		tokens, err := auth.OpenCLITokens(key, q.Get("tokens"))
+		if err != nil {
+			http.Error(w, "invalid tokens", http.StatusBadRequest)
+		} else {
+			fmt.Fprintf(w, "Logged in to %s. You can close this window now.\n", opts.Server)
+		}
+
+		select {
+		case results <- result{tokens: tokens, err: err}:
+		default:
+		}
+	})
+
+	srv := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		_ = srv.Serve(listener)
+	}()
+
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			log.Warningf("local HTTP server shutdown failed: %s", err)
+		}
+	}()
+
+	query := url.Values{
+		"redirect_uri": {fmt.Sprintf("http://%s/callback", listener.Addr())},
+		"state":        {state},
+		"public_key":   {auth.EncodeCLIPublicKey(key.PublicKey())},
+	}
+	if opts.Provider != "" {
+		query.Set("provider", opts.Provider)
+	}
+
+	loginURL := opts.Server + "/oauth2/cli/login?" + query.Encode()
+
+	if opts.NoBrowser {
+		log.Println("Please open the following URL in your browser to log in:\n\n%s\n", loginURL)
+	} else {
+		log.Waitingf("Opening browser. If this does not work, please open the following URL in your browser:\n")
+		log.Println("%s\n", loginURL)
+
+		var openErr error
+		if opts.OpenURL != nil {
+			openErr = opts.OpenURL(loginURL)
+		} else {
+			openErr = browser.OpenURL(loginURL)
+		}
+
+		if openErr != nil {
+			log.Failuref("Failed to open browser: %s. You can still open the URL manually.", openErr)
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("login did not complete: %w", ctx.Err())
+	case res := <-results:
+		return res.tokens, res.err
+	}
+}
+
+// deviceCodeLogin logs in with the device code flow, polling the
+// gitops-server until the user approved the login from another device.
+func deviceCodeLogin(ctx context.Context, opts Options, log logger.Logger) (*auth.CLITokens, error) {
+	var authorization auth.CLIDeviceAuthorization
+	if _, err := post(ctx, opts.HTTPClient, opts.Server+"/oauth2/cli/device", auth.CLIDeviceAuthRequest{Provider: opts.Provider}, &authorization); err != nil {
+		return nil, fmt.Errorf("failed to start device code login: %w", err)
+	}
+
+	if authorization.DeviceAuth == nil {
+		return nil, errors.New("failed to start device code login: no device authorization in response")
+	}
+
+	if uri := authorization.DeviceAuth.VerificationURIComplete; uri != "" {
+		log.Actionf("To log in, open %s and confirm the code %s", uri, authorization.DeviceAuth.UserCode)
+	} else {
+		log.Actionf("To log in, open %s and enter the code %s", authorization.DeviceAuth.VerificationURI, authorization.DeviceAuth.UserCode)
+	}
+
+	log.Waitingf("Waiting for the login to be approved")
+
+	interval := defaultDevicePollInterval
+	if authorization.DeviceAuth.Interval > 0 {
+		interval = time.Duration(authorization.DeviceAuth.Interval) * time.Second
+	}
+
+	for {
+		var tokens auth.CLITokens
+
+		resp, err := post(ctx, opts.HTTPClient, opts.Server+"/oauth2/cli/device/token", authorization, &tokens)
+		if err != nil {
+			return nil, fmt.Errorf("device code login failed: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusAccepted {
+			return &tokens, nil
+		}
+
+		// The server tells how long to wait, longer when the provider asked
+		// to slow down, which lasts for the rest of the login.
+		if seconds, err := strconv.ParseInt(resp.Header.Get("Retry-After"), 10, 64); err == nil && seconds >= 0 {
+			interval = time.Duration(seconds) * time.Second
+			authorization.DeviceAuth.Interval = seconds
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("login did not complete: %w", ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Refresh refreshes the tokens of login with the gitops-server at server.
+func Refresh(ctx context.Context, client *http.Client, server string, login config.ServerLogin) (*config.ServerLogin, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	server, err := ServerKey(server)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens auth.CLITokens
+	if _, err := post(ctx, client, server+"/oauth2/cli/refresh", auth.CLIRefreshRequest{Provider: login.Provider, RefreshToken: login.RefreshToken, IDToken: login.IDToken}, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to refresh login: %w", err)
+	}
+
+	// Providers may keep the refresh token.
+	if tokens.RefreshToken == "" {
+		tokens.RefreshToken = login.RefreshToken
+	}
+
+	return serverLogin(&tokens), nil
+}
+
+// Token returns the ID token of the login for server in the CLI config,
+// refreshing it first when it's about to expire.
+func Token(ctx context.Context, client *http.Client, server string) (string, error) {
+	key, err := ServerKey(server)
+	if err != nil {
+		return "", err
+	}
+
+	cfg, err := config.GetConfig(false)
+	if err != nil {
+		return "", ErrNotLoggedIn
+	}
+
+	login, ok := cfg.Logins[key]
+	if !ok {
+		return "", ErrNotLoggedIn
+	}
+
+	if time.Until(login.Expiry) > expiryLeeway {
+		return login.IDToken, nil
+	}
+
+	if login.RefreshToken == "" {
+		return "", ErrLoginExpired
+	}
+
+	refreshed, err := Refresh(ctx, client, key, login)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrLoginExpired, err)
+	}
+
+	cfg.Logins[key] = *refreshed
+
+	if err := config.SaveConfig(cfg); err != nil {
+		return "", fmt.Errorf("failed to save the refreshed login: %w", err)
+	}
+
+	return refreshed.IDToken, nil
+}
+
+// post posts the JSON of in to url and decodes the response to out, unless
+// it's 202 Accepted. It returns the response, whose body has been read.
+func post(ctx context.Context, client *http.Client, url string, in, out any) (*http.Response, error) {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp, json.NewDecoder(resp.Body).Decode(out)
+	case http.StatusAccepted:
+		return resp, nil
+	}
+
+	var apiErr struct {
+		Message string `json:"message"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&apiErr); err != nil || apiErr.Message == "" {
+		return resp, fmt.Errorf("server returned %s", resp.Status)
+	}
+
+	return resp, fmt.Errorf("server returned %s: %s", resp.Status, apiErr.Message)
+}
+
+func serverLogin(tokens *auth.CLITokens) *config.ServerLogin {
+	return &config.ServerLogin{
+		Provider:     tokens.Provider,
+		IDToken:      tokens.IDToken,
+		RefreshToken: tokens.RefreshToken,
+		Expiry:       tokens.Expiry,
+	}
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
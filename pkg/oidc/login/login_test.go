@@ -0,0 +1,206 @@
+package login_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/go-logr/logr"
+	"github.com/oauth2-proxy/mockoidc"
+	. "github.com/onsi/gomega"
+	"golang.org/x/oauth2"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/weaveworks/weave-gitops/pkg/config"
+	"github.com/weaveworks/weave-gitops/pkg/logger"
+	"github.com/weaveworks/weave-gitops/pkg/oidc/login"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+func TestLoginWithBrowser(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	m, err := mockoidc.Run()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	t.Cleanup(func() {
+		_ = m.Shutdown()
+	})
+
+	sm := scs.New()
+
+	authCfg, err := auth.NewAuthServerConfig(logr.Discard(), auth.OIDCConfig{
+		IssuerURL:    m.Config().Issuer,
+		ClientID:     m.Config().ClientID,
+		ClientSecret: m.Config().ClientSecret,
+		Scopes:       []string{"openid", "email", "profile", "groups"},
+	}, fake.NewClientBuilder().Build(), nil, "flux-system", map[auth.AuthMethod]bool{auth.OIDC: true}, "", sm)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	srv, err := auth.NewAuthServer(t.Context(), authCfg)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	mux := http.NewServeMux()
+	g.Expect(auth.RegisterAuthServer(mux, "/oauth2", srv, 10)).To(Succeed())
+
+	s := httptest.NewServer(sm.LoadAndSave(mux))
+	t.Cleanup(s.Close)
+
+	srv.SetRedirectURL(s.URL + "/oauth2/callback")
+
+	// The browser follows the redirects through the OIDC provider back to
+	// the CLI.
+	jar, err := cookiejar.New(nil)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	browser := &http.Client{Jar: jar}
+
+	var logBuf bytes.Buffer
+
+	serverLogin, err := login.Login(t.Context(), login.Options{
+		Server: s.URL + "/",
+		OpenURL: func(url string) error {
+			resp, err := browser.Get(url)
+			if err != nil {
+				return err
+			}
+
+			return resp.Body.Close()
+		},
+	}, logger.NewCLILogger(&logBuf))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(serverLogin.RefreshToken).NotTo(BeEmpty())
+	g.Expect(serverLogin.Expiry).To(BeTemporally(">", time.Now()))
+
+	_, err = m.Keypair.VerifyJWT(serverLogin.IDToken, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(logBuf.String()).To(ContainSubstring(s.URL + "/oauth2/cli/login?"))
+}
+
+func TestLoginWithDeviceCode(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	var polls atomic.Int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth2/cli/device", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(auth.CLIDeviceAuthorization{
+			Provider: "dex",
+			DeviceAuth: &oauth2.DeviceAuthResponse{
+				DeviceCode:      "device-code",
+				UserCode:        "ABCD-EFGH",
+				VerificationURI: "https://dex.example.com/device",
+				Interval:        7,
+			},
+		})
+	})
+	mux.HandleFunc("/oauth2/cli/device/token", func(w http.ResponseWriter, r *http.Request) {
+		var authorization auth.CLIDeviceAuthorization
+		if err := json.NewDecoder(r.Body).Decode(&authorization); err != nil || authorization.DeviceAuth.DeviceCode != "device-code" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		// The CLI waits for as long as the server says, and keeps the
+		// interval for the next polls.
+		poll := polls.Add(1)
+		if want := map[int32]int64{1: 7, 2: 0}[poll]; authorization.DeviceAuth.Interval != want {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if poll == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusAccepted)
+
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(auth.CLITokens{Provider: "dex", IDToken: "id-token", RefreshToken: "refresh-token"})
+	})
+
+	s := httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+
+	var logBuf bytes.Buffer
+
+	serverLogin, err := login.Login(t.Context(), login.Options{Server: s.URL, DeviceCode: true}, logger.NewCLILogger(&logBuf))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(serverLogin).To(Equal(&config.ServerLogin{Provider: "dex", IDToken: "id-token", RefreshToken: "refresh-token"}))
+	g.Expect(polls.Load()).To(Equal(int32(2)))
+	g.Expect(logBuf.String()).To(ContainSubstring("open https://dex.example.com/device and enter the code ABCD-EFGH"))
+}
+
+func TestLoginWithDeviceCodeUnsupported(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth.JSONError(logr.Discard(), w, "provider dex does not support device code login", http.StatusBadRequest)
+	}))
+	t.Cleanup(s.Close)
+
+	_, err := login.Login(t.Context(), login.Options{Server: s.URL, DeviceCode: true}, logger.NewCLILogger(&bytes.Buffer{}))
+	g.Expect(err).To(MatchError(ContainSubstring("provider dex does not support device code login")))
+}
+
+func TestToken(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	var refreshes atomic.Int32
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req auth.CLIRefreshRequest
+		if r.URL.Path != "/oauth2/cli/refresh" || json.NewDecoder(r.Body).Decode(&req) != nil ||
+			req.RefreshToken != "refresh-token" || req.IDToken != "expired-token" {
+			auth.JSONError(logr.Discard(), w, "failed to refresh", http.StatusUnauthorized)
+			return
+		}
+
+		refreshes.Add(1)
+
+		_ = json.NewEncoder(w).Encode(auth.CLITokens{IDToken: "refreshed-token", Expiry: time.Now().Add(time.Hour)})
+	}))
+	t.Cleanup(s.Close)
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	config.SetConfig(nil)
+	t.Cleanup(func() { config.SetConfig(nil) })
+
+	_, err := login.Token(t.Context(), nil, s.URL)
+	g.Expect(err).To(MatchError(login.ErrNotLoggedIn))
+
+	g.Expect(config.SaveConfig(&config.GitopsCLIConfig{
+		Logins: map[string]config.ServerLogin{
+			s.URL: {IDToken: "expired-token", RefreshToken: "refresh-token", Expiry: time.Now()},
+		},
+	})).To(Succeed())
+
+	token, err := login.Token(t.Context(), nil, s.URL+"/")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(token).To(Equal("refreshed-token"))
+
+	// The refreshed token is saved, keeping the refresh token.
+	config.SetConfig(nil)
+
+	cfg, err := config.GetConfig(false)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cfg.Logins[s.URL].IDToken).To(Equal("refreshed-token"))
+	g.Expect(cfg.Logins[s.URL].RefreshToken).To(Equal("refresh-token"))
+
+	token, err = login.Token(t.Context(), nil, s.URL)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(token).To(Equal("refreshed-token"))
+	g.Expect(refreshes.Load()).To(Equal(int32(1)))
+
+	cfg.Logins[s.URL] = config.ServerLogin{IDToken: "expired-token", RefreshToken: "revoked", Expiry: time.Now()}
+
+	_, err = login.Token(t.Context(), nil, s.URL)
+	g.Expect(err).To(MatchError(login.ErrLoginExpired))
+}
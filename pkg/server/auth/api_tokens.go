@@ -0,0 +1,313 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/weaveworks/weave-gitops/core/logger"
+)
+
+const (
+	// APITokensSecretName is the name of the Secret holding the API tokens,
+	// each under a key named after the token.
+	APITokensSecretName string = "weave-gitops-api-tokens" // #nosec G101
+	// APITokenPrefix starts every API token, telling them apart from the
+	// other bearer tokens.
+	APITokenPrefix string = "wgo_"
+
+	// DefaultAPITokenCacheTTL is how long the API tokens are cached for by
+	// default.
+	DefaultAPITokenCacheTTL = 10 * time.Second
+	// apiTokenMinRefresh is how often the tokens can be read again when a
+	// token isn't found in the cache, so new tokens work straight away
+	// without letting unknown tokens read the Secret on every request.
+	apiTokenMinRefresh = time.Second
+
+	APITokenStatusActive  = "Active"
+	APITokenStatusExpired = "Expired"
+	APITokenStatusRevoked = "Revoked"
+)
+
+var (
+	ErrAPITokenNotFound = errors.New("api token not found")
+	ErrAPITokenExists   = errors.New("api token already exists")
+
+	// apiTokenNameRegexp matches the names that are valid Secret keys.
+	apiTokenNameRegexp = regexp.MustCompile(`^[-._a-zA-Z0-9]+$`)
+)
+
+// APITokenEntry is a named token used by automation clients, impersonating User
+// and Groups. Only a hash of the token is kept.
+type APITokenEntry struct {
+	Name      string    `json:"name"`
+	Hash      string    `json:"hash"`
+	User      string    `json:"user"`
+	Groups    []string  `json:"groups,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	// ExpiresAt is when the token stops being valid, never if unset.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	// RevokedAt is set once the token has been revoked. Revoked tokens are
+	// kept so they still show up when listing tokens.
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+}
+
+// Status returns whether the token is Active, Expired or Revoked.
+func (t APITokenEntry) Status(now time.Time) string {
+	switch {
+	case t.RevokedAt != nil:
+		return APITokenStatusRevoked
+	case t.ExpiresAt != nil && !now.Before(*t.ExpiresAt):
+		return APITokenStatusExpired
+	default:
+		return APITokenStatusActive
+	}
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// CreateAPIToken stores a new token for apiToken's name, user, groups and
+// expiry, returning the token. It can't be retrieved afterwards.
+func CreateAPIToken(ctx context.Context, kubernetesClient ctrlclient.Client, namespace string, apiToken APITokenEntry) (string, error) {
+	if !apiTokenNameRegexp.MatchString(apiToken.Name) {
+		return "", fmt.Errorf("invalid token name %q, it can only contain alphanumeric characters, '-', '_' or '.'", apiToken.Name)
+	}
+
+	if apiToken.User == "" {
+		return "", errors.New("a user is required to create a token")
+	}
+
+	token := APITokenPrefix + rand.Text() + rand.Text()
+
+	apiToken.Hash = hashToken(token)
+	apiToken.CreatedAt = time.Now().UTC().Truncate(time.Second)
+	apiToken.RevokedAt = nil
+
+	data, err := json.Marshal(apiToken)
+	if err != nil {
+		return "", err
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret := &corev1.Secret{}
+
+		err := kubernetesClient.Get(ctx, ctrlclient.ObjectKey{Name: APITokensSecretName, Namespace: namespace}, secret)
+		if apierrors.IsNotFound(err) {
+			return kubernetesClient.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: APITokensSecretName, Namespace: namespace},
+				Data:       map[string][]byte{apiToken.Name: data},
+			})
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if _, ok := secret.Data[apiToken.Name]; ok {
+			return fmt.Errorf("%w: %s", ErrAPITokenExists, apiToken.Name)
+		}
+
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+
+		secret.Data[apiToken.Name] = data
+
+		return kubernetesClient.Update(ctx, secret)
+	})
+	if err != nil {
+		return "", fmt.Errorf("creating api token: %w", err)
+	}
+
+	return token, nil
+}
+
+// ListAPITokens returns the tokens, sorted by name.
+func ListAPITokens(ctx context.Context, kubernetesClient ctrlclient.Client, namespace string) ([]APITokenEntry, error) {
+	secret := &corev1.Secret{}
+
+	if err := kubernetesClient.Get(ctx, ctrlclient.ObjectKey{Name: APITokensSecretName, Namespace: namespace}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return []APITokenEntry{}, nil
+		}
+
+		return nil, fmt.Errorf("listing api tokens: %w", err)
+	}
+
+	return apiTokensFromSecret(secret)
+}
+
+func apiTokensFromSecret(secret *corev1.Secret) ([]APITokenEntry, error) {
+	tokens := []APITokenEntry{}
+
+	for name, data := range secret.Data {
+		var token APITokenEntry
+		if err := json.Unmarshal(data, &token); err != nil {
+			return nil, fmt.Errorf("reading api token %q: %w", name, err)
+		}
+
+		token.Name = name
+		tokens = append(tokens, token)
+	}
+
+	slices.SortFunc(tokens, func(a, b APITokenEntry) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return tokens, nil
+}
+
+// RevokeAPIToken revokes the token called name, which stops working straight away.
+func RevokeAPIToken(ctx context.Context, kubernetesClient ctrlclient.Client, namespace, name string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret := &corev1.Secret{}
+
+		if err := kubernetesClient.Get(ctx, ctrlclient.ObjectKey{Name: APITokensSecretName, Namespace: namespace}, secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				return fmt.Errorf("%w: %s", ErrAPITokenNotFound, name)
+			}
+
+			return err
+		}
+
+		data, ok := secret.Data[name]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrAPITokenNotFound, name)
+		}
+
+		var token APITokenEntry
+		if err := json.Unmarshal(data, &token); err != nil {
+			return fmt.Errorf("reading api token %q: %w", name, err)
+		}
+
+		if token.RevokedAt != nil {
+			return nil
+		}
+
+		now := time.Now().UTC().Truncate(time.Second)
+		token.RevokedAt = &now
+
+		data, err := json.Marshal(token)
+		if err != nil {
+			return err
+		}
+
+		secret.Data[name] = data
+
+		return kubernetesClient.Update(ctx, secret)
+	})
+}
+
+// APITokenPrincipalGetter authenticates the API tokens passed as bearer
+// tokens in a request header. The tokens are cached for a short time, so a
+// revoked token keeps working for up to that time.
+type APITokenPrincipalGetter struct {
+	log              logr.Logger
+	kubernetesClient ctrlclient.Client
+	namespace        string
+	headerName       string
+	ttl              time.Duration
+
+	mu       sync.Mutex
+	tokens   []APITokenEntry
+	loadedAt time.Time
+}
+
+// NewAPITokenPrincipalGetter creates a PrincipalGetter checking the tokens in
+// headerName against the tokens stored in namespace, caching them for ttl.
+func NewAPITokenPrincipalGetter(log logr.Logger, kubernetesClient ctrlclient.Client, namespace, headerName string, ttl time.Duration) PrincipalGetter {
+	return &APITokenPrincipalGetter{
+		log:              log,
+		kubernetesClient: kubernetesClient,
+		namespace:        namespace,
+		headerName:       headerName,
+		ttl:              ttl,
+	}
+}
+
+// Principal is an implementation of the PrincipalGetter interface.
+//
+// Bearer tokens that aren't API tokens are left to the other getters,
+// without reading the tokens.
+func (pg *APITokenPrincipalGetter) Principal(r *http.Request) (*UserPrincipal, error) {
+	token := extractToken(r.Header.Get(pg.headerName))
+	if !strings.HasPrefix(token, APITokenPrefix) {
+		return nil, nil
+	}
+
+	hash := []byte(hashToken(token))
+
+	apiToken, err := pg.find(r.Context(), hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if apiToken == nil {
+		return nil, errors.New("invalid api token")
+	}
+
+	if status := apiToken.Status(time.Now()); status != APITokenStatusActive {
+		return nil, fmt.Errorf("api token %q is %s", apiToken.Name, strings.ToLower(status))
+	}
+
+	pg.log.V(logger.LogLevelDebug).Info("authenticated api token", "name", apiToken.Name, "user", apiToken.User)
+
+	return NewUserPrincipal(ID(apiToken.User), Groups(apiToken.Groups)), nil
+}
+
+// find returns the token with hash, reading the tokens again when the cache
+// has expired, or when the token isn't in it and it's older than
+// apiTokenMinRefresh.
+func (pg *APITokenPrincipalGetter) find(ctx context.Context, hash []byte) (*APITokenEntry, error) {
+	pg.mu.Lock()
+	defer pg.mu.Unlock()
+
+	age := time.Since(pg.loadedAt)
+
+	if pg.tokens != nil && age < pg.ttl {
+		if apiToken := findAPIToken(pg.tokens, hash); apiToken != nil || age < apiTokenMinRefresh {
+			return apiToken, nil
+		}
+	}
+
+	tokens, err := ListAPITokens(ctx, pg.kubernetesClient, pg.namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	pg.tokens = tokens
+	pg.loadedAt = time.Now()
+
+	return findAPIToken(tokens, hash), nil
+}
+
+func findAPIToken(tokens []APITokenEntry, hash []byte) *APITokenEntry {
+	for i := range tokens {
+		if subtle.ConstantTimeCompare(hash, []byte(tokens[i].Hash)) == 1 {
+			return &tokens[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package auth_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+func TestAPITokens(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := t.Context()
+
+	k8s := fake.NewClientBuilder().Build()
+
+	tokens, err := auth.ListAPITokens(ctx, k8s, testNamespace)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tokens).To(BeEmpty())
+
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	token, err := auth.CreateAPIToken(ctx, k8s, testNamespace, auth.APITokenEntry{
+		Name:      "ci-bot",
+		User:      "ci",
+		Groups:    []string{"deployers"},
+		ExpiresAt: &expiresAt,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(token).To(HavePrefix(auth.APITokenPrefix))
+
+	_, err = auth.CreateAPIToken(ctx, k8s, testNamespace, auth.APITokenEntry{Name: "another", User: "someone"})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	_, err = auth.CreateAPIToken(ctx, k8s, testNamespace, auth.APITokenEntry{Name: "ci-bot", User: "ci"})
+	g.Expect(err).To(MatchError(auth.ErrAPITokenExists))
+
+	_, err = auth.CreateAPIToken(ctx, k8s, testNamespace, auth.APITokenEntry{Name: "not/valid", User: "ci"})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid token name")))
+
+	_, err = auth.CreateAPIToken(ctx, k8s, testNamespace, auth.APITokenEntry{Name: "no-user"})
+	g.Expect(err).To(MatchError(ContainSubstring("a user is required")))
+
+	tokens, err = auth.ListAPITokens(ctx, k8s, testNamespace)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tokens).To(HaveLen(2))
+	g.Expect(tokens[0].Name).To(Equal("another"))
+	g.Expect(tokens[1].Name).To(Equal("ci-bot"))
+	g.Expect(tokens[1].User).To(Equal("ci"))
+	g.Expect(tokens[1].Groups).To(Equal([]string{"deployers"}))
+	g.Expect(tokens[1].Hash).NotTo(ContainSubstring(strings.TrimPrefix(token, auth.APITokenPrefix)))
+	g.Expect(tokens[1].Status(time.Now())).To(Equal(auth.APITokenStatusActive))
+	g.Expect(tokens[1].Status(expiresAt)).To(Equal(auth.APITokenStatusExpired))
+
+	g.Expect(auth.RevokeAPIToken(ctx, k8s, testNamespace, "ci-bot")).To(Succeed())
+	g.Expect(auth.RevokeAPIToken(ctx, k8s, testNamespace, "missing")).To(MatchError(auth.ErrAPITokenNotFound))
+
+	tokens, err = auth.ListAPITokens(ctx, k8s, testNamespace)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tokens[1].Status(time.Now())).To(Equal(auth.APITokenStatusRevoked))
+}
+
+func TestAPITokenPrincipalGetter(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := t.Context()
+
+	k8s := fake.NewClientBuilder().Build()
+
+	valid, err := auth.CreateAPIToken(ctx, k8s, testNamespace, auth.APITokenEntry{Name: "valid", User: "ci", Groups: []string{"deployers"}})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	expiresAt := time.Now().Add(-time.Minute)
+	expired, err := auth.CreateAPIToken(ctx, k8s, testNamespace, auth.APITokenEntry{Name: "expired", User: "ci", ExpiresAt: &expiresAt})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	revoked, err := auth.CreateAPIToken(ctx, k8s, testNamespace, auth.APITokenEntry{Name: "revoked", User: "ci"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(auth.RevokeAPIToken(ctx, k8s, testNamespace, "revoked")).To(Succeed())
+
+	getter := auth.NewAPITokenPrincipalGetter(logr.Discard(), k8s, testNamespace, "Authorization", time.Hour)
+
+	principal := func(token string) (*auth.UserPrincipal, error) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/objects", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		return getter.Principal(req)
+	}
+
+	p, err := principal(valid)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(p.ID).To(Equal("ci"))
+	g.Expect(p.Groups).To(Equal([]string{"deployers"}))
+
+	_, err = principal(expired)
+	g.Expect(err).To(MatchError(ContainSubstring("is expired")))
+
+	_, err = principal(revoked)
+	g.Expect(err).To(MatchError(ContainSubstring("is revoked")))
+
+	_, err = principal(auth.APITokenPrefix + "unknown")
+	g.Expect(err).To(MatchError("invalid api token"))
+
+	p, err = principal("some-oidc-token")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(p).To(BeNil(), "other bearer tokens should be left to the other methods")
+
+	g.Expect(auth.RevokeAPIToken(ctx, k8s, testNamespace, "valid")).To(Succeed())
+
+	_, err = principal(valid)
+	g.Expect(err).NotTo(HaveOccurred(), "the tokens should be cached")
+
+	getter = auth.NewAPITokenPrincipalGetter(logr.Discard(), k8s, testNamespace, "Authorization", time.Hour)
+
+	_, err = principal(valid)
+	g.Expect(err).To(MatchError(ContainSubstring("is revoked")))
+}
+
+func TestWithAPIAuthAPITokens(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	k8s := fake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: auth.ClusterUserAuthSecretName, Namespace: testNamespace},
+		Data:       map[string][]byte{"password": []byte("hashed")},
+	}).Build()
+
+	token, err := auth.CreateAPIToken(t.Context(), k8s, testNamespace, auth.APITokenEntry{Name: "ci-bot", User: "ci"})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	tokenSignerVerifier, err := auth.NewHMACTokenSignerVerifier(5 * time.Minute)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	authMethods := map[auth.AuthMethod]bool{auth.UserAccount: true, auth.APIToken: true}
+	authCfg, err := auth.NewAuthServerConfig(logr.Discard(), auth.OIDCConfig{}, k8s, tokenSignerVerifier, testNamespace, authMethods, "", scs.New())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	authCfg.APITokenCacheTTL = time.Nanosecond
+
+	srv, err := auth.NewAuthServer(t.Context(), authCfg)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	var user string
+
+	handler := auth.WithAPIAuth(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		user = auth.Principal(r.Context()).ID
+	}), srv, nil, scs.New())
+
+	res := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v1/objects", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	handler.ServeHTTP(res, req)
+
+	g.Expect(res).To(HaveHTTPStatus(http.StatusOK))
+	g.Expect(user).To(Equal("ci"))
+
+	g.Expect(auth.RevokeAPIToken(t.Context(), k8s, testNamespace, "ci-bot")).To(Succeed())
+
+	res = httptest.NewRecorder()
+	handler.ServeHTTP(res, req)
+
+	g.Expect(res).To(HaveHTTPStatus(http.StatusUnauthorized))
+}
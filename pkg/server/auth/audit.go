@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/weaveworks/weave-gitops/pkg/audit"
+)
+
+// The actions of the auth events.
+const (
+	auditActionSignIn     = "SignIn"
+	auditActionOIDCSignIn = "OIDCSignIn"
+	auditActionRefresh    = "Refresh"
+	auditActionLogout     = "Logout"
+	// auditActionRevokeSession is an admin revoking another user's session.
+	auditActionRevokeSession = "RevokeSession"
+)
+
+// auditAuth records the auth action of the user of r, which failed if err is
+// set. The principal is the user as far as it's known.
+func (s *AuthServer) auditAuth(r *http.Request, action string, principal *UserPrincipal, err error, details map[string]string) {
+	event := audit.Event{
+		Type:     audit.EventTypeAuth,
+		Action:   action,
+		SourceIP: s.TrustedProxies.SourceIP(r),
+		Outcome:  audit.Outcome(err),
+		Details:  details,
+	}
+
+	if principal != nil {
+		event.Principal = principal.ID
+		event.Groups = principal.Groups
+	}
+
+	if err != nil {
+		event.Error = err.Error()
+	}
+
+	s.Audit.Record(r.Context(), event)
+}
+
+// sessionPrincipal returns the user of the session of r, or nil if it's
+// unknown or expired.
+func (s *AuthServer) sessionPrincipal(r *http.Request) *UserPrincipal {
+	idToken := s.SessionManager.GetString(r.Context(), IDTokenCookieName)
+	if idToken == "" {
+		return nil
+	}
+
+	if s.tokenSignerVerifier != nil {
+		if principal, err := parseJWTAdminToken(s.tokenSignerVerifier, idToken); err == nil {
+			return principal
+		}
+	}
+
+	if s.oidcEnabled() {
+		if principal, err := parseJWTToken(r.Context(), s.verifier(), idToken, s.OIDCConfig.ClaimsConfig, s.Log); err == nil {
+			return principal
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package auth_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/bcrypt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/weaveworks/weave-gitops/pkg/audit"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+type recordingSink struct {
+	mu     sync.Mutex
+	events []audit.Event
+}
+
+func (s *recordingSink) Write(_ context.Context, event audit.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	event.Time = time.Time{}
+	s.events = append(s.events, event)
+
+	return nil
+}
+
+func TestSignInAudit(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	users, err := json.Marshal([]auth.LocalUser{{Username: "jane", PasswordHash: string(hashed), Groups: []string{"team-a"}}})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	client := ctrlclientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: auth.ClusterUserAuthSecretName, Namespace: testNamespace},
+		Data:       map[string][]byte{auth.ClusterUserAuthUsersKey: users},
+	}).Build()
+
+	tsv, err := auth.NewHMACTokenSignerVerifier(5 * time.Minute)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	sink := &recordingSink{}
+	s, _ := makeAuthServer(t, client, tsv, []auth.AuthMethod{auth.UserAccount}, &fakeSessionManager{})
+	s.Audit = audit.NewRecorder(logr.Discard(), sink)
+
+	for _, password := range []string{"wrong", "password"} {
+		j, _ := json.Marshal(auth.LoginRequest{Username: "jane", Password: password})
+		req := httptest.NewRequest(http.MethodPost, "https://example.com/signin", bytes.NewReader(j))
+		req.RemoteAddr = "10.0.0.1:43210"
+		s.SignIn().ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	g.Expect(sink.events).To(Equal([]audit.Event{
+		{
+			Type:      audit.EventTypeAuth,
+			Action:    "SignIn",
+			Principal: "jane",
+			SourceIP:  "10.0.0.1",
+			Outcome:   audit.OutcomeFailure,
+			Error:     bcrypt.ErrMismatchedHashAndPassword.Error(),
+		},
+		{
+			Type:      audit.EventTypeAuth,
+			Action:    "SignIn",
+			Principal: "jane",
+			Groups:    []string{"team-a"},
+			SourceIP:  "10.0.0.1",
+			Outcome:   audit.OutcomeSuccess,
+		},
+	}))
+}
+
+func TestLogoutAudit(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tsv, err := auth.NewHMACTokenSignerVerifier(5 * time.Minute)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	token, err := tsv.Sign("jane", "team-a")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	client := ctrlclientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: auth.ClusterUserAuthSecretName, Namespace: testNamespace},
+	}).Build()
+
+	sink := &recordingSink{}
+	s, _ := makeAuthServer(t, client, tsv, []auth.AuthMethod{auth.UserAccount}, &fakeSessionManager{})
+	s.Audit = audit.NewRecorder(logr.Discard(), sink)
+
+	req := httptest.NewRequest(http.MethodPost, "https://example.com/logout", nil).WithContext(
+		contextWithSessionValues(map[string]any{
+			"sessionid":            "test-session",
+			auth.IDTokenCookieName: token,
+		}))
+	req.Header.Set("X-Forwarded-For", "192.168.1.5, 10.0.0.1")
+
+	// The test request comes from 192.0.2.1, through the proxy at 10.0.0.1.
+	s.TrustedProxies, err = audit.ParseTrustedProxies([]string{"192.0.2.1", "10.0.0.0/8"})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s.Logout(httptest.NewRecorder(), req)
+
+	g.Expect(sink.events).To(Equal([]audit.Event{{
+		Type:      audit.EventTypeAuth,
+		Action:    "Logout",
+		Principal: "jane",
+		Groups:    []string{"team-a"},
+		SourceIP:  "192.168.1.5",
+		Outcome:   audit.OutcomeSuccess,
+	}}))
+}
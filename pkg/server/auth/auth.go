@@ -31,6 +31,9 @@ const (
 	// the user has authenticated successfully with the OIDC Provider. It's used to refresh
 	// the id and access tokens once expired.
 	RefreshTokenCookieName = "refresh_token"
+	// OIDCProviderSessionKey is the name of the session value that holds the
+	// name of the OIDC provider the user logged in with, to refresh the tokens.
+	OIDCProviderSessionKey = "oidc_provider"
 	// AuthorizationTokenHeaderName is the name of the header that holds the bearer token
 	// used for token passthrough authentication.
 	AuthorizationTokenHeaderName = "Authorization"
@@ -60,8 +63,15 @@ func RegisterAuthServer(mux *http.ServeMux, prefix string, srv *AuthServer, logi
 	mux.HandleFunc(prefix+"/callback", srv.Callback)
 	mux.Handle(prefix+"/sign_in", middleware.Handle(srv.SignIn()))
 	mux.HandleFunc(prefix+"/userinfo", srv.UserInfo)
+	mux.HandleFunc(prefix+"/providers", srv.Providers)
 	mux.HandleFunc(prefix+"/refresh", srv.RefreshHandler)
 	mux.HandleFunc(prefix+"/logout", srv.Logout)
+	mux.Handle(prefix+"/cli/login", middleware.Handle(http.HandlerFunc(srv.CLILogin)))
+	mux.Handle(prefix+"/cli/device", middleware.Handle(http.HandlerFunc(srv.CLIDeviceAuth)))
+	mux.Handle(prefix+"/cli/device/token", middleware.Handle(http.HandlerFunc(srv.CLIDeviceToken)))
+	mux.Handle(prefix+"/cli/refresh", middleware.Handle(http.HandlerFunc(srv.CLIRefresh)))
+	mux.Handle(prefix+"/sessions", WithAPIAuth(http.HandlerFunc(srv.ListSessions), srv, nil, srv.SessionManager))
+	mux.Handle(prefix+"/sessions/revoke", WithAPIAuth(http.HandlerFunc(srv.RevokeSession), srv, nil, srv.SessionManager))
 
 	return nil
 }
@@ -159,10 +169,14 @@ func WithPrincipal(ctx context.Context, p *UserPrincipal) context.Context {
 // Unauthorized requests will be denied with a 401 status code.
 func WithAPIAuth(next http.Handler, srv *AuthServer, publicRoutes []string, sm SessionManager) http.Handler {
 	multi := MultiAuthPrincipal{Log: srv.Log, Getters: []PrincipalGetter{}}
+	if srv.Sessions != nil {
+		multi.Sessions = srv.Sessions
+	}
 
 	// FIXME: currently the order must be OIDC last, or it'll "shadow" the other
-	// methods so they don't work.
-	methods := []AuthMethod{UserAccount, TokenPassthrough, OIDC, Anonymous}
+	// methods so they don't work. API tokens go first, as the other bearer token
+	// methods would reject them.
+	methods := []AuthMethod{APIToken, UserAccount, TokenPassthrough, OIDC, Anonymous}
 	for _, method := range methods {
 		enabled, ok := srv.authMethods[method]
 		if !ok {
@@ -195,8 +209,17 @@ func WithAPIAuth(next http.Handler, srv *AuthServer, publicRoutes []string, sm S
 				multi.Getters = append(multi.Getters, adminAuth)
 			}
 
+		case APIToken:
+			multi.Getters = append(multi.Getters, NewAPITokenPrincipalGetter(srv.Log, srv.kubernetesClient, srv.namespace, AuthorizationTokenHeaderName, srv.apiTokenCacheTTL()))
+
 		case TokenPassthrough:
-			tokenAuth := NewBearerTokenPassthroughPrincipalGetter(srv.Log, nil, AuthorizationTokenHeaderName, srv.kubernetesClient)
+			var tokenAuth PrincipalGetter
+			if srv.TokenReview {
+				tokenAuth = NewTokenReviewPrincipalGetter(srv.Log, srv.kubernetesClient, AuthorizationTokenHeaderName, srv.tokenReviewTTL())
+			} else {
+				tokenAuth = NewBearerTokenPassthroughPrincipalGetter(srv.Log, nil, AuthorizationTokenHeaderName, srv.kubernetesClient)
+			}
+
 			multi.Getters = append(multi.Getters, tokenAuth)
 
 		case Anonymous:
@@ -460,8 +460,8 @@ func (sm *fakeSessionManager) GetString(ctx context.Context, key string) string
 	return ""
 }
 
-func (sm *fakeSessionManager) Remove(context.Context, string) {
-	panic("not implemented")
+func (sm *fakeSessionManager) Remove(_ context.Context, key string) {
+	delete(sm.PutValues, key)
 }
 
 func (sm *fakeSessionManager) Put(ctx context.Context, key string, val interface{}) {
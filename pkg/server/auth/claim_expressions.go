@@ -0,0 +1,229 @@
+package auth
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/ext"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// ClaimExpressionsSecretKey is the key in the OIDC secret holding the CEL
+// expressions computing the principal from the claims.
+const ClaimExpressionsSecretKey = "claimExpressions"
+
+// claimExpressionsCostLimit bounds the cost of evaluating a single
+// expression, so a bad expression can't stall the logins.
+const claimExpressionsCostLimit = 1000000
+
+var claimVariableNameRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// ClaimVariable is a named intermediate value of the claim expressions,
+// available to the later expressions as `variables.<name>`.
+type ClaimVariable struct {
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
+}
+
+// ClaimExpressions computes the principal from the full set of claims with
+// CEL expressions, e.g.
+//
+//	claimExpressions: |
+//	  variables:
+//	    - name: roles
+//	      expression: claims.realm_access.roles
+//	  username: claims.email.lowerAscii()
+//	  groups: |
+//	    variables.roles
+//	      .filter(r, r.startsWith("k8s-"))
+//	      .map(r, regex.replace(r, "^k8s-", ""))
+//
+// The claims are available as `claims`, the variables are evaluated in order.
+// The username expression must return a string and the groups expression a
+// list of strings; when either is empty the claim from the ClaimsConfig is
+// used instead. The prefixes of the ClaimsConfig are added to the results.
+type ClaimExpressions struct {
+	Variables []ClaimVariable `json:"variables,omitempty"`
+	Username  string          `json:"username,omitempty"`
+	Groups    string          `json:"groups,omitempty"`
+
+	once       sync.Once
+	compiled   *compiledClaimExpressions
+	compileErr error
+}
+
+type compiledClaimExpressions struct {
+	variables []compiledClaimVariable
+	username  cel.Program
+	groups    cel.Program
+}
+
+type compiledClaimVariable struct {
+	name    string
+	program cel.Program
+}
+
+// NewClaimExpressionsFromSecret reads and compiles the claim expressions in
+// the OIDC secret, returning nil if there are none.
+func NewClaimExpressionsFromSecret(secret corev1.Secret) (*ClaimExpressions, error) {
+	data := secret.Data[ClaimExpressionsSecretKey]
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	exprs := &ClaimExpressions{}
+	if err := yaml.UnmarshalStrict(data, exprs); err != nil {
+		return nil, fmt.Errorf("reading %q from secret %s: %w", ClaimExpressionsSecretKey, secret.Name, err)
+	}
+
+	if err := exprs.Compile(); err != nil {
+		return nil, err
+	}
+
+	return exprs, nil
+}
+
+// Compile checks and compiles the expressions. It's called on the first
+// evaluation otherwise, compiling early reports the errors on startup.
+func (e *ClaimExpressions) Compile() error {
+	e.once.Do(func() {
+		e.compiled, e.compileErr = e.compile()
+	})
+
+	return e.compileErr
+}
+
+func (e *ClaimExpressions) compile() (*compiledClaimExpressions, error) {
+	env, err := cel.NewEnv(
+		cel.Variable("claims", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("variables", cel.MapType(cel.StringType, cel.DynType)),
+		cel.OptionalTypes(),
+		ext.Strings(),
+		ext.Lists(),
+		ext.Regex(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating CEL environment: %w", err)
+	}
+
+	compiled := &compiledClaimExpressions{}
+	names := map[string]bool{}
+
+	for _, v := range e.Variables {
+		if !claimVariableNameRegexp.MatchString(v.Name) {
+			return nil, fmt.Errorf("invalid claim variable name %q", v.Name)
+		}
+
+		if names[v.Name] {
+			return nil, fmt.Errorf("duplicate claim variable name %q", v.Name)
+		}
+
+		names[v.Name] = true
+
+		program, err := compileClaimExpression(env, v.Expression, nil)
+		if err != nil {
+			return nil, fmt.Errorf("claim variable %q: %w", v.Name, err)
+		}
+
+		compiled.variables = append(compiled.variables, compiledClaimVariable{name: v.Name, program: program})
+	}
+
+	if e.Username != "" {
+		compiled.username, err = compileClaimExpression(env, e.Username, cel.StringType)
+		if err != nil {
+			return nil, fmt.Errorf("username expression: %w", err)
+		}
+	}
+
+	if e.Groups != "" {
+		compiled.groups, err = compileClaimExpression(env, e.Groups, cel.ListType(cel.StringType))
+		if err != nil {
+			return nil, fmt.Errorf("groups expression: %w", err)
+		}
+	}
+
+	return compiled, nil
+}
+
+// compileClaimExpression compiles expr, checking it returns outputType unless
+// the type is only known at runtime.
+func compileClaimExpression(env *cel.Env, expr string, outputType *cel.Type) (cel.Program, error) {
+	ast, issues := env.Compile(expr)
+	if issues.Err() != nil {
+		return nil, issues.Err()
+	}
+
+	if outputType != nil && ast.OutputType() != cel.DynType && !ast.OutputType().IsAssignableType(outputType) {
+		return nil, fmt.Errorf("must return %s, not %s", outputType, ast.OutputType())
+	}
+
+	return env.Program(ast, cel.CostLimit(claimExpressionsCostLimit))
+}
+
+// claimExpressionsResult is the username and groups computed by the
+// expressions, the has fields are false for those without an expression.
+type claimExpressionsResult struct {
+	username    string
+	hasUsername bool
+	groups      []string
+	hasGroups   bool
+}
+
+// evaluate evaluates the expressions against claims.
+func (e *ClaimExpressions) evaluate(claims map[string]interface{}) (*claimExpressionsResult, error) {
+	if err := e.Compile(); err != nil {
+		return nil, err
+	}
+
+	variables := map[string]ref.Val{}
+	activation := map[string]interface{}{
+		"claims":    claims,
+		"variables": variables,
+	}
+
+	for _, v := range e.compiled.variables {
+		out, _, err := v.program.Eval(activation)
+		if err != nil {
+			return nil, fmt.Errorf("evaluating claim variable %q: %w", v.name, err)
+		}
+
+		variables[v.name] = out
+	}
+
+	result := &claimExpressionsResult{}
+
+	if e.compiled.username != nil {
+		out, _, err := e.compiled.username.Eval(activation)
+		if err != nil {
+			return nil, fmt.Errorf("evaluating username expression: %w", err)
+		}
+
+		native, err := out.ConvertToNative(reflect.TypeOf(""))
+		if err != nil {
+			return nil, fmt.Errorf("username expression must return a string: %w", err)
+		}
+
+		result.username, result.hasUsername = native.(string), true
+	}
+
+	if e.compiled.groups != nil {
+		out, _, err := e.compiled.groups.Eval(activation)
+		if err != nil {
+			return nil, fmt.Errorf("evaluating groups expression: %w", err)
+		}
+
+		native, err := out.ConvertToNative(reflect.TypeOf([]string{}))
+		if err != nil {
+			return nil, fmt.Errorf("groups expression must return a list of strings: %w", err)
+		}
+
+		result.groups, result.hasGroups = native.([]string), true
+	}
+
+	return result, nil
+}
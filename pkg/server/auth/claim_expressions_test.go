@@ -0,0 +1,206 @@
+package auth_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+type jsonClaims string
+
+func (c jsonClaims) Claims(v interface{}) error {
+	return json.Unmarshal([]byte(c), v)
+}
+
+const expressionTestClaims = jsonClaims(`{
+	"sub": "1234",
+	"email": "Jane.Doe@Example.com",
+	"groups": ["flat"],
+	"realm_access": {
+		"roles": ["k8s-admins", "offline_access", "k8s-devs"]
+	}
+}`)
+
+func TestPrincipalFromClaimsWithExpressions(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *auth.ClaimsConfig
+		want   *auth.UserPrincipal
+	}{
+		{
+			name: "username expression",
+			config: &auth.ClaimsConfig{Expressions: &auth.ClaimExpressions{
+				Username: `claims.email.lowerAscii()`,
+			}},
+			want: &auth.UserPrincipal{ID: "jane.doe@example.com", Groups: []string{"flat"}},
+		},
+		{
+			name: "nested groups filtered and rewritten",
+			config: &auth.ClaimsConfig{Expressions: &auth.ClaimExpressions{
+				Groups: `claims.realm_access.roles.filter(r, r.startsWith("k8s-")).map(r, regex.replace(r, "^k8s-", ""))`,
+			}},
+			want: &auth.UserPrincipal{ID: "Jane.Doe@Example.com", Groups: []string{"admins", "devs"}},
+		},
+		{
+			name: "variables",
+			config: &auth.ClaimsConfig{Expressions: &auth.ClaimExpressions{
+				Variables: []auth.ClaimVariable{
+					{Name: "roles", Expression: `"realm_access" in claims ? claims.realm_access.roles : []`},
+					{Name: "k8sRoles", Expression: `variables.roles.filter(r, r.startsWith("k8s-"))`},
+				},
+				Username: `claims.sub`,
+				Groups:   `variables.k8sRoles + claims.groups`,
+			}},
+			want: &auth.UserPrincipal{ID: "1234", Groups: []string{"k8s-admins", "k8s-devs", "flat"}},
+		},
+		{
+			name: "prefixes are added to the results",
+			config: &auth.ClaimsConfig{
+				UsernamePrefix: "oidc:",
+				GroupsPrefix:   "oidc:",
+				Expressions: &auth.ClaimExpressions{
+					Username: `claims.sub`,
+					Groups:   `claims.realm_access.roles.filter(r, r.startsWith("k8s-"))`,
+				},
+			},
+			want: &auth.UserPrincipal{ID: "oidc:1234", Groups: []string{"oidc:k8s-admins", "oidc:k8s-devs"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := tt.config.PrincipalFromClaims(expressionTestClaims)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, principal, cmpopts.IgnoreUnexported(auth.UserPrincipal{})); diff != "" {
+				t.Fatalf("failed to generate principal:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPrincipalFromClaimsWithExpressionErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		exprs   *auth.ClaimExpressions
+		wantErr string
+	}{
+		{
+			name:    "missing claim",
+			exprs:   &auth.ClaimExpressions{Username: `claims.preferred_username`},
+			wantErr: "evaluating username expression: no such key: preferred_username",
+		},
+		{
+			name:    "empty username",
+			exprs:   &auth.ClaimExpressions{Username: `""`},
+			wantErr: "the username expression returned an empty value",
+		},
+		{
+			name:    "groups not strings",
+			exprs:   &auth.ClaimExpressions{Groups: `[claims.realm_access]`},
+			wantErr: "groups expression must return a list of strings",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			config := &auth.ClaimsConfig{Expressions: tt.exprs}
+			_, err := config.PrincipalFromClaims(expressionTestClaims)
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
+
+func TestClaimExpressionsCompile(t *testing.T) {
+	tests := []struct {
+		name    string
+		exprs   *auth.ClaimExpressions
+		wantErr string
+	}{
+		{
+			name:    "syntax error",
+			exprs:   &auth.ClaimExpressions{Username: `claims.email.`},
+			wantErr: "username expression: ",
+		},
+		{
+			name:    "username of the wrong type",
+			exprs:   &auth.ClaimExpressions{Username: `["a"]`},
+			wantErr: "username expression: must return string",
+		},
+		{
+			name:    "groups of the wrong type",
+			exprs:   &auth.ClaimExpressions{Groups: `"a"`},
+			wantErr: "groups expression: must return list(string)",
+		},
+		{
+			name: "invalid variable name",
+			exprs: &auth.ClaimExpressions{Variables: []auth.ClaimVariable{
+				{Name: "my-roles", Expression: `claims.roles`},
+			}},
+			wantErr: `invalid claim variable name "my-roles"`,
+		},
+		{
+			name: "duplicate variable name",
+			exprs: &auth.ClaimExpressions{Variables: []auth.ClaimVariable{
+				{Name: "roles", Expression: `claims.roles`},
+				{Name: "roles", Expression: `claims.groups`},
+			}},
+			wantErr: `duplicate claim variable name "roles"`,
+		},
+		{
+			name:    "undeclared reference",
+			exprs:   &auth.ClaimExpressions{Username: `token.sub`},
+			wantErr: "undeclared reference to 'token'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(tt.exprs.Compile()).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
+
+func TestNewClaimExpressionsFromSecret(t *testing.T) {
+	g := NewWithT(t)
+
+	exprs, err := auth.NewClaimExpressionsFromSecret(corev1.Secret{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(exprs).To(BeNil())
+
+	exprs, err = auth.NewClaimExpressionsFromSecret(corev1.Secret{Data: map[string][]byte{
+		auth.ClaimExpressionsSecretKey: []byte(`
+variables:
+  - name: roles
+    expression: claims.realm_access.roles
+groups: variables.roles.filter(r, r.startsWith("k8s-"))
+`),
+	}})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	principal, err := (&auth.ClaimsConfig{Expressions: exprs}).PrincipalFromClaims(expressionTestClaims)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(principal.Groups).To(Equal([]string{"k8s-admins", "k8s-devs"}))
+
+	_, err = auth.NewClaimExpressionsFromSecret(corev1.Secret{Data: map[string][]byte{
+		auth.ClaimExpressionsSecretKey: []byte(`usernam: claims.sub`),
+	}})
+	g.Expect(err).To(MatchError(ContainSubstring(`unknown field "usernam"`)))
+
+	_, err = auth.NewClaimExpressionsFromSecret(corev1.Secret{Data: map[string][]byte{
+		auth.ClaimExpressionsSecretKey: []byte(`groups: claims.sub.size()`),
+	}})
+	g.Expect(err).To(HaveOccurred())
+}
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"errors"
 	"fmt"
 )
 
@@ -9,6 +10,13 @@ import (
 type ClaimsConfig struct {
 	Username string
 	Groups   string
+	// UsernamePrefix and GroupsPrefix are added to the username and groups
+	// read from the claims, telling apart the users of different providers.
+	UsernamePrefix string
+	GroupsPrefix   string
+	// Expressions computes the username and groups from the claims, taking
+	// precedence over the Username and Groups claims.
+	Expressions *ClaimExpressions
 }
 
 type claimsToken interface {
@@ -27,6 +35,8 @@ func (c *ClaimsConfig) PrincipalFromClaims(token claimsToken) (*UserPrincipal, e
 	var (
 		idKey     = ScopeEmail
 		groupsKey = ScopeGroups
+		exprs     = &claimExpressionsResult{}
+		err       error
 	)
 
 	if c != nil && c.Username != "" {
@@ -37,11 +47,47 @@ func (c *ClaimsConfig) PrincipalFromClaims(token claimsToken) (*UserPrincipal, e
 		groupsKey = c.Groups
 	}
 
-	id, ok := claims[idKey].(string)
-	if !ok {
-		return nil, fmt.Errorf("missing %q claim in response", idKey)
+	if c != nil && c.Expressions != nil {
+		exprs, err = c.Expressions.evaluate(claims)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	id, groups := exprs.username, exprs.groups
+
+	if exprs.hasUsername {
+		if id == "" {
+			return nil, errors.New("the username expression returned an empty value")
+		}
+	} else {
+		var ok bool
+
+		id, ok = claims[idKey].(string)
+		if !ok {
+			return nil, fmt.Errorf("missing %q claim in response", idKey)
+		}
+	}
+
+	if !exprs.hasGroups {
+		groups, err = groupsFromClaims(claims, groupsKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if c != nil {
+		id = c.UsernamePrefix + id
+
+		for i := range groups {
+			groups[i] = c.GroupsPrefix + groups[i]
+		}
 	}
 
+	return &UserPrincipal{ID: id, Groups: groups}, nil
+}
+
+func groupsFromClaims(claims map[string]interface{}, groupsKey string) ([]string, error) {
 	groups := []string{}
 
 	if v, ok := claims[groupsKey]; ok {
@@ -64,5 +110,5 @@ func (c *ClaimsConfig) PrincipalFromClaims(token claimsToken) (*UserPrincipal, e
 		}
 	}
 
-	return &UserPrincipal{ID: id, Groups: groups}, nil
+	return groups, nil
 }
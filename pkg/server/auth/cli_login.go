@@ -0,0 +1,544 @@
+package auth
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/go-logr/logr"
+	"golang.org/x/oauth2"
+
+	"github.com/weaveworks/weave-gitops/core/logger"
+)
+
+const (
+	// cliDeviceTokenTimeout bounds the token request a device token request
+	// makes to the provider. Each request polls the provider once, the CLI
+	// polls again while the login is pending.
+	cliDeviceTokenTimeout = 10 * time.Second
+	// cliDeviceDefaultInterval is the polling interval of device code logins
+	// whose provider doesn't set one, in seconds.
+	// https://datatracker.ietf.org/doc/html/rfc8628#section-3.2
+	cliDeviceDefaultInterval = 5
+	// cliDeviceSlowDown is how much longer to wait between polls, in seconds,
+	// when the provider asks to slow down.
+	// https://datatracker.ietf.org/doc/html/rfc8628#section-3.5
+	cliDeviceSlowDown   = 5
+	deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+)
+
+// cliTokensInfo is the HKDF info deriving the keys sealing the tokens of CLI
+// logins.
+const cliTokensInfo = "weave-gitops cli login"
+
+// ErrCLIDevicePending is returned by device token requests while the user
+// hasn't approved the login yet.
+var ErrCLIDevicePending = errors.New("device authorization pending")
+
+// CLITokens are the tokens issued to the CLI by a login.
+type CLITokens struct {
+	// Provider is the name of the OIDC provider that issued the tokens.
+	Provider     string    `json:"provider,omitempty"`
+	IDToken      string    `json:"idToken"`
+	RefreshToken string    `json:"refreshToken,omitempty"`
+	Expiry       time.Time `json:"expiry"`
+}
+
+// CLILoginRequest is the state of a browser login of the CLI, carried
+// through the auth flow in the SessionState.
+type CLILoginRequest struct {
+	// RedirectURI is the loopback URI the CLI listens on for the tokens.
+	RedirectURI string `json:"r"`
+	// State is passed back to the CLI to match the tokens to its login.
+	State string `json:"s"`
+	// PublicKey is the base64url encoded X25519 public key of the CLI the
+	// tokens are sealed with.
+	PublicKey string `json:"k"`
+}
+
+// CLIDeviceAuthRequest is the data submitted to start a device code login.
+type CLIDeviceAuthRequest struct {
+	Provider string `json:"provider,omitempty"`
+}
+
+// CLIDeviceAuthorization is a pending device code login. The CLI shows the
+// user code to the user and submits it back to poll for the tokens.
+type CLIDeviceAuthorization struct {
+	Provider   string                     `json:"provider"`
+	DeviceAuth *oauth2.DeviceAuthResponse `json:"deviceAuth"`
+}
+
+// CLIRefreshRequest is the data submitted to refresh the tokens of the CLI.
+type CLIRefreshRequest struct {
+	Provider     string `json:"provider,omitempty"`
+	RefreshToken string `json:"refreshToken"`
+	// IDToken is the ID token being refreshed, the refreshed one is added
+	// to its session.
+	IDToken string `json:"idToken,omitempty"`
+}
+
+// CLILogin starts the browser login of the CLI. Once the user has logged in
+// with the OIDC provider, the Callback redirects them to the loopback URI of
+// the CLI with the tokens sealed to its public key, so they don't appear in
+// clear in the browser history.
+func (s *AuthServer) CLILogin(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		rw.Header().Add("Allow", "GET")
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	if !s.oidcEnabled() {
+		JSONError(s.Log, rw, "oidc provider not configured", http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+
+	cli := &CLILoginRequest{
+		RedirectURI: query.Get("redirect_uri"),
+		State:       query.Get("state"),
+		PublicKey:   query.Get("public_key"),
+	}
+
+	if err := cli.validate(); err != nil {
+		JSONError(s.Log, rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.startAuthFlow(rw, r, cli)
+}
+
+func (c *CLILoginRequest) validate() error {
+	if c.State == "" {
+		return errors.New("state is required")
+	}
+
+	if _, err := parseCLIPublicKey(c.PublicKey); err != nil {
+		return err
+	}
+
+	u, err := url.Parse(c.RedirectURI)
+	if err != nil {
+		return fmt.Errorf("invalid redirect_uri: %w", err)
+	}
+
+	// Only the CLI on the user's machine may receive the tokens.
+	if u.Scheme != "http" || u.Port() == "" || (u.Hostname() != "localhost" && !net.ParseIP(u.Hostname()).IsLoopback()) {
+		return errors.New("redirect_uri must be a loopback http URI")
+	}
+
+	return nil
+}
+
+// completeCLILogin records the session of principal and sends the tokens to
+// the CLI that started the login.
+func (s *AuthServer) completeCLILogin(rw http.ResponseWriter, r *http.Request, cli *CLILoginRequest, principal *UserPrincipal, tokens CLITokens) {
+	if err := cli.validate(); err != nil {
+		JSONError(s.Log, rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := s.startSession(r, principal, OIDC, tokens.IDToken); err != nil {
+		s.Log.Error(err, "failed to record session")
+		rw.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	sealed, err := sealCLITokens(cli.PublicKey, tokens)
+	if err != nil {
+		s.Log.Error(err, "failed to seal the CLI tokens")
+		rw.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	redirect, _ := url.Parse(cli.RedirectURI)
+	query := redirect.Query()
+	query.Set("state", cli.State)
+	query.Set("tokens", sealed)
+	redirect.RawQuery = query.Encode()
+
+	http.Redirect(rw, r, redirect.String(), http.StatusSeeOther)
+}
+
+// CLIDeviceAuth starts a device code login of the CLI with a provider
+// supporting the OAuth 2.0 device authorization grant.
+func (s *AuthServer) CLIDeviceAuth(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		rw.Header().Add("Allow", "POST")
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	if !s.oidcEnabled() {
+		JSONError(s.Log, rw, "oidc provider not configured", http.StatusBadRequest)
+		return
+	}
+
+	var req CLIDeviceAuthRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		JSONError(s.Log, rw, "invalid device authorization request", http.StatusBadRequest)
+		return
+	}
+
+	provider, err := s.oidcProvider(req.Provider)
+	if err != nil {
+		JSONError(s.Log, rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	cfg := s.oauth2Config(provider, provider.config.Scopes)
+	if cfg.Endpoint.DeviceAuthURL == "" {
+		JSONError(s.Log, rw, fmt.Sprintf("provider %s does not support device code login", provider.config.Name), http.StatusBadRequest)
+		return
+	}
+
+	deviceAuth, err := cfg.DeviceAuth(oidc.ClientContext(r.Context(), s.client))
+	if err != nil {
+		s.Log.Error(err, "failed to start device authorization", "provider", provider.config.Name)
+		JSONError(s.Log, rw, "failed to start device authorization", http.StatusBadGateway)
+
+		return
+	}
+
+	writeJSON(rw, CLIDeviceAuthorization{Provider: provider.config.Name, DeviceAuth: deviceAuth}, s.Log)
+}
+
+// CLIDeviceToken returns the tokens of a device code login once the user has
+// approved it. Each request polls the provider once: while the login is
+// pending, it answers with 202 Accepted and a Retry-After header telling the
+// CLI how long to wait before polling again.
+func (s *AuthServer) CLIDeviceToken(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		rw.Header().Add("Allow", "POST")
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	if !s.oidcEnabled() {
+		JSONError(s.Log, rw, "oidc provider not configured", http.StatusBadRequest)
+		return
+	}
+
+	var req CLIDeviceAuthorization
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.DeviceAuth == nil || req.DeviceAuth.DeviceCode == "" {
+		JSONError(s.Log, rw, "invalid device token request", http.StatusBadRequest)
+		return
+	}
+
+	provider, err := s.oidcProvider(req.Provider)
+	if err != nil {
+		JSONError(s.Log, rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	details := map[string]string{"provider": provider.config.Name, "client": "cli"}
+
+	if !req.DeviceAuth.Expiry.IsZero() && !time.Now().Before(req.DeviceAuth.Expiry) {
+		JSONError(s.Log, rw, "device code login expired", http.StatusUnauthorized)
+		return
+	}
+
+	token, err := s.deviceAccessToken(r.Context(), provider, req.DeviceAuth)
+	if err != nil {
+		var retrieveErr *oauth2.RetrieveError
+		if errors.As(err, &retrieveErr) {
+			interval := req.DeviceAuth.Interval
+			if interval <= 0 {
+				interval = cliDeviceDefaultInterval
+			}
+
+			switch retrieveErr.ErrorCode {
+			case "slow_down":
+				interval += cliDeviceSlowDown
+				fallthrough
+			case "authorization_pending":
+				rw.Header().Set("Retry-After", strconv.FormatInt(interval, 10))
+				JSONError(s.Log, rw, ErrCLIDevicePending.Error(), http.StatusAccepted)
+
+				return
+			}
+		}
+
+		s.Log.V(logger.LogLevelWarn).Info("device code login failed", "err", err)
+		s.auditAuth(r, auditActionOIDCSignIn, nil, err, details)
+		JSONError(s.Log, rw, "device code login failed", http.StatusUnauthorized)
+
+		return
+	}
+
+	tokens, principal, err := s.cliTokens(r.Context(), provider, token)
+	s.auditAuth(r, auditActionOIDCSignIn, principal, err, details)
+
+	if err != nil {
+		JSONError(s.Log, rw, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	if err := s.startSession(r, principal, OIDC, tokens.IDToken); err != nil {
+		s.Log.Error(err, "failed to record session")
+		rw.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	writeJSON(rw, tokens, s.Log)
+}
+
+// deviceAccessToken makes a single device access token request to provider.
+// Unlike oauth2.Config.DeviceAccessToken, it doesn't wait for the user to
+// approve the login, so the requests of the CLI return straight away.
+func (s *AuthServer) deviceAccessToken(ctx context.Context, provider *oidcProvider, deviceAuth *oauth2.DeviceAuthResponse) (*oauth2.Token, error) {
+	ctx, cancel := context.WithTimeout(ctx, cliDeviceTokenTimeout)
+	defer cancel()
+
+	cfg := s.oauth2Config(provider, nil)
+	// The device code grant has no redirect.
+	cfg.RedirectURL = ""
+
+	// https://datatracker.ietf.org/doc/html/rfc8628#section-3.4
+	return cfg.Exchange(oidc.ClientContext(ctx, s.client), "",
+		oauth2.SetAuthURLParam("grant_type", deviceCodeGrantType),
+		oauth2.SetAuthURLParam("device_code", deviceAuth.DeviceCode),
+		oauth2.SetAuthURLParam("client_id", cfg.ClientID),
+	)
+}
+
+// CLIRefresh refreshes the tokens of the CLI with the refresh token of its
+// login.
+func (s *AuthServer) CLIRefresh(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		rw.Header().Add("Allow", "POST")
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	if !s.oidcEnabled() {
+		JSONError(s.Log, rw, "oidc provider not configured", http.StatusBadRequest)
+		return
+	}
+
+	var req CLIRefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.RefreshToken == "" {
+		JSONError(s.Log, rw, "invalid refresh request", http.StatusBadRequest)
+		return
+	}
+
+	provider, err := s.oidcProvider(req.Provider)
+	if err != nil {
+		JSONError(s.Log, rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	token, err := s.oauth2Config(provider, nil).TokenSource(
+		oidc.ClientContext(r.Context(), s.client),
+		&oauth2.Token{RefreshToken: req.RefreshToken},
+	).Token()
+	if err == nil {
+		var tokens CLITokens
+
+		if tokens, _, err = s.cliTokens(r.Context(), provider, token); err == nil {
+			err = s.refreshCLISession(r.Context(), req.IDToken, tokens.IDToken)
+		}
+
+		if err == nil {
+			writeJSON(rw, tokens, s.Log)
+			return
+		}
+	}
+
+	s.Log.V(logger.LogLevelWarn).Info("refreshing CLI token failed", "err", err)
+	s.auditAuth(r, auditActionRefresh, nil, err, map[string]string{"provider": provider.config.Name, "client": "cli"})
+	JSONError(s.Log, rw, "failed to refresh", http.StatusUnauthorized)
+}
+
+// refreshCLISession adds the refreshed ID token of the CLI to the session of
+// its old one, if sessions are recorded. Sessions that have been revoked or
+// have expired can't be refreshed, so their user has to log in again.
+func (s *AuthServer) refreshCLISession(ctx context.Context, oldIDToken, newIDToken string) error {
+	if s.Sessions == nil {
+		return nil
+	}
+
+	if oldIDToken == "" {
+		return ErrSessionNotFound
+	}
+
+	if err := s.Sessions.Refresh(ctx, oldIDToken, newIDToken); err != nil {
+		return fmt.Errorf("failed to refresh session: %w", err)
+	}
+
+	return nil
+}
+
+// cliTokens returns the tokens of token issued by provider to the CLI, and
+// the user they belong to.
+func (s *AuthServer) cliTokens(ctx context.Context, provider *oidcProvider, token *oauth2.Token) (CLITokens, *UserPrincipal, error) {
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return CLITokens{}, nil, errors.New("no id_token in token response")
+	}
+
+	idToken, err := provider.verifier().Verify(ctx, rawIDToken)
+	if err != nil {
+		return CLITokens{}, nil, fmt.Errorf("failed to verify ID token: %w", err)
+	}
+
+	principal, err := s.claimsConfig(provider.config.IssuerURL).PrincipalFromClaims(idToken)
+	if err != nil {
+		return CLITokens{}, nil, err
+	}
+
+	return CLITokens{
+		Provider:     provider.config.Name,
+		IDToken:      rawIDToken,
+		RefreshToken: token.RefreshToken,
+		Expiry:       idToken.Expiry,
+	}, principal, nil
+}
+
+func writeJSON(rw http.ResponseWriter, v any, log logr.Logger) {
+	rw.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(rw).Encode(v); err != nil {
+		log.Error(err, "Failing to write response")
+	}
+}
+
+func parseCLIPublicKey(key string) (*ecdh.PublicKey, error) {
+	b, err := base64.RawURLEncoding.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public_key: %w", err)
+	}
+
+	pub, err := ecdh.X25519().NewPublicKey(b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public_key: %w", err)
+	}
+
+	return pub, nil
+}
+
+// EncodeCLIPublicKey encodes the public key of a CLI login for the
+// public_key parameter.
+func EncodeCLIPublicKey(key *ecdh.PublicKey) string {
+	return base64.RawURLEncoding.EncodeToString(key.Bytes())
+}
+
+// sealCLITokens encrypts tokens for the holder of the private key of
+// publicKey. It derives an AES-GCM key from an X25519 exchange with an
+// ephemeral key, and returns the ephemeral public key, the nonce and the
+// ciphertext, base64url encoded.
+func sealCLITokens(publicKey string, tokens CLITokens) (string, error) {
+	pub, err := parseCLIPublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := cliTokensAEAD(ephemeral, pub)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := json.Marshal(tokens)
+	if err != nil {
+		return "", err
+	}
+
+	out := ephemeral.PublicKey().Bytes()
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	out = append(out, nonce...)
+	out = aead.Seal(out, nonce, plaintext, nil)
+
+	return base64.RawURLEncoding.EncodeToString(out), nil
+}
+
+// OpenCLITokens decrypts the tokens sealed for the CLI holding key by the
+// Callback of a browser login.
+func OpenCLITokens(key *ecdh.PrivateKey, sealed string) (*CLITokens, error) {
+	b, err := base64.RawURLEncoding.DecodeString(sealed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sealed tokens: %w", err)
+	}
+
+	keySize := len(key.PublicKey().Bytes())
+	if len(b) < keySize {
+		return nil, errors.New("invalid sealed tokens: too short")
+	}
+
+	ephemeral, err := ecdh.X25519().NewPublicKey(b[:keySize])
+	if err != nil {
+		return nil, fmt.Errorf("invalid sealed tokens: %w", err)
+	}
+
+	aead, err := cliTokensAEAD(key, ephemeral)
+	if err != nil {
+		return nil, err
+	}
+
+	b = b[keySize:]
+	if len(b) < aead.NonceSize() {
+		return nil, errors.New("invalid sealed tokens: too short")
+	}
+
+	plaintext, err := aead.Open(nil, b[:aead.NonceSize()], b[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sealed tokens: %w", err)
+	}
+
+	var tokens CLITokens
+	if err := json.Unmarshal(plaintext, &tokens); err != nil {
+		return nil, fmt.Errorf("invalid sealed tokens: %w", err)
+	}
+
+	return &tokens, nil
+}
+
+func cliTokensAEAD(priv *ecdh.PrivateKey, pub *ecdh.PublicKey) (cipher.AEAD, error) {
+	secret, err := priv.ECDH(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := hkdf.Key(sha256.New, secret, nil, cliTokensInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
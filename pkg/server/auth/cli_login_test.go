@@ -0,0 +1,322 @@
+package auth_test
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/oauth2-proxy/mockoidc"
+	. "github.com/onsi/gomega"
+	"golang.org/x/oauth2"
+
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+func TestCLILoginRejectsInvalidRequests(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	s, _ := makeAuthServer(t, nil, nil, []auth.AuthMethod{auth.OIDC}, &fakeSessionManager{})
+
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	publicKey := auth.EncodeCLIPublicKey(key.PublicKey())
+
+	tests := []struct {
+		name  string
+		query map[string]string
+	}{
+		{
+			name:  "no state",
+			query: map[string]string{"redirect_uri": "http://127.0.0.1:8000/callback", "public_key": publicKey},
+		},
+		{
+			name:  "no public key",
+			query: map[string]string{"redirect_uri": "http://127.0.0.1:8000/callback", "state": "abc"},
+		},
+		{
+			name:  "invalid public key",
+			query: map[string]string{"redirect_uri": "http://127.0.0.1:8000/callback", "state": "abc", "public_key": "bm90LWEta2V5"},
+		},
+		{
+			name:  "remote redirect URI",
+			query: map[string]string{"redirect_uri": "http://example.com:8000/callback", "state": "abc", "public_key": publicKey},
+		},
+		{
+			name:  "https redirect URI",
+			query: map[string]string{"redirect_uri": "https://localhost:8000/callback", "state": "abc", "public_key": publicKey},
+		},
+		{
+			name:  "redirect URI without port",
+			query: map[string]string{"redirect_uri": "http://localhost/callback", "state": "abc", "public_key": publicKey},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			req := httptest.NewRequest(http.MethodGet, "https://example.com/oauth2/cli/login?"+valuesFromMap(tt.query).Encode(), nil)
+			w := httptest.NewRecorder()
+			s.CLILogin(w, req)
+
+			g.Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	}
+}
+
+func TestCLILogin(t *testing.T) {
+	g := NewGomegaWithT(t)
+	sm := &fakeSessionManager{}
+
+	s, m := makeAuthServer(t, nil, nil, []auth.AuthMethod{auth.OIDC}, sm)
+
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	redirect := loginCLI(t, s, m, sm, key)
+
+	// The browser doesn't get a session of its own.
+	g.Expect(sm.PutValues).NotTo(HaveKey(auth.IDTokenCookieName))
+
+	g.Expect(redirect.Host).To(Equal("127.0.0.1:8000"))
+	g.Expect(redirect.Path).To(Equal("/callback"))
+	g.Expect(redirect.Query().Get("state")).To(Equal("cli-state"))
+
+	otherKey, err := ecdh.X25519().GenerateKey(rand.Reader)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	_, err = auth.OpenCLITokens(otherKey, redirect.Query().Get("tokens"))
+	g.Expect(err).To(HaveOccurred())
+
+	tokens, err := auth.OpenCLITokens(key, redirect.Query().Get("tokens"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tokens.RefreshToken).NotTo(BeEmpty())
+	g.Expect(tokens.Expiry).NotTo(BeZero())
+
+	_, err = m.Keypair.VerifyJWT(tokens.IDToken, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestCLILoginSessions(t *testing.T) {
+	g := NewGomegaWithT(t)
+	sm := &fakeSessionManager{}
+
+	tsv, err := auth.NewHMACTokenSignerVerifier(5 * time.Minute)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	k8s := newSessionsAdminClient()
+	s, m := makeAuthServer(t, k8s, tsv, []auth.AuthMethod{auth.OIDC}, sm)
+	s.Sessions = auth.NewSessionRegistry(logr.Discard(), k8s, testNamespace, sm, tsv, []string{m.Config().ClientID}, nil)
+
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	tokens, err := auth.OpenCLITokens(key, loginCLI(t, s, m, sm, key).Query().Get("tokens"))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	sessions, err := s.Sessions.List(t.Context())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(sessions).To(HaveLen(1), "CLI logins should be recorded")
+	g.Expect(sessions[0].User).To(Equal("jane.doe@example.com"))
+
+	g.Expect(s.Sessions.CheckSession(bearerRequest(tokens.IDToken))).To(Succeed())
+	g.Expect(s.Sessions.CheckSession(bearerRequest(getVerifyTokens(t, m)["id_token"].(string)))).To(MatchError(auth.ErrSessionNotFound),
+		"ID tokens issued to Weave GitOps without a session should be rejected")
+
+	refresh := func(refreshToken, idToken string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(auth.CLIRefreshRequest{RefreshToken: refreshToken, IDToken: idToken})
+		w := httptest.NewRecorder()
+		s.CLIRefresh(w, httptest.NewRequest(http.MethodPost, "https://example.com/oauth2/cli/refresh", bytes.NewReader(body)))
+
+		return w
+	}
+
+	g.Expect(refresh(tokens.RefreshToken, "").Code).To(Equal(http.StatusUnauthorized), "refreshing needs the ID token of the session")
+
+	w := refresh(tokens.RefreshToken, tokens.IDToken)
+	g.Expect(w.Code).To(Equal(http.StatusOK))
+
+	var refreshed auth.CLITokens
+	g.Expect(json.Unmarshal(w.Body.Bytes(), &refreshed)).To(Succeed())
+	g.Expect(s.Sessions.CheckSession(bearerRequest(refreshed.IDToken))).To(Succeed())
+
+	_, err = s.Sessions.Revoke(t.Context(), sessions[0].ID)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(s.Sessions.CheckSession(bearerRequest(refreshed.IDToken))).To(MatchError(auth.ErrSessionRevoked))
+	g.Expect(refresh(refreshed.RefreshToken, refreshed.IDToken).Code).To(Equal(http.StatusUnauthorized))
+}
+
+// loginCLI logs in to s for the CLI holding key through the OIDC provider m,
+// returning where the CLI is redirected to with the sealed tokens.
+func loginCLI(t *testing.T, s *auth.AuthServer, m *mockoidc.MockOIDC, sm *fakeSessionManager, key *ecdh.PrivateKey) *url.URL {
+	t.Helper()
+	g := NewGomegaWithT(t)
+
+	s.SetRedirectURL("https://example.com/oauth2/callback")
+
+	query := valuesFromMap(map[string]string{
+		"redirect_uri": "http://127.0.0.1:8000/callback",
+		"state":        "cli-state",
+		"public_key":   auth.EncodeCLIPublicKey(key.PublicKey()),
+	})
+
+	w := httptest.NewRecorder()
+	s.CLILogin(w, httptest.NewRequest(http.MethodGet, "https://example.com/oauth2/cli/login?"+query.Encode(), nil))
+	g.Expect(w.Code).To(Equal(http.StatusSeeOther))
+
+	state := sm.stringValue(auth.StateCookieName)
+	g.Expect(state).NotTo(BeEmpty())
+
+	// Log in with the OIDC provider, with the scopes granting the claims the
+	// server maps to users.
+	authorizeURL, err := url.Parse(w.Header().Get("Location"))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	authorizeQuery := authorizeURL.Query()
+	authorizeQuery.Set("scope", "openid email profile groups")
+	authorizeURL.RawQuery = authorizeQuery.Encode()
+
+	m.QueueCode("cli-code")
+
+	authorizeResp, err := httpClient.Get(authorizeURL.String())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(authorizeResp.StatusCode).To(Equal(http.StatusFound))
+
+	callback, err := url.Parse(authorizeResp.Header.Get("Location"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(callback.Query().Get("state")).To(Equal(state))
+
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/oauth2/callback?"+callback.RawQuery, nil).
+		WithContext(contextWithValues(t.Context(), map[string]any{auth.StateCookieName: state}))
+	w = httptest.NewRecorder()
+	s.Callback(w, req)
+	g.Expect(w.Code).To(Equal(http.StatusSeeOther))
+
+	redirect, err := url.Parse(w.Header().Get("Location"))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	return redirect
+}
+
+func TestCLIDeviceAuthUnsupportedProvider(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	s, _ := makeAuthServer(t, nil, nil, []auth.AuthMethod{auth.OIDC}, &fakeSessionManager{})
+
+	w := httptest.NewRecorder()
+	s.CLIDeviceAuth(w, httptest.NewRequest(http.MethodPost, "https://example.com/oauth2/cli/device", bytes.NewBufferString("{}")))
+
+	g.Expect(w.Code).To(Equal(http.StatusBadRequest))
+	g.Expect(w.Body.String()).To(ContainSubstring("does not support device code login"))
+}
+
+func TestCLIDeviceToken(t *testing.T) {
+	s, m := makeAuthServer(t, nil, nil, []auth.AuthMethod{auth.OIDC}, &fakeSessionManager{})
+
+	poll := func(deviceAuth *oauth2.DeviceAuthResponse) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(auth.CLIDeviceAuthorization{DeviceAuth: deviceAuth})
+		w := httptest.NewRecorder()
+		s.CLIDeviceToken(w, httptest.NewRequest(http.MethodPost, "https://example.com/oauth2/cli/device/token", bytes.NewReader(body)))
+
+		return w
+	}
+
+	tests := []struct {
+		name           string
+		providerError  string
+		deviceAuth     *oauth2.DeviceAuthResponse
+		wantCode       int
+		wantRetryAfter string
+	}{
+		{
+			name:           "pending",
+			providerError:  "authorization_pending",
+			deviceAuth:     &oauth2.DeviceAuthResponse{DeviceCode: "device-code"},
+			wantCode:       http.StatusAccepted,
+			wantRetryAfter: "5",
+		},
+		{
+			name:           "pending with an interval",
+			providerError:  "authorization_pending",
+			deviceAuth:     &oauth2.DeviceAuthResponse{DeviceCode: "device-code", Interval: 2},
+			wantCode:       http.StatusAccepted,
+			wantRetryAfter: "2",
+		},
+		{
+			name:           "slow down",
+			providerError:  "slow_down",
+			deviceAuth:     &oauth2.DeviceAuthResponse{DeviceCode: "device-code", Interval: 2},
+			wantCode:       http.StatusAccepted,
+			wantRetryAfter: "7",
+		},
+		{
+			name:          "denied",
+			providerError: "access_denied",
+			deviceAuth:    &oauth2.DeviceAuthResponse{DeviceCode: "device-code"},
+			wantCode:      http.StatusUnauthorized,
+		},
+		{
+			name:          "expired",
+			providerError: "authorization_pending",
+			deviceAuth:    &oauth2.DeviceAuthResponse{DeviceCode: "device-code", Expiry: time.Now().Add(-time.Second)},
+			wantCode:      http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			// oauth2 retries with the client credentials in the form when
+			// the provider rejects them in the header.
+			m.ErrorQueue = &mockoidc.ErrorQueue{}
+			m.QueueError(&mockoidc.ServerError{Code: http.StatusBadRequest, Error: tt.providerError})
+			m.QueueError(&mockoidc.ServerError{Code: http.StatusBadRequest, Error: tt.providerError})
+
+			// The provider is polled once, the request doesn't wait for the
+			// user to approve the login.
+			start := time.Now()
+			w := poll(tt.deviceAuth)
+			g.Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+
+			g.Expect(w.Code).To(Equal(tt.wantCode))
+			g.Expect(w.Header().Get("Retry-After")).To(Equal(tt.wantRetryAfter))
+		})
+	}
+}
+
+func TestCLIRefresh(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	s, m := makeAuthServer(t, nil, nil, []auth.AuthMethod{auth.OIDC}, &fakeSessionManager{})
+
+	refresh := func(refreshToken string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(auth.CLIRefreshRequest{RefreshToken: refreshToken})
+		w := httptest.NewRecorder()
+		s.CLIRefresh(w, httptest.NewRequest(http.MethodPost, "https://example.com/oauth2/cli/refresh", bytes.NewReader(body)))
+
+		return w
+	}
+
+	g.Expect(refresh("").Code).To(Equal(http.StatusBadRequest))
+	g.Expect(refresh("not-a-token").Code).To(Equal(http.StatusUnauthorized))
+
+	w := refresh(getVerifyTokens(t, m)["refresh_token"].(string))
+	g.Expect(w.Code).To(Equal(http.StatusOK))
+
+	var tokens auth.CLITokens
+	g.Expect(json.Unmarshal(w.Body.Bytes(), &tokens)).To(Succeed())
+	g.Expect(tokens.RefreshToken).NotTo(BeEmpty())
+
+	_, err := m.Keypair.VerifyJWT(tokens.IDToken, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/alexedwards/scs/v2"
 	"github.com/go-logr/logr"
@@ -13,6 +14,7 @@ import (
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/weaveworks/weave-gitops/core/logger"
+	"github.com/weaveworks/weave-gitops/pkg/audit"
 	"github.com/weaveworks/weave-gitops/pkg/featureflags"
 )
 
@@ -24,6 +26,18 @@ type AuthParams struct {
 	NoAuthUser        string
 	Namespace         string
 	SessionManager    *scs.SessionManager
+	Audit             *audit.Recorder
+	// TrustedProxies are trusted for the addresses users sign in from.
+	TrustedProxies audit.TrustedProxies
+	// TrackSessions records the sessions of the users signing in, so admins
+	// can list and revoke them.
+	TrackSessions bool
+	// TokenReview fills the user and groups of the passthrough tokens from
+	// their TokenReview, cached for TokenReviewTTL.
+	TokenReview    bool
+	TokenReviewTTL time.Duration
+	// APITokenCacheTTL is how long the API tokens are cached for.
+	APITokenCacheTTL time.Duration
 }
 
 // InitAuthServer creates a new AuthServer and configures it for the correct
@@ -47,6 +61,9 @@ func InitAuthServer(ctx context.Context, log logr.Logger, rawKubernetesClient ct
 	}
 
 	oidcConfig := authParams.OIDCConfig
+
+	var oidcProviders []OIDCConfig
+
 	if authMethods[OIDC] {
 		if authParams.OIDCSecretName != DefaultOIDCAuthSecretName {
 			log.V(logger.LogLevelDebug).Info("Reading OIDC configuration from alternate secret",
@@ -66,6 +83,24 @@ func InitAuthServer(ctx context.Context, log logr.Logger, rawKubernetesClient ct
 			}
 
 			oidcConfig = NewOIDCConfigFromSecret(secret)
+
+			claimExpressions, err := NewClaimExpressionsFromSecret(secret)
+			if err != nil {
+				return nil, err
+			}
+
+			if claimExpressions != nil {
+				if oidcConfig.ClaimsConfig == nil {
+					oidcConfig.ClaimsConfig = &ClaimsConfig{}
+				}
+
+				oidcConfig.ClaimsConfig.Expressions = claimExpressions
+			}
+
+			oidcProviders, err = NewOIDCProvidersFromSecret(secret)
+			if err != nil {
+				return nil, err
+			}
 		} else if err != nil {
 			log.V(logger.LogLevelDebug).Info("Could not read OIDC secret",
 				"name", authParams.OIDCSecretName,
@@ -91,6 +126,18 @@ func InitAuthServer(ctx context.Context, log logr.Logger, rawKubernetesClient ct
 		if _, err := url.Parse(oidcConfig.RedirectURL); err != nil {
 			return nil, fmt.Errorf("invalid redirect URL: %w", err)
 		}
+
+		for _, provider := range oidcProviders {
+			log.V(logger.LogLevelDebug).Info("OIDC provider config",
+				"Name", provider.Name,
+				"IssuerURL", provider.IssuerURL,
+				"ClientID", provider.ClientID,
+			)
+
+			if _, err := url.Parse(provider.IssuerURL); err != nil {
+				return nil, fmt.Errorf("invalid issuer URL for OIDC provider %s: %w", provider.Name, err)
+			}
+		}
 	} else {
 		// Make sure there is no OIDC config if it's not an enabled authorization method
 		// the TokenDuration needs to be set so cookies can use it
@@ -107,6 +154,21 @@ func InitAuthServer(ctx context.Context, log logr.Logger, rawKubernetesClient ct
 		tsv.SetDevMode(true)
 	}
 
+	var sessions *SessionRegistry
+	if authParams.TrackSessions {
+		var clientIDs []string
+
+		if authMethods[OIDC] {
+			for _, cfg := range append([]OIDCConfig{oidcConfig}, oidcProviders...) {
+				if cfg.ClientID != "" {
+					clientIDs = append(clientIDs, cfg.ClientID)
+				}
+			}
+		}
+
+		sessions = NewSessionRegistry(log.WithName("sessions"), rawKubernetesClient, authParams.Namespace, authParams.SessionManager, tsv, clientIDs, authParams.TrustedProxies)
+	}
+
 	authServer, err := NewAuthServer(ctx, &AuthServerConfig{
 		Log:                 log.WithName("auth-server"),
 		client:              http.DefaultClient,
@@ -114,9 +176,16 @@ func InitAuthServer(ctx context.Context, log logr.Logger, rawKubernetesClient ct
 		tokenSignerVerifier: tsv,
 		authMethods:         authMethods,
 		OIDCConfig:          oidcConfig,
+		OIDCProviders:       oidcProviders,
 		namespace:           authParams.Namespace,
 		noAuthUser:          authParams.NoAuthUser,
 		SessionManager:      authParams.SessionManager,
+		Audit:               authParams.Audit,
+		TrustedProxies:      authParams.TrustedProxies,
+		Sessions:            sessions,
+		TokenReview:         authParams.TokenReview,
+		TokenReviewTTL:      authParams.TokenReviewTTL,
+		APITokenCacheTTL:    authParams.APITokenCacheTTL,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("could not create auth server: %w", err)
@@ -110,6 +110,10 @@ func parseJWTToken(ctx context.Context, verifier tokenVerifier, rawIDToken strin
 	}
 	log.V(logger.LogLevelDebug).Info("parsed JWT token", "expires", token.Expiry)
 
+	if v, ok := verifier.(issuerClaimsConfig); ok {
+		cc = v.claimsConfig(token.Issuer)
+	}
+
 	return cc.PrincipalFromClaims(token)
 }
 
@@ -148,7 +152,12 @@ func parseJWTAdminToken(verifier TokenSignerVerifier, rawIDToken string) (*UserP
 		return nil, nil
 	}
 
-	return &UserPrincipal{ID: claims.Subject, Groups: []string{}}, nil
+	groups := claims.Groups
+	if groups == nil {
+		groups = []string{}
+	}
+
+	return &UserPrincipal{ID: claims.Subject, Groups: groups}, nil
 }
 
 // MultiAuthPrincipal looks for a principal in an array of principal getters and
@@ -156,9 +165,20 @@ func parseJWTAdminToken(verifier TokenSignerVerifier, rawIDToken string) (*UserP
 type MultiAuthPrincipal struct {
 	Log     logr.Logger
 	Getters []PrincipalGetter
+	// Sessions rejects the requests of revoked sessions before the getters
+	// are tried, if set.
+	Sessions SessionChecker
 }
 
 func (m MultiAuthPrincipal) Principal(r *http.Request) (*UserPrincipal, error) {
+	if m.Sessions != nil {
+		if err := m.Sessions.CheckSession(r); err != nil {
+			m.Log.V(logger.LogLevelDebug).Info("Rejected session", "error", err)
+
+			return nil, err
+		}
+	}
+
 	for _, v := range m.Getters {
 		p, err := v.Principal(r)
 		if err != nil {
@@ -0,0 +1,163 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// ClusterUserAuthUsersKey is the key in the cluster-user-auth Secret holding
+// the list of local users. The single user in the `username` and `password`
+// keys is still supported, without any groups.
+const ClusterUserAuthUsersKey = "users"
+
+var ErrLocalUserNotFound = errors.New("local user not found")
+
+// LocalUser is a user that logs in with a password, impersonating Username and
+// Groups.
+type LocalUser struct {
+	Username string `json:"username"`
+	// PasswordHash is the bcrypt hash of the password.
+	PasswordHash string   `json:"passwordHash"`
+	Groups       []string `json:"groups,omitempty"`
+}
+
+// LocalUsersFromSecret returns the users in the cluster-user-auth Secret.
+func LocalUsersFromSecret(secret *corev1.Secret) ([]LocalUser, error) {
+	users := []LocalUser{}
+
+	if data := secret.Data[ClusterUserAuthUsersKey]; len(data) > 0 {
+		if err := yaml.Unmarshal(data, &users); err != nil {
+			return nil, fmt.Errorf("reading %q from secret %s: %w", ClusterUserAuthUsersKey, secret.Name, err)
+		}
+	}
+
+	if password := secret.Data["password"]; len(password) > 0 {
+		users = append(users, LocalUser{
+			Username:     string(secret.Data["username"]),
+			PasswordHash: string(password),
+		})
+	}
+
+	return users, nil
+}
+
+// authenticateLocalUser returns the user matching username and password.
+func authenticateLocalUser(users []LocalUser, username, password string) (*LocalUser, error) {
+	for _, user := range users {
+		if user.Username != username {
+			continue
+		}
+
+		if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+			return nil, err
+		}
+
+		return &user, nil
+	}
+
+	return nil, ErrLocalUserNotFound
+}
+
+// SetLocalUser adds user to the cluster-user-auth Secret, replacing any user
+// with the same username.
+func SetLocalUser(ctx context.Context, kubernetesClient ctrlclient.Client, namespace string, user LocalUser) error {
+	if user.Username == "" {
+		return errors.New("a username is required")
+	}
+
+	if _, err := bcrypt.Cost([]byte(user.PasswordHash)); err != nil {
+		return fmt.Errorf("invalid password hash for user %s: %w", user.Username, err)
+	}
+
+	return updateLocalUsers(ctx, kubernetesClient, namespace, true, func(users []LocalUser) ([]LocalUser, error) {
+		users = slices.DeleteFunc(users, func(u LocalUser) bool {
+			return u.Username == user.Username
+		})
+
+		return append(users, user), nil
+	})
+}
+
+// RemoveLocalUser removes the user with username from the cluster-user-auth
+// Secret.
+func RemoveLocalUser(ctx context.Context, kubernetesClient ctrlclient.Client, namespace, username string) error {
+	return updateLocalUsers(ctx, kubernetesClient, namespace, false, func(users []LocalUser) ([]LocalUser, error) {
+		remaining := slices.DeleteFunc(slices.Clone(users), func(u LocalUser) bool {
+			return u.Username == username
+		})
+
+		if len(remaining) == len(users) {
+			return nil, fmt.Errorf("%w: %s", ErrLocalUserNotFound, username)
+		}
+
+		return remaining, nil
+	})
+}
+
+// updateLocalUsers applies update to the users in the cluster-user-auth
+// Secret, creating it if create is set. The users are all written to the
+// users key, replacing the single user keys.
+func updateLocalUsers(ctx context.Context, kubernetesClient ctrlclient.Client, namespace string, create bool, update func([]LocalUser) ([]LocalUser, error)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret := &corev1.Secret{}
+
+		err := kubernetesClient.Get(ctx, ctrlclient.ObjectKey{Name: ClusterUserAuthSecretName, Namespace: namespace}, secret)
+		notFound := apierrors.IsNotFound(err)
+
+		if notFound && create {
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: ClusterUserAuthSecretName, Namespace: namespace},
+			}
+		} else if err != nil {
+			if notFound {
+				return fmt.Errorf("secret %s not found in namespace %s", ClusterUserAuthSecretName, namespace)
+			}
+
+			return err
+		}
+
+		users, err := LocalUsersFromSecret(secret)
+		if err != nil {
+			return err
+		}
+
+		users, err = update(users)
+		if err != nil {
+			return err
+		}
+
+		slices.SortFunc(users, func(a, b LocalUser) int {
+			return strings.Compare(a.Username, b.Username)
+		})
+
+		data, err := yaml.Marshal(users)
+		if err != nil {
+			return err
+		}
+
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+
+		delete(secret.Data, "username")
+		delete(secret.Data, "password")
+		secret.Data[ClusterUserAuthUsersKey] = data
+
+		if notFound {
+			return kubernetesClient.Create(ctx, secret)
+		}
+
+		return kubernetesClient.Update(ctx, secret)
+	})
+}
@@ -0,0 +1,107 @@
+package auth_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/bcrypt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+func TestLocalUsers(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := t.Context()
+
+	legacyHash, err := bcrypt.GenerateFromPassword([]byte("legacy-password"), bcrypt.MinCost)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	k8s := fake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: auth.ClusterUserAuthSecretName, Namespace: testNamespace},
+		Data: map[string][]byte{
+			"username": []byte("admin"),
+			"password": legacyHash,
+		},
+	}).Build()
+
+	aliceHash, err := bcrypt.GenerateFromPassword([]byte("alice-password"), bcrypt.MinCost)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(auth.SetLocalUser(ctx, k8s, testNamespace, auth.LocalUser{
+		Username:     "alice",
+		PasswordHash: string(aliceHash),
+		Groups:       []string{"developers"},
+	})).To(Succeed())
+
+	g.Expect(auth.SetLocalUser(ctx, k8s, testNamespace, auth.LocalUser{Username: "bob", PasswordHash: "not-a-hash"})).
+		To(MatchError(ContainSubstring("invalid password hash")))
+
+	secret := &corev1.Secret{}
+	g.Expect(k8s.Get(ctx, ctrlclient.ObjectKey{Name: auth.ClusterUserAuthSecretName, Namespace: testNamespace}, secret)).To(Succeed())
+	g.Expect(secret.Data).To(HaveKey(auth.ClusterUserAuthUsersKey))
+	g.Expect(secret.Data).NotTo(HaveKey("username"), "the legacy user should be moved to the users list")
+
+	users, err := auth.LocalUsersFromSecret(secret)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(users).To(Equal([]auth.LocalUser{
+		{Username: "admin", PasswordHash: string(legacyHash)},
+		{Username: "alice", PasswordHash: string(aliceHash), Groups: []string{"developers"}},
+	}))
+
+	g.Expect(auth.RemoveLocalUser(ctx, k8s, testNamespace, "admin")).To(Succeed())
+	g.Expect(auth.RemoveLocalUser(ctx, k8s, testNamespace, "admin")).To(MatchError(auth.ErrLocalUserNotFound))
+
+	g.Expect(k8s.Get(ctx, ctrlclient.ObjectKey{Name: auth.ClusterUserAuthSecretName, Namespace: testNamespace}, secret)).To(Succeed())
+
+	users, err = auth.LocalUsersFromSecret(secret)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(users).To(HaveLen(1))
+	g.Expect(users[0].Username).To(Equal("alice"))
+}
+
+func TestSignInLocalUserWithGroups(t *testing.T) {
+	g := NewGomegaWithT(t)
+	sm := &fakeSessionManager{}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("alice-password"), bcrypt.MinCost)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	k8s := fake.NewClientBuilder().Build()
+	g.Expect(auth.SetLocalUser(t.Context(), k8s, testNamespace, auth.LocalUser{
+		Username:     "alice",
+		PasswordHash: string(hash),
+		Groups:       []string{"developers", "admins"},
+	})).To(Succeed())
+
+	tokenSignerVerifier, err := auth.NewHMACTokenSignerVerifier(5 * time.Minute)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s, _ := makeAuthServer(t, k8s, tokenSignerVerifier, []auth.AuthMethod{auth.UserAccount}, sm)
+
+	signIn := func(username, password string) *http.Response {
+		j, _ := json.Marshal(auth.LoginRequest{Username: username, Password: password})
+
+		w := httptest.NewRecorder()
+		s.SignIn().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "https://example.com/signin", bytes.NewReader(j)))
+
+		return w.Result()
+	}
+
+	g.Expect(signIn("alice", "wrong")).To(HaveHTTPStatus(http.StatusUnauthorized))
+	g.Expect(signIn("mallory", "alice-password")).To(HaveHTTPStatus(http.StatusUnauthorized))
+	g.Expect(signIn("alice", "alice-password")).To(HaveHTTPStatus(http.StatusOK))
+
+	claims, err := tokenSignerVerifier.Verify(sm.stringValue(auth.IDTokenCookieName))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(claims.Subject).To(Equal("alice"))
+	g.Expect(claims.Groups).To(Equal([]string{"developers", "admins"}))
+}
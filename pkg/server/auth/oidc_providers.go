@@ -0,0 +1,208 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/golang-jwt/jwt/v5"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DefaultOIDCProviderName is the name of the provider configured by the
+	// OIDC flags and the top-level keys of the OIDC secret.
+	DefaultOIDCProviderName = "default"
+
+	// OIDCProvidersSecretKey is the key in the OIDC secret holding the list
+	// of additional named providers.
+	OIDCProvidersSecretKey = "providers"
+)
+
+var (
+	ErrUnknownOIDCProvider = errors.New("unknown OIDC provider")
+
+	oidcProviderNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+)
+
+// oidcProviderSecretEntry is an entry in the providers list of the OIDC
+// secret, using the same names as the top-level keys.
+type oidcProviderSecretEntry struct {
+	Name           string   `json:"name"`
+	DisplayName    string   `json:"displayName,omitempty"`
+	IssuerURL      string   `json:"issuerURL"`
+	ClientID       string   `json:"clientID"`
+	ClientSecret   string   `json:"clientSecret"`
+	RedirectURL    string   `json:"redirectURL,omitempty"`
+	CustomScopes   []string `json:"customScopes,omitempty"`
+	ClaimUsername  string   `json:"claimUsername,omitempty"`
+	ClaimGroups    string   `json:"claimGroups,omitempty"`
+	UsernamePrefix string   `json:"oidcUsernamePrefix,omitempty"`
+	GroupsPrefix   string   `json:"oidcGroupsPrefix,omitempty"`
+
+	ClaimExpressions *ClaimExpressions `json:"claimExpressions,omitempty"`
+}
+
+// NewOIDCProvidersFromSecret reads the additional named providers from the
+// providers key of the OIDC secret, e.g.
+//
+//	providers: |
+//	  - name: azure
+//	    displayName: Azure AD
+//	    issuerURL: https://login.microsoftonline.com/<tenant>/v2.0
+//	    clientID: <client-id>
+//	    clientSecret: <client-secret>
+//	    claimUsername: preferred_username
+//	    oidcUsernamePrefix: "azure:"
+//	    oidcGroupsPrefix: "azure:"
+//	    claimExpressions:
+//	      groups: claims.roles.filter(r, r.startsWith("k8s-"))
+//
+// The redirectURL defaults to the one of the default provider, as all
+// providers share the same callback.
+func NewOIDCProvidersFromSecret(secret corev1.Secret) ([]OIDCConfig, error) {
+	data := secret.Data[OIDCProvidersSecretKey]
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var entries []oidcProviderSecretEntry
+	if err := yaml.UnmarshalStrict(data, &entries); err != nil {
+		return nil, fmt.Errorf("reading %q from secret %s: %w", OIDCProvidersSecretKey, secret.Name, err)
+	}
+
+	configs := []OIDCConfig{}
+
+	for _, entry := range entries {
+		if entry.ClaimExpressions != nil {
+			if err := entry.ClaimExpressions.Compile(); err != nil {
+				return nil, fmt.Errorf("OIDC provider %q: %w", entry.Name, err)
+			}
+		}
+
+		cfg := OIDCConfig{
+			Name:         entry.Name,
+			DisplayName:  entry.DisplayName,
+			IssuerURL:    entry.IssuerURL,
+			ClientID:     entry.ClientID,
+			ClientSecret: entry.ClientSecret,
+			RedirectURL:  entry.RedirectURL,
+			Scopes:       entry.CustomScopes,
+			ClaimsConfig: &ClaimsConfig{
+				Username:       entry.ClaimUsername,
+				Groups:         entry.ClaimGroups,
+				UsernamePrefix: entry.UsernamePrefix,
+				GroupsPrefix:   entry.GroupsPrefix,
+				Expressions:    entry.ClaimExpressions,
+			},
+		}
+
+		if len(cfg.Scopes) == 0 {
+			cfg.Scopes = DefaultScopes
+		}
+
+		configs = append(configs, cfg)
+	}
+
+	return configs, nil
+}
+
+// oidcProvider is an OIDC issuer users can log in with.
+type oidcProvider struct {
+	config   OIDCConfig
+	provider *oidc.Provider
+}
+
+func (p *oidcProvider) verifier() *oidc.IDTokenVerifier {
+	return p.provider.Verifier(&oidc.Config{ClientID: p.config.ClientID})
+}
+
+// oidcProviderVerifier verifies tokens issued by any of the providers,
+// picking the provider from the `iss` claim.
+type oidcProviderVerifier struct {
+	providers []*oidcProvider
+}
+
+func (v oidcProviderVerifier) providerFor(rawIDToken string) (*oidcProvider, error) {
+	claims := jwt.RegisteredClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(rawIDToken, &claims); err != nil {
+		return nil, fmt.Errorf("malformed jwt: %w", err)
+	}
+
+	for _, p := range v.providers {
+		if p.config.IssuerURL == claims.Issuer {
+			return p, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no OIDC provider configured for issuer %q", claims.Issuer)
+}
+
+func (v oidcProviderVerifier) Verify(ctx context.Context, rawIDToken string) (*oidc.IDToken, error) {
+	p, err := v.providerFor(rawIDToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.verifier().Verify(ctx, rawIDToken)
+}
+
+// claimsConfig returns the claims config of the provider for issuer.
+func (v oidcProviderVerifier) claimsConfig(issuer string) *ClaimsConfig {
+	for _, p := range v.providers {
+		if p.config.IssuerURL == issuer {
+			return p.config.ClaimsConfig
+		}
+	}
+
+	return nil
+}
+
+// issuerClaimsConfig is implemented by verifiers for several issuers, which
+// each have their own claims config.
+type issuerClaimsConfig interface {
+	claimsConfig(issuer string) *ClaimsConfig
+}
+
+// oidcProviderConfigs returns the configs of the providers, the default one
+// first, checking the names are valid and unique.
+func oidcProviderConfigs(defaultCfg OIDCConfig, additional []OIDCConfig) ([]OIDCConfig, error) {
+	configs := []OIDCConfig{}
+
+	if defaultCfg.IssuerURL != "" {
+		if defaultCfg.Name == "" {
+			defaultCfg.Name = DefaultOIDCProviderName
+		}
+
+		configs = append(configs, defaultCfg)
+	}
+
+	names := map[string]bool{}
+	issuers := map[string]bool{}
+
+	for _, cfg := range append(configs, additional...) {
+		if !oidcProviderNameRegexp.MatchString(cfg.Name) {
+			return nil, fmt.Errorf("invalid OIDC provider name %q", cfg.Name)
+		}
+
+		if names[cfg.Name] {
+			return nil, fmt.Errorf("duplicate OIDC provider name %q", cfg.Name)
+		}
+
+		if cfg.IssuerURL == "" {
+			return nil, fmt.Errorf("OIDC provider %q has no issuer URL", cfg.Name)
+		}
+
+		if issuers[cfg.IssuerURL] {
+			return nil, fmt.Errorf("duplicate OIDC issuer %q, tokens can't be matched to a provider", cfg.IssuerURL)
+		}
+
+		names[cfg.Name] = true
+		issuers[cfg.IssuerURL] = true
+	}
+
+	return append(configs, additional...), nil
+}
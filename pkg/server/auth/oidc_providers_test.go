@@ -0,0 +1,231 @@
+package auth_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/oauth2-proxy/mockoidc"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/weaveworks/weave-gitops/pkg/featureflags"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+func TestNewOIDCProvidersFromSecret(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	providers, err := auth.NewOIDCProvidersFromSecret(corev1.Secret{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(providers).To(BeEmpty())
+
+	providers, err = auth.NewOIDCProvidersFromSecret(corev1.Secret{
+		Data: map[string][]byte{
+			"providers": []byte(`
+- name: azure
+  displayName: Azure AD
+  issuerURL: https://login.example.com/v2.0
+  clientID: client-id
+  clientSecret: client-secret
+  claimUsername: preferred_username
+  oidcUsernamePrefix: "azure:"
+  oidcGroupsPrefix: "azure-"
+`),
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(providers).To(Equal([]auth.OIDCConfig{
+		{
+			Name:         "azure",
+			DisplayName:  "Azure AD",
+			IssuerURL:    "https://login.example.com/v2.0",
+			ClientID:     "client-id",
+			ClientSecret: "client-secret",
+			Scopes:       auth.DefaultScopes,
+			ClaimsConfig: &auth.ClaimsConfig{
+				Username:       "preferred_username",
+				UsernamePrefix: "azure:",
+				GroupsPrefix:   "azure-",
+			},
+		},
+	}))
+
+	_, err = auth.NewOIDCProvidersFromSecret(corev1.Secret{
+		Data: map[string][]byte{"providers": []byte(`- name: azure
+  issuer: https://login.example.com/v2.0`)},
+	})
+	g.Expect(err).To(MatchError(ContainSubstring("unknown field")))
+
+	providers, err = auth.NewOIDCProvidersFromSecret(corev1.Secret{
+		Data: map[string][]byte{"providers": []byte(`- name: azure
+  issuerURL: https://login.example.com/v2.0
+  claimExpressions:
+    groups: claims.roles.filter(r, r.startsWith("k8s-"))`)},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(providers[0].ClaimsConfig.Expressions.Groups).To(Equal(`claims.roles.filter(r, r.startsWith("k8s-"))`))
+
+	_, err = auth.NewOIDCProvidersFromSecret(corev1.Secret{
+		Data: map[string][]byte{"providers": []byte(`- name: azure
+  issuerURL: https://login.example.com/v2.0
+  claimExpressions:
+    groups: claims.roles.filter(`)},
+	})
+	g.Expect(err).To(MatchError(ContainSubstring(`OIDC provider "azure": groups expression`)))
+}
+
+func TestMultipleOIDCProviders(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	featureflags.SetBoolean(auth.FeatureFlagOIDCAuth, false)
+
+	dex := runMockOIDC(t)
+	azure := runMockOIDC(t)
+
+	authCfg, err := auth.NewAuthServerConfig(logr.Discard(), auth.OIDCConfig{
+		ClientID:     dex.Config().ClientID,
+		ClientSecret: dex.Config().ClientSecret,
+		IssuerURL:    dex.Issuer(),
+		RedirectURL:  "https://example.com/oauth2/callback",
+	}, fake.NewClientBuilder().Build(), nil, testNamespace, map[auth.AuthMethod]bool{auth.OIDC: true}, "", &fakeSessionManager{})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	authCfg.OIDCProviders = []auth.OIDCConfig{
+		{
+			Name:         "azure",
+			DisplayName:  "Azure AD",
+			ClientID:     "azure-client",
+			ClientSecret: "azure-secret",
+			IssuerURL:    azure.Issuer(),
+			Scopes:       []string{"profile"},
+			ClaimsConfig: &auth.ClaimsConfig{Username: "preferred_username", UsernamePrefix: "azure:", GroupsPrefix: "azure:"},
+		},
+	}
+
+	srv, err := auth.NewAuthServer(t.Context(), authCfg)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	t.Run("providers are listed", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		w := httptest.NewRecorder()
+		srv.Providers(w, httptest.NewRequest(http.MethodGet, "https://example.com/oauth2/providers", nil))
+
+		var providers []auth.OIDCProviderInfo
+		g.Expect(json.NewDecoder(w.Body).Decode(&providers)).To(Succeed())
+		g.Expect(providers).To(Equal([]auth.OIDCProviderInfo{
+			{Name: auth.DefaultOIDCProviderName, DisplayName: auth.DefaultOIDCProviderName},
+			{Name: "azure", DisplayName: "Azure AD"},
+		}))
+	})
+
+	t.Run("the auth flow goes to the chosen provider", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		w := httptest.NewRecorder()
+		srv.OAuth2Flow().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "https://example.com/oauth2?provider=azure", nil))
+		g.Expect(w).To(HaveHTTPStatus(http.StatusSeeOther))
+
+		location, err := url.Parse(w.Header().Get("Location"))
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(location.String()).To(HavePrefix(azure.AuthorizationEndpoint()))
+		g.Expect(location.Query().Get("client_id")).To(Equal("azure-client"))
+		g.Expect(location.Query().Get("redirect_uri")).To(Equal("https://example.com/oauth2/callback"))
+		g.Expect(location.Query().Get("scope")).To(Equal("openid profile"))
+
+		w = httptest.NewRecorder()
+		srv.OAuth2Flow().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "https://example.com/oauth2", nil))
+		g.Expect(w.Header().Get("Location")).To(HavePrefix(dex.AuthorizationEndpoint()))
+
+		w = httptest.NewRecorder()
+		srv.OAuth2Flow().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "https://example.com/oauth2?provider=okta", nil))
+		g.Expect(w).To(HaveHTTPStatus(http.StatusBadRequest))
+	})
+
+	t.Run("tokens are verified by their issuer", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		var principal *auth.UserPrincipal
+
+		handler := auth.WithAPIAuth(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			principal = auth.Principal(r.Context())
+		}), srv, nil, &fakeSessionManager{})
+
+		request := func(token string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodGet, "https://example.com/v1/objects", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			return w
+		}
+
+		g.Expect(request(signMockToken(t, dex, dex.Config().ClientID, jwt.MapClaims{"email": "jane@example.com", "groups": []string{"dev"}}))).
+			To(HaveHTTPStatus(http.StatusOK))
+		g.Expect(principal.ID).To(Equal("jane@example.com"))
+		g.Expect(principal.Groups).To(Equal([]string{"dev"}))
+
+		g.Expect(request(signMockToken(t, azure, "azure-client", jwt.MapClaims{"preferred_username": "joe", "groups": []string{"contractors"}}))).
+			To(HaveHTTPStatus(http.StatusOK))
+		g.Expect(principal.ID).To(Equal("azure:joe"))
+		g.Expect(principal.Groups).To(Equal([]string{"azure:contractors"}))
+
+		g.Expect(request(signMockToken(t, azure, dex.Config().ClientID, jwt.MapClaims{"preferred_username": "joe"}))).
+			To(HaveHTTPStatus(http.StatusUnauthorized), "tokens for another provider's client should be rejected")
+	})
+}
+
+func TestOIDCProvidersMustBeUnique(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	authCfg, err := auth.NewAuthServerConfig(logr.Discard(), auth.OIDCConfig{IssuerURL: "https://dex.example.com"}, fake.NewClientBuilder().Build(), nil, testNamespace, map[auth.AuthMethod]bool{auth.OIDC: true}, "", &fakeSessionManager{})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	authCfg.OIDCProviders = []auth.OIDCConfig{{Name: "default", IssuerURL: "https://azure.example.com"}}
+
+	_, err = auth.NewAuthServer(t.Context(), authCfg)
+	g.Expect(err).To(MatchError(ContainSubstring("duplicate OIDC provider name")))
+
+	authCfg.OIDCProviders = []auth.OIDCConfig{{Name: "azure", IssuerURL: "https://dex.example.com"}}
+
+	_, err = auth.NewAuthServer(t.Context(), authCfg)
+	g.Expect(err).To(MatchError(ContainSubstring("duplicate OIDC issuer")))
+}
+
+func runMockOIDC(t *testing.T) *mockoidc.MockOIDC {
+	t.Helper()
+
+	m, err := mockoidc.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_ = m.Shutdown()
+	})
+
+	return m
+}
+
+func signMockToken(t *testing.T, m *mockoidc.MockOIDC, audience string, claims jwt.MapClaims) string {
+	t.Helper()
+
+	claims["iss"] = m.Issuer()
+	claims["aud"] = audience
+	claims["exp"] = time.Now().Add(time.Hour).Unix()
+
+	token, err := m.Keypair.SignJWT(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return token
+}
@@ -1,8 +1,12 @@
 package auth
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/go-logr/logr"
@@ -35,7 +39,8 @@ func NewBearerTokenPassthroughPrincipalGetter(log logr.Logger, verifier *oidc.ID
 // Principal is an implementation of the PrincipalGetter interface.
 //
 // Headers of the form Authorization: Bearer <token> are stored within a UserPrincipal.
-// The token is not verified, and no ID or Group information will be available.
+// The token is checked with a TokenReview, but no ID or Group information will
+// be available, see TokenReviewPrincipalGetter for that.
 func (pg *BearerTokenPassthroughPrincipalGetter) Principal(r *http.Request) (*UserPrincipal, error) {
 	token := r.Header.Get(pg.headerName)
 	if token == "" {
@@ -62,9 +67,127 @@ func (pg *BearerTokenPassthroughPrincipalGetter) Principal(r *http.Request) (*Us
 	return NewUserPrincipal(Token(token)), nil
 }
 
+// DefaultTokenReviewTTL is how long the TokenReviews of passthrough tokens
+// are cached for by default.
+const DefaultTokenReviewTTL = time.Minute
+
+// maxTokenReviews bounds the number of cached TokenReviews.
+const maxTokenReviews = 1000
+
+// TokenReviewPrincipalGetter inspects the Authorization header (bearer
+// token) like BearerTokenPassthroughPrincipalGetter, and fills the ID and
+// groups of the principal from the TokenReview of the token, so they show up
+// in the audit log and the UI. The reviews are cached for a short time, so
+// a token that's no longer valid keeps working for up to that time.
+type TokenReviewPrincipalGetter struct {
+	log              logr.Logger
+	headerName       string
+	kubernetesClient client.Client
+	ttl              time.Duration
+
+	mu      sync.Mutex
+	reviews map[string]tokenReview
+}
+
+// tokenReview is the cached outcome of a TokenReview.
+type tokenReview struct {
+	authenticated bool
+	err           string
+	user          string
+	groups        []string
+	expiresAt     time.Time
+}
+
+// NewTokenReviewPrincipalGetter creates a PrincipalGetter reviewing the
+// bearer tokens in headerName with the Kubernetes API, caching the reviews
+// for ttl.
+func NewTokenReviewPrincipalGetter(log logr.Logger, kubernetesClient client.Client, headerName string, ttl time.Duration) PrincipalGetter {
+	return &TokenReviewPrincipalGetter{
+		log:              log,
+		headerName:       headerName,
+		kubernetesClient: kubernetesClient,
+		ttl:              ttl,
+		reviews:          map[string]tokenReview{},
+	}
+}
+
+// Principal is an implementation of the PrincipalGetter interface.
+func (pg *TokenReviewPrincipalGetter) Principal(r *http.Request) (*UserPrincipal, error) {
+	token := extractToken(r.Header.Get(pg.headerName))
+	if token == "" {
+		return nil, nil
+	}
+
+	review, err := pg.review(r, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if !review.authenticated {
+		return nil, fmt.Errorf("user token authentication failed: %s", review.err)
+	}
+
+	pg.log.V(logger.LogLevelDebug).Info("reviewed passthrough token", "user", review.user, "groups", review.groups)
+
+	return NewUserPrincipal(ID(review.user), Groups(review.groups), Token(token)), nil
+}
+
+// review returns the cached review of token, or reviews it. The cache is
+// keyed by a hash of the token rather than the token.
+func (pg *TokenReviewPrincipalGetter) review(r *http.Request, token string) (tokenReview, error) {
+	sum := sha256.Sum256([]byte(token))
+	key := hex.EncodeToString(sum[:])
+	now := time.Now()
+
+	pg.mu.Lock()
+	review, ok := pg.reviews[key]
+	pg.mu.Unlock()
+
+	if ok && now.Before(review.expiresAt) {
+		return review, nil
+	}
+
+	tr := authv1.TokenReview{
+		Spec: authv1.TokenReviewSpec{
+			Token: token,
+		},
+	}
+
+	if err := pg.kubernetesClient.Create(r.Context(), &tr); err != nil {
+		return tokenReview{}, err
+	}
+
+	review = tokenReview{
+		authenticated: tr.Status.Authenticated,
+		err:           tr.Status.Error,
+		user:          tr.Status.User.Username,
+		groups:        tr.Status.User.Groups,
+		expiresAt:     now.Add(pg.ttl),
+	}
+
+	pg.mu.Lock()
+	defer pg.mu.Unlock()
+
+	if len(pg.reviews) >= maxTokenReviews {
+		for k, v := range pg.reviews {
+			if !now.Before(v.expiresAt) {
+				delete(pg.reviews, k)
+			}
+		}
+
+		if len(pg.reviews) >= maxTokenReviews {
+			pg.reviews = map[string]tokenReview{}
+		}
+	}
+
+	pg.reviews[key] = review
+
+	return review, nil
+}
+
 // NewJWTPassthroughCookiePrincipalGetter creates and returns a new
 // JWTPassthroughCookiePrincipalGetter.
-func NewJWTPassthroughCookiePrincipalGetter(log logr.Logger, verifier *oidc.IDTokenVerifier, cookieName string, sm SessionManager) PrincipalGetter {
+func NewJWTPassthroughCookiePrincipalGetter(log logr.Logger, verifier tokenVerifier, cookieName string, sm SessionManager) PrincipalGetter {
 	return &JWTPassthroughCookiePrincipalGetter{
 		log:        log,
 		verifier:   verifier,
@@ -79,7 +202,7 @@ func NewJWTPassthroughCookiePrincipalGetter(log logr.Logger, verifier *oidc.IDTo
 // The JWT Token is parsed, and the token and user/groups are available.
 type JWTPassthroughCookiePrincipalGetter struct {
 	log        logr.Logger
-	verifier   *oidc.IDTokenVerifier
+	verifier   tokenVerifier
 	cookieName string
 	sm         SessionManager
 }
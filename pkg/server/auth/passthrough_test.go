@@ -1,6 +1,8 @@
 package auth_test
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"testing"
 	"time"
@@ -9,6 +11,11 @@ import (
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
+	. "github.com/onsi/gomega"
+	authv1 "k8s.io/api/authentication/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	"github.com/weaveworks/weave-gitops/pkg/server/auth"
 	"github.com/weaveworks/weave-gitops/pkg/testutils"
@@ -51,3 +58,72 @@ func TestPassthroughPrincipalGetter(t *testing.T) {
 		})
 	}
 }
+
+func TestTokenReviewPrincipalGetter(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	reviews := 0
+	k8s := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, client ctrlclient.WithWatch, obj ctrlclient.Object, opts ...ctrlclient.CreateOption) error {
+			review, ok := obj.(*authv1.TokenReview)
+			if !ok {
+				return client.Create(ctx, obj, opts...)
+			}
+
+			reviews++
+
+			switch review.Spec.Token {
+			case "valid":
+				review.Status = authv1.TokenReviewStatus{
+					Authenticated: true,
+					User: authv1.UserInfo{
+						Username: "system:serviceaccount:ci:deployer",
+						Groups:   []string{"system:serviceaccounts", "system:authenticated"},
+					},
+				}
+			case "error":
+				return errors.New("connection refused")
+			default:
+				review.Status = authv1.TokenReviewStatus{Error: "invalid bearer token"}
+			}
+
+			return nil
+		},
+	}).Build()
+
+	getter := auth.NewTokenReviewPrincipalGetter(logr.Discard(), k8s, "Authorization", 50*time.Millisecond)
+
+	principal, err := getter.Principal(makeAuthenticatedRequest("Bearer valid"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(principal.ID).To(Equal("system:serviceaccount:ci:deployer"))
+	g.Expect(principal.Groups).To(Equal([]string{"system:serviceaccounts", "system:authenticated"}))
+	g.Expect(principal.Token()).To(Equal("valid"))
+
+	// The review is cached.
+	_, err = getter.Principal(makeAuthenticatedRequest("Bearer valid"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(reviews).To(Equal(1))
+
+	_, err = getter.Principal(makeAuthenticatedRequest("Bearer invalid"))
+	g.Expect(err).To(MatchError(ContainSubstring("invalid bearer token")))
+	_, err = getter.Principal(makeAuthenticatedRequest("Bearer invalid"))
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(reviews).To(Equal(2))
+
+	// Failing to review isn't cached.
+	_, err = getter.Principal(makeAuthenticatedRequest("Bearer error"))
+	g.Expect(err).To(MatchError("connection refused"))
+	_, err = getter.Principal(makeAuthenticatedRequest("Bearer error"))
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(reviews).To(Equal(4))
+
+	time.Sleep(60 * time.Millisecond)
+
+	_, err = getter.Principal(makeAuthenticatedRequest("Bearer valid"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(reviews).To(Equal(5))
+
+	principal, err = getter.Principal(&http.Request{Header: http.Header{}})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(principal).To(BeNil())
+}
@@ -12,12 +12,13 @@ import (
 
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/go-logr/logr"
-	"golang.org/x/crypto/bcrypt"
 	"golang.org/x/oauth2"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/weaveworks/weave-gitops/core/logger"
+	"github.com/weaveworks/weave-gitops/pkg/audit"
 	"github.com/weaveworks/weave-gitops/pkg/featureflags"
 )
 
@@ -51,6 +52,10 @@ var DefaultScopes = []string{
 // OIDCConfig is used to configure an AuthServer to interact with
 // an OIDC issuer.
 type OIDCConfig struct {
+	// Name identifies the provider when logging in, it defaults to "default".
+	Name string
+	// DisplayName is shown to users choosing a provider.
+	DisplayName    string
 	IssuerURL      string
 	ClientID       string
 	ClientSecret   string
@@ -73,18 +78,37 @@ type AuthServerConfig struct {
 	kubernetesClient    ctrlclient.Client
 	tokenSignerVerifier TokenSignerVerifier
 	OIDCConfig          OIDCConfig
-	authMethods         map[AuthMethod]bool
-	namespace           string
+	// OIDCProviders are the providers users can log in with as well as the
+	// one in OIDCConfig.
+	OIDCProviders []OIDCConfig
+	authMethods   map[AuthMethod]bool
+	namespace     string
 
 	noAuthUser     string
 	SessionManager SessionManager
+	// Audit records users signing in and out, nothing is recorded if nil.
+	Audit *audit.Recorder
+	// TrustedProxies are trusted to add the address of the users to
+	// X-Forwarded-For, for the source IP of the audit events.
+	TrustedProxies audit.TrustedProxies
+	// Sessions records the sessions of the users signing in, they aren't
+	// recorded if nil.
+	Sessions *SessionRegistry
+	// TokenReview fills the user and groups of the passthrough tokens from
+	// their TokenReview, cached for TokenReviewTTL, DefaultTokenReviewTTL if
+	// unset.
+	TokenReview    bool
+	TokenReviewTTL time.Duration
+	// APITokenCacheTTL is how long the API tokens are cached for,
+	// DefaultAPITokenCacheTTL if unset.
+	APITokenCacheTTL time.Duration
 }
 
 // AuthServer interacts with an OIDC issuer to handle the OAuth2 process flow.
 type AuthServer struct {
 	AuthServerConfig
-	provider *oidc.Provider
-	sm       SessionManager
+	providers []*oidcProvider
+	sm        SessionManager
 }
 
 // LoginRequest represents the data submitted by client when the auth flow (non-OIDC) is used.
@@ -93,6 +117,12 @@ type LoginRequest struct {
 	Password string `json:"password"`
 }
 
+// OIDCProviderInfo represents a provider returned from the providers handler.
+type OIDCProviderInfo struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
 // UserInfo represents the response returned from the user info handler.
 type UserInfo struct {
 	Email  string   `json:"email"`
@@ -211,16 +241,23 @@ func NewAuthServer(ctx context.Context, cfg *AuthServerConfig) (*AuthServer, err
 		featureflags.SetBoolean(FeatureFlagClusterUser, false)
 	}
 
-	var provider *oidc.Provider
+	var providers []*oidcProvider
+
+	providerConfigs, err := oidcProviderConfigs(cfg.OIDCConfig, cfg.OIDCProviders)
+	if err != nil {
+		return nil, err
+	}
 
-	if cfg.OIDCConfig.IssuerURL == "" {
+	if len(providerConfigs) == 0 {
 		featureflags.SetBoolean(FeatureFlagOIDCAuth, false)
 	} else if cfg.authMethods[OIDC] {
-		var err error
+		for _, providerConfig := range providerConfigs {
+			provider, err := oidc.NewProvider(ctx, providerConfig.IssuerURL)
+			if err != nil {
+				return nil, fmt.Errorf("could not create provider %s: %w", providerConfig.Name, err)
+			}
 
-		provider, err = oidc.NewProvider(ctx, cfg.OIDCConfig.IssuerURL)
-		if err != nil {
-			return nil, fmt.Errorf("could not create provider: %w", err)
+			providers = append(providers, &oidcProvider{config: providerConfig, provider: provider})
 		}
 		featureflags.SetBoolean(FeatureFlagOIDCAuth, true)
 	}
@@ -235,7 +272,23 @@ func NewAuthServer(ctx context.Context, cfg *AuthServerConfig) (*AuthServer, err
 		return nil, fmt.Errorf("OIDC auth, local auth or anonymous mode must be enabled, can't start")
 	}
 
-	return &AuthServer{*cfg, provider, cfg.SessionManager}, nil
+	return &AuthServer{*cfg, providers, cfg.SessionManager}, nil
+}
+
+func (s *AuthServer) tokenReviewTTL() time.Duration {
+	if s.TokenReviewTTL == 0 {
+		return DefaultTokenReviewTTL
+	}
+
+	return s.TokenReviewTTL
+}
+
+func (s *AuthServer) apiTokenCacheTTL() time.Duration {
+	if s.APITokenCacheTTL == 0 {
+		return DefaultAPITokenCacheTTL
+	}
+
+	return s.APITokenCacheTTL
 }
 
 // SetRedirectURL is used to set the redirect URL. This is meant to be used
@@ -252,11 +305,36 @@ func (s *AuthServer) oidcPassthroughEnabled() bool {
 	return featureflags.IsSet(FeatureFlagOIDCPassthrough)
 }
 
-func (s *AuthServer) verifier() *oidc.IDTokenVerifier {
-	return s.provider.Verifier(&oidc.Config{ClientID: s.OIDCConfig.ClientID})
+// verifier returns a verifier for the tokens issued by any of the providers.
+func (s *AuthServer) verifier() tokenVerifier {
+	if len(s.providers) == 1 {
+		return s.providers[0].verifier()
+	}
+
+	return oidcProviderVerifier{providers: s.providers}
+}
+
+// oidcProvider returns the provider called name, the first one if name is
+// empty.
+func (s *AuthServer) oidcProvider(name string) (*oidcProvider, error) {
+	if len(s.providers) == 0 {
+		return nil, ErrUnknownOIDCProvider
+	}
+
+	if name == "" {
+		return s.providers[0], nil
+	}
+
+	for _, p := range s.providers {
+		if p.config.Name == name {
+			return p, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnknownOIDCProvider, name)
 }
 
-func (s *AuthServer) oauth2Config(scopes []string) *oauth2.Config {
+func (s *AuthServer) oauth2Config(p *oidcProvider, scopes []string) *oauth2.Config {
 	requestScopes := []string{}
 	// Ensure "openid" scope is always present.
 	if !contains(scopes, oidc.ScopeOpenID) {
@@ -265,11 +343,17 @@ func (s *AuthServer) oauth2Config(scopes []string) *oauth2.Config {
 
 	requestScopes = append(requestScopes, scopes...)
 
+	// All the providers share the same callback.
+	redirectURL := p.config.RedirectURL
+	if redirectURL == "" || p.config.IssuerURL == s.OIDCConfig.IssuerURL {
+		redirectURL = s.OIDCConfig.RedirectURL
+	}
+
 	return &oauth2.Config{
-		ClientID:     s.OIDCConfig.ClientID,
-		ClientSecret: s.OIDCConfig.ClientSecret,
-		RedirectURL:  s.OIDCConfig.RedirectURL,
-		Endpoint:     s.provider.Endpoint(),
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		RedirectURL:  redirectURL,
+		Endpoint:     p.provider.Endpoint(),
 		Scopes:       requestScopes,
 	}
 }
@@ -281,7 +365,7 @@ func (s *AuthServer) OAuth2Flow() http.HandlerFunc {
 			return
 		}
 
-		s.startAuthFlow(rw, r)
+		s.startAuthFlow(rw, r, nil)
 	}
 }
 
@@ -298,6 +382,7 @@ func (s *AuthServer) Callback(rw http.ResponseWriter, r *http.Request) {
 	// Authorization redirect callback from OAuth2 auth flow.
 	if errorCode := r.FormValue("error"); errorCode != "" {
 		s.Log.Info("authz redirect callback failed", "error", errorCode, "error_description", r.FormValue("error_description"))
+		s.auditAuth(r, auditActionOIDCSignIn, nil, fmt.Errorf("%s: %s", errorCode, r.FormValue("error_description")), nil)
 		rw.WriteHeader(http.StatusBadRequest)
 
 		return
@@ -342,9 +427,20 @@ func (s *AuthServer) Callback(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := s.oauth2Config(nil).Exchange(ctx, code)
+	provider, err := s.oidcProvider(state.Provider)
+	if err != nil {
+		s.Log.Error(err, "failed to find the provider of the auth flow")
+		rw.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	details := map[string]string{"provider": provider.config.Name}
+
+	token, err := s.oauth2Config(provider, nil).Exchange(ctx, code)
 	if err != nil {
 		s.Log.Error(err, "failed to exchange auth code for token", "code", code)
+		s.auditAuth(r, auditActionOIDCSignIn, nil, err, details)
 		rw.WriteHeader(http.StatusInternalServerError)
 
 		return
@@ -352,17 +448,58 @@ func (s *AuthServer) Callback(rw http.ResponseWriter, r *http.Request) {
 
 	rawIDToken, ok := token.Extra("id_token").(string)
 	if !ok {
+		s.auditAuth(r, auditActionOIDCSignIn, nil, errors.New("no id_token in token response"), details)
 		JSONError(s.Log, rw, "no id_token in token response", http.StatusInternalServerError)
 		return
 	}
 
-	_, err = s.verifier().Verify(r.Context(), rawIDToken)
+	idToken, err := provider.verifier().Verify(r.Context(), rawIDToken)
 	if err != nil {
+		s.auditAuth(r, auditActionOIDCSignIn, nil, err, details)
 		JSONError(s.Log, rw, fmt.Sprintf("failed to verify ID token: %v", err), http.StatusInternalServerError)
 		return
 	}
 
+	principal, err := s.claimsConfig(provider.config.IssuerURL).PrincipalFromClaims(idToken)
+
+	if state.CLI != nil {
+		details["client"] = "cli"
+		s.auditAuth(r, auditActionOIDCSignIn, principal, err, details)
+		s.SessionManager.Remove(r.Context(), StateCookieName)
+
+		if err != nil {
+			JSONError(s.Log, rw, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		s.completeCLILogin(rw, r, state.CLI, principal, CLITokens{
+			Provider:     provider.config.Name,
+			IDToken:      rawIDToken,
+			RefreshToken: token.RefreshToken,
+			Expiry:       idToken.Expiry,
+		})
+
+		return
+	}
+
+	s.auditAuth(r, auditActionOIDCSignIn, principal, err, details)
+
+	if err != nil {
+		s.SessionManager.Remove(r.Context(), StateCookieName)
+		JSONError(s.Log, rw, err.Error(), http.StatusUnauthorized)
+
+		return
+	}
+
+	if err := s.startSession(r, principal, OIDC, rawIDToken); err != nil {
+		s.Log.Error(err, "failed to record session")
+		rw.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
 	s.setCookies(r.Context(), rawIDToken, token.AccessToken, token.RefreshToken)
+	s.SessionManager.Put(r.Context(), OIDCProviderSessionKey, provider.config.Name)
 	// Clear state cookie
 	s.SessionManager.Remove(r.Context(), StateCookieName)
 
@@ -411,21 +548,34 @@ func (s *AuthServer) SignIn() http.HandlerFunc {
 			return
 		}
 
-		if loginRequest.Username != string(hashedSecret.Data["username"]) {
+		users, err := LocalUsersFromSecret(&hashedSecret)
+		if err != nil {
+			s.Log.Error(err, "Failed to read the users from the secret")
+			rw.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		user, err := authenticateLocalUser(users, loginRequest.Username, loginRequest.Password)
+		if err != nil {
+			s.auditAuth(r, auditActionSignIn, &UserPrincipal{ID: loginRequest.Username}, err, nil)
+		}
+
+		if errors.Is(err, ErrLocalUserNotFound) {
 			s.Log.Info("Wrong username")
 			rw.WriteHeader(http.StatusUnauthorized)
 
 			return
 		}
 
-		if err := bcrypt.CompareHashAndPassword(hashedSecret.Data["password"], []byte(loginRequest.Password)); err != nil {
+		if err != nil {
 			s.Log.Error(err, "Failed to compare hash with password")
 			rw.WriteHeader(http.StatusUnauthorized)
 
 			return
 		}
 
-		signed, err := s.tokenSignerVerifier.Sign(loginRequest.Username)
+		signed, err := s.tokenSignerVerifier.Sign(user.Username, user.Groups...)
 		if err != nil {
 			s.Log.Error(err, "Failed to create and sign token")
 			rw.WriteHeader(http.StatusInternalServerError)
@@ -433,7 +583,17 @@ func (s *AuthServer) SignIn() http.HandlerFunc {
 			return
 		}
 
+		principal := &UserPrincipal{ID: user.Username, Groups: user.Groups}
+
+		if err := s.startSession(r, principal, UserAccount, signed); err != nil {
+			s.Log.Error(err, "Failed to record session")
+			rw.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
 		s.SessionManager.Put(r.Context(), IDTokenCookieName, signed)
+		s.auditAuth(r, auditActionSignIn, principal, nil, nil)
 		rw.WriteHeader(http.StatusOK)
 	}
 }
@@ -469,8 +629,9 @@ func (s *AuthServer) UserInfo(rw http.ResponseWriter, r *http.Request) {
 	claims, err := s.tokenSignerVerifier.Verify(idCookie)
 	if err == nil {
 		ui := UserInfo{
-			ID:    claims.Subject,
-			Email: claims.Subject,
+			ID:     claims.Subject,
+			Email:  claims.Subject,
+			Groups: claims.Groups,
 		}
 		toJSON(rw, ui, s.Log)
 
@@ -492,7 +653,7 @@ func (s *AuthServer) UserInfo(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userPrincipal, err := s.OIDCConfig.ClaimsConfig.PrincipalFromClaims(info)
+	userPrincipal, err := s.claimsConfig(info.Issuer).PrincipalFromClaims(info)
 	if err != nil {
 		s.Log.Error(err, "failed to parse user info")
 		JSONError(s.Log, rw, fmt.Sprintf("failed to query user info endpoint: %v", err), http.StatusUnauthorized)
@@ -519,6 +680,7 @@ func (s *AuthServer) RefreshHandler(rw http.ResponseWriter, r *http.Request) {
 	_, err := s.Refresh(rw, r)
 	if err != nil {
 		s.Log.V(logger.LogLevelWarn).Info("refreshing token failed", "err", err)
+		s.auditAuth(r, auditActionRefresh, nil, err, nil)
 		JSONError(s.Log, rw, "failed to refresh", http.StatusUnauthorized)
 		return
 	}
@@ -536,7 +698,12 @@ func (s *AuthServer) Refresh(rw http.ResponseWriter, r *http.Request) (*UserPrin
 		return nil, errors.New("couldn't fetch refresh token from cookie")
 	}
 
-	token, err := s.oauth2Config(nil).TokenSource(
+	provider, err := s.oidcProvider(s.SessionManager.GetString(r.Context(), OIDCProviderSessionKey))
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := s.oauth2Config(provider, nil).TokenSource(
 		ctx,
 		&oauth2.Token{
 			RefreshToken: refreshTokenCookie,
@@ -550,9 +717,33 @@ func (s *AuthServer) Refresh(rw http.ResponseWriter, r *http.Request) (*UserPrin
 		return nil, errors.New("no id_token in token response")
 	}
 
+	if s.Sessions != nil {
+		// The refreshed token belongs to the session of the old one, which
+		// can't be refreshed once revoked.
+		oldIDToken := s.SessionManager.GetString(r.Context(), IDTokenCookieName)
+		if err := s.Sessions.Refresh(r.Context(), oldIDToken, rawIDToken); err != nil {
+			return nil, fmt.Errorf("failed to refresh session: %w", err)
+		}
+	}
+
 	s.setCookies(r.Context(), rawIDToken, token.AccessToken, token.RefreshToken)
 
-	return parseJWTToken(ctx, s.verifier(), rawIDToken, s.OIDCConfig.ClaimsConfig, s.Log)
+	return parseJWTToken(ctx, provider.verifier(), rawIDToken, s.claimsConfig(provider.config.IssuerURL), s.Log)
+}
+
+// claimsConfig returns the claims config of the provider for issuer.
+func (s *AuthServer) claimsConfig(issuer string) *ClaimsConfig {
+	if issuer == s.OIDCConfig.IssuerURL {
+		return s.OIDCConfig.ClaimsConfig
+	}
+
+	for _, p := range s.providers {
+		if p.config.IssuerURL == issuer {
+			return p.config.ClaimsConfig
+		}
+	}
+
+	return s.OIDCConfig.ClaimsConfig
 }
 
 func toJSON(rw http.ResponseWriter, ui UserInfo, log logr.Logger) {
@@ -564,13 +755,21 @@ func toJSON(rw http.ResponseWriter, ui UserInfo, log logr.Logger) {
 	}
 }
 
-func (s *AuthServer) startAuthFlow(rw http.ResponseWriter, r *http.Request) {
+// startAuthFlow redirects the user to the OIDC provider to log in, for the
+// CLI if cli is set.
+func (s *AuthServer) startAuthFlow(rw http.ResponseWriter, r *http.Request, cli *CLILoginRequest) {
 	nonce, err := generateNonce()
 	if err != nil {
 		JSONError(s.Log, rw, fmt.Sprintf("failed to generate nonce: %v", err), http.StatusInternalServerError)
 		return
 	}
 
+	provider, err := s.oidcProvider(r.URL.Query().Get("provider"))
+	if err != nil {
+		JSONError(s.Log, rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	returnURL := r.URL.Query().Get("return_url")
 
 	if returnURL == "" {
@@ -580,11 +779,13 @@ func (s *AuthServer) startAuthFlow(rw http.ResponseWriter, r *http.Request) {
 	b, _ := json.Marshal(SessionState{
 		Nonce:     nonce,
 		ReturnURL: returnURL,
+		Provider:  provider.config.Name,
+		CLI:       cli,
 	})
 
 	state := base64.StdEncoding.EncodeToString(b)
 
-	authCodeURL := s.oauth2Config(s.OIDCConfig.Scopes).AuthCodeURL(state)
+	authCodeURL := s.oauth2Config(provider, provider.config.Scopes).AuthCodeURL(state)
 
 	// Issue state cookie
 	s.SessionManager.Put(r.Context(), StateCookieName, state)
@@ -592,6 +793,36 @@ func (s *AuthServer) startAuthFlow(rw http.ResponseWriter, r *http.Request) {
 	http.Redirect(rw, r, authCodeURL, http.StatusSeeOther)
 }
 
+// Providers returns the OIDC providers users can log in with, for the login
+// page to offer a choice.
+func (s *AuthServer) Providers(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		rw.Header().Add("Allow", "GET")
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	providers := []OIDCProviderInfo{}
+
+	if s.oidcEnabled() {
+		for _, p := range s.providers {
+			displayName := p.config.DisplayName
+			if displayName == "" {
+				displayName = p.config.Name
+			}
+
+			providers = append(providers, OIDCProviderInfo{Name: p.config.Name, DisplayName: displayName})
+		}
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(rw).Encode(providers); err != nil {
+		s.Log.Error(err, "Failing to write response")
+	}
+}
+
 func (s *AuthServer) Logout(rw http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		s.Log.Info("Only POST requests allowed")
@@ -600,15 +831,157 @@ func (s *AuthServer) Logout(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	principal := s.sessionPrincipal(r)
+
+	if s.Sessions != nil {
+		if err := s.Sessions.End(r); err != nil {
+			s.Log.Error(err, "failed to delete the session record")
+		}
+	}
+
 	if err := s.SessionManager.Destroy(r.Context()); err != nil {
 		s.Log.Error(err, "failed to destroy session")
+		s.auditAuth(r, auditActionLogout, principal, err, nil)
 		rw.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
+	s.auditAuth(r, auditActionLogout, principal, nil, nil)
+	rw.WriteHeader(http.StatusOK)
+}
+
+// RevokeSessionRequest is the data submitted to revoke a session.
+type RevokeSessionRequest struct {
+	ID string `json:"id"`
+}
+
+// startSession records the session of principal signing in with method and
+// given token, if sessions are recorded.
+func (s *AuthServer) startSession(r *http.Request, principal *UserPrincipal, method AuthMethod, token string) error {
+	if s.Sessions == nil {
+		return nil
+	}
+
+	lifetime := s.OIDCConfig.TokenDuration
+	if lifetime == 0 {
+		lifetime = defaultCookieDuration
+	}
+
+	return s.Sessions.Start(r, principal, method, lifetime, token)
+}
+
+// ListSessions returns the recorded sessions to admins, the users allowed to
+// list the Secrets in the namespace of the sessions.
+func (s *AuthServer) ListSessions(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		rw.Header().Add("Allow", "GET")
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	if !s.authorizeSessionsAdmin(rw, r, "list") {
+		return
+	}
+
+	sessions, err := s.Sessions.List(r.Context())
+	if err != nil {
+		s.Log.Error(err, "failed to list sessions")
+		JSONError(s.Log, rw, "failed to list sessions", http.StatusInternalServerError)
+
+		return
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(rw).Encode(sessions); err != nil {
+		s.Log.Error(err, "Failing to write response")
+	}
+}
+
+// RevokeSession revokes a session for admins, the users allowed to update
+// the Secrets in the namespace of the sessions. Its user has to sign in again.
+func (s *AuthServer) RevokeSession(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		rw.Header().Add("Allow", "POST")
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	if !s.authorizeSessionsAdmin(rw, r, "update") {
+		return
+	}
+
+	var revokeRequest RevokeSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&revokeRequest); err != nil || revokeRequest.ID == "" {
+		JSONError(s.Log, rw, "a session id is required", http.StatusBadRequest)
+		return
+	}
+
+	session, err := s.Sessions.Revoke(r.Context(), revokeRequest.ID)
+	s.auditAuth(r, auditActionRevokeSession, Principal(r.Context()), err, map[string]string{
+		"session": revokeRequest.ID,
+		"user":    session.User,
+	})
+
+	if errors.Is(err, ErrSessionNotFound) {
+		JSONError(s.Log, rw, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		s.Log.Error(err, "failed to revoke session", "id", revokeRequest.ID)
+		JSONError(s.Log, rw, "failed to revoke session", http.StatusInternalServerError)
+
+		return
+	}
+
 	rw.WriteHeader(http.StatusOK)
 }
 
+// authorizeSessionsAdmin checks the Kubernetes RBAC of the user of r allows
+// verb on the Secrets recording the sessions, writing the error response if
+// not.
+func (s *AuthServer) authorizeSessionsAdmin(rw http.ResponseWriter, r *http.Request, verb string) bool {
+	if s.Sessions == nil {
+		JSONError(s.Log, rw, "sessions are not recorded", http.StatusNotFound)
+		return false
+	}
+
+	principal := Principal(r.Context())
+	if principal == nil {
+		JSONError(s.Log, rw, "Authentication required", http.StatusUnauthorized)
+		return false
+	}
+
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   principal.ID,
+			Groups: principal.Groups,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: s.namespace,
+				Verb:      verb,
+				Resource:  "secrets",
+			},
+		},
+	}
+
+	if err := s.kubernetesClient.Create(r.Context(), review); err != nil {
+		s.Log.Error(err, "failed to review the access to the sessions", "user", principal.ID)
+		JSONError(s.Log, rw, "failed to check access to the sessions", http.StatusInternalServerError)
+
+		return false
+	}
+
+	if !review.Status.Allowed {
+		JSONError(s.Log, rw, fmt.Sprintf("user %q is not allowed to %s the sessions", principal.ID, verb), http.StatusForbidden)
+		return false
+	}
+
+	return true
+}
+
 // SessionState represents the state that needs to be persisted between
 // the AuthN request from the Relying Party (RP) to the authorization
 // endpoint of the OpenID Provider (OP) and the AuthN response back from
@@ -624,6 +997,11 @@ func (s *AuthServer) Logout(rw http.ResponseWriter, r *http.Request) {
 type SessionState struct {
 	Nonce     string `json:"n"`
 	ReturnURL string `json:"return_url"`
+	// Provider is the name of the OIDC provider the user is logging in with.
+	Provider string `json:"provider,omitempty"`
+	// CLI is set when the user is logging in the CLI, which gets the tokens
+	// instead of the browser.
+	CLI *CLILoginRequest `json:"cli,omitempty"`
 }
 
 func contains(ss []string, s string) bool {
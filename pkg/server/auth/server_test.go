@@ -173,6 +173,37 @@ func TestCallbackCodeExchangeError(t *testing.T) {
 	g.Expect(w.Result().StatusCode).To(Equal(http.StatusInternalServerError))
 }
 
+func TestCallbackRejectsInvalidClaims(t *testing.T) {
+	g := NewGomegaWithT(t)
+	sm := &fakeSessionManager{}
+
+	s, m := makeAuthServer(t, nil, nil, []auth.AuthMethod{auth.OIDC}, sm)
+	s.SetRedirectURL("https://example.com/oauth2/callback")
+	s.OIDCConfig.ClaimsConfig = &auth.ClaimsConfig{Username: "missing"}
+
+	w := httptest.NewRecorder()
+	s.OAuth2Flow().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "https://example.com/oauth2", nil))
+	g.Expect(w.Code).To(Equal(http.StatusSeeOther))
+
+	state := sm.stringValue(auth.StateCookieName)
+	m.QueueCode("browser-code")
+
+	authorizeResp, err := httpClient.Get(w.Header().Get("Location"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(authorizeResp.StatusCode).To(Equal(http.StatusFound))
+
+	callback, err := url.Parse(authorizeResp.Header.Get("Location"))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/oauth2/callback?"+callback.RawQuery, nil).
+		WithContext(contextWithValues(t.Context(), map[string]any{auth.StateCookieName: state}))
+	w = httptest.NewRecorder()
+	s.Callback(w, req)
+
+	g.Expect(w.Code).To(Equal(http.StatusUnauthorized))
+	g.Expect(sm.PutValues).NotTo(HaveKey(auth.IDTokenCookieName), "no session should be started for a user that can't be mapped")
+}
+
 func TestSignInAllowsPOST(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -0,0 +1,223 @@
+package auth
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// SessionStoreMemory keeps sessions in the server's memory, so they're
+	// lost on restart and not shared between replicas.
+	SessionStoreMemory = "memory"
+	// SessionStoreSecret keeps each session in a Secret.
+	SessionStoreSecret = "secret"
+	// SessionStoreConfigMap keeps each session in a ConfigMap.
+	SessionStoreConfigMap = "configmap"
+	// SessionStoreRedis keeps sessions in Redis, or anything speaking its protocol.
+	SessionStoreRedis = "redis"
+
+	// DefaultSessionKeySecretName is the name of the Secret holding the key
+	// sessions are encrypted with. It's created if it doesn't exist.
+	DefaultSessionKeySecretName = "weave-gitops-session-key"
+	sessionKeySecretKey         = "key"
+
+	sessionCleanupInterval = 5 * time.Minute
+)
+
+// SessionStores returns the valid values for SessionStoreOptions.Type.
+func SessionStores() []string {
+	return []string{SessionStoreMemory, SessionStoreSecret, SessionStoreConfigMap, SessionStoreRedis}
+}
+
+// SessionStoreOptions configures where sessions are stored.
+type SessionStoreOptions struct {
+	// Type is one of SessionStores(), memory if empty.
+	Type string
+	// Namespace is where the session Secrets or ConfigMaps and the
+	// encryption key Secret are kept.
+	Namespace string
+	// KeySecretName is the name of the Secret holding the encryption key.
+	KeySecretName string
+	Redis         RedisOptions
+}
+
+// NewSessionManager creates a session manager using the configured store.
+// Sessions kept outside of the server's memory are encrypted, and the tokens
+// identifying them are hashed.
+func NewSessionManager(ctx context.Context, log logr.Logger, kubernetesClient ctrlclient.Client, opts SessionStoreOptions) (*scs.SessionManager, error) {
+	sessionManager := scs.New()
+
+	var store scs.CtxStore
+
+	switch opts.Type {
+	case "", SessionStoreMemory:
+		return sessionManager, nil
+	case SessionStoreSecret, SessionStoreConfigMap:
+		kubeStore := NewKubeSessionStore(kubernetesClient, opts.Namespace, opts.Type)
+
+		go runSessionCleanup(ctx, log, kubeStore)
+
+		store = kubeStore
+	case SessionStoreRedis:
+		if opts.Redis.Address == "" {
+			return nil, errors.New("a redis address is required for the redis session store")
+		}
+
+		store = NewRedisSessionStore(opts.Redis)
+	default:
+		return nil, fmt.Errorf("unknown session store %q, valid values are %s", opts.Type, strings.Join(SessionStores(), ","))
+	}
+
+	keySecretName := opts.KeySecretName
+	if keySecretName == "" {
+		keySecretName = DefaultSessionKeySecretName
+	}
+
+	key, err := LoadSessionKey(ctx, kubernetesClient, opts.Namespace, keySecretName)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := NewEncryptedSessionStore(store, key)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("Using persistent session store", "type", opts.Type)
+
+	sessionManager.Store = encrypted
+	sessionManager.HashTokenInStore = true
+
+	return sessionManager, nil
+}
+
+func runSessionCleanup(ctx context.Context, log logr.Logger, store *KubeSessionStore) {
+	ticker := time.NewTicker(sessionCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := store.DeleteExpired(ctx); err != nil {
+				log.Error(err, "failed to delete expired sessions")
+			}
+		}
+	}
+}
+
+// LoadSessionKey returns the session encryption key from a Secret, creating
+// it with a random key if it doesn't exist so all replicas share the key.
+func LoadSessionKey(ctx context.Context, kubernetesClient ctrlclient.Client, namespace, name string) ([]byte, error) {
+	secret := &corev1.Secret{}
+	key := ctrlclient.ObjectKey{Name: name, Namespace: namespace}
+
+	err := kubernetesClient.Get(ctx, key, secret)
+	if apierrors.IsNotFound(err) {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Data:       map[string][]byte{sessionKeySecretKey: []byte(rand.Text() + rand.Text())},
+		}
+
+		err = kubernetesClient.Create(ctx, secret)
+		// Another replica got there first.
+		if apierrors.IsAlreadyExists(err) {
+			err = kubernetesClient.Get(ctx, key, secret)
+		}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("loading session key from secret %s/%s: %w", namespace, name, err)
+	}
+
+	data := secret.Data[sessionKeySecretKey]
+	if len(data) < 32 {
+		return nil, fmt.Errorf("session key in secret %s/%s must be at least 32 bytes", namespace, name)
+	}
+
+	return data[:32], nil
+}
+
+// EncryptedSessionStore encrypts the sessions of another store with AES-GCM.
+type EncryptedSessionStore struct {
+	store scs.CtxStore
+	aead  cipher.AEAD
+}
+
+// NewEncryptedSessionStore wraps store, encrypting with a 32 bytes key.
+func NewEncryptedSessionStore(store scs.CtxStore, key []byte) (*EncryptedSessionStore, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("creating session cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("creating session cipher: %w", err)
+	}
+
+	return &EncryptedSessionStore{store: store, aead: aead}, nil
+}
+
+func (s *EncryptedSessionStore) Delete(token string) error {
+	return s.DeleteCtx(context.Background(), token)
+}
+
+func (s *EncryptedSessionStore) Find(token string) ([]byte, bool, error) {
+	return s.FindCtx(context.Background(), token)
+}
+
+func (s *EncryptedSessionStore) Commit(token string, b []byte, expiry time.Time) error {
+	return s.CommitCtx(context.Background(), token, b, expiry)
+}
+
+func (s *EncryptedSessionStore) DeleteCtx(ctx context.Context, token string) error {
+	return s.store.DeleteCtx(ctx, token)
+}
+
+// FindCtx decrypts the session. Sessions that can't be decrypted are treated
+// as not found, as they've been tampered with or the key has changed.
+func (s *EncryptedSessionStore) FindCtx(ctx context.Context, token string) ([]byte, bool, error) {
+	data, found, err := s.store.FindCtx(ctx, token)
+	if err != nil || !found {
+		return nil, found, err
+	}
+
+	nonceSize := s.aead.NonceSize()
+	if len(data) < nonceSize {
+		return nil, false, nil
+	}
+
+	// The token is authenticated along with the data, so sessions can't be
+	// swapped around in the store.
+	b, err := s.aead.Open(nil, data[:nonceSize], data[nonceSize:], []byte(token))
+	if err != nil {
+		return nil, false, nil
+	}
+
+	return b, true, nil
+}
+
+func (s *EncryptedSessionStore) CommitCtx(ctx context.Context, token string, b []byte, expiry time.Time) error {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("generating session nonce: %w", err)
+	}
+
+	return s.store.CommitCtx(ctx, token, s.aead.Seal(slices.Clip(nonce), nonce, b, []byte(token)), expiry)
+}
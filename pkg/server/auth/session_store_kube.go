@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	sessionLabel            = "weave.works/session"
+	sessionExpiryAnnotation = "weave.works/session-expiry"
+	sessionDataKey          = "session"
+	sessionObjectPrefix     = "weave-gitops-session-"
+)
+
+// KubeSessionStore keeps each session in a Secret or a ConfigMap of its own,
+// so sessions are shared between replicas without any other infrastructure.
+type KubeSessionStore struct {
+	client    ctrlclient.Client
+	namespace string
+	kind      string
+}
+
+// NewKubeSessionStore creates a store keeping sessions in namespace, in
+// Secrets if kind is SessionStoreSecret and in ConfigMaps otherwise.
+func NewKubeSessionStore(client ctrlclient.Client, namespace, kind string) *KubeSessionStore {
+	return &KubeSessionStore{client: client, namespace: namespace, kind: kind}
+}
+
+// objectName returns the name of the object holding the session. Tokens can
+// contain characters that aren't valid in names, so they're hashed.
+func (s *KubeSessionStore) objectName(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return sessionObjectPrefix + hex.EncodeToString(sum[:20])
+}
+
+func (s *KubeSessionStore) newObject(token string) ctrlclient.Object {
+	meta := metav1.ObjectMeta{Name: s.objectName(token), Namespace: s.namespace}
+
+	if s.kind == SessionStoreSecret {
+		return &corev1.Secret{ObjectMeta: meta}
+	}
+
+	return &corev1.ConfigMap{ObjectMeta: meta}
+}
+
+func (s *KubeSessionStore) newList() ctrlclient.ObjectList {
+	if s.kind == SessionStoreSecret {
+		return &corev1.SecretList{}
+	}
+
+	return &corev1.ConfigMapList{}
+}
+
+func sessionData(obj ctrlclient.Object) []byte {
+	switch o := obj.(type) {
+	case *corev1.Secret:
+		return o.Data[sessionDataKey]
+	case *corev1.ConfigMap:
+		return o.BinaryData[sessionDataKey]
+	}
+
+	return nil
+}
+
+func setSessionData(obj ctrlclient.Object, b []byte) {
+	switch o := obj.(type) {
+	case *corev1.Secret:
+		o.Data = map[string][]byte{sessionDataKey: b}
+	case *corev1.ConfigMap:
+		o.BinaryData = map[string][]byte{sessionDataKey: b}
+	}
+}
+
+func sessionExpired(obj ctrlclient.Object, now time.Time) bool {
+	expiry, err := time.Parse(time.RFC3339Nano, obj.GetAnnotations()[sessionExpiryAnnotation])
+	return err != nil || !now.Before(expiry)
+}
+
+func (s *KubeSessionStore) Delete(token string) error {
+	return s.DeleteCtx(context.Background(), token)
+}
+
+func (s *KubeSessionStore) Find(token string) ([]byte, bool, error) {
+	return s.FindCtx(context.Background(), token)
+}
+
+func (s *KubeSessionStore) Commit(token string, b []byte, expiry time.Time) error {
+	return s.CommitCtx(context.Background(), token, b, expiry)
+}
+
+func (s *KubeSessionStore) DeleteCtx(ctx context.Context, token string) error {
+	if err := s.client.Delete(ctx, s.newObject(token)); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("deleting session: %w", err)
+	}
+
+	return nil
+}
+
+func (s *KubeSessionStore) FindCtx(ctx context.Context, token string) ([]byte, bool, error) {
+	obj := s.newObject(token)
+
+	if err := s.client.Get(ctx, ctrlclient.ObjectKeyFromObject(obj), obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, false, nil
+		}
+
+		return nil, false, fmt.Errorf("getting session: %w", err)
+	}
+
+	if sessionExpired(obj, time.Now()) {
+		return nil, false, nil
+	}
+
+	return sessionData(obj), true, nil
+}
+
+func (s *KubeSessionStore) CommitCtx(ctx context.Context, token string, b []byte, expiry time.Time) error {
+	obj := s.newObject(token)
+
+	_, err := controllerutil.CreateOrUpdate(ctx, s.client, obj, func() error {
+		obj.SetLabels(map[string]string{sessionLabel: "true"})
+		obj.SetAnnotations(map[string]string{sessionExpiryAnnotation: expiry.UTC().Format(time.RFC3339Nano)})
+		setSessionData(obj, b)
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("saving session: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpired deletes the sessions that have expired, which would
+// otherwise be kept forever.
+func (s *KubeSessionStore) DeleteExpired(ctx context.Context) error {
+	list := s.newList()
+
+	if err := s.client.List(ctx, list, ctrlclient.InNamespace(s.namespace), ctrlclient.MatchingLabels{sessionLabel: "true"}); err != nil {
+		return fmt.Errorf("listing sessions: %w", err)
+	}
+
+	var objects []ctrlclient.Object
+
+	switch l := list.(type) {
+	case *corev1.SecretList:
+		for i := range l.Items {
+			objects = append(objects, &l.Items[i])
+		}
+	case *corev1.ConfigMapList:
+		for i := range l.Items {
+			objects = append(objects, &l.Items[i])
+		}
+	}
+
+	now := time.Now()
+
+	for _, obj := range objects {
+		if !sessionExpired(obj, now) {
+			continue
+		}
+
+		if err := s.client.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("deleting session: %w", err)
+		}
+	}
+
+	return nil
+}
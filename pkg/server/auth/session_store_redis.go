@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	redisSessionKeyPrefix = "weave-gitops:session:"
+	redisTimeout          = 5 * time.Second
+)
+
+// RedisOptions configures the connection to the Redis session store.
+type RedisOptions struct {
+	// Address is the host:port of the server.
+	Address  string
+	Username string
+	Password string
+	TLS      bool
+}
+
+// RedisSessionStore keeps sessions in Redis, letting it expire them.
+type RedisSessionStore struct {
+	client *redis.Client
+}
+
+func NewRedisSessionStore(opts RedisOptions) *RedisSessionStore {
+	redisOpts := &redis.Options{
+		Addr:         opts.Address,
+		Username:     opts.Username,
+		Password:     opts.Password,
+		DialTimeout:  redisTimeout,
+		ReadTimeout:  redisTimeout,
+		WriteTimeout: redisTimeout,
+	}
+
+	if opts.TLS {
+		redisOpts.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	return &RedisSessionStore{client: redis.NewClient(redisOpts)}
+}
+
+func (s *RedisSessionStore) Delete(token string) error {
+	return s.DeleteCtx(context.Background(), token)
+}
+
+func (s *RedisSessionStore) Find(token string) ([]byte, bool, error) {
+	return s.FindCtx(context.Background(), token)
+}
+
+func (s *RedisSessionStore) Commit(token string, b []byte, expiry time.Time) error {
+	return s.CommitCtx(context.Background(), token, b, expiry)
+}
+
+func (s *RedisSessionStore) DeleteCtx(ctx context.Context, token string) error {
+	return s.client.Del(ctx, redisSessionKeyPrefix+token).Err()
+}
+
+func (s *RedisSessionStore) FindCtx(ctx context.Context, token string) ([]byte, bool, error) {
+	b, err := s.client.Get(ctx, redisSessionKeyPrefix+token).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	return b, true, nil
+}
+
+func (s *RedisSessionStore) CommitCtx(ctx context.Context, token string, b []byte, expiry time.Time) error {
+	ttl := time.Until(expiry)
+	if ttl <= 0 {
+		return s.DeleteCtx(ctx, token)
+	}
+
+	return s.client.Set(ctx, redisSessionKeyPrefix+token, b, ttl).Err()
+}
@@ -0,0 +1,174 @@
+package auth_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+const testSessionNamespace = "flux-system"
+
+func testSessionStore(t *testing.T, store scs.CtxStore) {
+	t.Helper()
+
+	g := NewGomegaWithT(t)
+	ctx := t.Context()
+
+	_, found, err := store.FindCtx(ctx, "missing")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(found).To(BeFalse())
+
+	g.Expect(store.CommitCtx(ctx, "token", []byte("first"), time.Now().Add(time.Hour))).To(Succeed())
+	g.Expect(store.CommitCtx(ctx, "token", []byte("second"), time.Now().Add(time.Hour))).To(Succeed())
+
+	b, found, err := store.FindCtx(ctx, "token")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(found).To(BeTrue())
+	g.Expect(string(b)).To(Equal("second"))
+
+	g.Expect(store.DeleteCtx(ctx, "token")).To(Succeed())
+	g.Expect(store.DeleteCtx(ctx, "token")).To(Succeed(), "deleting a missing session should be a no-op")
+
+	_, found, err = store.FindCtx(ctx, "token")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(found).To(BeFalse())
+}
+
+func TestKubeSessionStore(t *testing.T) {
+	for _, kind := range []string{auth.SessionStoreSecret, auth.SessionStoreConfigMap} {
+		t.Run(kind, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			ctx := t.Context()
+
+			k8s := fake.NewClientBuilder().Build()
+			store := auth.NewKubeSessionStore(k8s, testSessionNamespace, kind)
+
+			testSessionStore(t, store)
+
+			g.Expect(store.CommitCtx(ctx, "expired", []byte("data"), time.Now().Add(-time.Minute))).To(Succeed())
+			g.Expect(store.CommitCtx(ctx, "valid", []byte("data"), time.Now().Add(time.Hour))).To(Succeed())
+
+			_, found, err := store.FindCtx(ctx, "expired")
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(found).To(BeFalse())
+
+			g.Expect(store.DeleteExpired(ctx)).To(Succeed())
+
+			var names []string
+
+			if kind == auth.SessionStoreSecret {
+				list := &corev1.SecretList{}
+				g.Expect(k8s.List(ctx, list, client.InNamespace(testSessionNamespace))).To(Succeed())
+
+				for _, s := range list.Items {
+					names = append(names, s.Name)
+				}
+			} else {
+				list := &corev1.ConfigMapList{}
+				g.Expect(k8s.List(ctx, list, client.InNamespace(testSessionNamespace))).To(Succeed())
+
+				for _, cm := range list.Items {
+					names = append(names, cm.Name)
+				}
+			}
+
+			g.Expect(names).To(HaveLen(1))
+			g.Expect(names[0]).To(HavePrefix("weave-gitops-session-"))
+			g.Expect(names[0]).NotTo(ContainSubstring("valid"), "tokens should not be used as names")
+		})
+	}
+}
+
+func TestRedisSessionStore(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	server := miniredis.RunT(t)
+	server.RequireAuth("hunter2")
+
+	testSessionStore(t, auth.NewRedisSessionStore(auth.RedisOptions{Address: server.Addr(), Password: "hunter2"}))
+
+	store := auth.NewRedisSessionStore(auth.RedisOptions{Address: server.Addr(), Password: "wrong"})
+	_, _, err := store.FindCtx(t.Context(), "token")
+	g.Expect(err).To(MatchError(ContainSubstring("WRONGPASS")))
+}
+
+func TestEncryptedSessionStore(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := t.Context()
+
+	k8s := fake.NewClientBuilder().Build()
+
+	key, err := auth.LoadSessionKey(ctx, k8s, testSessionNamespace, auth.DefaultSessionKeySecretName)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	again, err := auth.LoadSessionKey(ctx, k8s, testSessionNamespace, auth.DefaultSessionKeySecretName)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(again).To(Equal(key), "the generated key should be reused")
+
+	kubeStore := auth.NewKubeSessionStore(k8s, testSessionNamespace, auth.SessionStoreSecret)
+
+	store, err := auth.NewEncryptedSessionStore(kubeStore, key)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	testSessionStore(t, store)
+
+	g.Expect(store.CommitCtx(ctx, "token", []byte("id-token"), time.Now().Add(time.Hour))).To(Succeed())
+
+	raw, found, err := kubeStore.FindCtx(ctx, "token")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(found).To(BeTrue())
+	g.Expect(string(raw)).NotTo(ContainSubstring("id-token"))
+
+	g.Expect(kubeStore.CommitCtx(ctx, "other", raw, time.Now().Add(time.Hour))).To(Succeed())
+
+	_, found, err = store.FindCtx(ctx, "other")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(found).To(BeFalse(), "sessions moved to another token should not decrypt")
+
+	otherKey := []byte(strings.Repeat("k", 32))
+	otherStore, err := auth.NewEncryptedSessionStore(kubeStore, otherKey)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	_, found, err = otherStore.FindCtx(ctx, "token")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(found).To(BeFalse())
+}
+
+func TestNewSessionManager(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := t.Context()
+
+	k8s := fake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "short-key", Namespace: testSessionNamespace},
+		Data:       map[string][]byte{"key": []byte("short")},
+	}).Build()
+
+	sm, err := auth.NewSessionManager(ctx, logr.Discard(), k8s, auth.SessionStoreOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(sm.HashTokenInStore).To(BeFalse())
+
+	sm, err = auth.NewSessionManager(ctx, logr.Discard(), k8s, auth.SessionStoreOptions{Type: auth.SessionStoreConfigMap, Namespace: testSessionNamespace})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(sm.Store).To(BeAssignableToTypeOf(&auth.EncryptedSessionStore{}))
+	g.Expect(sm.HashTokenInStore).To(BeTrue())
+
+	_, err = auth.NewSessionManager(ctx, logr.Discard(), k8s, auth.SessionStoreOptions{Type: auth.SessionStoreSecret, Namespace: testSessionNamespace, KeySecretName: "short-key"})
+	g.Expect(err).To(MatchError(ContainSubstring("at least 32 bytes")))
+
+	_, err = auth.NewSessionManager(ctx, logr.Discard(), k8s, auth.SessionStoreOptions{Type: auth.SessionStoreRedis})
+	g.Expect(err).To(MatchError(ContainSubstring("redis address is required")))
+
+	_, err = auth.NewSessionManager(ctx, logr.Discard(), k8s, auth.SessionStoreOptions{Type: "etcd"})
+	g.Expect(err).To(MatchError(ContainSubstring("unknown session store")))
+}
@@ -0,0 +1,590 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/golang-jwt/jwt/v5"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/weaveworks/weave-gitops/pkg/audit"
+)
+
+const (
+	// SessionRecordLabel labels the Secrets recording the sessions of the
+	// dashboard users, one Secret per session.
+	SessionRecordLabel = "weave.works/session-record"
+	// sessionRecordPrefix starts the names of the Secrets recording the
+	// sessions, followed by the session ID.
+	sessionRecordPrefix = "weave-gitops-session-record-" // #nosec G101
+	// sessionRecordKey is the key of the session in its Secret.
+	sessionRecordKey = "session"
+
+	SessionStatusActive  = "Active"
+	SessionStatusExpired = "Expired"
+	SessionStatusRevoked = "Revoked"
+
+	// sessionLastSeenInterval is how often the last seen time of a session is
+	// updated, so active users don't update its Secret on every request.
+	sessionLastSeenInterval = time.Minute
+	// sessionCacheTTL is how long the sessions are cached for, which is how
+	// long a session revoked by another replica or the CLI keeps working.
+	sessionCacheTTL = 10 * time.Second
+	// sessionMinRefresh is how often the sessions can be read again when a
+	// token isn't found in the cache, so the sessions started by other
+	// replicas are found straight away.
+	sessionMinRefresh = time.Second
+)
+
+var (
+	ErrSessionNotFound = errors.New("session not found")
+	ErrSessionRevoked  = errors.New("session has been revoked")
+	ErrSessionExpired  = errors.New("session has expired")
+)
+
+// SessionEntry records a session of a user signed in to the dashboard. The ID
+// isn't the token of the session cookie, so it can be shown to admins.
+type SessionEntry struct {
+	ID     string   `json:"id"`
+	User   string   `json:"user"`
+	Groups []string `json:"groups,omitempty"`
+	// AuthMethod is how the user signed in, like oidc or user-account.
+	AuthMethod string `json:"authMethod"`
+	// IP is the address the user last connected from.
+	IP        string    `json:"ip,omitempty"`
+	IssuedAt  time.Time `json:"issuedAt"`
+	LastSeen  time.Time `json:"lastSeen"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	// RevokedAt is set once the session has been revoked or its user logged
+	// out. Revoked sessions are kept until they expire, so their tokens keep
+	// being rejected and they still show up when listing sessions.
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+	// TokenHashes are the hashes of the OIDC ID tokens of the session,
+	// including the refreshed ones. The tokens signed by Weave GitOps carry
+	// the session ID instead.
+	TokenHashes []string `json:"tokenHashes,omitempty"`
+}
+
+// Status returns whether the session is Active, Expired or Revoked.
+func (s SessionEntry) Status(now time.Time) string {
+	switch {
+	case s.RevokedAt != nil:
+		return SessionStatusRevoked
+	case !now.Before(s.ExpiresAt):
+		return SessionStatusExpired
+	default:
+		return SessionStatusActive
+	}
+}
+
+func sessionRecordName(id string) string {
+	return sessionRecordPrefix + id
+}
+
+// ListSessions returns the sessions that haven't expired, the most recent
+// first.
+func ListSessions(ctx context.Context, kubernetesClient ctrlclient.Client, namespace string) ([]SessionEntry, error) {
+	sessions, err := listSessions(ctx, kubernetesClient, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return sortedSessions(sessions, time.Now()), nil
+}
+
+func listSessions(ctx context.Context, kubernetesClient ctrlclient.Client, namespace string) (map[string]SessionEntry, error) {
+	list := &corev1.SecretList{}
+
+	if err := kubernetesClient.List(ctx, list, ctrlclient.InNamespace(namespace), ctrlclient.HasLabels{SessionRecordLabel}); err != nil {
+		return nil, fmt.Errorf("listing sessions: %w", err)
+	}
+
+	sessions := map[string]SessionEntry{}
+
+	for i := range list.Items {
+		session, err := sessionFromSecret(&list.Items[i])
+		if err != nil {
+			return nil, err
+		}
+
+		sessions[session.ID] = session
+	}
+
+	return sessions, nil
+}
+
+func sortedSessions(sessions map[string]SessionEntry, now time.Time) []SessionEntry {
+	result := make([]SessionEntry, 0, len(sessions))
+
+	for _, session := range sessions {
+		if session.Status(now) != SessionStatusExpired {
+			result = append(result, session)
+		}
+	}
+
+	slices.SortFunc(result, func(a, b SessionEntry) int {
+		if c := b.IssuedAt.Compare(a.IssuedAt); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return result
+}
+
+func sessionFromSecret(secret *corev1.Secret) (SessionEntry, error) {
+	var session SessionEntry
+	if err := json.Unmarshal(secret.Data[sessionRecordKey], &session); err != nil {
+		return SessionEntry{}, fmt.Errorf("reading session %q: %w", secret.Name, err)
+	}
+
+	session.ID = strings.TrimPrefix(secret.Name, sessionRecordPrefix)
+
+	return session, nil
+}
+
+func setSessionRecord(secret *corev1.Secret, session SessionEntry) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	secret.Data = map[string][]byte{sessionRecordKey: data}
+
+	return nil
+}
+
+// RevokeSession revokes the session with id, its user has to sign in again.
+func RevokeSession(ctx context.Context, kubernetesClient ctrlclient.Client, namespace, id string) (SessionEntry, error) {
+	return updateSession(ctx, kubernetesClient, namespace, id, func(session *SessionEntry) error {
+		if session.RevokedAt == nil {
+			now := time.Now().UTC().Truncate(time.Second)
+			session.RevokedAt = &now
+		}
+
+		return nil
+	})
+}
+
+// updateSession applies update to the session with id, returning the session
+// as saved.
+func updateSession(ctx context.Context, kubernetesClient ctrlclient.Client, namespace, id string, update func(*SessionEntry) error) (SessionEntry, error) {
+	var session SessionEntry
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret := &corev1.Secret{}
+
+		if err := kubernetesClient.Get(ctx, ctrlclient.ObjectKey{Name: sessionRecordName(id), Namespace: namespace}, secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				return fmt.Errorf("%w: %s", ErrSessionNotFound, id)
+			}
+
+			return err
+		}
+
+		var err error
+
+		session, err = sessionFromSecret(secret)
+		if err != nil {
+			return err
+		}
+
+		if err := update(&session); err != nil {
+			return err
+		}
+
+		if err := setSessionRecord(secret, session); err != nil {
+			return err
+		}
+
+		return kubernetesClient.Update(ctx, secret)
+	})
+	if err != nil {
+		return SessionEntry{}, fmt.Errorf("updating session: %w", err)
+	}
+
+	return session, nil
+}
+
+// SessionChecker rejects the requests made with sessions that are no longer
+// valid.
+type SessionChecker interface {
+	CheckSession(r *http.Request) error
+}
+
+// SessionRegistry records the sessions of the users signing in, so admins
+// can list and revoke them. The sessions are bound to their tokens, so a
+// revoked session's token is rejected whether it's sent in the session
+// cookie or in the Authorization header.
+type SessionRegistry struct {
+	log              logr.Logger
+	kubernetesClient ctrlclient.Client
+	namespace        string
+	sm               SessionManager
+	verifier         TokenVerifier
+	// clientIDs are the client IDs of the OIDC providers, whose ID tokens
+	// are only given out with a session.
+	clientIDs []string
+	// proxies are trusted for the addresses the sessions are used from.
+	proxies audit.TrustedProxies
+
+	mu       sync.Mutex
+	sessions map[string]SessionEntry
+	loadedAt time.Time
+}
+
+// NewSessionRegistry creates a SessionRegistry keeping the sessions in
+// Secrets in namespace. verifier verifies the tokens signed by Weave GitOps,
+// which carry the ID of their session, and clientIDs are the client IDs of
+// the OIDC providers, the ID tokens issued to them need a session too. The
+// addresses of the sessions are read through proxies.
+func NewSessionRegistry(log logr.Logger, kubernetesClient ctrlclient.Client, namespace string, sm SessionManager, verifier TokenVerifier, clientIDs []string, proxies audit.TrustedProxies) *SessionRegistry {
+	return &SessionRegistry{
+		log:              log,
+		kubernetesClient: kubernetesClient,
+		namespace:        namespace,
+		sm:               sm,
+		verifier:         verifier,
+		clientIDs:        clientIDs,
+		proxies:          proxies,
+	}
+}
+
+// Start records a new session of principal, signed in with method and given
+// token. The session of a token signed by Weave GitOps takes the token's ID,
+// the others are recorded by their hash.
+func (sr *SessionRegistry) Start(r *http.Request, principal *UserPrincipal, method AuthMethod, lifetime time.Duration, token string) error {
+	now := time.Now().UTC().Truncate(time.Second)
+	session := SessionEntry{
+		AuthMethod: method.String(),
+		IP:         sr.proxies.SourceIP(r),
+		IssuedAt:   now,
+		LastSeen:   now,
+		ExpiresAt:  now.Add(lifetime),
+	}
+
+	if id, ok := sr.signedTokenID(token); ok {
+		session.ID = id
+	} else {
+		session.ID = strings.ToLower(rand.Text())
+		session.TokenHashes = []string{hashToken(token)}
+	}
+
+	if principal != nil {
+		session.User = principal.ID
+		session.Groups = principal.Groups
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      sessionRecordName(session.ID),
+			Namespace: sr.namespace,
+			Labels:    map[string]string{SessionRecordLabel: "true"},
+		},
+	}
+
+	if err := setSessionRecord(secret, session); err != nil {
+		return err
+	}
+
+	if err := sr.kubernetesClient.Create(r.Context(), secret); err != nil {
+		return fmt.Errorf("recording session: %w", err)
+	}
+
+	sr.cache(session)
+
+	return nil
+}
+
+// Refresh adds the hash of newToken to the active session of oldToken, when
+// the OIDC tokens of a session are refreshed.
+func (sr *SessionRegistry) Refresh(ctx context.Context, oldToken, newToken string) error {
+	session, _, err := sr.find(ctx, oldToken)
+	if err != nil {
+		return err
+	}
+
+	if err := checkSessionStatus(session, oldToken != "", time.Now()); err != nil || session == nil {
+		return err
+	}
+
+	updated, err := updateSession(ctx, sr.kubernetesClient, sr.namespace, session.ID, func(session *SessionEntry) error {
+		session.TokenHashes = append(session.TokenHashes, hashToken(newToken))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	sr.cache(updated)
+
+	return nil
+}
+
+// End revokes the session of r when its user logs out, so its tokens can't
+// be used any more.
+func (sr *SessionRegistry) End(r *http.Request) error {
+	token, _ := sr.requestToken(r)
+	if token == "" {
+		return nil
+	}
+
+	session, _, err := sr.find(r.Context(), token)
+	if err != nil || session == nil {
+		return err
+	}
+
+	_, err = sr.Revoke(r.Context(), session.ID)
+
+	return err
+}
+
+// List returns the sessions that haven't expired, the most recent first.
+func (sr *SessionRegistry) List(ctx context.Context) ([]SessionEntry, error) {
+	sessions, err := sr.load(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return sortedSessions(sessions, time.Now()), nil
+}
+
+// Revoke revokes the session with id, which stops working straight away on
+// this replica.
+func (sr *SessionRegistry) Revoke(ctx context.Context, id string) (SessionEntry, error) {
+	session, err := RevokeSession(ctx, sr.kubernetesClient, sr.namespace, id)
+	if err != nil {
+		return SessionEntry{}, err
+	}
+
+	sr.cache(session)
+
+	return session, nil
+}
+
+// CheckSession is an implementation of the SessionChecker interface.
+//
+// The token of the request, from the session cookie or the Authorization
+// header, has to belong to an active session if it's in the session cookie,
+// was signed by Weave GitOps or is an ID token issued to Weave GitOps, like
+// the tokens of the CLI logins. Other bearer tokens, like API tokens or the
+// tokens passed through to Kubernetes, are let through unless they belong to
+// a session that's no longer active. The sessions of rejected requests are
+// destroyed so their cookie is cleared.
+func (sr *SessionRegistry) CheckSession(r *http.Request) error {
+	token, fromCookie := sr.requestToken(r)
+	if token == "" {
+		return nil
+	}
+
+	session, signed, err := sr.find(r.Context(), token)
+	if err != nil {
+		return err
+	}
+
+	if session == nil && !signed && !fromCookie && !sr.issuedToClient(token) {
+		return nil
+	}
+
+	now := time.Now()
+
+	if err := checkSessionStatus(session, true, now); err != nil {
+		if destroyErr := sr.sm.Destroy(r.Context()); destroyErr != nil {
+			sr.log.Error(destroyErr, "failed to destroy session")
+		}
+
+		return err
+	}
+
+	ip := sr.proxies.SourceIP(r)
+	if now.Sub(session.LastSeen) < sessionLastSeenInterval && session.IP == ip {
+		return nil
+	}
+
+	updated, err := updateSession(r.Context(), sr.kubernetesClient, sr.namespace, session.ID, func(session *SessionEntry) error {
+		session.LastSeen = now.UTC().Truncate(time.Second)
+		session.IP = ip
+
+		return nil
+	})
+	if err != nil {
+		// Not knowing when the session was last used doesn't make it invalid.
+		sr.log.Error(err, "failed to update the last seen time of session", "id", session.ID)
+		return nil
+	}
+
+	sr.cache(updated)
+
+	return nil
+}
+
+// checkSessionStatus returns why session isn't active, or nil. A missing
+// session is an error if required.
+func checkSessionStatus(session *SessionEntry, required bool, now time.Time) error {
+	switch {
+	case session == nil && required:
+		return ErrSessionNotFound
+	case session == nil:
+		return nil
+	case session.Status(now) == SessionStatusRevoked:
+		return fmt.Errorf("%w: %s", ErrSessionRevoked, session.ID)
+	case session.Status(now) == SessionStatusExpired:
+		return fmt.Errorf("%w: %s", ErrSessionExpired, session.ID)
+	}
+
+	return nil
+}
+
+// requestToken returns the token of r, from the session cookie or the
+// Authorization header, and whether it came from the cookie. API tokens
+// aren't sessions and are left out.
+func (sr *SessionRegistry) requestToken(r *http.Request) (string, bool) {
+	if token := sr.sm.GetString(r.Context(), IDTokenCookieName); token != "" {
+		return token, true
+	}
+
+	token := extractToken(r.Header.Get(AuthorizationTokenHeaderName))
+	if strings.HasPrefix(token, APITokenPrefix) {
+		return "", false
+	}
+
+	return token, false
+}
+
+// issuedToClient returns whether token is an ID token issued to one of the
+// OIDC clients of Weave GitOps. It isn't verified, tokens claiming to be
+// issued to them and failing verification are rejected by the principal
+// getters anyway.
+func (sr *SessionRegistry) issuedToClient(token string) bool {
+	if len(sr.clientIDs) == 0 {
+		return false
+	}
+
+	claims := jwt.RegisteredClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &claims); err != nil {
+		return false
+	}
+
+	return slices.ContainsFunc(claims.Audience, func(audience string) bool {
+		return slices.Contains(sr.clientIDs, audience)
+	})
+}
+
+// signedTokenID returns the session ID of a token signed by Weave GitOps.
+func (sr *SessionRegistry) signedTokenID(token string) (string, bool) {
+	claims, err := sr.verifier.Verify(token)
+	if err != nil || claims.ID == "" {
+		return "", false
+	}
+
+	return claims.ID, true
+}
+
+// find returns the session of token, nil if it's unknown, and whether the
+// token was signed by Weave GitOps. The sessions are read again when the
+// token isn't in the cache, at most every sessionMinRefresh.
+func (sr *SessionRegistry) find(ctx context.Context, token string) (*SessionEntry, bool, error) {
+	id, signed := sr.signedTokenID(token)
+	hash := hashToken(token)
+
+	lookup := func(sessions map[string]SessionEntry) *SessionEntry {
+		if signed {
+			if session, ok := sessions[id]; ok {
+				return &session
+			}
+
+			return nil
+		}
+
+		for _, session := range sessions {
+			if slices.Contains(session.TokenHashes, hash) {
+				return &session
+			}
+		}
+
+		return nil
+	}
+
+	sessions, err := sr.load(ctx, false)
+	if err != nil {
+		return nil, signed, err
+	}
+
+	if session := lookup(sessions); session != nil {
+		return session, signed, nil
+	}
+
+	sr.mu.Lock()
+	stale := time.Since(sr.loadedAt) >= sessionMinRefresh
+	sr.mu.Unlock()
+
+	if !stale {
+		return nil, signed, nil
+	}
+
+	sessions, err = sr.load(ctx, true)
+	if err != nil {
+		return nil, signed, err
+	}
+
+	return lookup(sessions), signed, nil
+}
+
+// load returns the sessions, from the cache unless it's stale or fresh is
+// set. The records of the expired sessions are deleted when they're read.
+func (sr *SessionRegistry) load(ctx context.Context, fresh bool) (map[string]SessionEntry, error) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	if !fresh && sr.sessions != nil && time.Since(sr.loadedAt) < sessionCacheTTL {
+		return sr.sessions, nil
+	}
+
+	sessions, err := listSessions(ctx, sr.kubernetesClient, sr.namespace)
+	if err != nil {
+		return nil, fmt.Errorf("loading sessions: %w", err)
+	}
+
+	now := time.Now()
+
+	for id, session := range sessions {
+		if session.Status(now) != SessionStatusExpired {
+			continue
+		}
+
+		delete(sessions, id)
+
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: sessionRecordName(id), Namespace: sr.namespace}}
+		if err := sr.kubernetesClient.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+			sr.log.Error(err, "failed to delete expired session", "id", id)
+		}
+	}
+
+	sr.sessions, sr.loadedAt = sessions, now
+
+	return sessions, nil
+}
+
+// cache updates session in the cached sessions, if they've been loaded.
+func (sr *SessionRegistry) cache(session SessionEntry) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	if sr.sessions != nil {
+		sr.sessions[session.ID] = session
+	}
+}
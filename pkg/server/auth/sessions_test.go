@@ -0,0 +1,327 @@
+package auth_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/weaveworks/weave-gitops/pkg/audit"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+func TestSessionRegistry(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := t.Context()
+
+	tsv, err := auth.NewHMACTokenSignerVerifier(time.Hour)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	k8s := fake.NewClientBuilder().Build()
+	sm := &fakeSessionManager{}
+	registry := auth.NewSessionRegistry(logr.Discard(), k8s, testNamespace, sm, tsv, nil, nil)
+
+	sessions, err := auth.ListSessions(ctx, k8s, testNamespace)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(sessions).To(BeEmpty())
+
+	req := httptest.NewRequest(http.MethodPost, "https://example.com/signin", nil)
+	req.RemoteAddr = "10.0.0.1:43210"
+
+	token, err := tsv.Sign("jane", "team-a")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	principal := &auth.UserPrincipal{ID: "jane", Groups: []string{"team-a"}}
+	g.Expect(registry.Start(req, principal, auth.UserAccount, time.Hour, token)).To(Succeed())
+
+	id := tokenID(g, tsv, token)
+
+	sessions, err = auth.ListSessions(ctx, k8s, testNamespace)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(sessions).To(HaveLen(1))
+	g.Expect(sessions[0].ID).To(Equal(id))
+	g.Expect(sessions[0].User).To(Equal("jane"))
+	g.Expect(sessions[0].Groups).To(Equal([]string{"team-a"}))
+	g.Expect(sessions[0].AuthMethod).To(Equal("user-account"))
+	g.Expect(sessions[0].IP).To(Equal("10.0.0.1"))
+	g.Expect(sessions[0].TokenHashes).To(BeEmpty())
+	g.Expect(sessions[0].Status(time.Now())).To(Equal(auth.SessionStatusActive))
+	g.Expect(sessions[0].Status(sessions[0].ExpiresAt)).To(Equal(auth.SessionStatusExpired))
+
+	unknown, err := tsv.Sign("jane", "team-a")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// Requests without a token, or with a bearer token that isn't a session's,
+	// are left to the principal getters.
+	g.Expect(registry.CheckSession(cookieRequest("", nil))).To(Succeed())
+	g.Expect(registry.CheckSession(bearerRequest("idp-token"))).To(Succeed())
+	g.Expect(registry.CheckSession(bearerRequest("wgo_api-token"))).To(Succeed())
+	g.Expect(registry.CheckSession(cookieRequest(token, nil))).To(Succeed())
+	g.Expect(registry.CheckSession(bearerRequest(token))).To(Succeed())
+	g.Expect(registry.CheckSession(cookieRequest(unknown, map[string]any{"sessionid": "unknown-session"}))).To(MatchError(auth.ErrSessionNotFound))
+	g.Expect(registry.CheckSession(bearerRequest(unknown))).To(MatchError(auth.ErrSessionNotFound))
+	g.Expect(registry.CheckSession(cookieRequest("idp-token", nil))).To(MatchError(auth.ErrSessionNotFound))
+
+	revoked, err := registry.Revoke(ctx, id)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(revoked.User).To(Equal("jane"))
+
+	_, err = registry.Revoke(ctx, "unknown")
+	g.Expect(err).To(MatchError(auth.ErrSessionNotFound))
+
+	g.Expect(registry.CheckSession(cookieRequest(token, map[string]any{"sessionid": "revoked-session"}))).To(MatchError(auth.ErrSessionRevoked))
+	// Replaying the token of a revoked session as a bearer token fails too.
+	g.Expect(registry.CheckSession(bearerRequest(token))).To(MatchError(auth.ErrSessionRevoked))
+	g.Expect(sm.Destroyed).To(Equal([]string{"unknown-session", "revoked-session"}))
+
+	sessions, err = registry.List(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(sessions[0].Status(time.Now())).To(Equal(auth.SessionStatusRevoked))
+}
+
+func TestSessionRegistryOIDCTokens(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := t.Context()
+
+	tsv, err := auth.NewHMACTokenSignerVerifier(time.Hour)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	k8s := fake.NewClientBuilder().Build()
+	registry := auth.NewSessionRegistry(logr.Discard(), k8s, testNamespace, &fakeSessionManager{}, tsv, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/oauth2/callback", nil)
+	g.Expect(registry.Start(req, &auth.UserPrincipal{ID: "jane"}, auth.OIDC, time.Hour, "id-token-1")).To(Succeed())
+
+	g.Expect(registry.CheckSession(cookieRequest("id-token-1", nil))).To(Succeed())
+	g.Expect(registry.Refresh(ctx, "id-token-1", "id-token-2")).To(Succeed())
+	g.Expect(registry.CheckSession(cookieRequest("id-token-2", nil))).To(Succeed())
+	g.Expect(registry.Refresh(ctx, "unknown", "id-token-3")).To(MatchError(auth.ErrSessionNotFound))
+
+	// Logging out revokes all the tokens of the session, even the ones
+	// replayed from the Authorization header.
+	g.Expect(registry.End(cookieRequest("id-token-2", nil))).To(Succeed())
+	g.Expect(registry.CheckSession(bearerRequest("id-token-1"))).To(MatchError(auth.ErrSessionRevoked))
+	g.Expect(registry.CheckSession(bearerRequest("id-token-2"))).To(MatchError(auth.ErrSessionRevoked))
+	g.Expect(registry.Refresh(ctx, "id-token-2", "id-token-3")).To(MatchError(auth.ErrSessionRevoked))
+
+	// Other replicas see the revocation once they read the sessions.
+	other := auth.NewSessionRegistry(logr.Discard(), k8s, testNamespace, &fakeSessionManager{}, tsv, nil, nil)
+	g.Expect(other.CheckSession(bearerRequest("id-token-2"))).To(MatchError(auth.ErrSessionRevoked))
+}
+
+func TestSessionRegistryDropsExpiredSessions(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := t.Context()
+
+	tsv, err := auth.NewHMACTokenSignerVerifier(time.Hour)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	k8s := fake.NewClientBuilder().Build()
+	registry := auth.NewSessionRegistry(logr.Discard(), k8s, testNamespace, &fakeSessionManager{}, tsv, nil, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "https://example.com/signin", nil)
+	g.Expect(registry.Start(req, &auth.UserPrincipal{ID: "old"}, auth.OIDC, -time.Minute, "old-token")).To(Succeed())
+	g.Expect(registry.Start(req, &auth.UserPrincipal{ID: "new"}, auth.OIDC, time.Hour, "new-token")).To(Succeed())
+
+	// Each session is recorded in its own Secret.
+	secrets := &corev1.SecretList{}
+	g.Expect(k8s.List(ctx, secrets, ctrlclient.HasLabels{auth.SessionRecordLabel})).To(Succeed())
+	g.Expect(secrets.Items).To(HaveLen(2))
+
+	sessions, err := auth.ListSessions(ctx, k8s, testNamespace)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(sessions).To(HaveLen(1))
+	g.Expect(sessions[0].User).To(Equal("new"))
+	g.Expect(sessions[0].AuthMethod).To(Equal("oidc"))
+
+	_, err = registry.List(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(k8s.List(ctx, secrets, ctrlclient.HasLabels{auth.SessionRecordLabel})).To(Succeed())
+	g.Expect(secrets.Items).To(HaveLen(1))
+}
+
+// tokenID returns the session ID of a token signed by tsv.
+func tokenID(g *WithT, tsv auth.TokenVerifier, token string) string {
+	claims, err := tsv.Verify(token)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(claims.ID).NotTo(BeEmpty())
+
+	return claims.ID
+}
+
+// cookieRequest returns a request with token in its session cookie, along
+// with the other session values.
+func cookieRequest(token string, values map[string]any) *http.Request {
+	if values == nil {
+		values = map[string]any{}
+	}
+
+	if token != "" {
+		values[auth.IDTokenCookieName] = token
+	}
+
+	return httptest.NewRequest(http.MethodGet, "https://example.com/v1/objects", nil).WithContext(contextWithSessionValues(values))
+}
+
+// bearerRequest returns a request with token in its Authorization header.
+func bearerRequest(token string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/v1/objects", nil).WithContext(contextWithSessionValues(nil))
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	return req
+}
+
+// newSessionsAdminClient returns a client allowing the admins to manage the
+// sessions in its SubjectAccessReviews.
+func newSessionsAdminClient(admins ...string) ctrlclient.Client {
+	return fake.NewClientBuilder().
+		WithObjects(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: auth.ClusterUserAuthSecretName, Namespace: testNamespace},
+		}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, client ctrlclient.WithWatch, obj ctrlclient.Object, opts ...ctrlclient.CreateOption) error {
+				if review, ok := obj.(*authorizationv1.SubjectAccessReview); ok {
+					attrs := review.Spec.ResourceAttributes
+					review.Status.Allowed = slices.Contains(admins, review.Spec.User) &&
+						attrs.Resource == "secrets" && attrs.Name == "" && attrs.Namespace == testNamespace
+
+					return nil
+				}
+
+				return client.Create(ctx, obj, opts...)
+			},
+		}).
+		Build()
+}
+
+func TestSessionsAPI(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tsv, err := auth.NewHMACTokenSignerVerifier(5 * time.Minute)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	k8s := newSessionsAdminClient("admin")
+	sm := &fakeSessionManager{}
+	sink := &recordingSink{}
+
+	s, _ := makeAuthServer(t, k8s, tsv, []auth.AuthMethod{auth.UserAccount}, sm)
+	s.Sessions = auth.NewSessionRegistry(logr.Discard(), k8s, testNamespace, sm, tsv, nil, nil)
+	s.Audit = audit.NewRecorder(logr.Discard(), sink)
+
+	token, err := tsv.Sign("jane")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(s.Sessions.Start(httptest.NewRequest(http.MethodPost, "https://example.com/signin", nil),
+		&auth.UserPrincipal{ID: "jane"}, auth.UserAccount, time.Hour, token)).To(Succeed())
+	id := tokenID(g, tsv, token)
+
+	asUser := func(req *http.Request, user string) *http.Request {
+		return req.WithContext(auth.WithPrincipal(req.Context(), &auth.UserPrincipal{ID: user}))
+	}
+
+	w := httptest.NewRecorder()
+	s.ListSessions(w, asUser(httptest.NewRequest(http.MethodGet, "https://example.com/oauth2/sessions", nil), "jane"))
+	g.Expect(w.Code).To(Equal(http.StatusForbidden))
+
+	w = httptest.NewRecorder()
+	s.ListSessions(w, asUser(httptest.NewRequest(http.MethodGet, "https://example.com/oauth2/sessions", nil), "admin"))
+	g.Expect(w.Code).To(Equal(http.StatusOK))
+
+	var sessions []auth.SessionEntry
+	g.Expect(json.Unmarshal(w.Body.Bytes(), &sessions)).To(Succeed())
+	g.Expect(sessions).To(HaveLen(1))
+	g.Expect(sessions[0].ID).To(Equal(id))
+	g.Expect(sessions[0].User).To(Equal("jane"))
+
+	revoke := func(user, id string) int {
+		body, _ := json.Marshal(auth.RevokeSessionRequest{ID: id})
+		w := httptest.NewRecorder()
+		s.RevokeSession(w, asUser(httptest.NewRequest(http.MethodPost, "https://example.com/oauth2/sessions/revoke", bytes.NewReader(body)), user))
+
+		return w.Code
+	}
+
+	g.Expect(revoke("jane", id)).To(Equal(http.StatusForbidden))
+	g.Expect(revoke("admin", "unknown")).To(Equal(http.StatusNotFound))
+	g.Expect(revoke("admin", id)).To(Equal(http.StatusOK))
+
+	g.Expect(sink.events).To(HaveLen(2))
+	g.Expect(sink.events[1]).To(Equal(audit.Event{
+		Type:      audit.EventTypeAuth,
+		Action:    "RevokeSession",
+		Principal: "admin",
+		SourceIP:  "192.0.2.1",
+		Outcome:   audit.OutcomeSuccess,
+		Details:   map[string]string{"session": id, "user": "jane"},
+	}))
+
+	sessions, err = auth.ListSessions(t.Context(), k8s, testNamespace)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(sessions[0].Status(time.Now())).To(Equal(auth.SessionStatusRevoked))
+}
+
+func TestSessionsAPIWithoutTracking(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tsv, err := auth.NewHMACTokenSignerVerifier(5 * time.Minute)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s, _ := makeAuthServer(t, newSessionsAdminClient("admin"), tsv, []auth.AuthMethod{auth.UserAccount}, &fakeSessionManager{})
+
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/oauth2/sessions", nil)
+	w := httptest.NewRecorder()
+	s.ListSessions(w, req.WithContext(auth.WithPrincipal(req.Context(), &auth.UserPrincipal{ID: "admin"})))
+	g.Expect(w.Code).To(Equal(http.StatusNotFound))
+}
+
+func TestWithAPIAuthRejectsRevokedSessions(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tsv, err := auth.NewHMACTokenSignerVerifier(5 * time.Minute)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	token, err := tsv.Sign("jane", "team-a")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	k8s := newSessionsAdminClient()
+	sm := &fakeSessionManager{}
+
+	s, _ := makeAuthServer(t, k8s, tsv, []auth.AuthMethod{auth.UserAccount}, sm)
+	s.Sessions = auth.NewSessionRegistry(logr.Discard(), k8s, testNamespace, sm, tsv, nil, nil)
+
+	g.Expect(s.Sessions.Start(httptest.NewRequest(http.MethodPost, "https://example.com/signin", nil),
+		&auth.UserPrincipal{ID: "jane"}, auth.UserAccount, time.Hour, token)).To(Succeed())
+	id := tokenID(g, tsv, token)
+
+	handler := auth.WithAPIAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), s, nil, sm)
+
+	get := func(req *http.Request) int {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		return w.Code
+	}
+
+	g.Expect(get(cookieRequest(token, nil))).To(Equal(http.StatusOK))
+
+	_, err = s.Sessions.Revoke(t.Context(), id)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(get(cookieRequest(token, nil))).To(Equal(http.StatusUnauthorized))
+	g.Expect(get(bearerRequest(token))).To(Equal(http.StatusUnauthorized))
+}
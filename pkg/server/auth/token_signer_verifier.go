@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -11,10 +12,11 @@ import (
 
 type AdminClaims struct {
 	jwt.RegisteredClaims
+	Groups []string `json:"groups,omitempty"`
 }
 
 type TokenSigner interface {
-	Sign(subject string) (string, error)
+	Sign(subject string, groups ...string) (string, error)
 }
 
 type TokenVerifier interface {
@@ -47,14 +49,18 @@ func NewHMACTokenSignerVerifier(expireAfter time.Duration) (*HMACTokenSignerVeri
 	}, nil
 }
 
-func (sv *HMACTokenSignerVerifier) Sign(subject string) (string, error) {
+func (sv *HMACTokenSignerVerifier) Sign(subject string, groups ...string) (string, error) {
 	claims := AdminClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
 			IssuedAt:  jwt.NewNumericDate(time.Now().UTC()),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(sv.expireAfter).UTC()),
 			NotBefore: jwt.NewNumericDate(time.Now().UTC()),
 			Subject:   subject,
+			// The ID binds the token to its session, so revoking the
+			// session rejects the token.
+			ID: strings.ToLower(rand.Text()),
 		},
+		Groups: groups,
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
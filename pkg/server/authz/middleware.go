@@ -0,0 +1,243 @@
+package authz
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+
+	"github.com/go-logr/logr"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	pb "github.com/weaveworks/weave-gitops/pkg/api/core"
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+// route is an API method served by the gateway.
+type route struct {
+	rpc   string
+	input protoreflect.MessageType
+	// body is set if the request is in the body rather than the query.
+	body bool
+}
+
+var pathVariableRegexp = regexp.MustCompile(`\{([^}=]+)\}`)
+
+// coreRoutes returns the routes of the Core API methods, keyed by the HTTP
+// method and the path template as the gateway prints its patterns.
+func coreRoutes() (map[string]route, error) {
+	service := pb.File_api_core_core_proto.Services().ByName("Core")
+	if service == nil {
+		return nil, errors.New("core service not found")
+	}
+
+	routes := map[string]route{}
+
+	for i := 0; i < service.Methods().Len(); i++ {
+		method := service.Methods().Get(i)
+
+		rule, ok := proto.GetExtension(method.Options(), annotations.E_Http).(*annotations.HttpRule)
+		if !ok || rule == nil {
+			continue
+		}
+
+		input, err := protoregistry.GlobalTypes.FindMessageByName(method.Input().FullName())
+		if err != nil {
+			return nil, fmt.Errorf("finding the request type of %s: %w", method.Name(), err)
+		}
+
+		httpMethod, template := http.MethodGet, rule.GetGet()
+		if rule.GetPost() != "" {
+			httpMethod, template = http.MethodPost, rule.GetPost()
+		}
+
+		key := httpMethod + " " + pathVariableRegexp.ReplaceAllString(template, "{$1=*}")
+		routes[key] = route{
+			rpc:   string(method.Name()),
+			input: input,
+			body:  rule.GetBody() != "",
+		}
+	}
+
+	return routes, nil
+}
+
+// WithPolicy returns a ServeMuxOption evaluating policy before the handlers
+// of the Core API methods run. Calls without a principal, on the public
+// routes, aren't checked.
+func WithPolicy(log logr.Logger, policy *Policy) (runtime.ServeMuxOption, error) {
+	routes, err := coreRoutes()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(mux *runtime.ServeMux) {
+		runtime.WithMiddlewares(policyMiddleware(log, policy, routes, mux))(mux)
+	}, nil
+}
+
+func policyMiddleware(log logr.Logger, policy *Policy, routes map[string]route, mux *runtime.ServeMux) runtime.Middleware {
+	return func(next runtime.HandlerFunc) runtime.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+			principal := auth.Principal(r.Context())
+			pattern, ok := runtime.HTTPPattern(r.Context())
+
+			if principal == nil || !ok {
+				next(w, r, pathParams)
+				return
+			}
+
+			rt, ok := routes[r.Method+" "+pattern.String()]
+			if !ok {
+				next(w, r, pathParams)
+				return
+			}
+
+			inbound, outbound := runtime.MarshalerForRequest(mux, r)
+
+			targets, err := requestTargets(r, inbound, rt, pathParams)
+			if err != nil {
+				runtime.HTTPError(r.Context(), mux, outbound, w, r, status.Error(codes.InvalidArgument, err.Error()))
+				return
+			}
+
+			decision := policy.Evaluate(principal, rt.rpc, targets)
+			if !decision.Allowed {
+				log.Info("Call denied by the authorization policy", "user", principal.ID, "rpc", rt.rpc, "rule", decision.Rule)
+				runtime.HTTPError(r.Context(), mux, outbound, w, r, status.Errorf(codes.PermissionDenied, "%s %s", rt.rpc, ErrDenied))
+
+				return
+			}
+
+			next(w, r, pathParams)
+		}
+	}
+}
+
+// requestTargets decodes the request the same way the gateway does, leaving
+// the body for the handler to read again, and returns its targets.
+func requestTargets(r *http.Request, inbound runtime.Marshaler, rt route, pathParams map[string]string) ([]Target, error) {
+	msg := rt.input.New().Interface()
+
+	if rt.body {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		if err := inbound.NewDecoder(bytes.NewReader(body)).Decode(msg); err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+	} else if err := runtime.PopulateQueryParameters(msg, r.URL.Query(), utilities.NewDoubleArray(nil)); err != nil {
+		return nil, err
+	}
+
+	for name, value := range pathParams {
+		if err := runtime.PopulateFieldFromPath(msg, name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return messageTargets(msg.ProtoReflect()), nil
+}
+
+// messageTargets returns the target of a request, from its cluster,
+// namespace and kind fields, and the targets of the objects it refers to,
+// like the objects to sync.
+func messageTargets(m protoreflect.Message) []Target {
+	targets := []Target{}
+
+	if target, ok := messageTarget(m); ok {
+		targets = append(targets, target)
+	}
+
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.Kind() != protoreflect.MessageKind || fd.IsMap() {
+			return true
+		}
+
+		if fd.IsList() {
+			for i := 0; i < v.List().Len(); i++ {
+				targets = append(targets, objectTargets(v.List().Get(i).Message())...)
+			}
+		} else {
+			targets = append(targets, objectTargets(v.Message())...)
+		}
+
+		return true
+	})
+
+	return targets
+}
+
+// objectTargets returns the targets of the messages nested in a request,
+// which are only objects if they have a namespace. Their empty fields don't
+// reach everything, like the namespace of a cluster-scoped object.
+func objectTargets(m protoreflect.Message) []Target {
+	if m.Descriptor().Fields().ByName("namespace") == nil {
+		return nil
+	}
+
+	targets := messageTargets(m)
+	for i := range targets {
+		targets[i].AllClusters, targets[i].AllNamespaces, targets[i].AllKinds = false, false, false
+	}
+
+	return targets
+}
+
+// messageTarget returns the target made of the fields of m, if it has any.
+// The fields m has but leaves empty reach everything.
+func messageTarget(m protoreflect.Message) (Target, bool) {
+	var (
+		target Target
+		found  bool
+	)
+
+	stringField := func(names ...protoreflect.Name) (string, bool) {
+		has := false
+
+		for _, name := range names {
+			if fd := m.Descriptor().Fields().ByName(name); fd != nil && fd.Kind() == protoreflect.StringKind && !fd.IsList() {
+				found, has = true, true
+
+				if value := m.Get(fd).String(); value != "" {
+					return value, true
+				}
+			}
+		}
+
+		return "", has
+	}
+
+	var hasCluster, hasNamespace, hasKind bool
+
+	target.Cluster, hasCluster = stringField("cluster_name", "cluster")
+	target.Namespace, hasNamespace = stringField("namespace")
+	target.Kind, hasKind = stringField("kind", "automation_kind")
+
+	if fd := m.Descriptor().Fields().ByName("group_version_kind"); target.Kind == "" && fd != nil && fd.Kind() == protoreflect.MessageKind {
+		found, hasKind = true, true
+
+		if kind := m.Get(fd).Message().Descriptor().Fields().ByName("kind"); kind != nil && m.Has(fd) {
+			target.Kind = m.Get(fd).Message().Get(kind).String()
+		}
+	}
+
+	target.AllClusters = hasCluster && target.Cluster == ""
+	target.AllNamespaces = hasNamespace && target.Namespace == ""
+	target.AllKinds = hasKind && target.Kind == ""
+
+	return target, found
+}
@@ -0,0 +1,177 @@
+package authz_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+	"github.com/weaveworks/weave-gitops/pkg/server/authz"
+)
+
+func newPolicyServer(t *testing.T, policy *authz.Policy, principal *auth.UserPrincipal) *httptest.Server {
+	t.Helper()
+
+	opt, err := authz.WithPolicy(logr.Discard(), policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mux := runtime.NewServeMux(opt)
+
+	// The handlers echo the body to check it's still there once the policy
+	// has read it.
+	echo := func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	}
+
+	for _, route := range []struct{ method, path string }{
+		{http.MethodGet, "/v1/object/{name}"},
+		{http.MethodPost, "/v1/objects"},
+		{http.MethodPost, "/v1/sync"},
+		{http.MethodGet, "/v1/featureflags"},
+		{http.MethodGet, "/v1/unknown"},
+	} {
+		if err := mux.HandlePath(route.method, route.path, echo); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var handler http.Handler = mux
+	if principal != nil {
+		handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mux.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+		})
+	}
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestWithPolicy(t *testing.T) {
+	policy, err := authz.ParsePolicy([]byte(testPolicy))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+	}{
+		{
+			name:       "get object allowed",
+			method:     http.MethodGet,
+			path:       "/v1/object/podinfo?namespace=default&kind=Secret&clusterName=dev",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "get object denied by the query",
+			method:     http.MethodGet,
+			path:       "/v1/object/podinfo?namespace=default&kind=Secret&clusterName=prod-eu",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "list objects denied by the body",
+			method:     http.MethodPost,
+			path:       "/v1/objects",
+			body:       `{"kind": "Secret", "clusterName": "prod-us"}`,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "list objects in every cluster denied",
+			method:     http.MethodPost,
+			path:       "/v1/objects",
+			body:       `{"kind": "Secret"}`,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "list objects in every cluster allowed",
+			method:     http.MethodPost,
+			path:       "/v1/objects",
+			body:       `{"kind": "Kustomization"}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "sync allowed",
+			method:     http.MethodPost,
+			path:       "/v1/sync",
+			body:       `{"objects": [{"kind": "Kustomization", "name": "a", "namespace": "default", "clusterName": "dev"}]}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "sync denied for one of the objects",
+			method: http.MethodPost,
+			path:   "/v1/sync",
+			body: `{"objects": [
+				{"kind": "Kustomization", "name": "a", "namespace": "default", "clusterName": "dev"},
+				{"kind": "Kustomization", "name": "flux-system", "namespace": "flux-system", "clusterName": "dev"}
+			]}`,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "invalid body",
+			method:     http.MethodPost,
+			path:       "/v1/sync",
+			body:       `{"objects": 1}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "route not in the API",
+			method:     http.MethodGet,
+			path:       "/v1/unknown",
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	srv := newPolicyServer(t, policy, &auth.UserPrincipal{ID: "joe", Groups: []string{"devs"}})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			req, err := http.NewRequest(tt.method, srv.URL+tt.path, strings.NewReader(tt.body))
+			g.Expect(err).NotTo(HaveOccurred())
+
+			res, err := http.DefaultClient.Do(req)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			defer res.Body.Close()
+
+			body, err := io.ReadAll(res.Body)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(res.StatusCode).To(Equal(tt.wantStatus), string(body))
+
+			if tt.wantStatus == http.StatusOK {
+				g.Expect(string(body)).To(Equal(tt.body))
+			}
+		})
+	}
+}
+
+func TestWithPolicyNoPrincipal(t *testing.T) {
+	g := NewWithT(t)
+
+	policy, err := authz.ParsePolicy([]byte(`defaultEffect: deny`))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	srv := newPolicyServer(t, policy, nil)
+
+	res, err := http.Get(srv.URL + "/v1/featureflags")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	defer res.Body.Close()
+
+	g.Expect(res.StatusCode).To(Equal(http.StatusOK))
+}
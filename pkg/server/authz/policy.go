@@ -0,0 +1,213 @@
+// Package authz decides which API methods users can call on which objects,
+// on top of the Kubernetes RBAC they're impersonated with. It lets a group
+// view objects but not sync them, without changing their RBAC.
+package authz
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+)
+
+// The effects of rules.
+const (
+	EffectAllow = "allow"
+	EffectDeny  = "deny"
+)
+
+// Policy is a list of rules, the first rule matching a call decides whether
+// it's allowed. Calls no rule matches get the default effect.
+type Policy struct {
+	DefaultEffect string `json:"defaultEffect"`
+	Rules         []Rule `json:"rules"`
+}
+
+// Rule allows or denies calls matching all of its fields. The fields are
+// lists of glob patterns, an empty list matching anything. A call on several
+// objects, like syncing them, is only allowed if it's allowed for each of
+// them.
+type Rule struct {
+	// Name describes the rule, it's reported when it denies a call.
+	Name   string `json:"name,omitempty"`
+	Effect string `json:"effect"`
+	// Users and Groups match the principal, any of its groups matching the
+	// Groups patterns.
+	Users  []string `json:"users,omitempty"`
+	Groups []string `json:"groups,omitempty"`
+	// RPCs match the names of the API methods, like SyncFluxObject.
+	RPCs       []string `json:"rpcs,omitempty"`
+	Clusters   []string `json:"clusters,omitempty"`
+	Namespaces []string `json:"namespaces,omitempty"`
+	Kinds      []string `json:"kinds,omitempty"`
+}
+
+// Target is an object a call is about. The fields the request doesn't have
+// are empty and only match rules that don't restrict them.
+//
+// The fields the request has but leaves empty, like the namespace of a call
+// on all namespaces, reach every cluster, namespace or kind. Deny rules
+// restricting them match, as the call could reach what they deny, while allow
+// rules restricting them don't.
+type Target struct {
+	Cluster   string
+	Namespace string
+	Kind      string
+
+	AllClusters   bool
+	AllNamespaces bool
+	AllKinds      bool
+}
+
+// Decision is the outcome of evaluating a policy.
+type Decision struct {
+	Allowed bool
+	// Rule is the name of the rule that matched, empty if the default effect
+	// applied.
+	Rule string
+}
+
+// LoadPolicy reads a policy from a YAML file.
+func LoadPolicy(filename string) (*Policy, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("reading authorization policy: %w", err)
+	}
+
+	return ParsePolicy(data)
+}
+
+// ParsePolicy parses and validates a YAML policy.
+func ParsePolicy(data []byte) (*Policy, error) {
+	policy := &Policy{}
+	if err := yaml.UnmarshalStrict(data, policy); err != nil {
+		return nil, fmt.Errorf("parsing authorization policy: %w", err)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// Validate checks the effects and patterns of the policy.
+func (p *Policy) Validate() error {
+	if !validEffect(p.DefaultEffect) {
+		return fmt.Errorf("invalid authorization policy default effect %q, must be %s or %s", p.DefaultEffect, EffectAllow, EffectDeny)
+	}
+
+	for i, rule := range p.Rules {
+		if !validEffect(rule.Effect) {
+			return fmt.Errorf("invalid effect %q in authorization rule %d, must be %s or %s", rule.Effect, i, EffectAllow, EffectDeny)
+		}
+
+		for _, patterns := range [][]string{rule.Users, rule.Groups, rule.RPCs, rule.Clusters, rule.Namespaces, rule.Kinds} {
+			for _, pattern := range patterns {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return fmt.Errorf("invalid pattern %q in authorization rule %d: %w", pattern, i, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func validEffect(effect string) bool {
+	return effect == EffectAllow || effect == EffectDeny
+}
+
+// Evaluate decides whether principal can call rpc on targets, denying the
+// call if it's denied for any of them.
+func (p *Policy) Evaluate(principal *auth.UserPrincipal, rpc string, targets []Target) Decision {
+	if len(targets) == 0 {
+		targets = []Target{{}}
+	}
+
+	decision := Decision{Allowed: true}
+
+	for _, target := range targets {
+		decision = p.evaluateTarget(principal, rpc, target)
+		if !decision.Allowed {
+			return decision
+		}
+	}
+
+	return decision
+}
+
+func (p *Policy) evaluateTarget(principal *auth.UserPrincipal, rpc string, target Target) Decision {
+	for i, rule := range p.Rules {
+		if !rule.matches(principal, rpc, target) {
+			continue
+		}
+
+		name := rule.Name
+		if name == "" {
+			name = fmt.Sprintf("rule %d", i)
+		}
+
+		return Decision{Allowed: rule.Effect == EffectAllow, Rule: name}
+	}
+
+	return Decision{Allowed: p.DefaultEffect == EffectAllow}
+}
+
+func (r Rule) matches(principal *auth.UserPrincipal, rpc string, target Target) bool {
+	if !matchAny(r.Users, principal.ID) || !matchAny(r.RPCs, rpc) {
+		return false
+	}
+
+	if len(r.Groups) > 0 && !slices.ContainsFunc(principal.Groups, func(group string) bool {
+		return matchAny(r.Groups, group)
+	}) {
+		return false
+	}
+
+	deny := r.Effect == EffectDeny
+
+	return matchTarget(r.Clusters, target.Cluster, deny && target.AllClusters) &&
+		matchTarget(r.Namespaces, target.Namespace, deny && target.AllNamespaces) &&
+		matchTarget(r.Kinds, target.Kind, deny && target.AllKinds)
+}
+
+// matchTarget returns whether value matches any of the patterns, or true if
+// there are none or all values are matched.
+func matchTarget(patterns []string, value string, all bool) bool {
+	if len(patterns) > 0 && all {
+		return true
+	}
+
+	return matchAny(patterns, value)
+}
+
+// matchAny returns whether value matches any of the patterns, or true if
+// there are none.
+func matchAny(patterns []string, value string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+
+	return slices.ContainsFunc(patterns, func(pattern string) bool {
+		return matchPattern(pattern, value)
+	})
+}
+
+// matchPattern returns whether value matches the glob pattern, where unlike
+// path.Match the wildcards match "/" too, as in the names of Cluster API
+// clusters, like default/prod-eu. The slashes are swapped for a byte that
+// can't be in a pattern or a name before matching.
+func matchPattern(pattern, value string) bool {
+	matched, err := path.Match(strings.ReplaceAll(pattern, "/", "\x00"), strings.ReplaceAll(value, "/", "\x00"))
+	return err == nil && matched
+}
+
+// ErrDenied is returned for calls the policy denies.
+var ErrDenied = errors.New("denied by the authorization policy")
@@ -0,0 +1,241 @@
+package authz_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+	"github.com/weaveworks/weave-gitops/pkg/server/authz"
+)
+
+const testPolicy = `
+defaultEffect: allow
+rules:
+  - name: viewers can't change objects
+    effect: deny
+    groups: ["viewers"]
+    rpcs: ["SyncFluxObject", "ToggleSuspendResource"]
+  - name: no secrets in production
+    effect: deny
+    rpcs: ["GetObject", "ListObjects"]
+    clusters: ["prod-*"]
+    kinds: ["Secret"]
+  - name: platform team syncs flux-system
+    effect: allow
+    groups: ["platform"]
+    namespaces: ["flux-system"]
+  - name: nobody else syncs flux-system
+    effect: deny
+    rpcs: ["SyncFluxObject"]
+    namespaces: ["flux-system"]
+`
+
+func TestPolicyEvaluate(t *testing.T) {
+	policy, err := authz.ParsePolicy([]byte(testPolicy))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	viewer := &auth.UserPrincipal{ID: "jane", Groups: []string{"viewers"}}
+	dev := &auth.UserPrincipal{ID: "joe", Groups: []string{"devs"}}
+	platform := &auth.UserPrincipal{ID: "kim", Groups: []string{"devs", "platform"}}
+
+	tests := []struct {
+		name      string
+		principal *auth.UserPrincipal
+		rpc       string
+		targets   []authz.Target
+		allowed   bool
+		rule      string
+	}{
+		{
+			name:      "viewer lists objects",
+			principal: viewer,
+			rpc:       "ListObjects",
+			targets:   []authz.Target{{Cluster: "dev", Kind: "Kustomization"}},
+			allowed:   true,
+		},
+		{
+			name:      "viewer syncs",
+			principal: viewer,
+			rpc:       "SyncFluxObject",
+			targets:   []authz.Target{{Cluster: "dev", Namespace: "default", Kind: "Kustomization"}},
+			rule:      "viewers can't change objects",
+		},
+		{
+			name:      "secret in production",
+			principal: platform,
+			rpc:       "GetObject",
+			targets:   []authz.Target{{Cluster: "prod-eu", Namespace: "default", Kind: "Secret"}},
+			rule:      "no secrets in production",
+		},
+		{
+			name:      "secret in another cluster",
+			principal: dev,
+			rpc:       "GetObject",
+			targets:   []authz.Target{{Cluster: "dev", Namespace: "default", Kind: "Secret"}},
+			allowed:   true,
+		},
+		{
+			name:      "platform syncs flux-system",
+			principal: platform,
+			rpc:       "SyncFluxObject",
+			targets:   []authz.Target{{Cluster: "dev", Namespace: "flux-system", Kind: "Kustomization"}},
+			allowed:   true,
+			rule:      "platform team syncs flux-system",
+		},
+		{
+			name:      "dev syncs flux-system among other objects",
+			principal: dev,
+			rpc:       "SyncFluxObject",
+			targets: []authz.Target{
+				{Cluster: "dev", Namespace: "default", Kind: "Kustomization"},
+				{Cluster: "dev", Namespace: "flux-system", Kind: "Kustomization"},
+			},
+			rule: "nobody else syncs flux-system",
+		},
+		{
+			name:      "secrets in every cluster",
+			principal: dev,
+			rpc:       "ListObjects",
+			targets:   []authz.Target{{Kind: "Secret", AllClusters: true, AllNamespaces: true}},
+			rule:      "no secrets in production",
+		},
+		{
+			name:      "any kind in production",
+			principal: dev,
+			rpc:       "ListObjects",
+			targets:   []authz.Target{{Cluster: "prod-eu", AllNamespaces: true, AllKinds: true}},
+			rule:      "no secrets in production",
+		},
+		{
+			name:      "platform syncs every namespace",
+			principal: platform,
+			rpc:       "SyncFluxObject",
+			targets:   []authz.Target{{Cluster: "dev", Kind: "Kustomization", AllNamespaces: true}},
+			rule:      "nobody else syncs flux-system",
+		},
+		{
+			name:      "no targets",
+			principal: viewer,
+			rpc:       "ToggleSuspendResource",
+			rule:      "viewers can't change objects",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			decision := policy.Evaluate(tt.principal, tt.rpc, tt.targets)
+			g.Expect(decision).To(Equal(authz.Decision{Allowed: tt.allowed, Rule: tt.rule}))
+		})
+	}
+}
+
+func TestPolicyDefaultDeny(t *testing.T) {
+	g := NewWithT(t)
+
+	policy, err := authz.ParsePolicy([]byte(`
+defaultEffect: deny
+rules:
+  - effect: allow
+    users: ["*@example.com"]
+    rpcs: ["List*"]
+`))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(policy.Evaluate(&auth.UserPrincipal{ID: "jane@example.com"}, "ListObjects", nil)).
+		To(Equal(authz.Decision{Allowed: true, Rule: "rule 0"}))
+	g.Expect(policy.Evaluate(&auth.UserPrincipal{ID: "jane@example.com"}, "SyncFluxObject", nil).Allowed).To(BeFalse())
+	g.Expect(policy.Evaluate(&auth.UserPrincipal{ID: "jane@example.org"}, "ListObjects", nil).Allowed).To(BeFalse())
+}
+
+func TestPolicyClusterAPIClusters(t *testing.T) {
+	g := NewWithT(t)
+
+	policy, err := authz.ParsePolicy([]byte(`
+defaultEffect: deny
+rules:
+  - name: no production
+    effect: deny
+    clusters: ["*/prod-*"]
+  - name: staging namespace
+    effect: allow
+    clusters: ["staging/*"]
+  - name: any cluster
+    effect: allow
+    users: ["jane"]
+    clusters: ["*"]
+`))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	jane := &auth.UserPrincipal{ID: "jane"}
+	joe := &auth.UserPrincipal{ID: "joe"}
+
+	evaluate := func(principal *auth.UserPrincipal, cluster string) authz.Decision {
+		return policy.Evaluate(principal, "ListObjects", []authz.Target{{Cluster: cluster, AllNamespaces: true, AllKinds: true}})
+	}
+
+	g.Expect(evaluate(jane, "default/prod-eu")).To(Equal(authz.Decision{Rule: "no production"}))
+	g.Expect(evaluate(jane, "team/apps/prod-eu")).To(Equal(authz.Decision{Rule: "no production"}))
+	g.Expect(evaluate(jane, "default/dev-eu")).To(Equal(authz.Decision{Allowed: true, Rule: "any cluster"}))
+	g.Expect(evaluate(jane, "Default")).To(Equal(authz.Decision{Allowed: true, Rule: "any cluster"}))
+	g.Expect(evaluate(joe, "staging/eu")).To(Equal(authz.Decision{Allowed: true, Rule: "staging namespace"}))
+	g.Expect(evaluate(joe, "default/dev-eu").Allowed).To(BeFalse())
+}
+
+func TestParsePolicyErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  string
+		wantErr string
+	}{
+		{
+			name:    "missing default effect",
+			policy:  `rules: []`,
+			wantErr: `invalid authorization policy default effect ""`,
+		},
+		{
+			name:    "invalid rule effect",
+			policy:  "defaultEffect: allow\nrules:\n  - effect: permit",
+			wantErr: `invalid effect "permit" in authorization rule 0`,
+		},
+		{
+			name:    "invalid pattern",
+			policy:  "defaultEffect: allow\nrules:\n  - effect: deny\n    namespaces: [\"[\"]",
+			wantErr: `invalid pattern "[" in authorization rule 0`,
+		},
+		{
+			name:    "unknown field",
+			policy:  "defaultEffect: allow\nrules:\n  - effect: deny\n    namespace: [default]",
+			wantErr: `unknown field "namespace"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			_, err := authz.ParsePolicy([]byte(tt.policy))
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	g := NewWithT(t)
+
+	filename := filepath.Join(t.TempDir(), "policy.yaml")
+	g.Expect(os.WriteFile(filename, []byte(testPolicy), 0o600)).To(Succeed())
+
+	policy, err := authz.LoadPolicy(filename)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(policy.Rules).To(HaveLen(4))
+
+	_, err = authz.LoadPolicy(filepath.Join(t.TempDir(), "missing.yaml"))
+	g.Expect(err).To(HaveOccurred())
+}
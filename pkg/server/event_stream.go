@@ -0,0 +1,45 @@
+package server
+
+import (
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const eventStreamMIME = "text/event-stream"
+
+// eventStreamMarshaler encodes streaming responses as server-sent events.
+// It is picked by the gateway when the client sends `Accept: text/event-stream`,
+// e.g. when using an EventSource in the browser.
+type eventStreamMarshaler struct {
+	runtime.JSONPb
+}
+
+func newEventStreamMarshaler() *eventStreamMarshaler {
+	return &eventStreamMarshaler{
+		JSONPb: runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{
+				EmitUnpopulated: true,
+			},
+			UnmarshalOptions: protojson.UnmarshalOptions{
+				DiscardUnknown: true,
+			},
+		},
+	}
+}
+
+func (m *eventStreamMarshaler) ContentType(_ interface{}) string {
+	return eventStreamMIME
+}
+
+func (m *eventStreamMarshaler) Marshal(v interface{}) ([]byte, error) {
+	data, err := m.JSONPb.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte("data: "), data...), nil
+}
+
+func (m *eventStreamMarshaler) Delimiter() []byte {
+	return []byte("\n\n")
+}
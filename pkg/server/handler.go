@@ -10,6 +10,7 @@ import (
 
 	core "github.com/weaveworks/weave-gitops/core/server"
 	"github.com/weaveworks/weave-gitops/pkg/server/auth"
+	"github.com/weaveworks/weave-gitops/pkg/server/authz"
 	"github.com/weaveworks/weave-gitops/pkg/server/middleware"
 )
 
@@ -20,12 +21,29 @@ var PublicRoutes = []string{
 type Config struct {
 	CoreServerConfig core.CoreServerConfig
 	AuthServer       *auth.AuthServer
+	// AuthorizationPolicy restricts the API calls users can make, on top of
+	// the Kubernetes RBAC of the impersonated user. Optional.
+	AuthorizationPolicy *authz.Policy
 }
 
 // NewHandlers creates and returns a new server configured to serve the core
 // application.
 func NewHandlers(ctx context.Context, log logr.Logger, cfg *Config, sm auth.SessionManager) (http.Handler, error) {
-	mux := runtime.NewServeMux(middleware.WithGrpcErrorLogging(log))
+	opts := []runtime.ServeMuxOption{
+		middleware.WithGrpcErrorLogging(log),
+		runtime.WithMarshalerOption(eventStreamMIME, newEventStreamMarshaler()),
+	}
+
+	if cfg.AuthorizationPolicy != nil {
+		policyOpt, err := authz.WithPolicy(log.WithName("authz"), cfg.AuthorizationPolicy)
+		if err != nil {
+			return nil, fmt.Errorf("could not set up the authorization policy: %w", err)
+		}
+
+		opts = append(opts, policyOpt)
+	}
+
+	mux := runtime.NewServeMux(opts...)
 
 	if err := core.Hydrate(ctx, mux, cfg.CoreServerConfig); err != nil {
 		return nil, fmt.Errorf("could not start up core servers: %w", err)
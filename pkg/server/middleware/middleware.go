@@ -25,6 +25,16 @@ func (r *statusRecorder) WriteHeader(status int) {
 	r.ResponseWriter.WriteHeader(status)
 }
 
+// Flush sends any buffered data to the client, this is needed by the
+// streaming endpoints.
+func (r *statusRecorder) Flush() {
+	_ = http.NewResponseController(r.ResponseWriter).Flush()
+}
+
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
 var (
 	RequestOkText    = "request success"
 	RequestErrorText = "request error"
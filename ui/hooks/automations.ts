@@ -15,7 +15,7 @@ import {
   RequestError,
   SearchedNamespaces,
 } from "../lib/types";
-import { notifyError, notifySuccess } from "../lib/utils";
+import { notifyError, notifyResults } from "../lib/utils";
 import { convertResponse } from "./objects";
 
 type Res = {
@@ -80,7 +80,8 @@ export function useSyncFluxObject(objs: ObjectRef[]) {
   >({
     mutationFn: ({ withSource }) =>
       api.SyncFluxObject({ objects: objs, withSource }),
-    onSuccess: () => notifySuccess("Sync request successful!"),
+    onSuccess: ({ results }) =>
+      notifyResults("Sync request successful!", results),
     onError: (error) => notifyError(error.message),
   });
 
@@ -17,7 +17,7 @@ import {
   ReactQueryOptions,
   RequestError,
 } from "../lib/types";
-import { notifyError, notifySuccess } from "../lib/utils";
+import { notifyError, notifyResults } from "../lib/utils";
 export function useListFluxRuntimeObjects(
   clusterName = DefaultCluster,
   namespace = NoNamespace,
@@ -133,9 +133,9 @@ export function useToggleSuspend(
   const queryClient = useQueryClient();
   const mutation = useMutation<ToggleSuspendResourceResponse, RequestError>({
     mutationFn: () => api.ToggleSuspendResource(req),
-    onSuccess: () => {
+    onSuccess: ({ results }) => {
       const suspend = req.suspend ? "Suspend" : "Resume";
-      notifySuccess(`${suspend} request successful!`);
+      notifyResults(`${suspend} request successful!`, results);
       return queryClient.invalidateQueries({ queryKey: [type] });
     },
     onError: (error) => {
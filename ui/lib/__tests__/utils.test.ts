@@ -1,4 +1,5 @@
 import { JSDOM } from "jsdom";
+import { toast } from "react-toastify";
 import { GetVersionResponse } from "../api/core/core.pb";
 import { Kind } from "../api/core/types.pb";
 import { Automation, HelmRelease, Kustomization } from "../objects";
@@ -13,11 +14,16 @@ import {
   isAllowedLink,
   isHTTP,
   makeImageString,
+  notifyResults,
   pageTitleWithAppName,
   statusSortHelper,
   getBasePath,
 } from "../utils";
 
+jest.mock("react-toastify", () => ({
+  toast: { success: jest.fn(), error: jest.fn(), warning: jest.fn() },
+}));
+
 describe("utils lib", () => {
   describe("isHTTP", () => {
     it("detects HTTP", () => {
@@ -467,3 +473,56 @@ describe("createYamlCommand", () => {
     });
   });
 });
+
+describe("notifyResults", () => {
+  const ref = (name: string) => ({
+    kind: "Kustomization",
+    namespace: "flux-system",
+    name,
+  });
+
+  beforeEach(() => {
+    jest.clearAllMocks();
+  });
+
+  it("notifies the success if all objects succeeded", () => {
+    notifyResults("Sync request successful!", [
+      { object: ref("apps"), status: "Succeeded" },
+    ]);
+
+    expect(toast.success).toHaveBeenCalledWith("Sync request successful!");
+    expect(toast.error).not.toHaveBeenCalled();
+    expect(toast.warning).not.toHaveBeenCalled();
+  });
+
+  it("reports the failed objects and warns about the skipped ones", () => {
+    notifyResults("Sync request successful!", [
+      { object: ref("infra"), status: "Failed", error: "timed out" },
+      {
+        object: ref("apps"),
+        status: "Skipped",
+        error: "dependency Kustomization/flux-system infra was not synced",
+      },
+    ]);
+
+    expect(toast.success).not.toHaveBeenCalled();
+    expect(toast.error).toHaveBeenCalledWith(
+      "Error: Kustomization flux-system/infra: timed out",
+    );
+    expect(toast.warning).toHaveBeenCalledWith(
+      "Warning: skipped Kustomization flux-system/apps: dependency Kustomization/flux-system infra was not synced",
+    );
+  });
+
+  it("warns about the skipped objects without failures", () => {
+    notifyResults("Sync request successful!", [
+      { object: ref("apps"), status: "Skipped", error: "not synced" },
+    ]);
+
+    expect(toast.success).not.toHaveBeenCalled();
+    expect(toast.error).not.toHaveBeenCalled();
+    expect(toast.warning).toHaveBeenCalledWith(
+      "Warning: skipped Kustomization flux-system/apps: not synced",
+    );
+  });
+});
@@ -19,6 +19,77 @@ export type GetInventoryResponse = {
   entries?: Gitops_coreV1Types.InventoryEntry[]
 }
 
+export type GetDependencyGraphRequest = {
+  namespace?: string
+  clusterName?: string
+}
+
+export type DependencyGraphNode = {
+  id?: string
+  kind?: string
+  name?: string
+  namespace?: string
+  clusterName?: string
+  status?: string
+  missing?: boolean
+}
+
+export type DependencyGraphEdge = {
+  source?: string
+  target?: string
+  type?: string
+}
+
+export type DependencyGraphCycle = {
+  nodes?: string[]
+}
+
+export type GetDependencyGraphResponse = {
+  nodes?: DependencyGraphNode[]
+  edges?: DependencyGraphEdge[]
+  cycles?: DependencyGraphCycle[]
+  errors?: ListError[]
+}
+
+export type GetDriftRequest = {
+  name?: string
+  namespace?: string
+  clusterName?: string
+}
+
+export type DriftEntry = {
+  kind?: string
+  name?: string
+  namespace?: string
+  action?: string
+  diff?: string
+  error?: string
+}
+
+export type GetDriftResponse = {
+  revision?: string
+  entries?: DriftEntry[]
+}
+
+export type GetHealthHistoryRequest = {
+  name?: string
+  namespace?: string
+  kind?: string
+  clusterName?: string
+}
+
+export type HealthHistoryEntry = {
+  status?: string
+  message?: string
+  timestamp?: string
+}
+
+export type GetHealthHistoryResponse = {
+  entries?: HealthHistoryEntry[]
+  changes?: number
+  flapping?: boolean
+}
+
 export type PolicyValidation = {
   id?: string
   message?: string
@@ -136,6 +207,7 @@ export type GetObjectRequest = {
   namespace?: string
   kind?: string
   clusterName?: string
+  withHealthRollup?: boolean
 }
 
 export type GetObjectResponse = {
@@ -147,6 +219,26 @@ export type ListObjectsRequest = {
   kind?: string
   clusterName?: string
   labels?: {[key: string]: string}
+  pagination?: Pagination
+  fieldSelector?: string
+  search?: string
+  searchRegex?: boolean
+  status?: string
+  sortBy?: string
+  withHealthRollup?: boolean
+}
+
+export type WatchObjectsRequest = {
+  namespace?: string
+  kind?: string
+  clusterName?: string
+  labels?: {[key: string]: string}
+}
+
+export type WatchObjectsResponse = {
+  type?: string
+  object?: Gitops_coreV1Types.Object
+  error?: ListError
 }
 
 export type ClusterNamespaceList = {
@@ -158,6 +250,7 @@ export type ListObjectsResponse = {
   objects?: Gitops_coreV1Types.Object[]
   errors?: ListError[]
   searchedNamespaces?: ClusterNamespaceList[]
+  nextPageToken?: string
 }
 
 export type GetReconciledObjectsRequest = {
@@ -172,6 +265,50 @@ export type GetReconciledObjectsResponse = {
   objects?: Gitops_coreV1Types.Object[]
 }
 
+export type FindOwnersRequest = {
+  kind?: string
+  name?: string
+  namespace?: string
+  clusterName?: string
+  image?: string
+}
+
+export type ObjectOwners = {
+  object?: Gitops_coreV1Types.ObjectRef
+  owners?: Gitops_coreV1Types.ObjectRef[]
+}
+
+export type FindOwnersResponse = {
+  objects?: ObjectOwners[]
+  errors?: ListError[]
+}
+
+export type ListImagesRequest = {
+  namespace?: string
+  clusterName?: string
+  registry?: string
+  repository?: string
+  tag?: string
+}
+
+export type ImageWorkload = {
+  workload?: Gitops_coreV1Types.ObjectRef
+  owners?: Gitops_coreV1Types.ObjectRef[]
+}
+
+export type Image = {
+  registry?: string
+  repository?: string
+  tag?: string
+  digest?: string
+  workloads?: ImageWorkload[]
+}
+
+export type ListImagesResponse = {
+  images?: Image[]
+  errors?: ListError[]
+}
+
 export type GetChildObjectsRequest = {
   groupVersionKind?: Gitops_coreV1Types.GroupVersionKind
   namespace?: string
@@ -208,9 +345,45 @@ export type ListEventsResponse = {
 export type SyncFluxObjectRequest = {
   objects?: Gitops_coreV1Types.ObjectRef[]
   withSource?: boolean
+  withDependencies?: boolean
+  async?: boolean
 }
 
 export type SyncFluxObjectResponse = {
+  results?: Gitops_coreV1Types.ObjectResult[]
+  operationId?: string
+}
+
+export type OperationObject = {
+  result?: Gitops_coreV1Types.ObjectResult
+  readyCondition?: Gitops_coreV1Types.Condition
+  events?: Gitops_coreV1Types.Event[]
+}
+
+export type Operation = {
+  id?: string
+  type?: string
+  status?: string
+  createdBy?: string
+  startedAt?: string
+  finishedAt?: string
+  objects?: OperationObject[]
+  completed?: number
+}
+
+export type GetOperationRequest = {
+  id?: string
+}
+
+export type GetOperationResponse = {
+  operation?: Operation
+}
+
+export type ListOperationsRequest = {
+}
+
+export type ListOperationsResponse = {
+  operations?: Operation[]
 }
 
 export type GetVersionRequest = {
@@ -238,6 +411,7 @@ export type ToggleSuspendResourceRequest = {
 }
 
 export type ToggleSuspendResourceResponse = {
+  results?: Gitops_coreV1Types.ObjectResult[]
 }
 
 export type GetSessionLogsRequest = {
@@ -341,6 +515,9 @@ export class Core {
   static ListObjects(req: ListObjectsRequest, initReq?: fm.InitReq): Promise<ListObjectsResponse> {
     return fm.fetchReq<ListObjectsRequest, ListObjectsResponse>(`/v1/objects`, {...initReq, method: "POST", body: JSON.stringify(req, fm.replacer)})
   }
+  static WatchObjects(req: WatchObjectsRequest, entityNotifier?: fm.NotifyStreamEntityArrival<WatchObjectsResponse>, initReq?: fm.InitReq): Promise<void> {
+    return fm.fetchStreamingRequest<WatchObjectsRequest, WatchObjectsResponse>(`/v1/objects/watch?${fm.renderURLSearchParams(req, [])}`, entityNotifier, {...initReq, method: "GET"})
+  }
   static ListFluxRuntimeObjects(req: ListFluxRuntimeObjectsRequest, initReq?: fm.InitReq): Promise<ListFluxRuntimeObjectsResponse> {
     return fm.fetchReq<ListFluxRuntimeObjectsRequest, ListFluxRuntimeObjectsResponse>(`/v1/flux_runtime_objects?${fm.renderURLSearchParams(req, [])}`, {...initReq, method: "GET"})
   }
@@ -356,6 +533,12 @@ export class Core {
   static GetReconciledObjects(req: GetReconciledObjectsRequest, initReq?: fm.InitReq): Promise<GetReconciledObjectsResponse> {
     return fm.fetchReq<GetReconciledObjectsRequest, GetReconciledObjectsResponse>(`/v1/reconciled_objects`, {...initReq, method: "POST", body: JSON.stringify(req, fm.replacer)})
   }
+  static FindOwners(req: FindOwnersRequest, initReq?: fm.InitReq): Promise<FindOwnersResponse> {
+    return fm.fetchReq<FindOwnersRequest, FindOwnersResponse>(`/v1/owners?${fm.renderURLSearchParams(req, [])}`, {...initReq, method: "GET"})
+  }
+  static ListImages(req: ListImagesRequest, initReq?: fm.InitReq): Promise<ListImagesResponse> {
+    return fm.fetchReq<ListImagesRequest, ListImagesResponse>(`/v1/images?${fm.renderURLSearchParams(req, [])}`, {...initReq, method: "GET"})
+  }
   static GetChildObjects(req: GetChildObjectsRequest, initReq?: fm.InitReq): Promise<GetChildObjectsResponse> {
     return fm.fetchReq<GetChildObjectsRequest, GetChildObjectsResponse>(`/v1/child_objects`, {...initReq, method: "POST", body: JSON.stringify(req, fm.replacer)})
   }
@@ -371,6 +554,12 @@ export class Core {
   static SyncFluxObject(req: SyncFluxObjectRequest, initReq?: fm.InitReq): Promise<SyncFluxObjectResponse> {
     return fm.fetchReq<SyncFluxObjectRequest, SyncFluxObjectResponse>(`/v1/sync`, {...initReq, method: "POST", body: JSON.stringify(req, fm.replacer)})
   }
+  static GetOperation(req: GetOperationRequest, initReq?: fm.InitReq): Promise<GetOperationResponse> {
+    return fm.fetchReq<GetOperationRequest, GetOperationResponse>(`/v1/operations/${req["id"]}?${fm.renderURLSearchParams(req, ["id"])}`, {...initReq, method: "GET"})
+  }
+  static ListOperations(req: ListOperationsRequest, initReq?: fm.InitReq): Promise<ListOperationsResponse> {
+    return fm.fetchReq<ListOperationsRequest, ListOperationsResponse>(`/v1/operations?${fm.renderURLSearchParams(req, [])}`, {...initReq, method: "GET"})
+  }
   static GetVersion(req: GetVersionRequest, initReq?: fm.InitReq): Promise<GetVersionResponse> {
     return fm.fetchReq<GetVersionRequest, GetVersionResponse>(`/v1/version?${fm.renderURLSearchParams(req, [])}`, {...initReq, method: "GET"})
   }
@@ -389,6 +578,15 @@ export class Core {
   static GetInventory(req: GetInventoryRequest, initReq?: fm.InitReq): Promise<GetInventoryResponse> {
     return fm.fetchReq<GetInventoryRequest, GetInventoryResponse>(`/v1/inventory?${fm.renderURLSearchParams(req, [])}`, {...initReq, method: "GET"})
   }
+  static GetDependencyGraph(req: GetDependencyGraphRequest, initReq?: fm.InitReq): Promise<GetDependencyGraphResponse> {
+    return fm.fetchReq<GetDependencyGraphRequest, GetDependencyGraphResponse>(`/v1/dependency_graph?${fm.renderURLSearchParams(req, [])}`, {...initReq, method: "GET"})
+  }
+  static GetDrift(req: GetDriftRequest, initReq?: fm.InitReq): Promise<GetDriftResponse> {
+    return fm.fetchReq<GetDriftRequest, GetDriftResponse>(`/v1/drift?${fm.renderURLSearchParams(req, [])}`, {...initReq, method: "GET"})
+  }
+  static GetHealthHistory(req: GetHealthHistoryRequest, initReq?: fm.InitReq): Promise<GetHealthHistoryResponse> {
+    return fm.fetchReq<GetHealthHistoryRequest, GetHealthHistoryResponse>(`/v1/health/history?${fm.renderURLSearchParams(req, [])}`, {...initReq, method: "GET"})
+  }
   static ListPolicies(req: ListPoliciesRequest, initReq?: fm.InitReq): Promise<ListPoliciesResponse> {
     return fm.fetchReq<ListPoliciesRequest, ListPoliciesResponse>(`/v1/policies?${fm.renderURLSearchParams(req, [])}`, {...initReq, method: "GET"})
   }
@@ -40,6 +40,13 @@ export type ObjectRef = {
   clusterName?: string
 }
 
+export type ObjectResult = {
+  object?: ObjectRef
+  status?: string
+  error?: string
+  durationMs?: string
+}
+
 export type Condition = {
   type?: string
   status?: string
@@ -87,6 +94,7 @@ export type Object = {
   inventory?: GroupVersionKind[]
   info?: string
   health?: HealthStatus
+  healthRollup?: HealthStatus
 }
 
 export type Deployment = {
@@ -9,7 +9,7 @@ import { AppVersion, repoUrl } from "../components/Version";
 import { ThemeTypes } from "../contexts/AppContext";
 import { AuthRoutes } from "../contexts/AuthContext";
 import { GetVersionResponse } from "./api/core/core.pb";
-import { Condition, Kind, ObjectRef } from "./api/core/types.pb";
+import { Condition, Kind, ObjectRef, ObjectResult } from "./api/core/types.pb";
 import { Automation, HelmRelease, Kustomization } from "./objects";
 
 export function notifySuccess(message: string) {
@@ -20,6 +20,39 @@ export function notifyError(message: string) {
   toast["error"](`Error: ${message}`);
 }
 
+export function notifyWarning(message: string) {
+  toast["warning"](`Warning: ${message}`);
+}
+
+function describeResults(results: ObjectResult[]): string {
+  return results
+    .map(
+      ({ object, error }) =>
+        `${object?.kind} ${object?.namespace}/${object?.name}: ${error}`,
+    )
+    .join(", ");
+}
+
+// notifyResults reports the objects that failed and warns about the ones
+// that were skipped when a request only partially succeeded, or message if
+// all of them succeeded.
+export function notifyResults(message: string, results: ObjectResult[] = []) {
+  const failed = results.filter((result) => result.status === "Failed");
+  const skipped = results.filter((result) => result.status === "Skipped");
+  if (failed.length === 0 && skipped.length === 0) {
+    notifySuccess(message);
+    return;
+  }
+
+  if (failed.length > 0) {
+    notifyError(describeResults(failed));
+  }
+
+  if (skipped.length > 0) {
+    notifyWarning(`skipped ${describeResults(skipped)}`);
+  }
+}
+
 export function poller(cb, interval): any {
   if (process.env.NODE_ENV === "test") {
     // Stay synchronous in tests
@@ -8,7 +8,8 @@ import Button from "../components/Button";
 import DarkModeSwitch from "../components/DarkModeSwitch";
 import Flex from "../components/Flex";
 import LoadingPage from "../components/LoadingPage";
-import { Auth, AuthContext } from "../contexts/AuthContext";
+import { AppContext } from "../contexts/AppContext";
+import { Auth, AuthContext, AuthRoutes } from "../contexts/AuthContext";
 import { useFeatureFlags } from "../hooks/featureflags";
 import { useInDarkMode } from "../hooks/theme";
 import images from "../lib/images";
@@ -79,6 +80,43 @@ const MarginButton = styled(Button)`
 type Props = {
   darkModeEnabled?: boolean;
 };
+
+type OIDCProvider = {
+  name: string;
+  displayName: string;
+};
+
+// Fetches the OIDC providers to choose from, when there's more than one.
+function useOIDCProviders(enabled: boolean) {
+  const { request } = React.useContext(AppContext);
+  const [providers, setProviders] = React.useState<OIDCProvider[]>([]);
+
+  React.useEffect(() => {
+    if (!enabled) {
+      return;
+    }
+
+    let cancelled = false;
+
+    (async () => {
+      try {
+        const res = await request(AuthRoutes.OIDC_PROVIDERS);
+        const data: OIDCProvider[] = res.ok ? await res.json() : [];
+        if (!cancelled) {
+          setProviders(data);
+        }
+      } catch {
+        // Fall back to a single button for the default provider
+      }
+    })();
+
+    return () => {
+      cancelled = true;
+    };
+  }, [enabled, request]);
+
+  return providers;
+}
 function SignIn({ darkModeEnabled = true }: Props) {
   const { isFlagEnabled, flags } = useFeatureFlags();
 
@@ -91,8 +129,9 @@ function SignIn({ darkModeEnabled = true }: Props) {
   const [password, setPassword] = React.useState<string>("");
   const [username, setUsername] = React.useState<string>("");
   const [showPassword, setShowPassword] = React.useState<boolean>(false);
+  const oidcProviders = useOIDCProviders(isFlagEnabled("OIDC_AUTH"));
 
-  const handleOIDCSubmit = () => {
+  const handleOIDCSubmit = (provider?: string) => {
     const redirect = qs.parse(window.location.search).redirect || "";
 
     // Head to the BE to start the OIDC flow so we do not use any of
@@ -103,6 +142,7 @@ function SignIn({ darkModeEnabled = true }: Props) {
         // BE handles the redirect to return_url after authentication
         // so add the base path
         return_url: window.origin + withBasePath(redirect.toString()),
+        provider,
       }));
   };
 
@@ -163,19 +203,37 @@ function SignIn({ darkModeEnabled = true }: Props) {
           <OidcFlex
             wide
             center
+            column
+            align
+            gap="8"
             //extra padding-bottom for when both auth flags are enabled
             clusterAuth={isFlagEnabled("CLUSTER_USER_AUTH")}
           >
-            <Button
-              type="submit"
-              onClick={(e) => {
-                e.preventDefault();
-                handleOIDCSubmit();
-              }}
-            >
-              {flags.WEAVE_GITOPS_FEATURE_OIDC_BUTTON_LABEL ||
-                "LOGIN WITH OIDC PROVIDER"}
-            </Button>
+            {oidcProviders.length > 1 ? (
+              oidcProviders.map((provider) => (
+                <Button
+                  key={provider.name}
+                  type="submit"
+                  onClick={(e) => {
+                    e.preventDefault();
+                    handleOIDCSubmit(provider.name);
+                  }}
+                >
+                  LOGIN WITH {provider.displayName.toUpperCase()}
+                </Button>
+              ))
+            ) : (
+              <Button
+                type="submit"
+                onClick={(e) => {
+                  e.preventDefault();
+                  handleOIDCSubmit();
+                }}
+              >
+                {flags.WEAVE_GITOPS_FEATURE_OIDC_BUTTON_LABEL ||
+                  "LOGIN WITH OIDC PROVIDER"}
+              </Button>
+            )}
           </OidcFlex>
         ) : null}
         {isFlagEnabled("CLUSTER_USER_AUTH") ? (
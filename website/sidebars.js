@@ -119,6 +119,14 @@ module.exports = {
         "guides/fluxga-upgrade",
         "guides/anonymous-access",
         "guides/run-ui-subpath",
+        "guides/server-permissions",
+        "guides/audit-log",
+        "guides/authorization-policy",
+        "guides/sessions",
+        "guides/token-passthrough",
+        "guides/cli-login",
+        "guides/custom-health-checks",
+        "guides/health-history",
       ],
     },
     {